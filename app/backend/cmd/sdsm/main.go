@@ -41,10 +41,11 @@ type App struct {
 	wsHub       *middleware.Hub
 	rateLimiter *middleware.RateLimiter
 	userStore   *manager.UserStore
+	auditLog    *manager.AuditLog
 	tlsEnabled  bool
 	tlsCertPath string
 	tlsKeyPath  string
-	ginLogFile  *os.File
+	ginLogFile  *utils.RotatingFile
 }
 
 var app *App
@@ -64,13 +65,13 @@ func renderCardTemplate(name string, data interface{}) htmltmpl.HTML {
 	return htmltmpl.HTML(buf.String())
 }
 
-func logStuff(msg string) {
+// appLogger returns the manager log for component, or the default SDSM log before the
+// manager is up (instead of stdout).
+func appLogger(component string) *utils.Logger {
 	if app.manager != nil && app.manager.Log != nil {
-		app.manager.Log.Write(msg)
-	} else {
-		// Fallback: write to default SDSM log instead of stdout
-		utils.NewLogger("").Write(msg)
+		return app.manager.Logger(component, 0)
 	}
+	return utils.NewLogger("").With(component, 0)
 }
 
 func clearLogFile(path string) {
@@ -78,12 +79,12 @@ func clearLogFile(path string) {
 		return
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		logStuff(fmt.Sprintf("Failed to ensure log directory for %s: %v", path, err))
+		appLogger("manager").Error(fmt.Sprintf("Failed to ensure log directory for %s: %v", path, err))
 		return
 	}
 	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
 	if err != nil {
-		logStuff(fmt.Sprintf("Failed to clear log file %s: %v", path, err))
+		appLogger("manager").Warn(fmt.Sprintf("Failed to clear log file %s: %v", path, err))
 		return
 	}
 	_ = file.Close()
@@ -94,7 +95,11 @@ type managerLogWriter struct{ mgr *manager.Manager }
 
 func (w managerLogWriter) Write(p []byte) (int, error) {
 	msg := strings.TrimRight(string(p), "\n")
-	logStuff(msg)
+	// Request log entries are already formatted; pass them through so their fields survive.
+	if _, ok := utils.ParseLogLine(msg); ok && w.mgr != nil && w.mgr.Log != nil {
+		return w.mgr.Log.Write(p)
+	}
+	appLogger("http").Info(msg)
 	return len(p), nil
 }
 
@@ -135,6 +140,7 @@ func main() {
 		wsHub:       middleware.NewHub(mgr.Log),
 		rateLimiter: middleware.NewRateLimiter(rate.Every(time.Minute/100), 10),
 		userStore:   manager.NewUserStore(mgr.Paths),
+		auditLog:    manager.NewAuditLog(mgr.Paths),
 	}
 
 	// Configure cookie settings from manager config
@@ -170,6 +176,8 @@ func main() {
 		_ = os.MkdirAll(app.manager.Paths.ConfigDir(), 0o755)
 	}
 	_ = app.userStore.Load()
+	app.authService.SetAPITokenStore(app.userStore)
+	app.manager.SetUserStore(app.userStore)
 	// Log diagnostics to help when UI shows admin setup unexpectedly
 	if app.userStore.IsEmpty() {
 		cfg := strings.TrimSpace(app.manager.ConfigFile)
@@ -182,16 +190,20 @@ func main() {
 		if info, err := os.Stat(path); err == nil && !info.IsDir() {
 			exists = "present"
 		}
-		logStuff(fmt.Sprintf("User store appears empty. Config=%s | root_path=%s | users.json=%s (%s)", cfg, root, path, exists))
-		logStuff("If you previously had accounts, copy your old users.json into the current root_path/config/users.json, or start SDSM with --config pointing to your original sdsm.config.")
+		appLogger("auth").Warn(fmt.Sprintf("User store appears empty. Config=%s | root_path=%s | users.json=%s (%s)", cfg, root, path, exists))
+		appLogger("auth").Warn("If you previously had accounts, copy your old users.json into the current root_path/config/users.json, or start SDSM with --config pointing to your original sdsm.config.")
 	}
 
 	if !app.manager.IsActive() {
-		logStuff("Manager failed to initialize")
+		appLogger("manager").Error("Manager failed to initialize")
 		os.Exit(1)
 	}
 
 	app.manager.StartTelemetryMonitor()
+	app.manager.StartUpdateScheduler()
+	app.manager.StartTaskScheduler()
+	app.manager.StartDiscordBridge()
+	app.manager.StartBackupScheduler()
 
 	if app.manager.Paths != nil {
 		clearLogFile(filepath.Join(app.manager.Paths.LogsDir(), "GIN.log"))
@@ -203,14 +215,15 @@ func main() {
 	// Route Gin logs to dedicated GIN.log file (fallback to manager log on error)
 	if app.manager != nil && app.manager.Paths != nil {
 		if err := os.MkdirAll(app.manager.Paths.LogsDir(), 0o755); err != nil {
-			logStuff(fmt.Sprintf("Failed to ensure logs directory: %v", err))
+			appLogger("http").Error(fmt.Sprintf("Failed to ensure logs directory: %v", err))
 		} else {
 			ginLogPath := filepath.Join(app.manager.Paths.LogsDir(), "GIN.log")
-			file, err := os.OpenFile(ginLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			file, err := utils.OpenRotatingFile(ginLogPath)
 			if err != nil {
-				logStuff(fmt.Sprintf("Failed to open Gin log file: %v", err))
+				appLogger("http").Error(fmt.Sprintf("Failed to open Gin log file: %v", err))
 			} else {
 				app.ginLogFile = file
+				app.manager.SetWebLog(file)
 				gin.DefaultWriter = file
 				gin.DefaultErrorWriter = file
 			}
@@ -242,7 +255,7 @@ func main() {
 	keyMissing := strings.TrimSpace(app.tlsKeyPath) == ""
 	useTLS := app.tlsEnabled && !certMissing && !keyMissing
 	if app.tlsEnabled && !useTLS {
-		logStuff("TLS enabled but certificate or key missing; falling back to HTTP")
+		appLogger("http").Warn("TLS enabled but certificate or key missing; falling back to HTTP")
 		app.tlsEnabled = false
 		if app.manager != nil {
 			app.manager.TLSEnabled = false
@@ -252,15 +265,15 @@ func main() {
 
 	startServer := func() {
 		if useTLS {
-			logStuff(fmt.Sprintf("Starting HTTPS server on port %d", app.manager.Port))
+			appLogger("http").Info(fmt.Sprintf("Starting HTTPS server on port %d", app.manager.Port))
 			if err := srv.ListenAndServeTLS(app.tlsCertPath, app.tlsKeyPath); err != nil && err != http.ErrServerClosed {
-				logStuff(fmt.Sprintf("HTTPS server failed to start: %v", err))
+				appLogger("http").Error(fmt.Sprintf("HTTPS server failed to start: %v", err))
 				os.Exit(1)
 			}
 		} else {
-			logStuff(fmt.Sprintf("Starting HTTP server on port %d", app.manager.Port))
+			appLogger("http").Info(fmt.Sprintf("Starting HTTP server on port %d", app.manager.Port))
 			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				logStuff(fmt.Sprintf("Server failed to start: %v", err))
+				appLogger("http").Error(fmt.Sprintf("Server failed to start: %v", err))
 				os.Exit(1)
 			}
 		}
@@ -279,12 +292,12 @@ func main() {
 		app.manager.StartManagerPortForwarding()
 		go func() { // forward OS signals to tray exit
 			<-quit
-			logStuff("Shutdown signal received")
+			appLogger("manager").Info("Shutdown signal received")
 			trayQuit()
 		}()
 		// run tray on main thread (blocks until tray exit)
 		startTray(app, srv, trayDone)
-		logStuff("Tray exit requested")
+		appLogger("manager").Info("Tray exit requested")
 	} else {
 		trayDone = nil
 		go startServer()
@@ -292,13 +305,13 @@ func main() {
 		app.manager.StartManagerPortForwarding()
 		// Block on OS signal only (trayDone nil ignored)
 		<-quit
-		logStuff("Shutdown signal received")
+		appLogger("manager").Info("Shutdown signal received")
 	}
 
 	// Gracefully stop HTTP server (allow in-flight requests up to 5s)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	if err := srv.Shutdown(ctx); err != nil {
-		logStuff(fmt.Sprintf("HTTP server shutdown error: %v", err))
+		appLogger("http").Error(fmt.Sprintf("HTTP server shutdown error: %v", err))
 	}
 	cancel()
 
@@ -310,11 +323,15 @@ func main() {
 	// Exit manager, stopping servers unless detached mode keeps them running
 	if app.manager != nil {
 		app.manager.StopTelemetryMonitor()
+		app.manager.StopUpdateScheduler()
+		app.manager.StopTaskScheduler()
+		app.manager.StopDiscordBridge()
+		app.manager.StopBackupScheduler()
 		stopServers := !app.manager.DetachedServers
 		app.manager.ExitDetached(stopServers)
 	}
 
-	logStuff("Server exited")
+	appLogger("manager").Info("Server exited")
 }
 
 func setupRouter() *gin.Engine {
@@ -339,10 +356,12 @@ func setupRouter() *gin.Engine {
 
 	// Initialize handlers first, so they are available for all route registrations.
 	authHandlers := handlers.NewAuthHandlers(app.authService, app.manager, app.userStore)
-	userHandlers := handlers.NewUserHandlers(app.userStore, app.authService, app.manager.Log, app.manager)
+	userHandlers := handlers.NewUserHandlers(app.userStore, app.authService, app.manager.Logger("users", 0), app.manager)
+	userHandlers.SetAuditLog(app.auditLog)
 	profileHandlers := handlers.NewProfileHandlers(app.userStore, app.authService)
 	managerHandlers := handlers.NewManagerHandlersWithHub(app.manager, app.userStore, app.wsHub)
 	// Wire realtime broadcast for servers that are attached on startup (detached mode)
+	// or whose state the manager changes on its own (crash supervision).
 	if app != nil && app.manager != nil {
 		app.manager.OnServerAttached = func(s *models.Server) {
 			if s == nil {
@@ -350,17 +369,23 @@ func setupRouter() *gin.Engine {
 			}
 			managerHandlers.BroadcastStatusAndStats(s)
 		}
+		app.manager.OnServerStatusChanged = func(s *models.Server) {
+			if s == nil {
+				return
+			}
+			managerHandlers.BroadcastStatusAndStats(s)
+		}
 	}
 
 	updateHandler := func(c *gin.Context) {
-		if c.GetString("role") != "admin" {
+		if !handlers.Can(c, app.userStore, 0, manager.PermManagerUpdate) {
 			acceptsJSON := strings.Contains(strings.ToLower(c.GetHeader("Accept")), "application/json")
 			ajax := c.GetHeader("HX-Request") == "true" || strings.EqualFold(c.GetHeader("X-Requested-With"), "XMLHttpRequest")
 			if acceptsJSON || ajax {
-				handlers.ToastError(c, "Permission Denied", "Admin privileges required.")
-				c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+				handlers.ToastError(c, "Permission Denied", "You do not have permission to do that.")
+				c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 			} else {
-				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 			}
 			return
 		}
@@ -436,13 +461,13 @@ func setupRouter() *gin.Engine {
 	staticFS, err := fs.Sub(ui.Assets, "static")
 	if err != nil {
 		// Fail fast: embedded static must be present
-		logStuff(fmt.Sprintf("FATAL: embedded static directory missing: %v", err))
+		appLogger("http").Error(fmt.Sprintf("Embedded static directory missing: %v", err))
 		os.Exit(1)
 	}
 	// Validate embedded critical static assets exist
 	for _, asset := range []string{"sdsm.png", "css/ui-theme.css", "css/modern.css", "js/common/app.js"} {
 		if f, openErr := staticFS.Open(asset); openErr != nil {
-			logStuff(fmt.Sprintf("FATAL: embedded static asset missing: %s (%v)", asset, openErr))
+			appLogger("http").Error(fmt.Sprintf("Embedded static asset missing: %s (%v)", asset, openErr))
 			os.Exit(1)
 		} else {
 			_ = f.Close()
@@ -459,6 +484,10 @@ func setupRouter() *gin.Engine {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Prometheus exporter; disabled unless "metrics.enabled" is set and guarded by
+	// "metrics.bearer_token" when configured, so scrapers do not need a session.
+	r.GET("/metrics", managerHandlers.MetricsGET)
+
 	// Readiness probe: reports when the manager is active and critical components
 	// are initialized. Returns 200 when ready; 503 with a reason otherwise.
 	r.GET("/readyz", readyHandler)
@@ -495,6 +524,9 @@ func setupRouter() *gin.Engine {
 		auth.GET("/login", authHandlers.LoginGET)
 		auth.POST("/login", authHandlers.LoginPOST)
 		auth.GET("/logout", authHandlers.Logout)
+		// OpenID Connect single sign-on (enabled via the "oidc" section of sdsm.config)
+		auth.GET("/auth/oidc/login", authHandlers.OIDCLoginGET)
+		auth.GET("/auth/oidc/callback", authHandlers.OIDCCallbackGET)
 	}
 
 	// Root route: redirect to appropriate landing page
@@ -527,17 +559,25 @@ func setupRouter() *gin.Engine {
 	api.Use(app.authService.RequireAPIAuth())
 	// Attach role and perform admin safety net via shared middleware
 	api.Use(middleware.EnsureRoleContext(app.userStore, app.manager.Log, "API"))
+	// Record privileged (state-changing) calls to the audit log
+	api.Use(middleware.AuditTrail(app.auditLog))
+	// Limit personal access tokens to their scopes and servers
+	api.Use(middleware.EnforceAPITokenScope())
 	{
-		// Report a bug to configured SDSM Discord webhook (admin only)
+		// Report a bug to configured SDSM Discord webhook (requires manager.config)
 		api.POST("/bug-report", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.BugReportPOST(c)
 		})
 		// Profile self-service password change (JSON only)
 		api.POST("/profile/password", profileHandlers.APIProfileChangePassword)
+		// Personal access tokens for automation
+		api.GET("/profile/tokens", profileHandlers.APIProfileTokensList)
+		api.POST("/profile/tokens", profileHandlers.APIProfileTokensCreate)
+		api.DELETE("/profile/tokens/:token_id", profileHandlers.APIProfileTokenRevoke)
 		// Simple refresh endpoint used by UI header buttons to trigger htmx 'refresh' events.
 		api.GET("/refresh", func(c *gin.Context) {
 			// Return minimal JSON; htmx button uses hx-swap="none" so body is ignored.
@@ -545,44 +585,51 @@ func setupRouter() *gin.Engine {
 			c.JSON(http.StatusOK, gin.H{"status": "ok"})
 		})
 		api.GET("/stats", managerHandlers.APIStats)
+		api.GET("/stats/history", managerHandlers.APIStatsHistory)
 		api.GET("/servers", managerHandlers.APIServers)
 		api.POST("/servers", func(c *gin.Context) {
-			// Admin only create
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			// Requires servers.create
+			if !handlers.Can(c, app.userStore, 0, manager.PermServersCreate) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.APIServersCreate(c)
 		})
 		// Create from Save (multipart .save upload)
 		api.POST("/servers/create-from-save", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermServersCreate) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.APIServersCreateFromSave(c)
 		})
 		// Analyze Save (multipart .save upload) - returns world and world file name
 		api.POST("/servers/analyze-save", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermServersCreate) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.APIServersAnalyzeSave(c)
 		})
+		// Server templates and clones: export a definition, import it or copy a server with its save
+		api.GET("/servers/:server_id/template", managerHandlers.APIServerTemplateExport)
+		api.POST("/servers/import-template", managerHandlers.APIServerTemplateImport)
+		api.POST("/servers/:server_id/clone", managerHandlers.APIServerClone)
 		api.GET("/manager/status", managerHandlers.APIManagerStatus)
-		// Aggregated port forwarding metrics (admin)
+		// Aggregated port forwarding metrics (requires manager.config)
 		api.GET("/metrics/port-forward", managerHandlers.APIPortForwardMetrics)
 		// Manager networking diagnostics
 		api.GET("/manager/test-port", managerHandlers.APIManagerTestPort)
 		api.GET("/servers/:server_id/status", managerHandlers.APIServerStatus)
 		api.GET("/servers/:server_id/progress", managerHandlers.ServerProgressGET)
+		api.GET("/servers/:server_id/metrics", managerHandlers.APIServerMetricsHistory)
 		api.GET("/servers/:server_id/saves", managerHandlers.APIServerSaves)
 		api.DELETE("/servers/:server_id/saves", managerHandlers.APIServerSaveDelete)
 		api.GET("/servers/:server_id/logs", managerHandlers.APIServerLogsList)
 		api.GET("/servers/:server_id/log", managerHandlers.APIServerLog)
 		api.GET("/servers/:server_id/log/tail", managerHandlers.APIServerLogTail)
 		api.GET("/servers/:server_id/log/download", managerHandlers.APIServerLogDownload)
+		api.GET("/servers/:server_id/logs/search", managerHandlers.APIServerLogSearch)
 		api.GET("/servers/:server_id/world/saves", managerHandlers.APIServerWorldSaves)
 		api.GET("/servers/:server_id/world/download", managerHandlers.APIServerWorldDownload)
 		api.POST("/servers/:server_id/log/clear", managerHandlers.APIServerLogClear)
@@ -592,6 +639,8 @@ func setupRouter() *gin.Engine {
 		api.POST("/servers/:server_id/resume", managerHandlers.APIServerResume)
 		// legacy generic command removed; use explicit endpoints below
 		api.POST("/servers/:server_id/chat", managerHandlers.APIServerChat)
+		api.GET("/servers/:server_id/chat/moderation", managerHandlers.APIServerChatModerationGET)
+		api.PUT("/servers/:server_id/chat/moderation", managerHandlers.APIServerChatModerationUpdate)
 		api.POST("/servers/:server_id/console", managerHandlers.APIServerConsole)
 		api.GET("/servers/:server_id/scon/health", managerHandlers.APIServerSCONHealth)
 		api.POST("/servers/:server_id/save", managerHandlers.APIServerSave)
@@ -607,31 +656,63 @@ func setupRouter() *gin.Engine {
 		api.POST("/servers/:server_id/player-saves/exclude", managerHandlers.APIServerPlayerSaveExclude)
 		api.POST("/servers/:server_id/player-saves/delete-all", managerHandlers.APIServerPlayerSaveDeleteAll)
 		api.POST("/servers/:server_id/settings", managerHandlers.APIServerUpdateSettings)
+		api.GET("/servers/:server_id/schedules", managerHandlers.APIServerSchedulesList)
+		api.POST("/servers/:server_id/schedules", managerHandlers.APIServerSchedulesCreate)
+		api.GET("/servers/:server_id/schedules/preview", managerHandlers.APIServerSchedulesPreview)
+		api.PUT("/servers/:server_id/schedules/:schedule_id", managerHandlers.APIServerSchedulesUpdate)
+		api.DELETE("/servers/:server_id/schedules/:schedule_id", managerHandlers.APIServerSchedulesDelete)
+		api.GET("/servers/:server_id/backups", managerHandlers.APIServerBackupsList)
+		api.GET("/servers/:server_id/whitelist", managerHandlers.APIServerWhitelistGET)
+		api.PUT("/servers/:server_id/whitelist", managerHandlers.APIServerWhitelistUpdate)
+		api.POST("/servers/:server_id/whitelist", managerHandlers.APIServerWhitelistAdd)
+		api.DELETE("/servers/:server_id/whitelist/:steam_id", managerHandlers.APIServerWhitelistRemove)
+		api.POST("/servers/:server_id/backups", managerHandlers.APIServerBackupsCreate)
+		api.POST("/servers/:server_id/backups/restore", managerHandlers.APIServerBackupRestore)
+		api.DELETE("/servers/:server_id/backups", managerHandlers.APIServerBackupDelete)
+		api.GET("/backup-targets", managerHandlers.APIBackupTargetsList)
+		api.PUT("/backup-targets", managerHandlers.APIBackupTargetsUpdate)
+		api.POST("/backup-targets/test", managerHandlers.APIBackupTargetTest)
+		api.GET("/notify-channels", managerHandlers.APINotifyChannelsList)
+		api.PUT("/notify-channels", managerHandlers.APINotifyChannelsUpdate)
+		api.POST("/notify-channels/test", managerHandlers.APINotifyChannelTest)
+		api.GET("/discord-bot", managerHandlers.APIDiscordBotGET)
+		api.PUT("/discord-bot", managerHandlers.APIDiscordBotUpdate)
+		api.GET("/alerts", managerHandlers.APIAlertsList)
+		api.GET("/alert-rules", managerHandlers.APIAlertRulesList)
+		api.PUT("/alert-rules", managerHandlers.APIAlertRulesUpdate)
+		api.GET("/bans", managerHandlers.APIBansList)
+		api.POST("/bans", managerHandlers.APIBanCreate)
+		api.GET("/bans/export", managerHandlers.APIBansExport)
+		api.POST("/bans/import", managerHandlers.APIBansImport)
+		api.DELETE("/bans/:ban_id", managerHandlers.APIBanDelete)
+		api.GET("/players", managerHandlers.APIPlayersSearch)
+		api.GET("/players/:steam_id", managerHandlers.APIPlayerGet)
+		api.POST("/players/:steam_id/notes", managerHandlers.APIPlayerNoteAdd)
+		api.DELETE("/players/:steam_id/notes/:note_id", managerHandlers.APIPlayerNoteDelete)
+		api.GET("/mods", managerHandlers.APIModsList)
+		api.POST("/mods", managerHandlers.APIModsUpload)
+		api.GET("/servers/:server_id/mods", managerHandlers.APIServerModsList)
+		api.POST("/servers/:server_id/mods/:mod_id/enable", managerHandlers.APIServerModEnable)
+		api.POST("/servers/:server_id/mods/:mod_id/disable", managerHandlers.APIServerModDisable)
 		api.POST("/servers/:server_id/rename", managerHandlers.APIServerRename)
 		api.GET("/servers/:server_id/settings/attach-defaults", managerHandlers.APIServerAttachDefaults)
 		api.POST("/servers/:server_id/language", managerHandlers.APIServerSetLanguage)
 		api.POST("/servers/:server_id/update-server", managerHandlers.APIServerUpdateServerFiles)
 		api.POST("/servers/:server_id/reinstall", managerHandlers.APIServerReinstall)
-		// Admin-only delete via API
-		api.POST("/servers/:server_id/delete", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
-				return
-			}
-			managerHandlers.APIServerDelete(c)
-		})
-		// Start all servers (admin)
+		// Delete via API (requires server.delete on the server)
+		api.POST("/servers/:server_id/delete", managerHandlers.APIServerDelete)
+		// Start all servers (requires servers.bulk)
 		api.POST("/servers/start-all", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermServersBulk) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.APIServersStartAll(c)
 		})
-		// Stop all servers (admin)
+		// Stop all servers (requires servers.bulk)
 		api.POST("/servers/stop-all", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermServersBulk) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.APIServersStopAll(c)
@@ -645,78 +726,93 @@ func setupRouter() *gin.Engine {
 		api.GET("/manager/log/tail", managerHandlers.APIManagerLogTail)
 		api.POST("/manager/log/clear", managerHandlers.APIManagerLogClear)
 		api.GET("/manager/log/download", managerHandlers.APIManagerLogDownload)
+		api.GET("/log-rotation", managerHandlers.APILogRotationGET)
+		api.PUT("/log-rotation", managerHandlers.APILogRotationUpdate)
+		api.GET("/logging", managerHandlers.APILoggingGET)
+		api.PUT("/logging", managerHandlers.APILoggingUpdate)
 		api.GET("/paths/browse", managerHandlers.APIPathBrowser)
 		api.POST("/manager/update", updateHandler)
 
-		// Admin-only user management API
+		// User management API (requires users.manage)
 		api.GET("/users", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersList(c)
 		})
 		api.POST("/users", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersCreate(c)
 		})
 		api.PATCH("/users/:username/role", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersSetRole(c)
 		})
 		api.POST("/users/:username/reset-password", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersResetPassword(c)
 		})
 		api.DELETE("/users/:username", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersDelete(c)
 		})
 		api.GET("/users/:username/assignments", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersGetAssignments(c)
 		})
 		api.POST("/users/:username/assignments", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			userHandlers.APIUsersSetAssignments(c)
 		})
+		api.GET("/users/:username/tokens", userHandlers.APIUsersTokensList)
+		api.DELETE("/users/:username/tokens/:token_id", userHandlers.APIUsersTokenRevoke)
+		api.POST("/users/:username/server-roles", userHandlers.APIUsersSetServerRole)
+		api.PUT("/users/:username/steam-id", userHandlers.APIUsersSetSteamID)
+		api.PUT("/users/:username/discord-id", userHandlers.APIUsersSetDiscordID)
+		// Audit log of privileged actions (requires audit.view)
+		api.GET("/audit", userHandlers.APIAuditList)
+		// Custom roles (requires users.manage)
+		api.GET("/roles", userHandlers.APIRolesList)
+		api.PUT("/roles/:role", userHandlers.APIRoleSave)
+		api.DELETE("/roles/:role", userHandlers.APIRoleDelete)
 	}
 
 	managerPageHandler := func(c *gin.Context) {
-		if c.GetString("role") != "admin" {
-			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+		if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 			return
 		}
 		managerHandlers.ManagerGET(c)
 	}
 	usersPageHandler := func(c *gin.Context) {
-		if c.GetString("role") != "admin" {
-			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+		if !handlers.Can(c, app.userStore, 0, manager.PermUsersManage) {
+			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 			return
 		}
 		userHandlers.UsersGET(c)
 	}
 	newServerPageHandler := func(c *gin.Context) {
-		if c.GetString("role") != "admin" {
-			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required."})
+		if !handlers.Can(c, app.userStore, 0, manager.PermServersCreate) {
+			c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that."})
 			return
 		}
 		managerHandlers.NewServerGET(c)
@@ -727,46 +823,47 @@ func setupRouter() *gin.Engine {
 	protected.Use(app.authService.RequireAuth())
 	// Attach user role to context for downstream checks via shared middleware
 	protected.Use(middleware.EnsureRoleContext(app.userStore, app.manager.Log, "UI"))
+	protected.Use(middleware.AuditTrail(app.auditLog))
 	{
-		// Setup pages (admin only)
+		// Setup pages (requires manager.config)
 		protected.GET("/setup", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 				return
 			}
 			managerHandlers.SetupGET(c)
 		})
 		protected.POST("/setup/skip", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 				return
 			}
 			managerHandlers.SetupSkipPOST(c)
 		})
 		protected.POST("/setup/install", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 				return
 			}
 			managerHandlers.SetupInstallPOST(c)
 		})
 		protected.GET("/setup/status", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.SetupStatusGET(c)
 		})
 		protected.GET("/setup/progress", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.SetupProgressGET(c)
 		})
 		protected.POST("/setup/update", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 				return
 			}
 			managerHandlers.SetupUpdatePOST(c)
@@ -788,16 +885,17 @@ func setupRouter() *gin.Engine {
 				"role":     c.GetString("role"),
 			})
 		})
-		// Admin-only user management
+		// User management (requires users.manage)
 		protected.GET("/users", usersPageHandler)
+		protected.GET("/players", managerHandlers.PlayersGET)
 		protected.GET("/users/cards/:card_id", userHandlers.UsersCardGET)
 		// Users POST removed; UI uses /api endpoints.
 		protected.POST("/update", updateHandler)
 		protected.POST("/manager/update", updateHandler)
 		// Graceful shutdown with optional server stop based on detached mode
 		protected.POST("/shutdown", func(c *gin.Context) {
-			if c.GetString("role") != "admin" {
-				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required.", "username": c.GetString("username"), "role": c.GetString("role")})
+			if !handlers.Can(c, app.userStore, 0, manager.PermManagerConfig) {
+				c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to do that.", "username": c.GetString("username"), "role": c.GetString("role")})
 				return
 			}
 			stop := strings.TrimSpace(c.PostForm("stop_servers")) == "1"
@@ -809,7 +907,7 @@ func setupRouter() *gin.Engine {
 		protected.GET("/updating", managerHandlers.UpdateStream)
 		protected.GET("/logs/updates", managerHandlers.UpdateLogGET)
 		protected.GET("/logs/sdsm", managerHandlers.ManagerLogGET)
-		// Admin-only: server creation
+		// Server creation (requires servers.create)
 		protected.GET("/server/new", newServerPageHandler)
 		// /server/new POST removed; creation flows moved to /api/servers
 		protected.GET("/server/:server_id/status.json", managerHandlers.APIServerStatus)
@@ -829,6 +927,7 @@ func setupRouter() *gin.Engine {
 		pagesAPI.GET("/dashboard", managerHandlers.Dashboard)
 		pagesAPI.GET("/manager", managerPageHandler)
 		pagesAPI.GET("/users", usersPageHandler)
+		pagesAPI.GET("/players", managerHandlers.PlayersGET)
 		pagesAPI.GET("/profile", profileHandlers.ProfileGET)
 		pagesAPI.GET("/help/tokens", managerHandlers.TokensHelpGET)
 		pagesAPI.GET("/help/commands", managerHandlers.CommandsHelpGET)
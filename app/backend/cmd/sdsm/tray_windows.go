@@ -26,7 +26,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 	iconPNG, _ := ui.Assets.ReadFile("static/sdsm.png")
 
 	if app.manager != nil && app.manager.Log != nil {
-		app.manager.Log.Write("Tray: initialization starting")
+		app.manager.Logger("tray", 0).Debug("Initialization starting")
 	}
 
 	onReady := func() {
@@ -34,7 +34,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 		if len(iconICO) > 0 {
 			systray.SetIcon(iconICO)
 			if app.manager != nil && app.manager.Log != nil {
-				app.manager.Log.Write("Tray: icon set from embedded ICO")
+				app.manager.Logger("tray", 0).Debug("Icon set from embedded ICO")
 			}
 		} else if len(iconPNG) > 0 {
 			// Convert PNG -> ICO bytes
@@ -44,7 +44,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 				if err := encodeICO(&buf, img); err == nil {
 					systray.SetIcon(buf.Bytes())
 					if app.manager != nil && app.manager.Log != nil {
-						app.manager.Log.Write("Tray: icon converted from PNG")
+						app.manager.Logger("tray", 0).Debug("Icon converted from PNG")
 					}
 				}
 			}
@@ -60,7 +60,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 		mQuit := systray.AddMenuItem("Quit", "Stop SDSM server")
 
 		if app.manager != nil && app.manager.Log != nil {
-			app.manager.Log.Write("Tray: menu items created")
+			app.manager.Logger("tray", 0).Debug("Menu items created")
 		}
 
 		go func() {
@@ -75,13 +75,13 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 					}
 					url := fmt.Sprintf("%s://localhost:%d", proto, app.manager.Port)
 					if app.manager != nil && app.manager.Log != nil {
-						app.manager.Log.Write("Tray: Open UI")
+						app.manager.Logger("tray", 0).Info("Open UI")
 					}
 					_ = launchBrowser(url)
 				case <-mLogs.ClickedCh:
 					if app.manager != nil && app.manager.Paths != nil {
 						if app.manager.Log != nil {
-							app.manager.Log.Write("Tray: Open Logs Folder")
+							app.manager.Logger("tray", 0).Info("Open Logs Folder")
 						}
 						_ = openPath(app.manager.Paths.LogsDir())
 					}
@@ -90,7 +90,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 						confirmStopAll = true
 						mStopAll.SetTitle("Confirm Stop All")
 						if app.manager != nil && app.manager.Log != nil {
-							app.manager.Log.Write("Tray: Stop All requested - awaiting confirmation")
+							app.manager.Logger("tray", 0).Info("Stop All requested - awaiting confirmation")
 						}
 						go func() {
 							time.Sleep(4 * time.Second)
@@ -105,7 +105,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 					mStopAll.SetTitle("Stop All Servers")
 					if app.manager != nil {
 						if app.manager.Log != nil {
-							app.manager.Log.Write("Tray: Stopping all servers")
+							app.manager.Logger("tray", 0).Info("Stopping all servers")
 						}
 						for _, srv := range app.manager.Servers {
 							if srv != nil && srv.IsRunning() {
@@ -118,7 +118,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 						confirmRestart = true
 						mRestart.SetTitle("Confirm Restart")
 						if app.manager != nil && app.manager.Log != nil {
-							app.manager.Log.Write("Tray: Restart requested - awaiting confirmation")
+							app.manager.Logger("tray", 0).Info("Restart requested - awaiting confirmation")
 						}
 						go func() {
 							time.Sleep(4 * time.Second)
@@ -133,13 +133,13 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 					mRestart.SetTitle("Restart SDSM")
 					if app.manager != nil {
 						if app.manager.Log != nil {
-							app.manager.Log.Write("Tray: Restarting SDSM")
+							app.manager.Logger("tray", 0).Info("Restarting SDSM")
 						}
 						go app.manager.Restart()
 					}
 				case <-mQuit.ClickedCh:
 					if app.manager != nil && app.manager.Log != nil {
-						app.manager.Log.Write("Tray: Quit")
+						app.manager.Logger("tray", 0).Info("Quit")
 					}
 					systray.Quit()
 				}
@@ -149,7 +149,7 @@ func startTray(app *App, srv *http.Server, done chan struct{}) {
 
 	onExit := func() {
 		if app.manager != nil && app.manager.Log != nil {
-			app.manager.Log.Write("Tray: exit invoked")
+			app.manager.Logger("tray", 0).Info("Exit invoked")
 		}
 		close(done)
 	}
@@ -2,6 +2,8 @@ package cards
 
 import (
 	"strings"
+
+	"sdsm/app/backend/internal/manager"
 )
 
 // CapabilityAwareCard can declare additional requirements before a card renders.
@@ -18,6 +20,9 @@ type CardCapabilities struct {
 	// AllowedRoles restricts rendering to a list of user roles (case-insensitive).
 	// When empty, all authenticated roles may view the card.
 	AllowedRoles []string
+	// RequiredPermissions hides the card unless the user holds at least one of the
+	// listed permissions on the active server.
+	RequiredPermissions []manager.Permission
 }
 
 // Allows reports whether the provided request satisfies the card capabilities.
@@ -51,11 +56,31 @@ func (caps CardCapabilities) Allows(req *Request) bool {
 			return false
 		}
 	}
+	if len(caps.RequiredPermissions) > 0 {
+		if !permissionsFromRequest(req).HasAny(caps.RequiredPermissions...) {
+			return false
+		}
+	}
 	return true
 }
 
 func isZeroCapabilities(caps CardCapabilities) bool {
-	return !caps.RequirePlayerSaves && !caps.RequireServerRunning && len(caps.AllowedRoles) == 0
+	return !caps.RequirePlayerSaves && !caps.RequireServerRunning && len(caps.AllowedRoles) == 0 && len(caps.RequiredPermissions) == 0
+}
+
+// permissionsFromRequest returns the permissions resolved by the handler, falling back to
+// full access for admins when none were attached.
+func permissionsFromRequest(req *Request) manager.PermissionSet {
+	if req == nil {
+		return nil
+	}
+	if req.Permissions != nil {
+		return req.Permissions
+	}
+	if normalizeRole(roleFromRequest(req)) == string(manager.RoleAdmin) {
+		return manager.FullPermissionSet()
+	}
+	return nil
 }
 
 func roleFromRequest(req *Request) string {
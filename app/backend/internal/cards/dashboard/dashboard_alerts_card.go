@@ -0,0 +1,73 @@
+package dashboard
+
+import (
+	"github.com/gin-gonic/gin"
+
+	cards "sdsm/app/backend/internal/cards"
+	"sdsm/app/backend/internal/manager"
+)
+
+const (
+	dashboardAlertsTemplate = "cards/dashboard_alerts.html"
+	dashboardResolvedLimit  = 5
+)
+
+type dashboardAlertsCard struct{}
+
+func init() {
+	cards.Register(dashboardAlertsCard{})
+}
+
+func (dashboardAlertsCard) ID() string {
+	return "dashboard-alerts"
+}
+
+func (dashboardAlertsCard) Template() string {
+	return dashboardAlertsTemplate
+}
+
+func (dashboardAlertsCard) Screens() []cards.Screen {
+	return []cards.Screen{cards.ScreenDashboard}
+}
+
+func (dashboardAlertsCard) Slot() cards.Slot {
+	return cards.SlotPrimary
+}
+
+// FetchData lists alerts for the host and for the servers visible on the dashboard.
+func (dashboardAlertsCard) FetchData(req *cards.Request) (gin.H, error) {
+	data := gin.H{}
+	if req == nil || req.Manager == nil {
+		return data, nil
+	}
+	visible := map[int]bool{}
+	for _, s := range extractServersFromPayload(req) {
+		if s != nil {
+			visible[s.ID] = true
+		}
+	}
+	filter := func(alerts []manager.Alert, limit int) []manager.Alert {
+		out := make([]manager.Alert, 0, len(alerts))
+		for _, a := range alerts {
+			if a.ServerID != 0 && !visible[a.ServerID] {
+				continue
+			}
+			out = append(out, a)
+			if limit > 0 && len(out) == limit {
+				break
+			}
+		}
+		return out
+	}
+	active := filter(req.Manager.ActiveAlerts(), 0)
+	firing := 0
+	for _, a := range active {
+		if a.State == manager.AlertStateFiring {
+			firing++
+		}
+	}
+	data["active"] = active
+	data["firingCount"] = firing
+	data["resolved"] = filter(req.Manager.ResolvedAlerts(0), dashboardResolvedLimit)
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package dashboard
+
+import (
+	"github.com/gin-gonic/gin"
+
+	cards "sdsm/app/backend/internal/cards"
+)
+
+const dashboardHistoryTemplate = "cards/dashboard_history.html"
+
+type dashboardHistoryCard struct{}
+
+func init() {
+	cards.Register(dashboardHistoryCard{})
+}
+
+func (dashboardHistoryCard) ID() string {
+	return "dashboard-history"
+}
+
+func (dashboardHistoryCard) Template() string {
+	return dashboardHistoryTemplate
+}
+
+func (dashboardHistoryCard) Screens() []cards.Screen {
+	return []cards.Screen{cards.ScreenDashboard}
+}
+
+func (dashboardHistoryCard) Slot() cards.Slot {
+	return cards.SlotPrimary
+}
+
+// FetchData only supplies defaults; the chart loads /api/stats/history client-side.
+func (dashboardHistoryCard) FetchData(req *cards.Request) (gin.H, error) {
+	return gin.H{"defaultRange": "24h"}, nil
+}
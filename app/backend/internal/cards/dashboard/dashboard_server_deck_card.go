@@ -56,9 +56,11 @@ func (dashboardServerDeckCard) FetchData(req *cards.Request) (gin.H, error) {
 		}
 	}
 	context := gin.H{
-		"servers": servers,
-		"role":    role,
+		"servers":          servers,
+		"role":             role,
+		"canRenameServers": req.Payload["canRenameServers"],
 	}
+	copyAccessFlags(data, req.Payload)
 	data["role"] = role
 	data["active"] = active
 	data["startable"] = startable
@@ -1,6 +1,8 @@
 package dashboard
 
 import (
+	"github.com/gin-gonic/gin"
+
 	cards "sdsm/app/backend/internal/cards"
 	"sdsm/app/backend/internal/models"
 )
@@ -21,3 +23,12 @@ func extractServersFromPayload(req *cards.Request) []*models.Server {
 	}
 	return nil
 }
+
+// copyAccessFlags passes the requester's permission flags from the page payload to a card.
+func copyAccessFlags(data, payload gin.H) {
+	for _, k := range []string{"canCreateServers", "canBulkControl"} {
+		if v, ok := payload[k]; ok {
+			data[k] = v
+		}
+	}
+}
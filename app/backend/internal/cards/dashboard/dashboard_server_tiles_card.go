@@ -57,9 +57,11 @@ func (dashboardServerTilesCard) FetchData(req *cards.Request) (gin.H, error) {
 		}
 	}
 	context := gin.H{
-		"servers": servers,
-		"role":    role,
+		"servers":          servers,
+		"role":             role,
+		"canRenameServers": req.Payload["canRenameServers"],
 	}
+	copyAccessFlags(data, req.Payload)
 
 	data["role"] = role
 	data["servers"] = servers
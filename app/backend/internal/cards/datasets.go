@@ -124,7 +124,7 @@ func (d *Datasets) PlayerRoster() *PlayerRosterDataset {
 		d.roster = &PlayerRosterDataset{
 			LiveClients:    liveSorted,
 			HistoryClients: historySorted,
-			BannedEntries:  server.BannedEntries(),
+			BannedEntries:  d.manager.AnnotateBannedEntries(server.ID, server.BannedEntries()),
 			BannedIDs:      server.ReadBlacklistIDs(),
 		}
 	})
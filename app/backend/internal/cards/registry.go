@@ -38,6 +38,8 @@ type Request struct {
 	Payload  gin.H
 	Manager  *manager.Manager
 	Datasets *Datasets
+	// Permissions the requesting user holds on Server (nil when not resolved).
+	Permissions manager.PermissionSet
 }
 
 // Card describes a renderable dashboard component.
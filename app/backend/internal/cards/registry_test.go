@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"sdsm/app/backend/internal/manager"
 	"sdsm/app/backend/internal/models"
 )
 
@@ -189,6 +190,30 @@ func TestCardCapabilitiesAllowedRoles(t *testing.T) {
 	})
 }
 
+func TestCardCapabilitiesRequiredPermissions(t *testing.T) {
+	withIsolatedRegistry(t, func() {
+		Register(capabilityStubCard{
+			stubCard: stubCard{
+				id:       "saves-card",
+				template: "cards/saves.html",
+				slot:     SlotPrimary,
+				screens:  []Screen{ScreenServerStatus},
+			},
+			caps: CardCapabilities{RequiredPermissions: []manager.Permission{manager.PermSavesDelete, manager.PermServerSave}},
+		})
+
+		moderatorReq := &Request{Server: &models.Server{}, Permissions: manager.PermissionSet{manager.PermServerKick: true}}
+		if renderables := BuildRenderables(ScreenServerStatus, moderatorReq); len(renderables) != 0 {
+			t.Fatalf("expected card hidden without save permissions, got %d", len(renderables))
+		}
+
+		operatorReq := &Request{Server: &models.Server{}, Permissions: manager.PermissionSet{manager.PermServerSave: true}}
+		if renderables := BuildRenderables(ScreenServerStatus, operatorReq); len(renderables) != 1 {
+			t.Fatalf("expected card visible with server.save, got %d", len(renderables))
+		}
+	})
+}
+
 func TestCardCapabilitiesRequirePlayerSaves(t *testing.T) {
 	withIsolatedRegistry(t, func() {
 		Register(capabilityStubCard{
@@ -2,13 +2,18 @@ package serverstatus
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	cards "sdsm/app/backend/internal/cards"
 )
 
-const serverStatusChatTemplate = "cards/server_status_chat.html"
+const (
+	serverStatusChatTemplate = "cards/server_status_chat.html"
+	// maxChatCardModerationHits caps the moderation log shown under the transcript.
+	maxChatCardModerationHits = 25
+)
 
 type serverStatusChatCard struct{}
 
@@ -45,7 +50,19 @@ func (serverStatusChatCard) FetchData(req *cards.Request) (gin.H, error) {
 		if role, ok := req.Payload["role"].(string); ok {
 			data["role"] = role
 		}
+		if perms, ok := req.Payload["perms"]; ok {
+			data["perms"] = perms
+		}
+	}
+
+	hits := req.Server.ModerationHits()
+	if len(hits) > maxChatCardModerationHits {
+		hits = hits[:maxChatCardModerationHits]
 	}
+	data["moderation"] = req.Server.ChatModeration
+	data["moderationHits"] = hits
+	data["blockedWords"] = strings.Join(req.Server.ChatModeration.BlockedWords, "\n")
+	data["blockedPatterns"] = strings.Join(req.Server.ChatModeration.BlockedPatterns, "\n")
 
 	if _, ok := data["server"]; !ok {
 		data["server"] = req.Server
@@ -36,7 +36,9 @@ func (serverStatusConfigCard) Slot() cards.Slot {
 
 func (serverStatusConfigCard) Capabilities() cards.CardCapabilities {
 	return cards.CardCapabilities{
-		AllowedRoles: []string{string(manager.RoleAdmin)},
+		RequiredPermissions: []manager.Permission{
+			manager.PermServerSettings,
+		},
 	}
 }
 
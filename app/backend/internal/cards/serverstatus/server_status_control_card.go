@@ -35,9 +35,8 @@ func (serverStatusControlCard) Slot() cards.Slot {
 
 func (serverStatusControlCard) Capabilities() cards.CardCapabilities {
 	return cards.CardCapabilities{
-		AllowedRoles: []string{
-			string(manager.RoleAdmin),
-			string(manager.RoleOperator),
+		RequiredPermissions: []manager.Permission{
+			manager.PermServerStart, manager.PermServerStop, manager.PermServerRestart, manager.PermServerConsole, manager.PermServerSave,
 		},
 	}
 }
@@ -35,7 +35,9 @@ func (serverStatusDiscordCard) Slot() cards.Slot {
 
 func (serverStatusDiscordCard) Capabilities() cards.CardCapabilities {
 	return cards.CardCapabilities{
-		AllowedRoles: []string{string(manager.RoleAdmin)},
+		RequiredPermissions: []manager.Permission{
+			manager.PermServerSettings,
+		},
 	}
 }
 
@@ -0,0 +1,41 @@
+package serverstatus
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	cards "sdsm/app/backend/internal/cards"
+)
+
+const serverStatusHistoryTemplate = "cards/server_status_history.html"
+
+type serverStatusHistoryCard struct{}
+
+func init() {
+	cards.Register(serverStatusHistoryCard{})
+}
+
+func (serverStatusHistoryCard) ID() string {
+	return "server-status-history"
+}
+
+func (serverStatusHistoryCard) Template() string {
+	return serverStatusHistoryTemplate
+}
+
+func (serverStatusHistoryCard) Screens() []cards.Screen {
+	return []cards.Screen{cards.ScreenServerStatus}
+}
+
+func (serverStatusHistoryCard) Slot() cards.Slot {
+	return cards.SlotGrid
+}
+
+// FetchData only supplies the server; the chart loads /api/servers/:id/metrics client-side.
+func (serverStatusHistoryCard) FetchData(req *cards.Request) (gin.H, error) {
+	if req == nil || req.Server == nil {
+		return nil, errors.New("server context required")
+	}
+	return gin.H{"server": req.Server, "defaultRange": "24h"}, nil
+}
@@ -45,6 +45,9 @@ func (serverStatusLogsCard) FetchData(req *cards.Request) (gin.H, error) {
 		if role, ok := req.Payload["role"].(string); ok {
 			data["role"] = role
 		}
+		if perms, ok := req.Payload["perms"]; ok {
+			data["perms"] = perms
+		}
 	}
 
 	return data, nil
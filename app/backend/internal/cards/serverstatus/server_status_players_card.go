@@ -46,6 +46,9 @@ func (serverStatusPlayersCard) FetchData(req *cards.Request) (gin.H, error) {
 		if role, ok := req.Payload["role"].(string); ok {
 			data["role"] = role
 		}
+		if perms, ok := req.Payload["perms"]; ok {
+			data["perms"] = perms
+		}
 	}
 
 	if ds := req.Datasets; ds != nil {
@@ -35,9 +35,8 @@ func (serverStatusSavesCard) Slot() cards.Slot {
 
 func (serverStatusSavesCard) Capabilities() cards.CardCapabilities {
 	return cards.CardCapabilities{
-		AllowedRoles: []string{
-			string(manager.RoleAdmin),
-			string(manager.RoleOperator),
+		RequiredPermissions: []manager.Permission{
+			manager.PermServerSave, manager.PermSavesDelete, manager.PermSavesDownload, manager.PermServerBackup,
 		},
 	}
 }
@@ -0,0 +1,55 @@
+package serverstatus
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	cards "sdsm/app/backend/internal/cards"
+	"sdsm/app/backend/internal/manager"
+)
+
+const serverStatusWhitelistTemplate = "cards/server_status_whitelist.html"
+
+type serverStatusWhitelistCard struct{}
+
+func init() {
+	cards.Register(serverStatusWhitelistCard{})
+}
+
+func (serverStatusWhitelistCard) ID() string {
+	return "server-status-whitelist"
+}
+
+func (serverStatusWhitelistCard) Template() string {
+	return serverStatusWhitelistTemplate
+}
+
+func (serverStatusWhitelistCard) Screens() []cards.Screen {
+	return []cards.Screen{cards.ScreenServerStatus}
+}
+
+func (serverStatusWhitelistCard) Slot() cards.Slot {
+	return cards.SlotGrid
+}
+
+func (serverStatusWhitelistCard) Capabilities() cards.CardCapabilities {
+	return cards.CardCapabilities{
+		RequiredPermissions: []manager.Permission{
+			manager.PermServerBan,
+			manager.PermServerSettings,
+		},
+	}
+}
+
+func (serverStatusWhitelistCard) FetchData(req *cards.Request) (gin.H, error) {
+	if req == nil || req.Server == nil {
+		return nil, errors.New("server context required")
+	}
+	return gin.H{
+		"server":       req.Server,
+		"entries":      req.Server.WhitelistEntries(),
+		"has_password": strings.TrimSpace(req.Server.Password) != "",
+	}, nil
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"github.com/gin-gonic/gin"
+
+	cards "sdsm/app/backend/internal/cards"
+	"sdsm/app/backend/internal/manager"
+)
+
+const usersAuditTemplate = "cards/users_audit.html"
+
+type usersAuditCard struct{}
+
+func init() {
+	cards.Register(usersAuditCard{})
+}
+
+func (usersAuditCard) ID() string {
+	return "users-audit"
+}
+
+func (usersAuditCard) Template() string {
+	return usersAuditTemplate
+}
+
+func (usersAuditCard) Screens() []cards.Screen {
+	return []cards.Screen{cards.ScreenUsers}
+}
+
+func (usersAuditCard) Slot() cards.Slot {
+	return cards.SlotPrimary
+}
+
+func (usersAuditCard) Capabilities() cards.CardCapabilities {
+	return cards.CardCapabilities{RequiredPermissions: []manager.Permission{manager.PermAuditView}}
+}
+
+func (usersAuditCard) FetchData(req *cards.Request) (gin.H, error) {
+	data := gin.H{"filter": gin.H{}}
+	if req == nil || req.Payload == nil {
+		return data, nil
+	}
+	if entries, ok := req.Payload["auditEntries"]; ok {
+		data["entries"] = entries
+	}
+	if filter, ok := req.Payload["auditFilter"]; ok {
+		data["filter"] = filter
+	}
+	return data, nil
+}
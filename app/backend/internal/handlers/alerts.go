@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// visibleAlerts drops server alerts for servers the requester cannot view.
+func (h *ManagerHandlers) visibleAlerts(c *gin.Context, alerts []manager.Alert) []manager.Alert {
+	out := make([]manager.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.ServerID == 0 || h.can(c, a.ServerID, manager.PermServerView) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// APIAlertsList returns pending/firing alerts and recently resolved ones.
+func (h *ManagerHandlers) APIAlertsList(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"active":   h.visibleAlerts(c, h.manager.ActiveAlerts()),
+		"resolved": h.visibleAlerts(c, h.manager.ResolvedAlerts(0)),
+	})
+}
+
+// APIAlertRulesList returns the configured alert rules (requires manager.config).
+func (h *ManagerHandlers) APIAlertRulesList(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": h.manager.AlertRuleConfigs()})
+}
+
+// APIAlertRulesUpdate replaces the alert rule list (requires manager.config).
+// JSON: { "rules": [ { id, name, kind, enabled, threshold, for_seconds, severity, server_ids } ] }
+func (h *ManagerHandlers) APIAlertRulesUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Rules []manager.AlertRule `json:"rules"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetAlertRules(req.Rules); err != nil {
+		ToastError(c, "Alert Rules", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Alert Rules", "Alert rules saved.")
+	c.JSON(http.StatusOK, gin.H{"rules": h.manager.AlertRuleConfigs()})
+}
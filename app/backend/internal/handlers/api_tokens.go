@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIProfileTokensList returns the current user's personal access tokens (without secrets).
+func (h *ProfileHandlers) APIProfileTokensList(c *gin.Context) {
+	tokens, err := h.users.APITokens(c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// APIProfileTokensCreate issues a personal access token for the current user.
+// Request JSON: { "name": string, "scopes": ["read","control","console","admin"],
+// "all_servers": bool, "servers": [int], "expires_in_days": int (0 = never) }
+// The plaintext token is only returned in this response.
+func (h *ProfileHandlers) APIProfileTokensCreate(c *gin.Context) {
+	var req struct {
+		Name          string   `json:"name"`
+		Scopes        []string `json:"scopes"`
+		AllServers    bool     `json:"all_servers"`
+		Servers       []int    `json:"servers"`
+		ExpiresInDays int      `json:"expires_in_days"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ToastError(c, "Invalid Request", "Malformed JSON payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	scopes := make([]manager.TokenScope, 0, len(req.Scopes))
+	for _, raw := range req.Scopes {
+		scope, ok := manager.ParseTokenScope(raw)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown scope %q", raw)})
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > 3650 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_days must be between 0 and 3650"})
+		return
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		t := time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		expiresAt = &t
+	}
+	raw, token, err := h.users.CreateAPIToken(c.GetString("username"), req.Name, scopes, req.AllServers, req.Servers, expiresAt)
+	if err != nil {
+		ToastError(c, "Token Not Created", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Token Created", "Copy the token now; it will not be shown again.")
+	c.JSON(http.StatusCreated, gin.H{"token": raw, "info": token})
+}
+
+// APIProfileTokenRevoke deletes one of the current user's tokens.
+func (h *ProfileHandlers) APIProfileTokenRevoke(c *gin.Context) {
+	revokeAPIToken(c, h.users, c.GetString("username"))
+}
+
+// APIUsersTokensList returns another user's tokens (requires users.manage).
+func (h *UserHandlers) APIUsersTokensList(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	tokens, err := h.users.APITokens(strings.TrimSpace(c.Param("username")))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// APIUsersTokenRevoke deletes another user's token (requires users.manage).
+func (h *UserHandlers) APIUsersTokenRevoke(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	revokeAPIToken(c, h.users, strings.TrimSpace(c.Param("username")))
+}
+
+func revokeAPIToken(c *gin.Context, users *manager.UserStore, username string) {
+	id := strings.TrimSpace(c.Param("token_id"))
+	if err := users.RevokeAPIToken(username, id); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, manager.ErrAPITokenNotFound) {
+			status = http.StatusNotFound
+		}
+		ToastError(c, "Revoke Failed", err.Error())
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Token Revoked", "The API token can no longer be used.")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditCardLimit is how many entries the Users-screen audit card shows.
+const auditCardLimit = 25
+
+// SetAuditLog attaches the audit log used by the audit API and card.
+func (h *UserHandlers) SetAuditLog(audit *manager.AuditLog) { h.audit = audit }
+
+// APIAuditList returns audit entries newest first.
+// Query: user, server_id, route, result (ok|denied|error), q (free text),
+// since/until (RFC3339 or YYYY-MM-DD), limit (default 200, max 1000).
+func (h *UserHandlers) APIAuditList(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermAuditView) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 200
+	}
+	entries, err := h.audit.Query(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if entries == nil {
+		entries = []manager.AuditEntry{}
+	}
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+func auditFilterFromQuery(c *gin.Context) (manager.AuditFilter, error) {
+	f := manager.AuditFilter{
+		User:   strings.TrimSpace(c.Query("user")),
+		Route:  strings.TrimSpace(c.Query("route")),
+		Result: strings.TrimSpace(c.Query("result")),
+		Query:  strings.TrimSpace(c.Query("q")),
+	}
+	if raw := strings.TrimSpace(c.Query("server_id")); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return f, errors.New("invalid server_id")
+		}
+		f.ServerID = id
+	}
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return f, errors.New("invalid limit")
+		}
+		f.Limit = n
+	}
+	var err error
+	if f.Since, err = parseAuditTime(c.Query("since"), false); err != nil {
+		return f, errors.New("invalid since")
+	}
+	if f.Until, err = parseAuditTime(c.Query("until"), true); err != nil {
+		return f, errors.New("invalid until")
+	}
+	return f, nil
+}
+
+// parseAuditTime accepts RFC3339 or a bare date; a bare "until" date covers the whole day.
+func parseAuditTime(raw string, endOfDay bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// auditCardPayload loads the entries shown on the Users-screen audit card.
+func (h *UserHandlers) auditCardPayload(c *gin.Context) ([]manager.AuditEntry, gin.H) {
+	filter, _ := auditFilterFromQuery(c)
+	filter.Limit = auditCardLimit
+	entries, _ := h.audit.Query(filter)
+	return entries, gin.H{"user": filter.User, "q": filter.Query, "result": filter.Result}
+}
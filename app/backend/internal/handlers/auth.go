@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+
+	"sdsm/app/backend/internal/integrations/oidc"
 
 	"sdsm/app/backend/internal/manager"
 	"sdsm/app/backend/internal/middleware"
+	"sdsm/app/backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,13 +19,18 @@ type AuthHandlers struct {
 	authService *middleware.AuthService
 	manager     *manager.Manager
 	users       *manager.UserStore
+
+	oidcMu sync.Mutex
+	oidc   *oidc.Provider
 }
 
-func (h *AuthHandlers) logAuthEvent(format string, args ...interface{}) {
-	if h == nil || h.manager == nil || h.manager.Log == nil {
-		return
+// authLog returns the manager log for authentication events.
+func (h *AuthHandlers) authLog() *utils.Logger {
+	var m *manager.Manager
+	if h != nil {
+		m = h.manager
 	}
-	h.manager.Log.Write(fmt.Sprintf(format, args...))
+	return m.Logger("auth", 0)
 }
 
 func (h *AuthHandlers) reloadUsers(reason string) {
@@ -29,7 +38,7 @@ func (h *AuthHandlers) reloadUsers(reason string) {
 		return
 	}
 	if err := h.users.Load(); err != nil {
-		h.logAuthEvent("User store reload failed (%s): %v", reason, err)
+		h.authLog().Error(fmt.Sprintf("User store reload failed (%s): %v", reason, err))
 	}
 }
 
@@ -73,10 +82,10 @@ func (h *AuthHandlers) LoginGET(c *gin.Context) {
 	}
 
 	redirect := c.Query("redirect")
-	c.HTML(http.StatusOK, "login.html", gin.H{
+	c.HTML(http.StatusOK, "login.html", h.loginView(gin.H{
 		"redirect": redirect,
 		"error":    c.Query("error"),
-	})
+	}))
 }
 
 func (h *AuthHandlers) LoginPOST(c *gin.Context) {
@@ -90,11 +99,11 @@ func (h *AuthHandlers) LoginPOST(c *gin.Context) {
 	redirect := strings.TrimSpace(c.PostForm("redirect"))
 
 	if username == "" || password == "" {
-		h.logAuthEvent("UI login rejected: missing credentials from %s", c.ClientIP())
-		c.HTML(http.StatusBadRequest, "login.html", gin.H{
+		h.authLog().Warn(fmt.Sprintf("UI login rejected: missing credentials from %s", c.ClientIP()))
+		c.HTML(http.StatusBadRequest, "login.html", h.loginView(gin.H{
 			"error":    "Username and password are required",
 			"redirect": redirect,
-		})
+		}))
 		return
 	}
 
@@ -104,26 +113,26 @@ func (h *AuthHandlers) LoginPOST(c *gin.Context) {
 	u, exists := h.users.Get(username)
 	if !exists || !h.authService.CheckPassword(password, u.PasswordHash) {
 		if !exists {
-			h.logAuthEvent("UI login failed for unknown user '%s' from %s", username, c.ClientIP())
+			h.authLog().Warn(fmt.Sprintf("UI login failed for unknown user '%s' from %s", username, c.ClientIP()))
 		} else {
-			h.logAuthEvent("UI login failed for user '%s' from %s: password mismatch", username, c.ClientIP())
+			h.authLog().Warn(fmt.Sprintf("UI login failed for user '%s' from %s: password mismatch", username, c.ClientIP()))
 		}
-		c.HTML(http.StatusUnauthorized, "login.html", gin.H{
+		c.HTML(http.StatusUnauthorized, "login.html", h.loginView(gin.H{
 			"error":    "Invalid username or password",
 			"redirect": redirect,
-		})
+		}))
 		return
 	}
 
-	h.logAuthEvent("UI login successful for user '%s' from %s", username, c.ClientIP())
+	h.authLog().Info(fmt.Sprintf("UI login successful for user '%s' from %s", username, c.ClientIP()))
 
 	// Generate JWT token
 	token, err := h.authService.GenerateToken(username)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "login.html", gin.H{
+		c.HTML(http.StatusInternalServerError, "login.html", h.loginView(gin.H{
 			"error":    "Failed to generate authentication token",
 			"redirect": redirect,
-		})
+		}))
 		return
 	}
 
@@ -165,9 +174,9 @@ func (h *AuthHandlers) APILogin(c *gin.Context) {
 	u, exists := h.users.Get(username)
 	if !exists || !h.authService.CheckPassword(password, u.PasswordHash) {
 		if !exists {
-			h.logAuthEvent("API login failed for unknown user '%s' from %s", username, c.ClientIP())
+			h.authLog().Warn(fmt.Sprintf("API login failed for unknown user '%s' from %s", username, c.ClientIP()))
 		} else {
-			h.logAuthEvent("API login failed for user '%s' from %s: password mismatch", username, c.ClientIP())
+			h.authLog().Warn(fmt.Sprintf("API login failed for user '%s' from %s: password mismatch", username, c.ClientIP()))
 		}
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid username or password",
@@ -175,7 +184,7 @@ func (h *AuthHandlers) APILogin(c *gin.Context) {
 		return
 	}
 
-	h.logAuthEvent("API login successful for user '%s' from %s", username, c.ClientIP())
+	h.authLog().Info(fmt.Sprintf("API login successful for user '%s' from %s", username, c.ClientIP()))
 
 	// Generate JWT token
 	token, err := h.authService.GenerateToken(username)
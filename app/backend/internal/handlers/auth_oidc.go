@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/oidc"
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie carries state, nonce, PKCE verifier and the post-login redirect
+// between the authorization redirect and the callback.
+const oidcStateCookie = "sdsm_oidc"
+
+// oidcProvider returns the provider for the current config, rebuilding it when the
+// connection settings change. It returns nil when single sign-on is disabled. Role
+// mappings and the redirect URL are read from h.manager.OIDC on every login.
+func (h *AuthHandlers) oidcProvider() *oidc.Provider {
+	if h.manager == nil || !h.manager.OIDC.Enabled {
+		return nil
+	}
+	cfg := h.manager.OIDC
+	h.oidcMu.Lock()
+	defer h.oidcMu.Unlock()
+	if h.oidc == nil || !sameOIDCConfig(h.oidc.Config(), cfg) {
+		h.oidc = oidc.NewProvider(cfg, nil)
+	}
+	return h.oidc
+}
+
+func sameOIDCConfig(a, b oidc.Config) bool {
+	return strings.TrimRight(a.Issuer, "/") == strings.TrimRight(b.Issuer, "/") &&
+		a.ClientID == b.ClientID && a.ClientSecret == b.ClientSecret &&
+		strings.Join(a.Scopes, " ") == strings.Join(b.Scopes, " ") &&
+		a.UsernameClaim == b.UsernameClaim
+}
+
+// loginView adds the single sign-on button label to login page data.
+func (h *AuthHandlers) loginView(data gin.H) gin.H {
+	if h.manager != nil && h.manager.OIDC.Enabled {
+		data["oidcLabel"] = h.manager.OIDC.Label()
+	}
+	return data
+}
+
+func oidcRedirectURL(c *gin.Context, cfg oidc.Config) string {
+	if u := strings.TrimSpace(cfg.RedirectURL); u != "" {
+		return u
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/auth/oidc/callback"
+}
+
+// OIDCLoginGET starts a single sign-on login by redirecting to the provider.
+func (h *AuthHandlers) OIDCLoginGET(c *gin.Context) {
+	p := h.oidcProvider()
+	if p == nil {
+		c.Redirect(http.StatusFound, "/login")
+		return
+	}
+	req, err := oidc.NewAuthRequest()
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "login.html", h.loginView(gin.H{"error": "Unable to start single sign-on"}))
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+	target, err := p.AuthCodeURL(ctx, req, oidcRedirectURL(c, h.manager.OIDC))
+	if err != nil {
+		h.authLog().Error(fmt.Sprintf("SSO login failed to start: %v", err))
+		c.HTML(http.StatusBadGateway, "login.html", h.loginView(gin.H{"error": "Single sign-on provider is unavailable"}))
+		return
+	}
+	redirect := safeLocalRedirect(c.Query("redirect"))
+	value := url.Values{"s": {req.State}, "n": {req.Nonce}, "v": {req.Verifier}, "r": {redirect}}.Encode()
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/auth/oidc",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Redirect(http.StatusFound, target)
+}
+
+// OIDCCallbackGET completes a single sign-on login: it verifies the ID token, provisions
+// the user just in time with the mapped role and issues the regular session cookie.
+func (h *AuthHandlers) OIDCCallbackGET(c *gin.Context) {
+	p := h.oidcProvider()
+	if p == nil {
+		c.Redirect(http.StatusFound, "/login")
+		return
+	}
+	fail := func(status int, msg, detail string) {
+		h.authLog().Warn(fmt.Sprintf("SSO login failed from %s: %s", c.ClientIP(), detail))
+		c.HTML(status, "login.html", h.loginView(gin.H{"error": msg}))
+	}
+	raw, err := c.Cookie(oidcStateCookie)
+	http.SetCookie(c.Writer, &http.Cookie{Name: oidcStateCookie, Value: "", Path: "/auth/oidc", MaxAge: -1, HttpOnly: true})
+	if err != nil {
+		fail(http.StatusBadRequest, "Single sign-on session expired, please try again", "missing state cookie")
+		return
+	}
+	saved, _ := url.ParseQuery(raw)
+	if e := c.Query("error"); e != "" {
+		fail(http.StatusUnauthorized, "Single sign-on was cancelled or denied", "provider error "+e)
+		return
+	}
+	if saved.Get("s") == "" || c.Query("state") != saved.Get("s") {
+		fail(http.StatusBadRequest, "Single sign-on session expired, please try again", "state mismatch")
+		return
+	}
+	req := oidc.AuthRequest{State: saved.Get("s"), Nonce: saved.Get("n"), Verifier: saved.Get("v")}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+	id, err := p.Exchange(ctx, c.Query("code"), req, oidcRedirectURL(c, h.manager.OIDC))
+	if err != nil {
+		fail(http.StatusUnauthorized, "Single sign-on failed", err.Error())
+		return
+	}
+	role, ok := h.manager.OIDC.Role(id)
+	if !ok {
+		fail(http.StatusForbidden, "Your account is not allowed to use SDSM", "no role mapping for "+id.Username)
+		return
+	}
+	u, err := h.users.UpsertOIDCUser(id.Issuer, id.Subject, id.Username, manager.Role(role))
+	if err != nil {
+		fail(http.StatusInternalServerError, "Single sign-on failed", err.Error())
+		return
+	}
+	token, err := h.authService.GenerateToken(u.Username)
+	if err != nil {
+		fail(http.StatusInternalServerError, "Failed to generate authentication token", err.Error())
+		return
+	}
+	h.authLog().Info(fmt.Sprintf("SSO login successful for user '%s' (subject %s, role %s) from %s", u.Username, id.Subject, u.Role, c.ClientIP()))
+	middleware.SetAuthCookie(c, token)
+	redirect := saved.Get("r")
+	if redirect == "" {
+		redirect = "/dashboard"
+		if u.Role == manager.RoleAdmin {
+			redirect = "/manager"
+		}
+	}
+	c.Redirect(http.StatusFound, redirect)
+}
+
+// safeLocalRedirect only keeps same-site absolute paths to avoid open redirects.
+func safeLocalRedirect(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") || strings.HasPrefix(raw, "/\\") {
+		return ""
+	}
+	if raw == "/login" || raw == "/setup" {
+		return ""
+	}
+	return raw
+}
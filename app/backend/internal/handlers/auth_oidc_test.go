@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"sdsm/app/backend/internal/integrations/oidc"
+	"sdsm/app/backend/internal/integrations/oidc/oidctest"
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOIDCLoginProvisionsUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mock := oidctest.NewProvider("sdsm", "s3cret")
+	defer mock.Close()
+
+	store := setupTestUserStore(t)
+	if _, err := store.CreateUser("alice", "hash", manager.RoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	mgr := &manager.Manager{OIDC: oidc.Config{
+		Enabled: true, Issuer: mock.Issuer(), ClientID: "sdsm", ClientSecret: "s3cret",
+		RedirectURL:  "http://sdsm.test/auth/oidc/callback",
+		RoleMappings: []oidc.RoleMapping{{Value: "ops", Role: "operator"}},
+	}}
+	auth := middleware.NewAuthService()
+	h := NewAuthHandlers(auth, mgr, store)
+	r := gin.New()
+	r.SetHTMLTemplate(template.Must(template.New("login.html").Parse(`{{.error}}`)))
+	r.GET("/auth/oidc/login", h.OIDCLoginGET)
+	r.GET("/auth/oidc/callback", h.OIDCCallbackGET)
+
+	signIn := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/oidc/login?redirect=/server/1", nil))
+		if w.Code != http.StatusFound || !strings.HasPrefix(w.Header().Get("Location"), mock.Issuer()+"/authorize") {
+			t.Fatalf("expected redirect to provider, got %d %q", w.Code, w.Header().Get("Location"))
+		}
+		stateCookie := w.Result().Cookies()[0]
+
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		resp, err := client.Get(w.Header().Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		back, _ := url.Parse(resp.Header.Get("Location"))
+
+		req := httptest.NewRequest(http.MethodGet, back.RequestURI(), nil)
+		req.AddCookie(stateCookie)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Unmapped group and no default role: refused, no account created.
+	mock.SetClaims(map[string]interface{}{"sub": "kc-1", "preferred_username": "alice", "groups": []string{"players"}})
+	if w := signIn(); w.Code != http.StatusForbidden {
+		t.Fatalf("expected unmapped user to be refused, got %d", w.Code)
+	}
+	if len(store.Users()) != 1 {
+		t.Fatalf("no user should be created for a refused login")
+	}
+
+	mock.SetClaims(map[string]interface{}{"sub": "kc-1", "preferred_username": "alice", "groups": []string{"ops"}})
+	w := signIn()
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/server/1" {
+		t.Fatalf("expected redirect after login, got %d %q: %s", w.Code, w.Header().Get("Location"), w.Body.String())
+	}
+	var session string
+	for _, ck := range w.Result().Cookies() {
+		if ck.Name == middleware.CookieName {
+			session = ck.Value
+		}
+	}
+	claims, err := auth.ValidateToken(session)
+	if err != nil {
+		t.Fatalf("expected session cookie: %v", err)
+	}
+	// The existing local "alice" must not be taken over by the SSO identity.
+	if claims.Username != "alice-2" {
+		t.Fatalf("expected a separate account, got %q", claims.Username)
+	}
+	u, ok := store.Get("alice-2")
+	if !ok || u.Role != manager.RoleOperator || u.OIDCSubject != "kc-1" || auth.CheckPassword("", u.PasswordHash) {
+		t.Fatalf("unexpected provisioned user %+v", u)
+	}
+
+	// Role follows the provider on the next login.
+	mock.SetClaims(map[string]interface{}{"sub": "kc-1", "preferred_username": "alice", "groups": []string{"ops", "viewers"}})
+	mgr.OIDC.RoleMappings = []oidc.RoleMapping{{Value: "viewers", Role: "viewer"}}
+	if w := signIn(); w.Code != http.StatusFound {
+		t.Fatalf("second login failed: %d", w.Code)
+	}
+	if u, _ := store.Get("alice-2"); u.Role != manager.RoleViewer || len(store.Users()) != 2 {
+		t.Fatalf("expected role update on the linked account, got %+v", u)
+	}
+
+	// A callback without the state cookie is rejected.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?code=x&state=y", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected missing state to fail, got %d", w.Code)
+	}
+}
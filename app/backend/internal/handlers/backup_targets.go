@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/backuptarget"
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+func backupTargetsJSON(cfgs []backuptarget.Config) []gin.H {
+	items := make([]gin.H, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		items = append(items, gin.H{
+			"target":     cfg.Redacted(),
+			"has_secret": cfg.HasSecret(),
+		})
+	}
+	return items
+}
+
+// APIBackupTargetsList returns the configured off-host backup targets with credentials redacted (requires manager.config).
+func (h *ManagerHandlers) APIBackupTargetsList(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"targets": backupTargetsJSON(h.manager.BackupTargetConfigs())})
+}
+
+// APIBackupTargetsUpdate replaces the backup target list (requires manager.config).
+// JSON: { "targets": [ { name, type, enabled, ... } ] }. Omitted credentials keep the stored values.
+func (h *ManagerHandlers) APIBackupTargetsUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Targets []backuptarget.Config `json:"targets"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetBackupTargets(req.Targets); err != nil {
+		ToastError(c, "Backup Targets", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Backup Targets", "Backup targets saved.")
+	c.JSON(http.StatusOK, gin.H{"targets": backupTargetsJSON(h.manager.BackupTargetConfigs())})
+}
+
+// APIBackupTargetTest uploads and verifies a probe file on one target (requires manager.config).
+// JSON: { "name": "<target name>" }
+func (h *ManagerHandlers) APIBackupTargetTest(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name required"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+	if err := h.manager.TestBackupTarget(ctx, req.Name); err != nil {
+		ToastError(c, "Backup Target Test Failed", err.Error())
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Backup Target OK", req.Name+" accepted and verified a probe upload.")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxBanImportBytes = 4 << 20
+
+// canBanRecord reports whether the requester holds server.ban on every server the record
+// covers; global bans need it on every server.
+func (h *ManagerHandlers) canBanRecord(c *gin.Context, b manager.BanRecord) bool {
+	if b.Scope == manager.BanScopeGlobal {
+		if !h.can(c, 0, manager.PermServerBan) {
+			return false
+		}
+		for _, s := range h.manager.Servers {
+			if s != nil && !h.can(c, s.ID, manager.PermServerBan) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, id := range b.ServerIDs {
+		if !h.can(c, id, manager.PermServerBan) {
+			return false
+		}
+	}
+	return len(b.ServerIDs) > 0
+}
+
+// visibleBans keeps global records and records covering a server the requester can view.
+func (h *ManagerHandlers) visibleBans(c *gin.Context, recs []manager.BanRecord) []manager.BanRecord {
+	out := make([]manager.BanRecord, 0, len(recs))
+	for _, b := range recs {
+		if b.Scope == manager.BanScopeGlobal {
+			out = append(out, b)
+			continue
+		}
+		for _, id := range b.ServerIDs {
+			if h.can(c, id, manager.PermServerView) {
+				out = append(out, b)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (h *ManagerHandlers) listBans(c *gin.Context) []manager.BanRecord {
+	serverID, _ := strconv.Atoi(c.Query("server_id"))
+	includeInactive := c.Query("include_inactive") == "1" || strings.EqualFold(c.Query("include_inactive"), "true")
+	return h.visibleBans(c, h.manager.Bans().List(serverID, includeInactive, time.Now()))
+}
+
+// APIBansList returns ban records, newest first.
+// Query: server_id (only bans covering it), include_inactive=1 (also expired and lifted).
+func (h *ManagerHandlers) APIBansList(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"bans": h.listBans(c)})
+}
+
+// APIBanCreate issues a ban.
+// JSON: { steam_id, name?, reason?, duration? ("12h", "7d") or expires_at?, scope ("server", "group", "global"), server_ids? }
+func (h *ManagerHandlers) APIBanCreate(c *gin.Context) {
+	var req struct {
+		manager.BanRecord
+		Duration string `json:"duration"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	rec := req.BanRecord
+	rec.Author = c.GetString("username")
+	rec.CreatedAt = time.Time{}
+	if req.Duration != "" {
+		d, err := manager.ParseBanDuration(req.Duration)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if d > 0 {
+			expires := time.Now().UTC().Add(d)
+			rec.ExpiresAt = &expires
+		}
+	}
+	if err := rec.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.canBanRecord(c, rec) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	for _, id := range rec.ServerIDs {
+		if h.manager.ServerByID(id) == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("server %d not found", id)})
+			return
+		}
+	}
+	recs, err := h.manager.AddBans([]manager.BanRecord{rec})
+	if err != nil {
+		ToastError(c, "Ban Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.broadcastBanChange(recs[0])
+	ToastSuccess(c, "Player Banned", rec.SteamID+" banned ("+string(rec.Scope)+").")
+	c.JSON(http.StatusOK, gin.H{"ban": recs[0]})
+}
+
+// APIBanDelete lifts a ban record and rewrites the affected blacklists.
+func (h *ManagerHandlers) APIBanDelete(c *gin.Context) {
+	id := c.Param("ban_id")
+	var target *manager.BanRecord
+	for _, b := range h.manager.Bans().List(0, false, time.Now()) {
+		if b.ID == id {
+			b := b
+			target = &b
+			break
+		}
+	}
+	if target == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ban not found"})
+		return
+	}
+	if !h.canBanRecord(c, *target) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	rec, err := h.manager.LiftBan(id, c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	h.broadcastBanChange(rec)
+	ToastSuccess(c, "Ban Lifted", rec.SteamID+" is no longer banned.")
+	c.JSON(http.StatusOK, gin.H{"ban": rec})
+}
+
+// APIBansExport downloads the visible ban list as JSON (default) or CSV (?format=csv).
+// Query parameters of APIBansList apply.
+func (h *ManagerHandlers) APIBansExport(c *gin.Context) {
+	recs := h.listBans(c)
+	stamp := time.Now().UTC().Format("20060102")
+	if strings.EqualFold(c.Query("format"), "csv") {
+		var buf bytes.Buffer
+		if err := manager.WriteBansCSV(&buf, recs); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"sdsm-bans-%s.csv\"", stamp))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"sdsm-bans-%s.json\"", stamp))
+	c.JSON(http.StatusOK, recs)
+}
+
+// APIBansImport adds bans from a JSON array or CSV body (?format=csv or a text/csv
+// Content-Type). ?scope=global|group|server with ?server_ids=1,2 re-targets every record,
+// which is needed for lists shared from another SDSM install.
+func (h *ManagerHandlers) APIBansImport(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBanImportBytes+1))
+	if err != nil || len(body) > maxBanImportBytes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "import too large or unreadable"})
+		return
+	}
+	var recs []manager.BanRecord
+	if strings.EqualFold(c.Query("format"), "csv") || strings.HasPrefix(c.ContentType(), "text/csv") {
+		recs, err = manager.ParseBansCSV(bytes.NewReader(body))
+	} else {
+		err = json.Unmarshal(body, &recs)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import: " + err.Error()})
+		return
+	}
+	scope := manager.BanScope(strings.ToLower(strings.TrimSpace(c.Query("scope"))))
+	var serverIDs []int
+	for _, part := range strings.Split(c.Query("server_ids"), ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid server_ids"})
+			return
+		}
+		serverIDs = append(serverIDs, id)
+	}
+	for _, b := range recs {
+		if scope != "" {
+			b.Scope, b.ServerIDs = scope, serverIDs
+		}
+		if b.Scope == "" {
+			b.Scope = manager.BanScopeServer
+		}
+		if !h.canBanRecord(c, b) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+	}
+	added, skipped, err := h.manager.ImportBans(recs, c.GetString("username"), scope, serverIDs)
+	if err != nil {
+		ToastError(c, "Ban Import", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, b := range added {
+		h.broadcastBanChange(b)
+	}
+	ToastSuccess(c, "Ban Import", fmt.Sprintf("Imported %d bans (%d skipped).", len(added), skipped))
+	c.JSON(http.StatusOK, gin.H{"imported": len(added), "skipped": skipped})
+}
+
+// broadcastBanChange refreshes status for every server the record covers.
+func (h *ManagerHandlers) broadcastBanChange(b manager.BanRecord) {
+	for _, s := range h.manager.Servers {
+		if s != nil && b.Covers(s.ID) {
+			h.BroadcastStatusAndStats(s)
+		}
+	}
+}
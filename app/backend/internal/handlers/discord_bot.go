@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *ManagerHandlers) discordBotJSON() gin.H {
+	cfg := h.manager.DiscordBotConfig()
+	return gin.H{
+		"bot":       cfg.Redacted(),
+		"has_token": cfg.Token != "",
+		"connected": h.manager.DiscordBotConnected(),
+	}
+}
+
+// APIDiscordBotGET returns the Discord bot configuration with the token redacted (requires manager.config).
+func (h *ManagerHandlers) APIDiscordBotGET(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, h.discordBotJSON())
+}
+
+// APIDiscordBotUpdate replaces the Discord bot configuration and reconnects (requires manager.config).
+// JSON: { enabled, token?, guild_id?, bridges: [ { server_id, channel_id } ] }. An omitted token keeps the stored one.
+func (h *ManagerHandlers) APIDiscordBotUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var cfg discord.BotConfig
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetDiscordBot(cfg); err != nil {
+		ToastError(c, "Discord Bot", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Discord Bot", "Discord bot settings saved.")
+	c.JSON(http.StatusOK, h.discordBotJSON())
+}
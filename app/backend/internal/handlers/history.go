@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIServerMetricsHistory returns downsampled CPU, memory and player history for a server.
+// Query: range (e.g. 6h, 48h, 7d, 90d; default 24h).
+func (h *ManagerHandlers) APIServerMetricsHistory(c *gin.Context) {
+	serverID, err := strconv.Atoi(c.Param("server_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	if h.manager.ServerByID(serverID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+	h.writeHistory(c, manager.ServerHistorySource(serverID))
+}
+
+// APIStatsHistory returns downsampled host CPU/memory history and total concurrent players.
+// Query: range (e.g. 6h, 48h, 7d, 90d; default 24h).
+func (h *ManagerHandlers) APIStatsHistory(c *gin.Context) {
+	h.writeHistory(c, manager.HostHistorySource)
+}
+
+func (h *ManagerHandlers) writeHistory(c *gin.Context, source string) {
+	rangeParam := strings.TrimSpace(c.Query("range"))
+	span, err := manager.ParseHistoryRange(rangeParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	store := h.manager.History()
+	if store == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "history unavailable"})
+		return
+	}
+	now := time.Now()
+	points, step, err := store.Query(source, now.Add(-span), now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "unable to read history"})
+		return
+	}
+	if rangeParam == "" {
+		rangeParam = "24h"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"range":        rangeParam,
+		"from":         now.Add(-span).UTC().Format(time.RFC3339),
+		"to":           now.UTC().Format(time.RFC3339),
+		"step_seconds": int(step / time.Second),
+		"points":       points,
+	})
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APILogRotationGET returns the rotation policy for each log kind (requires manager.config).
+func (h *ManagerHandlers) APILogRotationGET(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"log_rotation": h.manager.LogRotationConfig()})
+}
+
+// APILogRotationUpdate replaces the rotation policies (requires manager.config).
+// JSON: { "manager": { max_size_mb, max_age_days, max_archives, retention_days, compress }, "update": ..., "server": ..., "output": ..., "web": ... }
+func (h *ManagerHandlers) APILogRotationUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	cfg := h.manager.LogRotationConfig()
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetLogRotation(cfg); err != nil {
+		ToastError(c, "Log Rotation", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Log Rotation", "Log rotation settings saved.")
+	c.JSON(http.StatusOK, gin.H{"log_rotation": h.manager.LogRotationConfig()})
+}
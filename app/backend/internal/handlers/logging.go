@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APILoggingGET returns the log output format and minimum level (requires manager.config).
+func (h *ManagerHandlers) APILoggingGET(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"logging": h.manager.LoggingConfig()})
+}
+
+// APILoggingUpdate sets the log output format and minimum level (requires manager.config).
+// JSON: { "format": "text"|"json", "level": "debug"|"info"|"warn"|"error" }
+func (h *ManagerHandlers) APILoggingUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	cfg := h.manager.LoggingConfig()
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetLogging(cfg); err != nil {
+		ToastError(c, "Logging", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Logging", "Logging settings saved.")
+	c.JSON(http.StatusOK, gin.H{"logging": h.manager.LoggingConfig()})
+}
+
+// logTailFilter reads the optional level and component query params of the tail endpoints.
+// It responds with 400 and returns false when the level is unknown.
+func logTailFilter(c *gin.Context) (utils.LogFilter, bool) {
+	f := utils.LogFilter{MinLevel: utils.LevelDebug, Component: strings.TrimSpace(c.Query("component"))}
+	if raw := strings.TrimSpace(c.Query("level")); raw != "" {
+		lvl, ok := utils.ParseLevel(raw)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid level"})
+			return f, false
+		}
+		f.MinLevel = lvl
+	}
+	return f, true
+}
+
+// filterLogChunk applies f to a chunk read at start. A trailing partial line is left for the
+// next poll so an entry is never judged on half its text; the returned offset accounts for it.
+func filterLogChunk(f utils.LogFilter, buf []byte, start int64) (string, int64) {
+	next := start + int64(len(buf))
+	if !f.Active() {
+		return string(buf), next
+	}
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 && i < len(buf)-1 {
+		buf = buf[:i+1]
+		next = start + int64(len(buf))
+	}
+	return utils.FilterLogLines(string(buf), f), next
+}
@@ -50,11 +50,11 @@ func NewManagerHandlersWithHub(mgr *manager.Manager, us *manager.UserStore, hub
 	return &ManagerHandlers{manager: mgr, userStore: us, hub: hub}
 }
 
-// APIManagerLogsList returns a JSON array of available *.log files in the manager's logs directory.
+// APIManagerLogsList returns the *.log files in the manager's logs directory and their rotated archives.
 func (h *ManagerHandlers) APIManagerLogsList(c *gin.Context) {
 	// Admin only
-	if c.GetString("role") != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	logsDir := ""
@@ -62,12 +62,12 @@ func (h *ManagerHandlers) APIManagerLogsList(c *gin.Context) {
 		logsDir = h.manager.Paths.LogsDir()
 	}
 	if strings.TrimSpace(logsDir) == "" {
-		c.JSON(http.StatusOK, gin.H{"files": []string{}})
+		c.JSON(http.StatusOK, gin.H{"files": []string{}, "archives": []utils.LogArchive{}})
 		return
 	}
 	entries, err := os.ReadDir(logsDir)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{"files": []string{}})
+		c.JSON(http.StatusOK, gin.H{"files": []string{}, "archives": []utils.LogArchive{}})
 		return
 	}
 	files := make([]string, 0, len(entries))
@@ -76,19 +76,19 @@ func (h *ManagerHandlers) APIManagerLogsList(c *gin.Context) {
 			continue
 		}
 		name := e.Name()
-		if strings.HasSuffix(strings.ToLower(name), ".log") {
+		if utils.IsActiveLogName(name) {
 			files = append(files, name)
 		}
 	}
 	sort.Strings(files)
-	c.JSON(http.StatusOK, gin.H{"files": files})
+	c.JSON(http.StatusOK, gin.H{"files": files, "archives": utils.ListLogArchives(logsDir)})
 }
 
 // APIManagerLogTail mirrors APIServerLogTail but reads from the manager logs directory.
-// Query: name (required), offset (-1 for tail), back, max
+// Query: name (required), offset (-1 for tail), back, max, level, component
 func (h *ManagerHandlers) APIManagerLogTail(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	name := filepath.Base(strings.TrimSpace(c.Query("name")))
@@ -96,6 +96,10 @@ func (h *ManagerHandlers) APIManagerLogTail(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid log file"})
 		return
 	}
+	filter, ok := logTailFilter(c)
+	if !ok {
+		return
+	}
 	logsDir := ""
 	if h.manager != nil && h.manager.Paths != nil {
 		logsDir = h.manager.Paths.LogsDir()
@@ -191,15 +195,15 @@ func (h *ManagerHandlers) APIManagerLogTail(c *gin.Context) {
 	if length > 0 {
 		_, _ = file.ReadAt(buf, start)
 	}
-	next := start + length
-	c.JSON(http.StatusOK, gin.H{"data": string(buf), "offset": next, "size": size, "reset": reset})
+	data, next := filterLogChunk(filter, buf, start)
+	c.JSON(http.StatusOK, gin.H{"data": data, "offset": next, "size": size, "reset": reset})
 }
 
 // APIManagerLogClear truncates a manager log file (admin only).
 // JSON/Form params: name=<log filename>
 func (h *ManagerHandlers) APIManagerLogClear(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	name := c.PostForm("name")
@@ -237,14 +241,14 @@ func (h *ManagerHandlers) APIManagerLogClear(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "cleared"})
 }
 
-// APIManagerLogDownload streams a manager log file as an attachment (admin only).
+// APIManagerLogDownload streams a manager log file or rotated archive as an attachment (admin only).
 func (h *ManagerHandlers) APIManagerLogDownload(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	name := filepath.Base(strings.TrimSpace(c.Query("name")))
-	if name == "" || !strings.HasSuffix(strings.ToLower(name), ".log") {
+	if name == "" || !(utils.IsActiveLogName(name) || utils.IsLogArchiveName(name)) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid log file"})
 		return
 	}
@@ -337,7 +341,7 @@ func (h *ManagerHandlers) buildServerStatusCardRequest(c *gin.Context, s *models
 		}
 	}
 	if bannedEntries == nil {
-		bannedEntries = s.BannedEntries()
+		bannedEntries = mgr.AnnotateBannedEntries(s.ID, s.BannedEntries())
 	}
 	if bannedIDs == nil {
 		bannedIDs = s.ReadBlacklistIDs()
@@ -611,12 +615,20 @@ func (h *ManagerHandlers) buildServerStatusCardRequest(c *gin.Context, s *models
 
 	payload["currentServerPath"] = fmt.Sprintf("server/%d", s.ID)
 
+	perms := requestPermissions(c, h.userStore, s.ID)
+	permNames := make(map[string]bool, len(perms))
+	for p, ok := range perms {
+		permNames[string(p)] = ok
+	}
+	payload["perms"] = permNames
+
 	cardReq := &cards.Request{
-		Context:  c,
-		Server:   s,
-		Payload:  payload,
-		Manager:  mgr,
-		Datasets: datasets,
+		Context:     c,
+		Server:      s,
+		Payload:     payload,
+		Manager:     mgr,
+		Datasets:    datasets,
+		Permissions: perms,
 	}
 	return cardReq, payload
 }
@@ -740,17 +752,7 @@ func (h *ManagerHandlers) buildDashboardPayload(c *gin.Context) gin.H {
 	role := c.GetString("role")
 	username, _ := c.Get("username")
 
-	servers := h.manager.Servers
-	if role != "admin" {
-		uname, _ := username.(string)
-		filtered := make([]*models.Server, 0, len(servers))
-		for _, s := range servers {
-			if h.userStore != nil && h.userStore.CanAccess(uname, s.ID) {
-				filtered = append(filtered, s)
-			}
-		}
-		servers = filtered
-	}
+	servers := h.visibleServers(c)
 
 	rootPath := ""
 	if h.manager != nil && h.manager.Paths != nil {
@@ -884,18 +886,20 @@ func (h *ManagerHandlers) buildDashboardPayload(c *gin.Context) gin.H {
 		"uptimeLabel":           uptimeLabel,
 		"loadAverageLabel":      loadLabel,
 	}
+	renamable := renamableServers(c, h.userStore, servers)
 	serverDeck := gin.H{
 		"role":      role,
 		"startable": startableServers,
 		"active":    activeServers,
 		"context": gin.H{
-			"servers": servers,
-			"role":    role,
+			"servers":          servers,
+			"role":             role,
+			"canRenameServers": renamable,
 		},
 	}
 
 	var userStats gin.H
-	if strings.EqualFold(role, "admin") && h.userStore != nil {
+	if Can(c, h.userStore, 0, manager.PermUsersManage) && h.userStore != nil {
 		users := h.userStore.Users()
 		totalUsers := len(users)
 		adminCount := 0
@@ -919,7 +923,7 @@ func (h *ManagerHandlers) buildDashboardPayload(c *gin.Context) gin.H {
 		}
 	}
 
-	return gin.H{
+	return withPageAccess(c, h.userStore, gin.H{
 		"servers":         servers,
 		"user":            user,
 		"username":        username,
@@ -943,7 +947,8 @@ func (h *ManagerHandlers) buildDashboardPayload(c *gin.Context) gin.H {
 		"activeServers":    activeServers,
 		"startableServers": startableServers,
 		"connectedPlayers": connectedPlayers,
-	}
+		"canRenameServers": renamable,
+	})
 }
 
 func (h *ManagerHandlers) buildManagerStatusPayload(rootPath string) gin.H {
@@ -1486,7 +1491,7 @@ func (h *ManagerHandlers) renderError(c *gin.Context, code int, message string)
 	}
 
 	// Otherwise, render the full frame with the error
-	c.HTML(code, "frame.html", gin.H{
+	c.HTML(code, "frame.html", withPageAccess(c, h.userStore, gin.H{
 		"error":     message,
 		"username":  username,
 		"role":      role,
@@ -1495,7 +1500,7 @@ func (h *ManagerHandlers) renderError(c *gin.Context, code int, message string)
 		"active":    h.manager.IsActive(),
 		"page":      "error",
 		"title":     "Error",
-	})
+	}))
 }
 
 func (h *ManagerHandlers) startDeployAsync(deployType manager.DeployType) error {
@@ -1551,7 +1556,7 @@ func (h *ManagerHandlers) ensureProgressBroadcastLoop() {
 	}()
 }
 
-func (h *ManagerHandlers) startServerUpdateAsync(s *models.Server) {
+func (h *ManagerHandlers) startServerUpdateAsync(s *models.Server, backupReason string) {
 	// Discord notification: update started
 	h.manager.NotifyServerEvent(s, "update-started", "Server file update started.")
 	h.manager.ServerProgressBegin(s.ID, "Queued")
@@ -1565,15 +1570,20 @@ func (h *ManagerHandlers) startServerUpdateAsync(s *models.Server) {
 			if r := recover(); r != nil {
 				err := fmt.Errorf("server update panic: %v", r)
 				if s.Logger != nil {
-					s.Logger.Write(err.Error())
+					s.Logger.Error(err.Error())
 				}
 				h.manager.ServerProgressComplete(s.ID, "Failed", err)
 			}
 		}()
 
+		if backupReason != "" {
+			h.manager.ServerProgressUpdate(s.ID, "Backing up saves", 0, 0)
+			h.manager.BackupBeforeDeploy(s, backupReason)
+		}
+
 		if err := s.Deploy(); err != nil {
 			if s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Server update failed: %v", err))
+				s.Logger.Error(fmt.Sprintf("Server update failed: %v", err))
 			}
 			h.manager.ServerProgressComplete(s.ID, "Failed", err)
 			// Discord notification: update failed
@@ -1584,7 +1594,7 @@ func (h *ManagerHandlers) startServerUpdateAsync(s *models.Server) {
 		// Persist a deploy snapshot capturing manager's deployed component versions
 		if err := h.writeServerDeploySnapshot(s); err != nil {
 			if s.Logger != nil {
-				s.Logger.Write("Warning: failed to write deploy snapshot: " + err.Error())
+				s.Logger.Warn("Failed to write deploy snapshot: " + err.Error())
 			}
 		}
 
@@ -1600,65 +1610,7 @@ func (h *ManagerHandlers) writeServerDeploySnapshot(s *models.Server) error {
 	if h == nil || h.manager == nil || s == nil {
 		return fmt.Errorf("invalid context")
 	}
-	// Resolve path helpers from server when available, otherwise from manager
-	var paths *utils.Paths
-	if s.Paths != nil {
-		paths = s.Paths
-	} else {
-		paths = h.manager.Paths
-	}
-	if paths == nil {
-		return fmt.Errorf("paths unavailable")
-	}
-	// Ensure settings directory exists
-	if err := os.MkdirAll(paths.ServerSettingsDir(s.ID), 0o755); err != nil {
-		return err
-	}
-	// Build snapshot payload
-	snap := struct {
-		Timestamp         string `json:"timestamp"`
-		Beta              bool   `json:"beta"`
-		ReleaseDeployed   string `json:"release_deployed"`
-		BetaDeployed      string `json:"beta_deployed"`
-		BepInExDeployed   string `json:"bepinex_deployed"`
-		LaunchPadDeployed string `json:"launchpad_deployed"`
-		SCONDeployed      string `json:"scon_deployed"`
-	}{
-		Timestamp:         time.Now().Format(time.RFC3339),
-		Beta:              s.Beta,
-		ReleaseDeployed:   strings.TrimSpace(h.manager.ReleaseDeployed()),
-		BetaDeployed:      strings.TrimSpace(h.manager.BetaDeployed()),
-		BepInExDeployed:   strings.TrimSpace(h.manager.BepInExDeployed()),
-		LaunchPadDeployed: strings.TrimSpace(h.manager.LaunchPadDeployed()),
-		SCONDeployed:      strings.TrimSpace(h.manager.SCONDeployed()),
-	}
-	data, err := json.MarshalIndent(snap, "", "  ")
-	if err != nil {
-		return err
-	}
-	// Write atomically: temp file then rename
-	dst := paths.ServerDeploySnapshotFile(s.ID)
-	tmp, err := os.CreateTemp(paths.ServerSettingsDir(s.ID), "deploy-*.tmp")
-	if err != nil {
-		return err
-	}
-	tmpPath := tmp.Name()
-	if _, err := tmp.Write(data); err != nil {
-		tmp.Close()
-		_ = os.Remove(tmpPath)
-		return err
-	}
-	if err := tmp.Close(); err != nil {
-		_ = os.Remove(tmpPath)
-		return err
-	}
-	if err := os.Rename(tmpPath, dst); err != nil {
-		_ = os.Remove(tmpPath)
-		return err
-	}
-	// Best-effort permissions
-	_ = os.Chmod(dst, 0o644)
-	return nil
+	return h.manager.WriteServerDeploySnapshot(s)
 }
 
 // Home redirects to the login page (root entry point).
@@ -1671,17 +1623,7 @@ func (h *ManagerHandlers) Frame(c *gin.Context) {
 	username, _ := c.Get("username")
 	role := c.GetString("role")
 
-	servers := h.manager.Servers
-	if role != "admin" {
-		user, _ := username.(string)
-		filtered := make([]*models.Server, 0, len(servers))
-		for _, s := range servers {
-			if h.userStore != nil && h.userStore.CanAccess(user, s.ID) {
-				filtered = append(filtered, s)
-			}
-		}
-		servers = filtered
-	}
+	servers := h.visibleServers(c)
 
 	data := gin.H{
 		"active":    h.manager.IsActive(),
@@ -1692,7 +1634,7 @@ func (h *ManagerHandlers) Frame(c *gin.Context) {
 		"page":      "dashboard", // Default to dashboard
 		"title":     "Dashboard",
 	}
-	c.HTML(http.StatusOK, "frame.html", data)
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, data))
 }
 
 // Dashboard renders the main dashboard page, showing a list of servers.
@@ -1745,7 +1687,7 @@ func (h *ManagerHandlers) ManagerGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame with manager selected
-	c.HTML(http.StatusOK, "frame.html", data)
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, data))
 }
 
 // ManagerCardGET renders a single manager card for HTMX refresh requests.
@@ -1843,7 +1785,7 @@ func (h *ManagerHandlers) TokensHelpGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame, which will then load the content.
-	c.HTML(http.StatusOK, "frame.html", gin.H{
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, gin.H{
 		"username":  username,
 		"role":      role,
 		"servers":   h.manager.Servers,
@@ -1851,7 +1793,7 @@ func (h *ManagerHandlers) TokensHelpGET(c *gin.Context) {
 		"active":    h.manager.IsActive(),
 		"page":      "help/tokens",
 		"title":     "Chat Tokens",
-	})
+	}))
 }
 
 // CommandsHelpGET renders a reference page for console commands parsed from docs/Commands.txt.
@@ -1954,7 +1896,7 @@ func (h *ManagerHandlers) CommandsHelpGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame, which will then load the content.
-	c.HTML(http.StatusOK, "frame.html", gin.H{
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, gin.H{
 		"username":  username,
 		"role":      role,
 		"servers":   h.manager.Servers,
@@ -1964,7 +1906,7 @@ func (h *ManagerHandlers) CommandsHelpGET(c *gin.Context) {
 		"title":     "Console Commands",
 		"commands":  commands,
 		"letters":   letters,
-	})
+	}))
 }
 
 // ServerWorldImage streams the PNG planet image for the server's configured world.
@@ -2022,7 +1964,7 @@ func (h *ManagerHandlers) ManagerLogGET(c *gin.Context) {
 	username, _ := c.Get("username")
 	role := c.GetString("role")
 
-	if role != "admin" {
+	if !h.can(c, 0, manager.PermManagerConfig) {
 		h.renderError(c, http.StatusForbidden, "Admin privileges required.")
 		return
 	}
@@ -2037,7 +1979,7 @@ func (h *ManagerHandlers) ManagerLogGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame, which will then load the content.
-	c.HTML(http.StatusOK, "frame.html", gin.H{
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, gin.H{
 		"username":  username,
 		"role":      role,
 		"servers":   h.manager.Servers,
@@ -2045,7 +1987,7 @@ func (h *ManagerHandlers) ManagerLogGET(c *gin.Context) {
 		"active":    h.manager.IsActive(),
 		"page":      "logs/sdsm",
 		"title":     "Manager Logs",
-	})
+	}))
 }
 
 // UpdateLogGET renders the update log viewer page.
@@ -2053,7 +1995,7 @@ func (h *ManagerHandlers) UpdateLogGET(c *gin.Context) {
 	username, _ := c.Get("username")
 	role := c.GetString("role")
 
-	if role != "admin" {
+	if !h.can(c, 0, manager.PermManagerConfig) {
 		h.renderError(c, http.StatusForbidden, "Admin privileges required.")
 		return
 	}
@@ -2068,7 +2010,7 @@ func (h *ManagerHandlers) UpdateLogGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame, which will then load the content.
-	c.HTML(http.StatusOK, "frame.html", gin.H{
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, gin.H{
 		"username":  username,
 		"role":      role,
 		"servers":   h.manager.Servers,
@@ -2076,7 +2018,7 @@ func (h *ManagerHandlers) UpdateLogGET(c *gin.Context) {
 		"active":    h.manager.IsActive(),
 		"page":      "logs/update",
 		"title":     "Update Logs",
-	})
+	}))
 }
 
 // render wraps c.HTML with a panic-recovery mechanism to log template execution errors.
@@ -2087,7 +2029,7 @@ func (h *ManagerHandlers) render(c *gin.Context, name string, data gin.H) {
 			// Log the panic
 			err := fmt.Errorf("panic recovered while rendering template %s: %v", name, r)
 			if h.manager != nil && h.manager.Log != nil {
-				h.manager.Log.Write(err.Error())
+				h.manager.Logger("http", 0).Error(err.Error())
 			} else {
 				log.Println(err)
 			}
@@ -2096,7 +2038,7 @@ func (h *ManagerHandlers) render(c *gin.Context, name string, data gin.H) {
 			n := runtime.Stack(buf, false)
 			stackTrace := string(buf[:n])
 			if h.manager != nil && h.manager.Log != nil {
-				h.manager.Log.Write(fmt.Sprintf("Stack trace:\n%s", stackTrace))
+				h.manager.Logger("http", 0).Error(fmt.Sprintf("Stack trace:\n%s", stackTrace))
 			} else {
 				log.Printf("Stack trace:\n%s", stackTrace)
 			}
@@ -145,7 +145,7 @@ func (h *ManagerHandlers) UpdatePOST(c *gin.Context) {
 			if copyErr != nil {
 				// Disable TLS on failure to avoid broken startup and surface error
 				h.manager.TLSEnabled = false
-				h.manager.Log.Write("TLS asset installation failed: " + copyErr.Error())
+				h.manager.Logger("tls", 0).Error("TLS asset installation failed: " + copyErr.Error())
 				if isAsync {
 					ToastError(c, "TLS Setup Failed", copyErr.Error())
 				}
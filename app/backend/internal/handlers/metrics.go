@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/prometheus"
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsGET serves manager and per-server telemetry in the Prometheus text format.
+// The endpoint is disabled unless metrics.enabled is set; when metrics.bearer_token is
+// configured scrapers must present it, otherwise the endpoint is open.
+func (h *ManagerHandlers) MetricsGET(c *gin.Context) {
+	if h.manager == nil || !h.manager.Metrics.Enabled {
+		c.String(http.StatusNotFound, "metrics exporter disabled\n")
+		return
+	}
+	if !h.manager.Metrics.Authorized(c.GetHeader("Authorization")) {
+		c.Header("WWW-Authenticate", `Bearer realm="sdsm"`)
+		c.String(http.StatusUnauthorized, "unauthorized\n")
+		return
+	}
+	c.Header("Content-Type", prometheus.ContentType)
+	c.Status(http.StatusOK)
+	_ = prometheus.Write(c.Writer, h.collectMetrics())
+}
+
+func (h *ManagerHandlers) collectMetrics() []*prometheus.Family {
+	mgr := h.manager
+	now := time.Now()
+
+	build := &prometheus.Family{Name: "sdsm_build_info", Help: "SDSM build metadata; value is always 1."}
+	build.Add(1, "version", version.Version, "commit", version.Commit)
+	wsClients := &prometheus.Family{Name: "sdsm_websocket_clients", Help: "Connected dashboard WebSocket clients."}
+	if h.hub != nil {
+		wsClients.Add(float64(h.hub.GetClientCount()))
+	}
+	updating := &prometheus.Family{Name: "sdsm_deploy_in_progress", Help: "1 while a component deployment is running."}
+	updating.Add(boolToFloat(mgr.IsUpdating()))
+
+	families := []*prometheus.Family{build, wsClients, updating}
+	families = append(families, hostMetricFamilies(mgr)...)
+	families = append(families, deployMetricFamilies(mgr)...)
+	families = append(families, serverMetricFamilies(mgr, now)...)
+	return families
+}
+
+func hostMetricFamilies(mgr *manager.Manager) []*prometheus.Family {
+	t := mgr.SystemTelemetry()
+	if t == nil {
+		return nil
+	}
+	gauge := func(name, help string, v float64) *prometheus.Family {
+		f := &prometheus.Family{Name: name, Help: help, Type: prometheus.TypeGauge}
+		f.Add(v)
+		return f
+	}
+	counter := func(name, help string, v float64) *prometheus.Family {
+		f := &prometheus.Family{Name: name, Help: help, Type: prometheus.TypeCounter}
+		f.Add(v)
+		return f
+	}
+	return []*prometheus.Family{
+		gauge("sdsm_host_cpu_percent", "Host CPU utilisation (0-100).", t.CPUPercent),
+		gauge("sdsm_host_memory_used_bytes", "Host memory in use.", float64(t.MemoryUsed)),
+		gauge("sdsm_host_memory_total_bytes", "Host memory installed.", float64(t.MemoryTotal)),
+		gauge("sdsm_host_disk_used_bytes", "Used bytes on the volume holding the SDSM root path.", float64(t.DiskUsed)),
+		gauge("sdsm_host_disk_total_bytes", "Size of the volume holding the SDSM root path.", float64(t.DiskTotal)),
+		counter("sdsm_host_network_receive_bytes_total", "Bytes received across all interfaces.", float64(t.NetworkInboundBytes)),
+		counter("sdsm_host_network_transmit_bytes_total", "Bytes sent across all interfaces.", float64(t.NetworkOutboundBytes)),
+		gauge("sdsm_host_load1", "1-minute load average.", t.Load1),
+		gauge("sdsm_host_load5", "5-minute load average.", t.Load5),
+		gauge("sdsm_host_load15", "15-minute load average.", t.Load15),
+		gauge("sdsm_host_uptime_seconds", "Host uptime.", float64(t.UptimeSeconds)),
+		gauge("sdsm_host_health_percent", "Dashboard health score (100 minus the busiest resource).", t.HealthPercent),
+		gauge("sdsm_host_sampled_timestamp_seconds", "Unix time of the last telemetry sample.", float64(t.SampledAt.Unix())),
+	}
+}
+
+func deployMetricFamilies(mgr *manager.Manager) []*prometheus.Family {
+	runs := &prometheus.Family{Name: "sdsm_deploy_runs_total", Help: "Completed deployments by type.", Type: prometheus.TypeCounter}
+	failures := &prometheus.Family{Name: "sdsm_deploy_failures_total", Help: "Deployments that completed with errors.", Type: prometheus.TypeCounter}
+	total := &prometheus.Family{Name: "sdsm_deploy_duration_seconds_total", Help: "Cumulative time spent deploying.", Type: prometheus.TypeCounter}
+	last := &prometheus.Family{Name: "sdsm_deploy_last_duration_seconds", Help: "Duration of the most recent deployment."}
+	lastAt := &prometheus.Family{Name: "sdsm_deploy_last_completed_timestamp_seconds", Help: "Unix time the most recent deployment finished."}
+	lastFailed := &prometheus.Family{Name: "sdsm_deploy_last_failed", Help: "1 when the most recent deployment had errors."}
+	for _, s := range mgr.DeployStatsSnapshot() {
+		typ := string(s.Type)
+		runs.Add(float64(s.Runs), "type", typ)
+		failures.Add(float64(s.Failures), "type", typ)
+		total.Add(s.TotalDuration.Seconds(), "type", typ)
+		last.Add(s.LastDuration.Seconds(), "type", typ)
+		lastAt.Add(float64(s.LastCompletedAt.Unix()), "type", typ)
+		lastFailed.Add(boolToFloat(s.LastCompletedFail), "type", typ)
+	}
+	return []*prometheus.Family{runs, failures, total, last, lastAt, lastFailed}
+}
+
+func serverMetricFamilies(mgr *manager.Manager, now time.Time) []*prometheus.Family {
+	running := &prometheus.Family{Name: "sdsm_server_running", Help: "1 when the game server process is running."}
+	players := &prometheus.Family{Name: "sdsm_server_players", Help: "Connected players."}
+	maxPlayers := &prometheus.Family{Name: "sdsm_server_max_players", Help: "Configured player limit."}
+	uptime := &prometheus.Family{Name: "sdsm_server_uptime_seconds", Help: "Time since the server process started."}
+	cpu := &prometheus.Family{Name: "sdsm_server_cpu_percent", Help: "Server process CPU usage (100 = one core)."}
+	rss := &prometheus.Family{Name: "sdsm_server_memory_rss_bytes", Help: "Server process resident memory."}
+	diskUsed := &prometheus.Family{Name: "sdsm_server_disk_used_bytes", Help: "Used bytes on the server's volume."}
+	saveAge := &prometheus.Family{Name: "sdsm_server_last_save_age_seconds", Help: "Seconds since the game last reported a world save."}
+	scon := &prometheus.Family{Name: "sdsm_server_scon_up", Help: "1 when the SCON HTTP API answered the last probe."}
+	portForward := &prometheus.Family{Name: "sdsm_server_port_forward_active", Help: "1 when automatic port forwarding holds a mapping (only for servers with it enabled)."}
+
+	for _, s := range mgr.Servers {
+		if s == nil {
+			continue
+		}
+		labels := []string{"server_id", strconv.Itoa(s.ID), "server_name", s.Name}
+		isRunning := s.IsRunning()
+		running.Add(boolToFloat(isRunning), labels...)
+		players.Add(float64(s.ClientCount()), labels...)
+		maxPlayers.Add(float64(s.MaxClients), labels...)
+		if isRunning {
+			uptime.Add(s.Uptime().Seconds(), labels...)
+		} else {
+			uptime.Add(0, labels...)
+		}
+		if usage := s.ResourceUsage(); usage != nil && isRunning {
+			cpu.Add(usage.CPUPercent, labels...)
+			rss.Add(float64(usage.MemoryRSSBytes), labels...)
+			diskUsed.Add(float64(usage.DiskUsageBytes), labels...)
+		}
+		if s.ServerSaved != nil {
+			saveAge.Add(now.Sub(*s.ServerSaved).Seconds(), labels...)
+		}
+		if probe, ok := mgr.SCONHealth(s.ID); ok {
+			scon.Add(boolToFloat(probe.Reachable), labels...)
+		}
+		if s.AutoPortForward {
+			portForward.Add(boolToFloat(s.PortForwardActive), labels...)
+		}
+	}
+	return []*prometheus.Family{running, players, maxPlayers, uptime, cpu, rss, diskUsed, saveAge, scon, portForward}
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsGET(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	saved := time.Now().Add(-90 * time.Second)
+	mgr := &manager.Manager{
+		Servers: []*models.Server{{ID: 3, Name: "Mars", MaxClients: 8, AutoPortForward: true, ServerSaved: &saved}},
+	}
+	h := NewManagerHandlers(mgr, nil)
+	r := gin.New()
+	r.GET("/metrics", h.MetricsGET)
+
+	scrape := func(auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := scrape(""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 while disabled, got %d", w.Code)
+	}
+
+	mgr.Metrics = manager.MetricsConfig{Enabled: true, BearerToken: "scrape-me"}
+	if w := scrape(""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", w.Code)
+	}
+	if w := scrape("Bearer wrong"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with wrong token, got %d", w.Code)
+	}
+
+	w := scrape("Bearer scrape-me")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with token, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`sdsm_server_running{server_id="3",server_name="Mars"} 0`,
+		`sdsm_server_players{server_id="3",server_name="Mars"} 0`,
+		`sdsm_server_max_players{server_id="3",server_name="Mars"} 8`,
+		`sdsm_server_port_forward_active{server_id="3",server_name="Mars"} 0`,
+		`sdsm_server_last_save_age_seconds{server_id="3",server_name="Mars"} 9`,
+		"sdsm_deploy_in_progress 0",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("metrics output missing %q:\n%s", want, body)
+		}
+	}
+
+	mgr.Metrics.BearerToken = ""
+	if w := scrape(""); w.Code != http.StatusOK {
+		t.Fatalf("expected open endpoint without token configured, got %d", w.Code)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/notify"
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+func notifyChannelsJSON(cfgs []notify.Config) []gin.H {
+	items := make([]gin.H, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		items = append(items, gin.H{
+			"channel":    cfg.Redacted(),
+			"has_secret": cfg.HasSecret(),
+		})
+	}
+	return items
+}
+
+// APINotifyChannelsList returns the configured notification channels with credentials redacted (requires manager.config).
+func (h *ManagerHandlers) APINotifyChannelsList(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"channels": notifyChannelsJSON(h.manager.NotifyChannelConfigs())})
+}
+
+// APINotifyChannelsUpdate replaces the notification channel list (requires manager.config).
+// JSON: { "channels": [ { name, type, enabled, events, ... } ] }. Omitted credentials keep the stored values.
+func (h *ManagerHandlers) APINotifyChannelsUpdate(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Channels []notify.Config `json:"channels"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.manager.SetNotifyChannels(req.Channels); err != nil {
+		ToastError(c, "Notification Channels", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Notification Channels", "Notification channels saved.")
+	c.JSON(http.StatusOK, gin.H{"channels": notifyChannelsJSON(h.manager.NotifyChannelConfigs())})
+}
+
+// APINotifyChannelTest sends a test notification to one channel (requires manager.config).
+// JSON: { "name": "<channel name>" }
+func (h *ManagerHandlers) APINotifyChannelTest(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name required"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+	if err := h.manager.TestNotifyChannel(ctx, req.Name); err != nil {
+		ToastError(c, "Notification Test Failed", err.Error())
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Notification Sent", req.Name+" accepted the test notification.")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
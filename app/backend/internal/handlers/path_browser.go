@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"sdsm/app/backend/internal/manager"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -55,8 +57,8 @@ type pathBrowseResponse struct {
 //	path: relative (default "")
 //	mode: directory | file | any (default directory)
 func (h *ManagerHandlers) APIPathBrowser(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	rootPath := ""
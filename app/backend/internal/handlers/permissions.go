@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/middleware"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Can reports whether the requesting user holds perm on serverID (0 for manager-wide
+// permissions). The admin role always passes, matching EnsureRoleContext's safety net.
+// Requests authenticated by an API token are further limited to the token's servers.
+func Can(c *gin.Context, users *manager.UserStore, serverID int, perm manager.Permission) bool {
+	if !tokenAllowsPermission(c, serverID, perm) {
+		return false
+	}
+	if c.GetString("role") == string(manager.RoleAdmin) {
+		return true
+	}
+	if users == nil {
+		return false
+	}
+	return users.HasPermission(c.GetString("username"), serverID, perm)
+}
+
+// requestPermissions returns the permission set used by cards and templates for serverID.
+func requestPermissions(c *gin.Context, users *manager.UserStore, serverID int) manager.PermissionSet {
+	var ps manager.PermissionSet
+	switch {
+	case c.GetString("role") == string(manager.RoleAdmin):
+		ps = manager.FullPermissionSet()
+	case users == nil:
+		return manager.PermissionSet{}
+	default:
+		ps = users.Permissions(c.GetString("username"), serverID)
+	}
+	for p := range ps {
+		if !tokenAllowsPermission(c, serverID, p) {
+			delete(ps, p)
+		}
+	}
+	return ps
+}
+
+// tokenAllowsPermission applies an API token's server list. Server-scoped permissions
+// asked at serverID 0 span every server, so only all-server tokens may hold them there.
+func tokenAllowsPermission(c *gin.Context, serverID int, perm manager.Permission) bool {
+	t, ok := middleware.APITokenFromContext(c)
+	if !ok || t.AllServers {
+		return true
+	}
+	if serverID > 0 {
+		return t.AllowsServer(serverID)
+	}
+	return !perm.ServerScoped()
+}
+
+// withPageAccess adds the manager-wide permission flags that the frame, dashboard and server
+// card templates use to show navigation entries and controls, plus the role's display label.
+func withPageAccess(c *gin.Context, users *manager.UserStore, data gin.H) gin.H {
+	data["canManageUsers"] = Can(c, users, 0, manager.PermUsersManage)
+	data["canManagerConfig"] = Can(c, users, 0, manager.PermManagerConfig)
+	data["canCreateServers"] = Can(c, users, 0, manager.PermServersCreate)
+	data["canBulkControl"] = Can(c, users, 0, manager.PermServersBulk)
+	data["roleLabel"] = humanRole(manager.Role(c.GetString("role")))
+	return data
+}
+
+// renamableServers returns the IDs of servers the requester may rename (server.settings),
+// for the Rename button on server cards.
+func renamableServers(c *gin.Context, users *manager.UserStore, servers []*models.Server) map[int]bool {
+	out := make(map[int]bool, len(servers))
+	for _, s := range servers {
+		if s != nil && Can(c, users, s.ID, manager.PermServerSettings) {
+			out[s.ID] = true
+		}
+	}
+	return out
+}
+
+// visibleServers returns the servers the requester holds server.view on.
+func (h *ManagerHandlers) visibleServers(c *gin.Context) []*models.Server {
+	out := make([]*models.Server, 0, len(h.manager.Servers))
+	for _, s := range h.manager.Servers {
+		if s != nil && h.can(c, s.ID, manager.PermServerView) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (h *ManagerHandlers) can(c *gin.Context, serverID int, perm manager.Permission) bool {
+	return Can(c, h.userStore, serverID, perm)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCanHonoursAPITokenServers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("role", string(manager.RoleAdmin))
+	c.Set("api_token", manager.APIToken{Scopes: []manager.TokenScope{manager.ScopeRead}, Servers: []int{1}})
+
+	if !Can(c, nil, 1, manager.PermServerView) {
+		t.Fatal("expected token to allow its own server")
+	}
+	if Can(c, nil, 2, manager.PermServerView) {
+		t.Fatal("expected token to be denied another server")
+	}
+	if Can(c, nil, 0, manager.PermServerBan) {
+		t.Fatal("expected server-scoped permission at server 0 to be denied to a limited token")
+	}
+	if !Can(c, nil, 0, manager.PermManagerConfig) {
+		t.Fatal("expected manager-wide permission to be unaffected by the server list")
+	}
+	if ps := requestPermissions(c, nil, 2); len(ps) != 0 {
+		t.Fatalf("expected no permissions on another server, got %v", ps)
+	}
+
+	c.Set("api_token", manager.APIToken{AllServers: true})
+	if !Can(c, nil, 0, manager.PermServerBan) || !Can(c, nil, 2, manager.PermServerView) {
+		t.Fatal("expected all-server token to pass")
+	}
+}
+
+func TestWithPageAccessFollowsPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("role", string(manager.RoleOperator))
+	data := withPageAccess(c, nil, gin.H{})
+	for _, key := range []string{"canManageUsers", "canManagerConfig", "canCreateServers", "canBulkControl"} {
+		if data[key] != false {
+			t.Fatalf("expected %s to be denied to an operator without a user store", key)
+		}
+	}
+	if data["roleLabel"] != "Operator" {
+		t.Fatalf("roleLabel = %v", data["roleLabel"])
+	}
+
+	c.Set("role", string(manager.RoleAdmin))
+	data = withPageAccess(c, nil, gin.H{})
+	if data["canManageUsers"] != true || data["canBulkControl"] != true {
+		t.Fatalf("expected admin to see every control, got %v", data)
+	}
+	servers := []*models.Server{{ID: 1}, {ID: 2}}
+	c.Set("api_token", manager.APIToken{Servers: []int{2}})
+	if got := renamableServers(c, nil, servers); got[1] || !got[2] {
+		t.Fatalf("renamable = %v, want only the token's server", got)
+	}
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPlayerSearchLimit = 50
+	maxPlayerSearchLimit     = 500
+)
+
+type playerRow struct {
+	manager.PlayerProfile
+	PlaytimeSeconds int64  `json:"playtime_seconds"`
+	Playtime        string `json:"playtime"`
+}
+
+type playerServerRow struct {
+	manager.PlayerServerStats
+	Playtime string `json:"playtime"`
+	Online   bool   `json:"online"`
+	Admin    bool   `json:"admin"`
+	Banned   bool   `json:"banned"`
+	Deleted  bool   `json:"deleted"`
+}
+
+type playerSessionRow struct {
+	manager.PlayerSession
+	ServerName string `json:"server_name"`
+	Duration   string `json:"duration"`
+}
+
+// canModeratePlayers reports whether the requester may kick or ban on any server; staff
+// notes are only shown to and editable by such users.
+func (h *ManagerHandlers) canModeratePlayers(c *gin.Context) bool {
+	if c.GetString("role") == string(manager.RoleAdmin) {
+		return true
+	}
+	for _, s := range h.manager.Servers {
+		if s != nil && (h.can(c, s.ID, manager.PermServerKick) || h.can(c, s.ID, manager.PermServerBan)) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopePlayer drops stats and sessions for servers the requester cannot view. It returns
+// false when nothing visible remains.
+func (h *ManagerHandlers) scopePlayer(c *gin.Context, p manager.PlayerProfile) (manager.PlayerProfile, bool) {
+	servers := p.Servers[:0:0]
+	for _, st := range p.Servers {
+		if h.can(c, st.ServerID, manager.PermServerView) {
+			servers = append(servers, st)
+		}
+	}
+	if len(servers) == 0 {
+		return p, false
+	}
+	sessions := p.Sessions[:0:0]
+	for _, sess := range p.Sessions {
+		if h.can(c, sess.ServerID, manager.PermServerView) {
+			sessions = append(sessions, sess)
+		}
+	}
+	p.Servers, p.Sessions = servers, sessions
+	if !h.canModeratePlayers(c) {
+		p.Notes = nil
+	}
+	return p, true
+}
+
+func newPlayerRow(p manager.PlayerProfile) playerRow {
+	row := playerRow{PlayerProfile: p}
+	for _, st := range p.Servers {
+		row.PlaytimeSeconds += st.PlaytimeSeconds
+	}
+	row.Playtime = formatDurationShort(time.Duration(row.PlaytimeSeconds) * time.Second)
+	// The list view only needs the summary; details come from the profile endpoint.
+	row.Sessions = nil
+	row.Notes = nil
+	return row
+}
+
+// APIPlayersSearch lists known players matching ?q= (SteamID prefix or name substring).
+// HTMX requests receive the results table partial.
+func (h *ManagerHandlers) APIPlayersSearch(c *gin.Context) {
+	limit := defaultPlayerSearchLimit
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if limit > maxPlayerSearchLimit {
+		limit = maxPlayerSearchLimit
+	}
+	query := c.Query("q")
+	rows := make([]playerRow, 0)
+	for _, p := range h.manager.SearchPlayers(query, 0) {
+		scoped, ok := h.scopePlayer(c, p)
+		if !ok {
+			continue
+		}
+		rows = append(rows, newPlayerRow(scoped))
+		if len(rows) >= limit {
+			break
+		}
+	}
+	if c.GetHeader("HX-Request") == "true" {
+		c.HTML(http.StatusOK, "players_results", gin.H{"players": rows, "query": query})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"players": rows})
+}
+
+// APIPlayerGet returns one player's profile with per-server stats, recent sessions,
+// live admin/ban/online status and, for moderators, staff notes.
+func (h *ManagerHandlers) APIPlayerGet(c *gin.Context) {
+	data, ok := h.playerDetailData(c, c.Param("steam_id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+		return
+	}
+	if c.GetHeader("HX-Request") == "true" {
+		c.HTML(http.StatusOK, "players_detail", data)
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+func (h *ManagerHandlers) playerDetailData(c *gin.Context, steamID string) (gin.H, bool) {
+	view, ok := h.manager.PlayerDetail(steamID)
+	if !ok {
+		return nil, false
+	}
+	scoped, ok := h.scopePlayer(c, view.PlayerProfile)
+	if !ok {
+		return nil, false
+	}
+	contains := func(ids []int, id int) bool {
+		for _, v := range ids {
+			if v == id {
+				return true
+			}
+		}
+		return false
+	}
+	names := make(map[int]string, len(h.manager.Servers))
+	for _, s := range h.manager.Servers {
+		if s != nil {
+			names[s.ID] = s.Name
+		}
+	}
+
+	var total int64
+	servers := make([]playerServerRow, 0, len(scoped.Servers))
+	for _, st := range scoped.Servers {
+		total += st.PlaytimeSeconds
+		name, exists := names[st.ServerID]
+		if exists {
+			st.ServerName = name
+		}
+		servers = append(servers, playerServerRow{
+			PlayerServerStats: st,
+			Playtime:          formatDurationShort(time.Duration(st.PlaytimeSeconds) * time.Second),
+			Online:            contains(view.OnlineServerIDs, st.ServerID),
+			Admin:             contains(view.AdminServerIDs, st.ServerID),
+			Banned:            contains(view.BannedServerIDs, st.ServerID),
+			Deleted:           !exists,
+		})
+	}
+	sessions := make([]playerSessionRow, 0, len(scoped.Sessions))
+	for _, sess := range scoped.Sessions {
+		end := time.Now()
+		if sess.Disconnected != nil {
+			end = *sess.Disconnected
+		}
+		row := playerSessionRow{PlayerSession: sess, ServerName: names[sess.ServerID]}
+		if row.ServerName == "" {
+			row.ServerName = "Server " + strconv.Itoa(sess.ServerID)
+		}
+		row.Duration = formatDurationShort(end.Sub(sess.Connected))
+		sessions = append(sessions, row)
+	}
+	online := false
+	for _, st := range servers {
+		online = online || st.Online
+	}
+
+	return gin.H{
+		"steam_id":         scoped.SteamID,
+		"name":             scoped.Name,
+		"names":            scoped.Names,
+		"first_seen":       scoped.FirstSeen,
+		"last_seen":        scoped.LastSeen,
+		"playtime_seconds": total,
+		"playtime":         formatDurationShort(time.Duration(total) * time.Second),
+		"online":           online,
+		"servers":          servers,
+		"sessions":         sessions,
+		"notes":            scoped.Notes,
+		"can_moderate":     h.canModeratePlayers(c),
+	}, true
+}
+
+// APIPlayerNoteAdd attaches a staff note to a player (requires kick or ban on some server).
+// Body (form or JSON): { "text": string }
+func (h *ManagerHandlers) APIPlayerNoteAdd(c *gin.Context) {
+	if !h.canModeratePlayers(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	steamID := c.Param("steam_id")
+	if _, ok := h.playerDetailData(c, steamID); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+		return
+	}
+	var req struct {
+		Text string `form:"text" json:"text"`
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	note, err := h.manager.Players().AddNote(steamID, c.GetString("username"), req.Text, time.Now().UTC())
+	if err != nil {
+		ToastError(c, "Player Notes", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Player Notes", "Note added.")
+	h.respondPlayerNotes(c, steamID, gin.H{"note": note})
+}
+
+// APIPlayerNoteDelete removes a staff note (requires kick or ban on some server).
+func (h *ManagerHandlers) APIPlayerNoteDelete(c *gin.Context) {
+	if !h.canModeratePlayers(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	steamID := c.Param("steam_id")
+	if _, ok := h.playerDetailData(c, steamID); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+		return
+	}
+	if err := h.manager.Players().DeleteNote(steamID, c.Param("note_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Player Notes", "Note deleted.")
+	h.respondPlayerNotes(c, steamID, gin.H{"status": "deleted"})
+}
+
+// respondPlayerNotes re-renders the detail partial for HTMX callers, JSON otherwise.
+func (h *ManagerHandlers) respondPlayerNotes(c *gin.Context, steamID string, payload gin.H) {
+	if c.GetHeader("HX-Request") == "true" {
+		if data, ok := h.playerDetailData(c, steamID); ok {
+			c.HTML(http.StatusOK, "players_detail", data)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, payload)
+}
+
+// PlayersGET renders the Players screen with the most recently seen players.
+func (h *ManagerHandlers) PlayersGET(c *gin.Context) {
+	username, _ := c.Get("username")
+	rows := make([]playerRow, 0)
+	for _, p := range h.manager.SearchPlayers("", 0) {
+		if scoped, ok := h.scopePlayer(c, p); ok {
+			rows = append(rows, newPlayerRow(scoped))
+			if len(rows) >= defaultPlayerSearchLimit {
+				break
+			}
+		}
+	}
+	data := gin.H{
+		"username":  username,
+		"role":      c.GetString("role"),
+		"players":   rows,
+		"page":      "players",
+		"title":     "Players",
+		"servers":   h.visibleServers(c),
+		"buildTime": h.manager.BuildTime(),
+		"active":    h.manager.IsActive(),
+	}
+	if c.GetHeader("HX-Request") == "true" {
+		c.HTML(http.StatusOK, "players.html", data)
+		return
+	}
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, data))
+}
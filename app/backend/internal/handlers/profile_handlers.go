@@ -35,7 +35,7 @@ func (h *ProfileHandlers) ProfileGET(c *gin.Context) {
 	if c.GetHeader("HX-Request") == "true" {
 		c.HTML(http.StatusOK, "profile.html", data)
 	} else {
-		c.HTML(http.StatusOK, "frame.html", data)
+		c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.users, data))
 	}
 }
 
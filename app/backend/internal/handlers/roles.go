@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIRolesList returns built-in and custom roles plus the permission catalogue.
+func (h *UserHandlers) APIRolesList(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	perms := make([]gin.H, 0, len(manager.AllPermissions))
+	for _, p := range manager.AllPermissions {
+		perms = append(perms, gin.H{"name": p, "server_scoped": p.ServerScoped()})
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": h.users.Roles(), "permissions": perms})
+}
+
+// APIRoleSave creates or replaces a custom role.
+// Request JSON: { "description": string, "permissions": ["server.kick", ...] }
+func (h *UserHandlers) APIRoleSave(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req struct {
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ToastError(c, "Invalid Request", "Malformed JSON payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	def := manager.RoleDefinition{Name: manager.Role(c.Param("role")), Description: req.Description}
+	for _, p := range req.Permissions {
+		def.Permissions = append(def.Permissions, manager.Permission(strings.TrimSpace(p)))
+	}
+	if err := h.users.SaveRole(def); err != nil {
+		ToastError(c, "Role Not Saved", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Role Saved", fmt.Sprintf("Role %s saved.", strings.ToLower(strings.TrimSpace(c.Param("role")))))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// APIRoleDelete removes a custom role that is no longer assigned.
+func (h *UserHandlers) APIRoleDelete(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	name := manager.Role(strings.TrimSpace(c.Param("role")))
+	if err := h.users.DeleteRole(name); err != nil {
+		ToastError(c, "Delete Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Role Deleted", fmt.Sprintf("Role %s deleted.", name))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// APIUsersSetServerRole assigns a role to a user on a single server.
+// Request JSON: { "server_id": int, "role": string } (empty role clears the override)
+func (h *UserHandlers) APIUsersSetServerRole(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
+	var req struct {
+		ServerID int    `json:"server_id"`
+		Role     string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ToastError(c, "Invalid Request", "Malformed JSON payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	role := manager.Role(strings.ToLower(strings.TrimSpace(req.Role)))
+	if err := h.users.SetServerRole(username, req.ServerID, role); err != nil {
+		ToastError(c, "Update Access Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Access Updated", fmt.Sprintf("Updated server role for %s.", username))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// APIUsersSetSteamID links a user to a SteamID so in-game chat commands can check the
+// user's permissions. Request JSON: { "steam_id": string } (empty removes the link)
+func (h *UserHandlers) APIUsersSetSteamID(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
+	var req struct {
+		SteamID string `json:"steam_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ToastError(c, "Invalid Request", "Malformed JSON payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.users.SetSteamID(username, req.SteamID); err != nil {
+		ToastError(c, "Update Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Steam Account Linked", fmt.Sprintf("Updated the Steam link for %s.", username))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// APIUsersSetDiscordID links a user to a Discord account so bot slash commands can check the
+// user's permissions. Request JSON: { "discord_id": string } (empty removes the link)
+func (h *UserHandlers) APIUsersSetDiscordID(c *gin.Context) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
+	var req struct {
+		DiscordID string `json:"discord_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ToastError(c, "Invalid Request", "Malformed JSON payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if err := h.users.SetDiscordID(username, req.DiscordID); err != nil {
+		ToastError(c, "Update Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Discord Account Linked", fmt.Sprintf("Updated the Discord link for %s.", username))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
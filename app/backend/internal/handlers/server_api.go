@@ -170,17 +170,10 @@ func (h *ManagerHandlers) APIServerStatus(c *gin.Context) {
 		return
 	}
 
-	// Enforce RBAC: operators must be assigned to the server
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// Enforce RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -246,6 +239,7 @@ func (h *ManagerHandlers) APIServerStatus(c *gin.Context) {
 			"player":  entry.Name,
 			"message": entry.Message,
 			"time":    timestamp,
+			"flag":    entry.Flag,
 		})
 	}
 
@@ -253,10 +247,14 @@ func (h *ManagerHandlers) APIServerStatus(c *gin.Context) {
 
 	// Banned list (from Blacklist.txt cross-referenced with players.log for names)
 	banned := make([]gin.H, 0)
-	for _, be := range s.BannedEntries() {
+	for _, be := range h.manager.AnnotateBannedEntries(s.ID, s.BannedEntries()) {
 		banned = append(banned, gin.H{
-			"name":     be.Name,
-			"steam_id": be.SteamID,
+			"name":       be.Name,
+			"steam_id":   be.SteamID,
+			"reason":     be.Reason,
+			"author":     be.Author,
+			"scope":      be.Scope,
+			"expires_at": be.ExpiresAt,
 		})
 	}
 
@@ -357,86 +355,7 @@ func (h *ManagerHandlers) serverUpdateNeeded(s *models.Server) bool {
 	if h.manager.IsServerUpdateRunning(s.ID) {
 		return false
 	}
-	var paths *utils.Paths
-	if s.Paths != nil {
-		paths = s.Paths
-	} else {
-		paths = h.manager.Paths
-	}
-	if paths == nil {
-		return false
-	}
-	snapPath := paths.ServerDeploySnapshotFile(s.ID)
-	data, err := os.ReadFile(snapPath)
-	if err != nil {
-		return false
-	}
-	var snap struct {
-		Timestamp         string `json:"timestamp"`
-		Beta              bool   `json:"beta"`
-		ReleaseDeployed   string `json:"release_deployed"`
-		BetaDeployed      string `json:"beta_deployed"`
-		BepInExDeployed   string `json:"bepinex_deployed"`
-		LaunchPadDeployed string `json:"launchpad_deployed"`
-		SCONDeployed      string `json:"scon_deployed"`
-	}
-	if err := json.Unmarshal(data, &snap); err != nil {
-		return false
-	}
-	isSentinel := func(v string) bool {
-		v = strings.TrimSpace(v)
-		if v == "" {
-			return true
-		}
-		switch v {
-		case "Missing", "Unknown", "Error", "Installed":
-			return true
-		}
-		return false
-	}
-	// Release/Beta channel source comparison
-	if s.Beta {
-		cur := strings.TrimSpace(h.manager.BetaDeployed())
-		old := strings.TrimSpace(snap.BetaDeployed)
-		if !isSentinel(cur) && !isSentinel(old) && cur != old {
-			return true
-		}
-	} else {
-		cur := strings.TrimSpace(h.manager.ReleaseDeployed())
-		old := strings.TrimSpace(snap.ReleaseDeployed)
-		if !isSentinel(cur) && !isSentinel(old) && cur != old {
-			return true
-		}
-	}
-	// BepInEx: direct string change indicates out-of-sync
-	if cur, old := strings.TrimSpace(h.manager.BepInExDeployed()), strings.TrimSpace(snap.BepInExDeployed); !isSentinel(cur) && !isSentinel(old) {
-		if !strings.EqualFold(cur, old) {
-			return true
-		}
-	}
-	// LaunchPad: case-insensitive equality
-	if cur, old := strings.TrimSpace(h.manager.LaunchPadDeployed()), strings.TrimSpace(snap.LaunchPadDeployed); !isSentinel(cur) && !isSentinel(old) {
-		if !strings.EqualFold(cur, old) {
-			return true
-		}
-	}
-	// SCON: normalize leading 'v' and case-insensitive
-	norm := func(v string) string {
-		v = strings.TrimSpace(v)
-		if v == "" {
-			return v
-		}
-		if v[0] == 'v' || v[0] == 'V' {
-			v = v[1:]
-		}
-		return strings.ToLower(v)
-	}
-	if curRaw, oldRaw := strings.TrimSpace(h.manager.SCONDeployed()), strings.TrimSpace(snap.SCONDeployed); !isSentinel(curRaw) && !isSentinel(oldRaw) {
-		if norm(curRaw) != norm(oldRaw) {
-			return true
-		}
-	}
-	return false
+	return h.manager.ServerNeedsUpdate(s)
 }
 
 // APIServerTestPort performs a best-effort validation of port forwarding state.
@@ -447,9 +366,8 @@ func (h *ManagerHandlers) APIServerTestPort(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	s := h.manager.ServerByID(serverID)
@@ -499,15 +417,9 @@ func (h *ManagerHandlers) APIServerStart(c *gin.Context) {
 	}
 
 	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerStart) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -524,7 +436,7 @@ func (h *ManagerHandlers) APIServerStart(c *gin.Context) {
 			if strings.EqualFold(c.GetHeader("HX-Request"), "true") || strings.Contains(c.GetHeader("Accept"), "text/html") {
 				c.Header("HX-Trigger", "refresh")
 				ToastSuccess(c, "Shutdown Canceled", s.Name+" will keep running.")
-				c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role})
+				c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role, "canRenameServer": h.can(c, s.ID, manager.PermServerSettings)})
 				return
 			}
 			ToastSuccess(c, "Shutdown Canceled", s.Name+" will keep running.")
@@ -542,7 +454,7 @@ func (h *ManagerHandlers) APIServerStart(c *gin.Context) {
 		// Trigger a stats refresh on the page (stats-grid listens to 'refresh')
 		c.Header("HX-Trigger", "refresh")
 		ToastSuccess(c, "Server Started", s.Name+" is starting...")
-		c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role})
+		c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role, "canRenameServer": h.can(c, s.ID, manager.PermServerSettings)})
 		return
 	}
 	ToastSuccess(c, "Server Started", s.Name+" is starting...")
@@ -557,15 +469,9 @@ func (h *ManagerHandlers) APIServerStop(c *gin.Context) {
 	}
 
 	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerStop) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -626,7 +532,7 @@ func (h *ManagerHandlers) APIServerStop(c *gin.Context) {
 		} else {
 			ToastInfo(c, "Shutdown Scheduled", s.Name+" is stopping now.")
 		}
-		c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role})
+		c.HTML(http.StatusOK, "server_card.html", gin.H{"server": s, "role": role, "canRenameServer": h.can(c, s.ID, manager.PermServerSettings)})
 		return
 	}
 	// JSON branch
@@ -681,16 +587,9 @@ func (h *ManagerHandlers) APIServerPause(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerStop) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -729,16 +628,9 @@ func (h *ManagerHandlers) APIServerResume(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerStart) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -777,16 +669,9 @@ func (h *ManagerHandlers) APIServerRestart(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerRestart) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -863,9 +748,8 @@ func (h *ManagerHandlers) APIServerDelete(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerDelete) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 
@@ -880,17 +764,20 @@ func (h *ManagerHandlers) APIServerDelete(c *gin.Context) {
 		s.Stop()
 	}
 
+	// Capture the server's players log into the player index before the files go away.
+	h.manager.RefreshPlayerIndex()
+
 	// Delete server directory if paths available
 	if s.Paths != nil {
 		if err := s.Paths.DeleteServerDirectory(s.ID, s.Logger); err != nil {
 			if s.Logger != nil {
-				s.Logger.Write("Failed to delete server directory: " + err.Error())
+				s.Logger.Error("Failed to delete server directory: " + err.Error())
 			}
 		}
 	} else if h.manager.Paths != nil {
 		if err := h.manager.Paths.DeleteServerDirectory(s.ID, s.Logger); err != nil {
 			if s.Logger != nil {
-				s.Logger.Write("Failed to delete server directory: " + err.Error())
+				s.Logger.Error("Failed to delete server directory: " + err.Error())
 			}
 		}
 	}
@@ -902,6 +789,7 @@ func (h *ManagerHandlers) APIServerDelete(c *gin.Context) {
 			break
 		}
 	}
+	h.manager.ForgetServerHistory(s.ID)
 	h.manager.Save()
 
 	// Broadcast that server roster changed and stats should update
@@ -926,16 +814,9 @@ func (h *ManagerHandlers) APIServerLog(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.String(http.StatusForbidden, "forbidden")
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.String(http.StatusForbidden, "forbidden")
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -998,7 +879,7 @@ func (h *ManagerHandlers) APIServerLog(c *gin.Context) {
 	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
 }
 
-// APIServerLogsList returns a JSON array of available *.log files in the server's logs directory.
+// APIServerLogsList returns the *.log files in the server's logs directory and their rotated archives.
 func (h *ManagerHandlers) APIServerLogsList(c *gin.Context) {
 	serverID, err := strconv.Atoi(c.Param("server_id"))
 	if err != nil {
@@ -1006,17 +887,10 @@ func (h *ManagerHandlers) APIServerLogsList(c *gin.Context) {
 		return
 	}
 
-	// Enforce RBAC: operators must be assigned to the server
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// Enforce RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -1032,13 +906,13 @@ func (h *ManagerHandlers) APIServerLogsList(c *gin.Context) {
 		logsDir = h.manager.Paths.ServerLogsDir(s.ID)
 	}
 	if logsDir == "" {
-		c.JSON(http.StatusOK, gin.H{"files": []string{}})
+		c.JSON(http.StatusOK, gin.H{"files": []string{}, "archives": []utils.LogArchive{}})
 		return
 	}
 
 	entries, err := os.ReadDir(logsDir)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{"files": []string{}})
+		c.JSON(http.StatusOK, gin.H{"files": []string{}, "archives": []utils.LogArchive{}})
 		return
 	}
 	files := make([]string, 0, len(entries))
@@ -1047,12 +921,12 @@ func (h *ManagerHandlers) APIServerLogsList(c *gin.Context) {
 			continue
 		}
 		name := e.Name()
-		if strings.HasSuffix(strings.ToLower(name), ".log") {
+		if utils.IsActiveLogName(name) {
 			files = append(files, name)
 		}
 	}
 	sort.Strings(files)
-	c.JSON(http.StatusOK, gin.H{"files": files})
+	c.JSON(http.StatusOK, gin.H{"files": files, "archives": utils.ListLogArchives(logsDir)})
 }
 
 // APIServerUpdateSettings updates server startup/config parameters (admin-only for many fields).
@@ -1064,9 +938,8 @@ func (h *ManagerHandlers) APIServerUpdateSettings(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		ToastError(c, "Permission Denied", "Admin privileges required to change startup parameters.")
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		ToastError(c, "Permission Denied", "You do not have permission to change startup parameters.")
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
@@ -1171,6 +1044,13 @@ func (h *ManagerHandlers) APIServerUpdateSettings(c *gin.Context) {
 		}
 	}
 
+	if prefix, ok := body["chat_command_prefix"]; ok {
+		prefix = strings.TrimSpace(prefix)
+		if len(prefix) <= 3 && !strings.ContainsAny(prefix, " \t") {
+			s.ChatCommandPrefix = prefix
+		}
+	}
+
 	if maxClientsStr := body["max_clients"]; maxClientsStr != "" {
 		if maxClients, err := strconv.Atoi(maxClientsStr); err == nil && maxClients >= 1 && maxClients <= 100 {
 			s.MaxClients = maxClients
@@ -1280,11 +1160,52 @@ func (h *ManagerHandlers) APIServerUpdateSettings(c *gin.Context) {
 	}
 
 	s.Visible = body["server_visible"] == "on" || body["server_visible"] == "true" || body["server_visible"] == "1"
+	s.ChatCommandsEnabled = body["chat_commands_enabled"] == "on" || body["chat_commands_enabled"] == "true" || body["chat_commands_enabled"] == "1"
 	s.Beta = body["beta"] == "true"
 	s.AutoStart = body["auto_start"] == "on" || body["auto_start"] == "true" || body["auto_start"] == "1"
 	s.AutoUpdate = body["auto_update"] == "on" || body["auto_update"] == "true" || body["auto_update"] == "1"
 	s.AutoSave = body["auto_save"] == "on" || body["auto_save"] == "true" || body["auto_save"] == "1"
 	s.AutoPause = body["auto_pause"] == "on" || body["auto_pause"] == "true" || body["auto_pause"] == "1"
+	// Crash supervision policy
+	s.CrashAutoRestart = body["crash_auto_restart"] == "on" || body["crash_auto_restart"] == "true" || body["crash_auto_restart"] == "1"
+	s.CrashRestoreAutosave = body["crash_restore_autosave"] == "on" || body["crash_restore_autosave"] == "true" || body["crash_restore_autosave"] == "1"
+	if v := strings.TrimSpace(body["crash_max_restarts"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 20 {
+			s.CrashMaxRestarts = n
+		}
+	}
+	if v := strings.TrimSpace(body["crash_window_minutes"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 1440 {
+			s.CrashWindowMinutes = n
+		}
+	}
+	if v := strings.TrimSpace(body["crash_backoff_seconds"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 600 {
+			s.CrashBackoffSeconds = n
+		}
+	}
+	// World backup policy
+	s.BackupEnabled = body["backup_enabled"] == "on" || body["backup_enabled"] == "true" || body["backup_enabled"] == "1"
+	if v := strings.TrimSpace(body["backup_interval_minutes"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 5 && n <= 10080 {
+			s.BackupIntervalMinutes = n
+		}
+	}
+	if v := strings.TrimSpace(body["backup_keep_hourly"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 168 {
+			s.BackupKeepHourly = n
+		}
+	}
+	if v := strings.TrimSpace(body["backup_keep_daily"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 90 {
+			s.BackupKeepDaily = n
+		}
+	}
+	if v := strings.TrimSpace(body["backup_keep_weekly"]); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 104 {
+			s.BackupKeepWeekly = n
+		}
+	}
 	// Allow toggling PlayerSaves via API as part of settings
 	if pv, ok := body["player_saves"]; ok {
 		v := strings.TrimSpace(pv)
@@ -1366,7 +1287,7 @@ func (h *ManagerHandlers) APIServerUpdateSettings(c *gin.Context) {
 		return
 	}
 
-	h.manager.Log.Write(fmt.Sprintf("Server %s (ID: %d) configuration updated.", s.Name, s.ID))
+	h.manager.Logger("servers", s.ID).Info(fmt.Sprintf("Server %s (ID: %d) configuration updated.", s.Name, s.ID))
 	h.manager.Save()
 
 	// Broadcast realtime update and updated stats
@@ -1417,8 +1338,8 @@ func (h *ManagerHandlers) APIServerRename(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 
@@ -1471,9 +1392,8 @@ func (h *ManagerHandlers) APIServerSetLanguage(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	s := h.manager.ServerByID(serverID)
@@ -1514,9 +1434,8 @@ func (h *ManagerHandlers) APIServerUpdateServerFiles(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerUpdate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	s := h.manager.ServerByID(serverID)
@@ -1529,7 +1448,7 @@ func (h *ManagerHandlers) APIServerUpdateServerFiles(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "running"})
 		return
 	}
-	h.startServerUpdateAsync(s)
+	h.startServerUpdateAsync(s, manager.BackupReasonPreUpdate)
 	ToastSuccess(c, "Update Started", s.Name+" update started.")
 	c.JSON(http.StatusOK, gin.H{"status": "started"})
 }
@@ -1541,8 +1460,8 @@ func (h *ManagerHandlers) APIServerReinstall(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, serverID, manager.PermServerUpdate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	s := h.manager.ServerByID(serverID)
@@ -1585,15 +1504,15 @@ func (h *ManagerHandlers) APIServerReinstall(c *gin.Context) {
 		_ = os.Remove(snap)
 	}
 
-	h.startServerUpdateAsync(s)
+	h.startServerUpdateAsync(s, manager.BackupReasonPreReinstall)
 	ToastSuccess(c, "Reinstall Started", s.Name+" reinstall started.")
 	c.JSON(http.StatusOK, gin.H{"status": "started"})
 }
 
-// APIServersCreate creates a new server (admin only). Expects JSON body mapping fields of ServerConfig.
+// APIServersCreate creates a new server (servers.create). Expects JSON body mapping fields of ServerConfig.
 func (h *ManagerHandlers) APIServersCreate(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermServersCreate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	// Support both JSON and x-www-form-urlencoded bodies
@@ -1801,11 +1720,11 @@ func (h *ManagerHandlers) APIServersCreate(c *gin.Context) {
 		return
 	}
 	if err := newServer.Deploy(); err != nil {
-		h.manager.Log.Write(fmt.Sprintf("Initial deploy failed for %s (ID:%d): %v", newServer.Name, newServer.ID, err))
+		h.manager.Logger("deploy", newServer.ID).Error(fmt.Sprintf("Initial deploy failed for %s (ID:%d): %v", newServer.Name, newServer.ID, err))
 	} else {
 		// Persist initial deploy snapshot
 		if err := h.writeServerDeploySnapshot(newServer); err != nil {
-			h.manager.Log.Write("Warning: failed to write initial deploy snapshot: " + err.Error())
+			h.manager.Logger("deploy", newServer.ID).Warn("Failed to write initial deploy snapshot: " + err.Error())
 		}
 	}
 
@@ -1916,7 +1835,7 @@ func (h *ManagerHandlers) saveUploadToTemp(c *gin.Context, fh *multipart.FileHea
 	src, err := fh.Open()
 	if err != nil {
 		if h.manager != nil && h.manager.Log != nil {
-			h.manager.Log.Write("Upload open failed: " + err.Error())
+			h.manager.Logger("uploads", 0).Error("Upload open failed: " + err.Error())
 		}
 		return "", err
 	}
@@ -1924,7 +1843,7 @@ func (h *ManagerHandlers) saveUploadToTemp(c *gin.Context, fh *multipart.FileHea
 	tmpf, err := os.CreateTemp("", pattern)
 	if err != nil {
 		if h.manager != nil && h.manager.Log != nil {
-			h.manager.Log.Write("Temp file create failed: " + err.Error())
+			h.manager.Logger("uploads", 0).Error("Temp file create failed: " + err.Error())
 		}
 		return "", err
 	}
@@ -1933,14 +1852,14 @@ func (h *ManagerHandlers) saveUploadToTemp(c *gin.Context, fh *multipart.FileHea
 	cerr := tmpf.Close()
 	if copyErr != nil {
 		if h.manager != nil && h.manager.Log != nil {
-			h.manager.Log.Write("Upload copy failed: " + copyErr.Error())
+			h.manager.Logger("uploads", 0).Error("Upload copy failed: " + copyErr.Error())
 		}
 		os.Remove(tmp)
 		return "", copyErr
 	}
 	if cerr != nil {
 		if h.manager != nil && h.manager.Log != nil {
-			h.manager.Log.Write("Temp file close failed: " + cerr.Error())
+			h.manager.Logger("uploads", 0).Error("Temp file close failed: " + cerr.Error())
 		}
 	}
 	return tmp, nil
@@ -1950,8 +1869,8 @@ func (h *ManagerHandlers) saveUploadToTemp(c *gin.Context, fh *multipart.FileHea
 // returns minimal metadata for UI prefill: world and world_file_name (server name).
 // Response: { world: string, world_file_name: string }
 func (h *ManagerHandlers) APIServersAnalyzeSave(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermServersCreate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	file, err := c.FormFile("save_file")
@@ -2020,8 +1939,8 @@ func (h *ManagerHandlers) APIServersAnalyzeSave(c *gin.Context) {
 // APIServersCreateFromSave creates a new server using posted fields and an uploaded .save file.
 // Multipart form fields mirror APIServersCreate; file field name: "save_file".
 func (h *ManagerHandlers) APIServersCreateFromSave(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermServersCreate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 
@@ -2320,10 +2239,10 @@ func (h *ManagerHandlers) APIServersCreateFromSave(c *gin.Context) {
 
 	// Best-effort initial deploy
 	if err := newServer.Deploy(); err != nil {
-		h.manager.Log.Write(fmt.Sprintf("Initial deploy failed for %s (ID:%d): %v", newServer.Name, newServer.ID, err))
+		h.manager.Logger("deploy", newServer.ID).Error(fmt.Sprintf("Initial deploy failed for %s (ID:%d): %v", newServer.Name, newServer.ID, err))
 	} else {
 		if err := h.writeServerDeploySnapshot(newServer); err != nil {
-			h.manager.Log.Write("Warning: failed to write initial deploy snapshot: " + err.Error())
+			h.manager.Logger("deploy", newServer.ID).Warn("Failed to write initial deploy snapshot: " + err.Error())
 		}
 	}
 
@@ -2346,6 +2265,8 @@ func parseIntSafe(s string, def int) int {
 //   - offset >= 0: read from offset up to 'max' bytes
 //   - offset == -1: read the last 'back' bytes from end (or 0 if back not provided)
 //
+// Optional level (minimum) and component params keep only matching entries.
+//
 // Returns JSON: { data: string, offset: nextOffset, size: fileSize, reset: bool }
 func (h *ManagerHandlers) APIServerLogTail(c *gin.Context) {
 	serverID, err := strconv.Atoi(c.Param("server_id"))
@@ -2355,16 +2276,9 @@ func (h *ManagerHandlers) APIServerLogTail(c *gin.Context) {
 	}
 
 	// Enforce RBAC
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -2383,6 +2297,10 @@ func (h *ManagerHandlers) APIServerLogTail(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid log file"})
 		return
 	}
+	filter, ok := logTailFilter(c)
+	if !ok {
+		return
+	}
 
 	var logsDir string
 	if s.Paths != nil {
@@ -2494,16 +2412,16 @@ func (h *ManagerHandlers) APIServerLogTail(c *gin.Context) {
 		}
 	}
 
-	next := start + length
+	data, next := filterLogChunk(filter, buf, start)
 	c.JSON(http.StatusOK, gin.H{
-		"data":   string(buf),
+		"data":   data,
 		"offset": next,
 		"size":   size,
 		"reset":  reset,
 	})
 }
 
-// APIServerLogDownload streams a server log file as an attachment.
+// APIServerLogDownload streams a server log file or rotated archive (.log.gz) as an attachment.
 // RBAC: admins or assigned operators may download.
 func (h *ManagerHandlers) APIServerLogDownload(c *gin.Context) {
 	serverID, err := strconv.Atoi(c.Param("server_id"))
@@ -2512,17 +2430,10 @@ func (h *ManagerHandlers) APIServerLogDownload(c *gin.Context) {
 		return
 	}
 
-	// RBAC: allow admin or assigned operator
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -2532,7 +2443,7 @@ func (h *ManagerHandlers) APIServerLogDownload(c *gin.Context) {
 	}
 
 	name := filepath.Base(strings.TrimSpace(c.Query("name")))
-	if name == "" || !strings.HasSuffix(strings.ToLower(name), ".log") {
+	if name == "" || !(utils.IsActiveLogName(name) || utils.IsLogArchiveName(name)) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid log file"})
 		return
 	}
@@ -2853,16 +2764,9 @@ func (h *ManagerHandlers) APIServerWorldDownload(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermSavesDownload) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -2906,16 +2810,9 @@ func (h *ManagerHandlers) APIServerWorldSaves(c *gin.Context) {
 		return
 	}
 
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -3066,17 +2963,15 @@ func (h *ManagerHandlers) serverWorldSavesDir(s *models.Server) (string, error)
 // APIServerLogClear truncates a server log file. Admin-only.
 // Accepts name via form or JSON body.
 func (h *ManagerHandlers) APIServerLogClear(c *gin.Context) {
-	// Admin only
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
-		return
-	}
-
 	serverID, err := strconv.Atoi(c.Param("server_id"))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
@@ -3123,8 +3018,8 @@ func (h *ManagerHandlers) APIServerLogClear(c *gin.Context) {
 // JSON: { totalEnabled, activeTotal, activeGame, activeSDSM, pending, failures }
 func (h *ManagerHandlers) APIPortForwardMetrics(c *gin.Context) {
 	// Admin-only visibility for fleet-level diagnostics
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	totalEnabled := 0
@@ -3179,16 +3074,9 @@ func (h *ManagerHandlers) APIServerChat(c *gin.Context) {
 	}
 
 	// RBAC
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerConsole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -3224,17 +3112,10 @@ func (h *ManagerHandlers) APIServerConsole(c *gin.Context) {
 		return
 	}
 
-	// RBAC: allow admins, or assigned operators
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermServerConsole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -3272,16 +3153,9 @@ func (h *ManagerHandlers) APIServerSCONHealth(c *gin.Context) {
 	}
 
 	// RBAC
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -3290,28 +3164,21 @@ func (h *ManagerHandlers) APIServerSCONHealth(c *gin.Context) {
 		return
 	}
 
-	// Determine SCON port using dynamic detection (BepInEx LogOutput), with fallback
-	port := s.CurrentSCONPort()
-	url := fmt.Sprintf("http://localhost:%d/command", port)
-
-	// Lightweight probe: try GET (expecting 404/405/200). Any response means reachable.
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
-	client := &http.Client{Timeout: 1500 * time.Millisecond}
-	resp, reqErr := client.Do(req)
-	if reqErr != nil {
+	// Lightweight probe: any HTTP response means reachable.
+	probe := s.ProbeSCON(1500 * time.Millisecond)
+	if !probe.Reachable {
 		c.JSON(http.StatusOK, gin.H{
 			"reachable": false,
 			"status":    0,
-			"url":       url,
-			"error":     reqErr.Error(),
+			"url":       probe.URL,
+			"error":     probe.Error,
 		})
 		return
 	}
-	defer resp.Body.Close()
 	c.JSON(http.StatusOK, gin.H{
 		"reachable": true,
-		"status":    resp.StatusCode,
-		"url":       url,
+		"status":    probe.Status,
+		"url":       probe.URL,
 	})
 }
 
@@ -3322,16 +3189,9 @@ func (h *ManagerHandlers) APIServerSave(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3354,16 +3214,9 @@ func (h *ManagerHandlers) APIServerQuickSave(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3392,16 +3245,9 @@ func (h *ManagerHandlers) APIServerSaveAs(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3499,16 +3345,9 @@ func (h *ManagerHandlers) APIServerLoad(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3546,16 +3385,9 @@ func (h *ManagerHandlers) APIServerStorm(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerConsole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3593,16 +3425,9 @@ func (h *ManagerHandlers) APIServerCleanup(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3637,16 +3462,9 @@ func (h *ManagerHandlers) APIServerKick(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerKick) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3702,24 +3520,18 @@ func (h *ManagerHandlers) APIServerKick(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
-// APIServerBan bans a player by SteamID or name.
-// JSON: { "steam_id": "7656..." } or { "name": "PlayerName" }
+// APIServerBan bans a player by SteamID or name and records who banned them and why.
+// JSON: { "steam_id": "7656..." } or { "name": "PlayerName" }, optionally with
+// "reason" and "duration" ("12h", "7d"; empty for a permanent ban).
 func (h *ManagerHandlers) APIServerBan(c *gin.Context) {
 	serverID, err := strconv.Atoi(c.Param("server_id"))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerBan) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3727,8 +3539,10 @@ func (h *ManagerHandlers) APIServerBan(c *gin.Context) {
 		return
 	}
 	type banReq struct {
-		SteamID string `json:"steam_id"`
-		Name    string `json:"name"`
+		SteamID  string `json:"steam_id"`
+		Name     string `json:"name"`
+		Reason   string `json:"reason"`
+		Duration string `json:"duration"`
 	}
 	var r banReq
 	if err := c.ShouldBindJSON(&r); err != nil {
@@ -3741,6 +3555,11 @@ func (h *ManagerHandlers) APIServerBan(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "steam_id or name required"})
 		return
 	}
+	duration, err := manager.ParseBanDuration(r.Duration)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	// Resolve by name if needed
 	if steam == "" && name != "" {
 		candidate := strings.ToLower(name)
@@ -3762,31 +3581,38 @@ func (h *ManagerHandlers) APIServerBan(c *gin.Context) {
 			steam = name
 		} // fallback
 	}
-	// Try console BAN when running, else write to blacklist file
-	if s.Running {
-		if err := s.SendCommand("console", "BAN "+steam); err != nil {
-			ToastError(c, "Ban Failed", err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		// Realtime: a live player may be removed as a result of BAN
-		h.BroadcastStatusAndStats(s)
-		ToastSuccess(c, "Player Banned", "BAN command sent.")
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-		return
+	rec := manager.BanRecord{
+		SteamID:   steam,
+		Name:      name,
+		Reason:    r.Reason,
+		Author:    c.GetString("username"),
+		Scope:     manager.BanScopeServer,
+		ServerIDs: []int{s.ID},
+	}
+	if duration > 0 {
+		expires := time.Now().UTC().Add(duration)
+		rec.ExpiresAt = &expires
 	}
-	if err := s.AddBlacklistID(steam); err != nil {
+	// AddBans sends a console BAN when the server is running, else writes the blacklist file.
+	recs, err := h.manager.AddBans([]manager.BanRecord{rec})
+	if err != nil {
 		ToastError(c, "Ban Failed", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	// Realtime: ban list changed; broadcast stats (player counts unchanged) and status for consistency
+	// Realtime: a live player may be removed and the ban list changed
 	h.BroadcastStatusAndStats(s)
-	ToastSuccess(c, "Player Banned", steam+" added to blacklist.")
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	detail := steam + " banned."
+	if rec.ExpiresAt != nil {
+		detail = steam + " banned until " + rec.ExpiresAt.Local().Format("Jan 2 15:04") + "."
+	}
+	ToastSuccess(c, "Player Banned", detail)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "ban": recs[0]})
 }
 
-// APIServerUnban removes a SteamID from blacklist.
+// APIServerUnban lifts a SteamID's server bans and removes it from the blacklist.
+// Global and group bans still covering the server are reported with 409 and must be
+// lifted through /api/bans.
 // JSON: { "steam_id": "7656..." }
 func (h *ManagerHandlers) APIServerUnban(c *gin.Context) {
 	serverID, err := strconv.Atoi(c.Param("server_id"))
@@ -3794,16 +3620,9 @@ func (h *ManagerHandlers) APIServerUnban(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
 		return
 	}
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerBan) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 	s := h.manager.ServerByID(serverID)
 	if s == nil {
@@ -3817,17 +3636,31 @@ func (h *ManagerHandlers) APIServerUnban(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "steam_id required"})
 		return
 	}
-	_ = s.RemoveBlacklistID(strings.TrimSpace(r.SteamID))
+	steam := strings.TrimSpace(r.SteamID)
+	remaining, err := h.manager.LiftServerBans(s.ID, steam, c.GetString("username"))
+	if err != nil {
+		ToastError(c, "Unban Failed", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(remaining) > 0 {
+		msg := fmt.Sprintf("%s is covered by a %s ban; lift it from the ban list.", steam, remaining[0].Scope)
+		ToastError(c, "Unban Failed", msg)
+		c.JSON(http.StatusConflict, gin.H{"error": msg, "bans": remaining})
+		return
+	}
+	// Entries added outside SDSM have no record; drop them from the file directly.
+	_ = s.RemoveBlacklistID(steam)
 	// Realtime: unban list changed; broadcast status and stats
 	h.BroadcastStatusAndStats(s)
 	ToastSuccess(c, "Player Unbanned", "Removed from blacklist.")
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
-// APIServersStartAll starts every stopped server (admin only).
+// APIServersStartAll starts every stopped server (servers.bulk).
 func (h *ManagerHandlers) APIServersStartAll(c *gin.Context) {
-	if c.GetString("role") != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermServersBulk) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	started := 0
@@ -3866,11 +3699,10 @@ func (h *ManagerHandlers) APIServersStartAll(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"started": started, "shutdowns_canceled": shutdownsCanceled})
 }
 
-// APIServersStopAll stops all running servers (admin only).
+// APIServersStopAll stops all running servers (servers.bulk).
 func (h *ManagerHandlers) APIServersStopAll(c *gin.Context) {
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermServersBulk) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	scheduled := 0
@@ -3899,17 +3731,10 @@ func (h *ManagerHandlers) APIServerSaves(c *gin.Context) {
 		return
 	}
 
-	// Enforce RBAC: operators must be assigned to the server
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// Enforce RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -4244,17 +4069,10 @@ func (h *ManagerHandlers) APIServerSaveDelete(c *gin.Context) {
 		return
 	}
 
-	// Enforce RBAC: operators must be assigned to the server
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	// Enforce RBAC: require the permission on this server
+	if !h.can(c, serverID, manager.PermSavesDelete) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -4421,9 +4239,8 @@ func (h *ManagerHandlers) APIManagerStatus(c *gin.Context) {
 // APIManagerTestPort performs a best-effort validation of manager port forwarding state.
 // Returns external IP/port and whether a mapping appears active.
 func (h *ManagerHandlers) APIManagerTestPort(c *gin.Context) {
-	role := c.GetString("role")
-	if role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	if !h.manager.AutoPortForwardManager {
@@ -4630,17 +4447,9 @@ func (h *ManagerHandlers) APIServerPlayerSaveExclude(c *gin.Context) {
 	}
 
 	// Enforce RBAC
-	role := c.GetString("role")
-	if role != "admin" {
-		// Allow operators with access as well
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermServerSave) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -4728,16 +4537,9 @@ func (h *ManagerHandlers) APIServerPlayerSaveDeleteAll(c *gin.Context) {
 	}
 
 	// Enforce RBAC
-	role := c.GetString("role")
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			if user, ok2 := val.(string); ok2 {
-				if h.userStore == nil || !h.userStore.CanAccess(user, serverID) {
-					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-					return
-				}
-			}
-		}
+	if !h.can(c, serverID, manager.PermSavesDelete) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
 	}
 
 	s := h.manager.ServerByID(serverID)
@@ -4804,27 +4606,23 @@ func (h *ManagerHandlers) APIServerPlayerSaveDeleteAll(c *gin.Context) {
 
 func (h *ManagerHandlers) APIServers(c *gin.Context) {
 	role := c.GetString("role")
-	servers := h.manager.Servers
-	if role != "admin" {
-		if val, ok := c.Get("username"); ok {
-			user, _ := val.(string)
-			filtered := make([]*models.Server, 0, len(servers))
-			for _, s := range servers {
-				if h.userStore != nil && h.userStore.CanAccess(user, s.ID) {
-					filtered = append(filtered, s)
-				}
+	servers := h.visibleServers(c)
+	if _, ok := middleware.APITokenFromContext(c); ok {
+		filtered := make([]*models.Server, 0, len(servers))
+		for _, s := range servers {
+			if middleware.TokenAllowsServer(c, s.ID) {
+				filtered = append(filtered, s)
 			}
-			servers = filtered
-		} else {
-			servers = []*models.Server{}
 		}
+		servers = filtered
 	}
 
 	if strings.EqualFold(c.GetHeader("HX-Request"), "true") || strings.Contains(c.GetHeader("Accept"), "text/html") {
-		// Include role so nested partials (e.g., server_card.html) can conditionally render admin controls
+		// Include the role and rename permissions for the nested server_card.html controls
 		c.HTML(http.StatusOK, "server_cards.html", gin.H{
-			"servers": servers,
-			"role":    role,
+			"servers":          servers,
+			"role":             role,
+			"canRenameServers": renamableServers(c, h.userStore, servers),
 		})
 		return
 	}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+
+	"github.com/gin-gonic/gin"
+)
+
+func backupJSON(b manager.WorldBackup) gin.H {
+	return gin.H{
+		"name":       b.Name,
+		"reason":     b.Reason,
+		"created_at": b.CreatedAt.Format(time.RFC3339),
+		"size":       b.Size,
+	}
+}
+
+func backupErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, manager.ErrServerNotFound), errors.Is(err, manager.ErrBackupNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// APIServerBackupsList returns the server's world backups, newest first.
+func (h *ManagerHandlers) APIServerBackupsList(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerView)
+	if !ok {
+		return
+	}
+	backups, err := h.manager.ServerBackups(serverID)
+	if err != nil {
+		c.JSON(backupErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	items := make([]gin.H, 0, len(backups))
+	for _, b := range backups {
+		items = append(items, backupJSON(b))
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// APIServerBackupsCreate takes a manual backup of the server's saves directory.
+// Manual backups are exempt from automatic retention pruning.
+func (h *ManagerHandlers) APIServerBackupsCreate(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerBackup)
+	if !ok {
+		return
+	}
+	b, err := h.manager.CreateServerBackup(serverID, manager.BackupReasonManual)
+	if err != nil {
+		ToastError(c, "Backup Failed", err.Error())
+		c.JSON(backupErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Backup Created", b.Name)
+	c.JSON(http.StatusOK, gin.H{"backup": backupJSON(b)})
+}
+
+// APIServerBackupRestore restores a backup in the background: the server is stopped, the saves
+// directory swapped, and the server started again if it was running.
+// JSON: { "name": "backup_YYYYMMDD_HHMMSS_<reason>.zip" }
+func (h *ManagerHandlers) APIServerBackupRestore(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerBackup)
+	if !ok {
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name required"})
+		return
+	}
+	if h.manager.IsServerUpdateRunning(serverID) {
+		ToastWarn(c, "Restore Blocked", "Wait for the running update to finish.")
+		c.JSON(http.StatusConflict, gin.H{"error": "update running"})
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	found := false
+	if backups, err := h.manager.ServerBackups(serverID); err == nil {
+		for _, b := range backups {
+			if b.Name == name {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": manager.ErrBackupNotFound.Error()})
+		return
+	}
+
+	s := h.manager.ServerByID(serverID)
+	go func() {
+		if err := h.manager.RestoreServerBackup(serverID, name); err != nil {
+			h.manager.NotifyServerEvent(s, "restore-failed", fmt.Sprintf("Restore of %s failed: %v", name, err))
+			return
+		}
+		h.manager.NotifyServerEvent(s, "restore-completed", fmt.Sprintf("World restored from %s.", name))
+	}()
+	ToastInfo(c, "Restore Started", "Restoring "+name+"; the server will restart if it was running.")
+	c.JSON(http.StatusOK, gin.H{"status": "started"})
+}
+
+// APIServerBackupDelete removes a backup archive.
+// Query: name=<backup file name>
+func (h *ManagerHandlers) APIServerBackupDelete(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermSavesDelete)
+	if !ok {
+		return
+	}
+	name := strings.TrimSpace(c.Query("name"))
+	if err := h.manager.DeleteServerBackup(serverID, name); err != nil {
+		c.JSON(backupErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Backup Deleted", name)
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
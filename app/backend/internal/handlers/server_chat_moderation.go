@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const chatCardID = "server-status-chat"
+
+// APIServerChatModerationGET returns the moderation rules and recorded hits, newest first.
+func (h *ManagerHandlers) APIServerChatModerationGET(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerView)
+	if !ok {
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	c.JSON(http.StatusOK, gin.H{"settings": s.ChatModeration, "hits": s.ModerationHits()})
+}
+
+// APIServerChatModerationUpdate replaces the moderation rules (requires server.settings).
+// Accepts a JSON ChatModeration object, or the chat card form where blocked words and
+// patterns are newline-separated.
+func (h *ManagerHandlers) APIServerChatModerationUpdate(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	var cm models.ChatModeration
+	if strings.HasPrefix(c.ContentType(), "application/json") {
+		if err := c.ShouldBindJSON(&cm); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	} else {
+		var err error
+		if cm, err = chatModerationFromForm(c); err != nil {
+			ToastError(c, "Chat Moderation", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	cm.BanDuration = strings.TrimSpace(cm.BanDuration)
+	if cm.BanDuration != "" {
+		if _, err := manager.ParseBanDuration(cm.BanDuration); err != nil {
+			ToastError(c, "Chat Moderation", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	s := h.manager.ServerByID(serverID)
+	if err := s.SetChatModeration(cm); err != nil {
+		ToastError(c, "Chat Moderation", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.manager.Save()
+	triggerCardRefresh(c, chatCardID)
+	ToastSuccess(c, "Chat Moderation", "Moderation rules saved.")
+	c.JSON(http.StatusOK, gin.H{"settings": s.ChatModeration})
+}
+
+func chatModerationFromForm(c *gin.Context) (models.ChatModeration, error) {
+	cm := models.ChatModeration{
+		Enabled:         c.PostForm("enabled") == "true" || c.PostForm("enabled") == "on",
+		BlockedWords:    splitLines(c.PostForm("blocked_words")),
+		BlockedPatterns: splitLines(c.PostForm("blocked_patterns")),
+		WarnMessage:     strings.TrimSpace(c.PostForm("warn_message")),
+		BanDuration:     c.PostForm("ban_duration"),
+	}
+	ints := []struct {
+		field string
+		dst   *int
+	}{
+		{"spam_max_messages", &cm.SpamMaxMessages},
+		{"spam_window_seconds", &cm.SpamWindowSeconds},
+		{"caps_min_length", &cm.CapsMinLength},
+		{"caps_max_percent", &cm.CapsMaxPercent},
+		{"kick_after", &cm.KickAfter},
+		{"ban_after", &cm.BanAfter},
+		{"strike_reset_minutes", &cm.StrikeResetMinutes},
+	}
+	for _, f := range ints {
+		raw := strings.TrimSpace(c.PostForm(f.field))
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return cm, fmt.Errorf("%s must be a number", f.field)
+		}
+		*f.dst = v
+	}
+	return cm, nil
+}
+
+func splitLines(raw string) []string {
+	var out []string
+	for _, line := range strings.Split(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
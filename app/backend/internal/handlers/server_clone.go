@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIServerClone copies a server's configuration, and optionally its head save or a backup,
+// into a new server (requires servers.create and server.settings on the source). The copy and
+// deploy run in the background; poll the new server's progress endpoint.
+func (h *ManagerHandlers) APIServerClone(c *gin.Context) {
+	serverID, err := strconv.Atoi(c.Param("server_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+	if !h.can(c, 0, manager.PermServersCreate) || !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var opts manager.CloneOptions
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&opts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+			return
+		}
+	}
+	opts.Name = middleware.SanitizeString(opts.Name)
+	newServer, err := h.manager.CloneServer(serverID, opts)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, manager.ErrServerNotFound) {
+			status = http.StatusNotFound
+		}
+		ToastError(c, "Clone Failed", err.Error())
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.broadcastServersChanged()
+	h.BroadcastStatusAndStats(newServer)
+
+	ToastSuccess(c, "Clone Started", newServer.Name+" is being created.")
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "started",
+		"server_id": newServer.ID,
+		"name":      newServer.Name,
+		"port":      newServer.Port,
+	})
+}
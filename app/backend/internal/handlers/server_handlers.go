@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"sdsm/app/backend/internal/cards"
+	"sdsm/app/backend/internal/manager"
 )
 
 func (h *ManagerHandlers) ServerGET(c *gin.Context) {
@@ -26,13 +27,9 @@ func (h *ManagerHandlers) ServerGET(c *gin.Context) {
 		return
 	}
 
-	if role != "admin" {
-		if user, ok := username.(string); ok {
-			if h.userStore == nil || !h.userStore.CanAccess(user, s.ID) {
-				h.renderError(c, http.StatusForbidden, "You do not have access to this server.")
-				return
-			}
-		}
+	if !h.can(c, s.ID, manager.PermServerView) {
+		h.renderError(c, http.StatusForbidden, "You do not have access to this server.")
+		return
 	}
 
 	// If the request is from HTMX, render the partial view
@@ -57,13 +54,12 @@ func (h *ManagerHandlers) ServerGET(c *gin.Context) {
 		frameData[key] = value
 	}
 
-	c.HTML(http.StatusOK, "frame.html", frameData)
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, frameData))
 }
 
 // ServerCardGET renders a single card partial for HTMX refresh requests.
 func (h *ManagerHandlers) ServerCardGET(c *gin.Context) {
 	username, _ := c.Get("username")
-	role := c.GetString("role")
 
 	serverID, err := strconv.Atoi(c.Param("server_id"))
 	if err != nil {
@@ -80,13 +76,9 @@ func (h *ManagerHandlers) ServerCardGET(c *gin.Context) {
 		return
 	}
 
-	if role != "admin" {
-		if user, ok := username.(string); ok {
-			if h.userStore == nil || !h.userStore.CanAccess(user, s.ID) {
-				c.String(http.StatusForbidden, "access denied")
-				return
-			}
-		}
+	if !h.can(c, s.ID, manager.PermServerView) {
+		c.String(http.StatusForbidden, "access denied")
+		return
 	}
 
 	cardID := strings.TrimSpace(c.Param("card_id"))
@@ -153,7 +145,7 @@ func (h *ManagerHandlers) NewServerGET(c *gin.Context) {
 		frameData[k] = v
 	}
 
-	c.HTML(http.StatusOK, "frame.html", frameData)
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.userStore, frameData))
 }
 
 // NewServerPOST removed: server creation now performed via /api/servers and /api/servers/create-from-save.
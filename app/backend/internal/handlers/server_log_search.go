@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIServerLogSearch searches a server's log and game output log, including rotated archives.
+// Query: q, regex=1, since/until (RFC 3339, "2006-01-02 15:04" or "2006-01-02", local time),
+// class (comma-separated chat, connect, disconnect, save, error), context (lines), limit.
+// Results stream as server-sent events: "match" per hit, then "done" with a summary, or "error".
+func (h *ManagerHandlers) APIServerLogSearch(c *gin.Context) {
+	serverID, err := strconv.Atoi(c.Param("server_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+	if !h.can(c, serverID, manager.PermServerView) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	if s == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+
+	q, errMsg := parseLogSearchQuery(c)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	// Surface a bad pattern as a plain 400 before the stream starts.
+	if err := q.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	flush := func() {
+		if f, ok := c.Writer.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	sum, err := s.SearchLogs(c.Request.Context(), q, func(m models.LogSearchMatch) error {
+		c.SSEvent("match", m)
+		flush()
+		return nil
+	})
+	if err != nil {
+		if c.Request.Context().Err() == nil {
+			c.SSEvent("error", gin.H{"error": err.Error()})
+			flush()
+		}
+		return
+	}
+	c.SSEvent("done", sum)
+	flush()
+}
+
+func parseLogSearchQuery(c *gin.Context) (models.LogSearchQuery, string) {
+	q := models.LogSearchQuery{Text: c.Query("q"), Context: 2}
+	q.Regex, _ = strconv.ParseBool(c.DefaultQuery("regex", "false"))
+	var ok bool
+	if q.Since, ok = parseSearchTime(c.Query("since")); !ok {
+		return q, "invalid since"
+	}
+	if q.Until, ok = parseSearchTime(c.Query("until")); !ok {
+		return q, "invalid until"
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return q, "until is before since"
+	}
+	for _, raw := range strings.Split(c.Query("class"), ",") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		class, ok := models.ParseLogClass(raw)
+		if !ok {
+			return q, "unknown class " + strings.TrimSpace(raw)
+		}
+		q.Classes = append(q.Classes, class)
+	}
+	if v := strings.TrimSpace(c.Query("context")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > models.MaxLogSearchContext {
+			return q, "context must be 0-" + strconv.Itoa(models.MaxLogSearchContext)
+		}
+		q.Context = n
+	}
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > models.MaxLogSearchResults {
+			return q, "limit must be 1-" + strconv.Itoa(models.MaxLogSearchResults)
+		}
+		q.Limit = n
+	}
+	return q, ""
+}
+
+// parseSearchTime accepts an empty value (open bound) or one of the layouts documented above.
+func parseSearchTime(v string) (time.Time, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, true
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func modJSON(mod models.Mod) gin.H {
+	return gin.H{
+		"id":          mod.ID,
+		"name":        mod.Name,
+		"version":     mod.Version,
+		"author":      mod.Author,
+		"description": mod.Description,
+		"image":       mod.Image,
+	}
+}
+
+// APIModsList returns the shared mod library.
+func (h *ManagerHandlers) APIModsList(c *gin.Context) {
+	mods, err := h.manager.LibraryMods()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	items := make([]gin.H, 0, len(mods))
+	for _, mod := range mods {
+		items = append(items, modJSON(mod))
+	}
+	c.JSON(http.StatusOK, gin.H{"mods": items})
+}
+
+// APIModsUpload installs a mod from a zip archive (requires manager.config).
+// Multipart field name: "mod_file". The archive must contain About/About.xml.
+func (h *ManagerHandlers) APIModsUpload(c *gin.Context) {
+	if !h.can(c, 0, manager.PermManagerConfig) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	file, err := c.FormFile("mod_file")
+	if err != nil || file == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mod_file (.zip) is required"})
+		return
+	}
+	if !strings.HasSuffix(strings.ToLower(filepath.Base(file.Filename)), ".zip") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mod archive; must end with .zip"})
+		return
+	}
+	tmp, err := h.saveUploadToTemp(c, file, "mod-*.zip")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save upload"})
+		return
+	}
+	defer os.Remove(tmp)
+
+	mod, err := h.manager.ImportMod(tmp)
+	if err != nil {
+		ToastError(c, "Mod Upload Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Mod Installed", mod.Name+" added to the mod library.")
+	c.JSON(http.StatusOK, gin.H{"mod": modJSON(mod)})
+}
+
+// APIServerModsList returns the mod library with each mod's enabled state for the server.
+func (h *ManagerHandlers) APIServerModsList(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerView)
+	if !ok {
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	mods, err := h.manager.LibraryMods()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	enabled := make(map[int]bool)
+	for _, id := range s.ModIDs() {
+		enabled[id] = true
+	}
+	items := make([]gin.H, 0, len(mods))
+	for _, mod := range mods {
+		item := modJSON(mod)
+		item["enabled"] = enabled[mod.ID]
+		items = append(items, item)
+	}
+	c.JSON(http.StatusOK, gin.H{"mods": items, "running": s.IsRunning()})
+}
+
+// APIServerModEnable adds a library mod to the server (requires server.settings).
+func (h *ManagerHandlers) APIServerModEnable(c *gin.Context) {
+	h.setServerMod(c, true)
+}
+
+// APIServerModDisable removes a mod from the server (requires server.settings).
+func (h *ManagerHandlers) APIServerModDisable(c *gin.Context) {
+	h.setServerMod(c, false)
+}
+
+func (h *ManagerHandlers) setServerMod(c *gin.Context, enabled bool) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	modID, err := strconv.Atoi(c.Param("mod_id"))
+	if err != nil || modID <= 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mod not found"})
+		return
+	}
+	if err := h.manager.SetServerModEnabled(serverID, modID, enabled); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, manager.ErrModNotFound) || errors.Is(err, manager.ErrServerNotFound) {
+			status = http.StatusNotFound
+		}
+		ToastError(c, "Mod Update Failed", err.Error())
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	msg := "Mod selection saved."
+	if s != nil && s.IsRunning() {
+		msg = "Mod selection saved; it applies on the next update or redeploy."
+	}
+	if enabled {
+		ToastSuccess(c, "Mod Enabled", msg)
+	} else {
+		ToastSuccess(c, "Mod Disabled", msg)
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "enabled": enabled})
+}
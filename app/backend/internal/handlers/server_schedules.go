@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scheduleRequest struct {
+	Name    string `json:"name"`
+	Cron    string `json:"cron"`
+	Action  string `json:"action"`
+	Payload string `json:"payload"`
+	Enabled *bool  `json:"enabled"`
+}
+
+func (r scheduleRequest) toJob() models.ScheduledJob {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	return models.ScheduledJob{
+		Name:    r.Name,
+		Cron:    r.Cron,
+		Action:  r.Action,
+		Payload: r.Payload,
+		Enabled: enabled,
+	}
+}
+
+// requireServerPermission parses the server id and enforces perm on that server.
+func (h *ManagerHandlers) requireServerPermission(c *gin.Context, perm manager.Permission) (int, bool) {
+	serverID, err := strconv.Atoi(c.Param("server_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return 0, false
+	}
+	if !h.can(c, serverID, perm) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return 0, false
+	}
+	if h.manager.ServerByID(serverID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return 0, false
+	}
+	return serverID, true
+}
+
+func scheduleJSON(job models.ScheduledJob, now time.Time) gin.H {
+	out := gin.H{
+		"id":          job.ID,
+		"name":        job.Name,
+		"cron":        job.Cron,
+		"action":      job.Action,
+		"payload":     job.Payload,
+		"enabled":     job.Enabled,
+		"last_result": job.LastResult,
+		"last_run":    "",
+		"next_run":    "",
+	}
+	if job.LastRun != nil {
+		out["last_run"] = job.LastRun.Format(time.RFC3339)
+	}
+	if next := manager.NextScheduleRun(job, now); !next.IsZero() {
+		out["next_run"] = next.Format(time.RFC3339)
+	}
+	return out
+}
+
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, manager.ErrServerNotFound), errors.Is(err, manager.ErrScheduleNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// APIServerSchedulesList returns the server's scheduled jobs with their next run times.
+func (h *ManagerHandlers) APIServerSchedulesList(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerView)
+	if !ok {
+		return
+	}
+	jobs, err := h.manager.ServerSchedules(serverID)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	items := make([]gin.H, 0, len(jobs))
+	for _, job := range jobs {
+		items = append(items, scheduleJSON(job, now))
+	}
+	c.JSON(http.StatusOK, gin.H{"schedules": items})
+}
+
+// APIServerSchedulesCreate adds a scheduled job (requires server.settings).
+// JSON: { "name", "cron", "action", "payload", "enabled" }
+func (h *ManagerHandlers) APIServerSchedulesCreate(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	job, err := h.manager.AddServerSchedule(serverID, req.toJob())
+	if err != nil {
+		ToastError(c, "Schedule Failed", err.Error())
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Schedule Added", "Scheduled job created.")
+	c.JSON(http.StatusOK, gin.H{"schedule": scheduleJSON(job, time.Now())})
+}
+
+// APIServerSchedulesUpdate replaces a scheduled job's definition (requires server.settings).
+func (h *ManagerHandlers) APIServerSchedulesUpdate(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	job, err := h.manager.UpdateServerSchedule(serverID, strings.TrimSpace(c.Param("schedule_id")), req.toJob())
+	if err != nil {
+		ToastError(c, "Schedule Failed", err.Error())
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Schedule Updated", "Scheduled job updated.")
+	c.JSON(http.StatusOK, gin.H{"schedule": scheduleJSON(job, time.Now())})
+}
+
+// APIServerSchedulesDelete removes a scheduled job (requires server.settings).
+func (h *ManagerHandlers) APIServerSchedulesDelete(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	if err := h.manager.DeleteServerSchedule(serverID, strings.TrimSpace(c.Param("schedule_id"))); err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ToastSuccess(c, "Schedule Deleted", "Scheduled job removed.")
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// APIServerSchedulesPreview returns upcoming run times for a cron expression.
+// Query: cron=<expr>&count=<n> (default 5, max 50)
+func (h *ManagerHandlers) APIServerSchedulesPreview(c *gin.Context) {
+	if _, ok := h.requireServerPermission(c, manager.PermServerView); !ok {
+		return
+	}
+	count := 5
+	if v := strings.TrimSpace(c.Query("count")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			count = n
+		}
+	}
+	runs, err := h.manager.PreviewSchedule(c.Query("cron"), count)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out := make([]string, 0, len(runs))
+	for _, t := range runs {
+		out = append(out, t.Format(time.RFC3339))
+	}
+	c.JSON(http.StatusOK, gin.H{"cron": strings.TrimSpace(c.Query("cron")), "next_runs": out})
+}
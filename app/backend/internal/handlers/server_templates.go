@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/middleware"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxTemplateImportBytes = 1 << 20
+
+// APIServerTemplateExport downloads the server as a portable template (requires server.settings).
+// Secrets are stripped unless ?secrets=include.
+func (h *ManagerHandlers) APIServerTemplateExport(c *gin.Context) {
+	serverID, err := strconv.Atoi(c.Param("server_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Server not found"})
+		return
+	}
+	if !h.can(c, serverID, manager.PermServerSettings) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	strip := !strings.EqualFold(strings.TrimSpace(c.Query("secrets")), "include")
+	tmpl, err := h.manager.ExportServerTemplate(serverID, strip)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	filename := middleware.SanitizeFilename(tmpl.Name)
+	if filename == "" {
+		filename = fmt.Sprintf("server-%d", serverID)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.sdsm-template.json\"", filename))
+	c.JSON(http.StatusOK, tmpl)
+}
+
+// APIServerTemplateImport creates a server from a template in the request body (requires
+// servers.create). ?name= overrides the template's name. The new server gets the next free port
+// and is deployed before responding, like APIServersCreate.
+func (h *ManagerHandlers) APIServerTemplateImport(c *gin.Context) {
+	if !h.can(c, 0, manager.PermServersCreate) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxTemplateImportBytes+1))
+	if err != nil || len(body) > maxTemplateImportBytes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template too large or unreadable"})
+		return
+	}
+	var tmpl models.ServerTemplate
+	if err := json.Unmarshal(body, &tmpl); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template: " + err.Error()})
+		return
+	}
+	tmpl.Name = middleware.SanitizeString(tmpl.Name)
+	name := middleware.SanitizeString(c.Query("name"))
+	newServer, err := h.manager.ImportServerTemplate(&tmpl, name)
+	if err != nil {
+		ToastError(c, "Import Failed", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := newServer.Deploy(); err != nil {
+		h.manager.Logger("deploy", newServer.ID).Error(fmt.Sprintf("Initial deploy failed for %s (ID:%d): %v", newServer.Name, newServer.ID, err))
+	} else if err := h.writeServerDeploySnapshot(newServer); err != nil {
+		h.manager.Logger("deploy", newServer.ID).Warn("Failed to write initial deploy snapshot: " + err.Error())
+	}
+
+	h.broadcastServersChanged()
+	h.BroadcastStatusAndStats(newServer)
+
+	msg := newServer.Name + " created from template."
+	if tmpl.SecretsStripped {
+		msg += " Set its password and auth secret."
+	}
+	ToastSuccess(c, "Server Imported", msg)
+	c.JSON(http.StatusOK, gin.H{"server_id": newServer.ID, "name": newServer.Name, "port": newServer.Port})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const whitelistCardID = "server-status-whitelist"
+
+func whitelistJSON(s *models.Server) gin.H {
+	return gin.H{
+		"enabled":      s.WhitelistEnabled,
+		"auto_add":     s.WhitelistAutoAdd,
+		"message":      s.WhitelistMessage,
+		"has_password": strings.TrimSpace(s.Password) != "",
+		"entries":      s.WhitelistEntries(),
+	}
+}
+
+// APIServerWhitelistGET returns the whitelist mode settings and allowed SteamIDs.
+func (h *ManagerHandlers) APIServerWhitelistGET(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerView)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, whitelistJSON(h.manager.ServerByID(serverID)))
+}
+
+// APIServerWhitelistUpdate changes whitelist mode settings (requires server.settings).
+// Body (form or JSON): { "enabled": bool, "auto_add": bool, "message": string }; omitted fields are kept.
+func (h *ManagerHandlers) APIServerWhitelistUpdate(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerSettings)
+	if !ok {
+		return
+	}
+	var req struct {
+		Enabled *bool   `form:"enabled" json:"enabled"`
+		AutoAdd *bool   `form:"auto_add" json:"auto_add"`
+		Message *string `form:"message" json:"message"`
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	if req.Enabled != nil {
+		s.WhitelistEnabled = *req.Enabled
+	}
+	if req.AutoAdd != nil {
+		s.WhitelistAutoAdd = *req.AutoAdd
+	}
+	if req.Message != nil {
+		s.WhitelistMessage = strings.TrimSpace(*req.Message)
+	}
+	h.manager.Save()
+	triggerCardRefresh(c, whitelistCardID)
+	switch {
+	case s.WhitelistEnabled && s.WhitelistAutoAdd && strings.TrimSpace(s.Password) == "":
+		ToastWarn(c, "Whitelist", "Auto-add needs a server password; unlisted players will be kicked.")
+	case s.WhitelistEnabled && !s.WhitelistAutoAdd && len(s.Whitelist) == 0:
+		ToastWarn(c, "Whitelist", "The whitelist is empty; only admins can stay connected.")
+	default:
+		ToastSuccess(c, "Whitelist", "Whitelist settings saved.")
+	}
+	c.JSON(http.StatusOK, whitelistJSON(s))
+}
+
+// APIServerWhitelistAdd allows a SteamID (requires server.ban).
+// Body (form or JSON): { "steam_id": "7656..." }
+func (h *ManagerHandlers) APIServerWhitelistAdd(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerBan)
+	if !ok {
+		return
+	}
+	var req struct {
+		SteamID string `form:"steam_id" json:"steam_id"`
+	}
+	if err := c.ShouldBind(&req); err != nil || strings.TrimSpace(req.SteamID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "steam_id required"})
+		return
+	}
+	steamID := strings.TrimSpace(req.SteamID)
+	if strings.ContainsAny(steamID, ", \t\r\n") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid steam_id"})
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	if s.AddWhitelistID(steamID) {
+		h.manager.Save()
+	}
+	triggerCardRefresh(c, whitelistCardID)
+	ToastSuccess(c, "Whitelist", steamID+" is allowed.")
+	c.JSON(http.StatusOK, whitelistJSON(s))
+}
+
+// APIServerWhitelistRemove removes a SteamID from the whitelist (requires server.ban).
+func (h *ManagerHandlers) APIServerWhitelistRemove(c *gin.Context) {
+	serverID, ok := h.requireServerPermission(c, manager.PermServerBan)
+	if !ok {
+		return
+	}
+	s := h.manager.ServerByID(serverID)
+	if !s.RemoveWhitelistID(c.Param("steam_id")) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "steam_id not on the whitelist"})
+		return
+	}
+	h.manager.Save()
+	triggerCardRefresh(c, whitelistCardID)
+	ToastSuccess(c, "Whitelist", "Removed from the whitelist.")
+	c.JSON(http.StatusOK, whitelistJSON(s))
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestServerWhitelistEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := &models.Server{ID: 5, Name: "Alpha"}
+	dir := t.TempDir()
+	mgr := &manager.Manager{
+		Servers:    []*models.Server{server},
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+	}
+	handler := &ManagerHandlers{manager: mgr}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("username", "tester"); c.Set("role", "admin") })
+	r.PUT("/api/servers/:server_id/whitelist", handler.APIServerWhitelistUpdate)
+	r.POST("/api/servers/:server_id/whitelist", handler.APIServerWhitelistAdd)
+	r.DELETE("/api/servers/:server_id/whitelist/:steam_id", handler.APIServerWhitelistRemove)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPut, "/api/servers/5/whitelist", "enabled=true&message=Members+only")
+	if w.Code != http.StatusOK || !server.WhitelistEnabled || server.WhitelistMessage != "Members only" {
+		t.Fatalf("update: %d %s (enabled=%v)", w.Code, w.Body.String(), server.WhitelistEnabled)
+	}
+	if w.Header().Get("X-Toast-Type") != "warning" {
+		t.Fatalf("expected empty-whitelist warning, got %q", w.Header().Get("X-Toast-Type"))
+	}
+	if !strings.Contains(w.Header().Get("HX-Trigger"), whitelistCardID) {
+		t.Fatalf("missing card refresh trigger: %q", w.Header().Get("HX-Trigger"))
+	}
+
+	if w := do(http.MethodPost, "/api/servers/5/whitelist", "steam_id=76561198000000001"); w.Code != http.StatusOK || !server.IsWhitelisted("76561198000000001") {
+		t.Fatalf("add: %d %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodPost, "/api/servers/5/whitelist", "steam_id=1,2"); w.Code != http.StatusBadRequest {
+		t.Fatalf("add with separator: %d", w.Code)
+	}
+	if w := do(http.MethodDelete, "/api/servers/5/whitelist/76561198000000001", ""); w.Code != http.StatusOK || len(server.Whitelist) != 0 {
+		t.Fatalf("remove: %d %v", w.Code, server.Whitelist)
+	}
+	if w := do(http.MethodDelete, "/api/servers/5/whitelist/76561198000000001", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("remove missing: %d", w.Code)
+	}
+}
@@ -52,7 +52,7 @@ func (h *ManagerHandlers) SetupGET(c *gin.Context) {
 // SetupSkipPOST marks setup as skipped and redirects to manager.
 func (h *ManagerHandlers) SetupSkipPOST(c *gin.Context) {
 	h.manager.NeedsUploadPrompt = false
-	h.manager.Log.Write("User skipped initial setup")
+	h.manager.Logger("setup", 0).Info("User skipped initial setup")
 
 	if strings.Contains(c.GetHeader("Accept"), "application/json") {
 		c.JSON(http.StatusOK, gin.H{
@@ -85,7 +85,7 @@ func (h *ManagerHandlers) SetupInstallPOST(c *gin.Context) {
 	deployList, fallbackAll := determineSetupDeployTargets(missing, h.manager.ServerCount())
 	if !fallbackAll && len(deployList) == 0 {
 		message := "No missing components detected"
-		h.manager.Log.Write("Setup requested but no missing components detected; skipping automatic install")
+		h.manager.Logger("setup", 0).Info("Setup requested but no missing components detected; skipping automatic install")
 		c.JSON(http.StatusOK, gin.H{
 			"status":  "noop",
 			"message": message,
@@ -94,7 +94,7 @@ func (h *ManagerHandlers) SetupInstallPOST(c *gin.Context) {
 	}
 
 	h.manager.SetupInProgress = true
-	h.manager.Log.Write("User initiated automatic setup for missing components")
+	h.manager.Logger("setup", 0).Info("User initiated automatic setup for missing components")
 
 	go func(missing []string, deployList []manager.DeployType, fallbackAll bool) {
 		defer func() {
@@ -102,17 +102,17 @@ func (h *ManagerHandlers) SetupInstallPOST(c *gin.Context) {
 		}()
 
 		if fallbackAll || len(deployList) == 0 {
-			h.manager.Log.Write("Automatic setup will deploy all components")
+			h.manager.Logger("setup", 0).Info("Automatic setup will deploy all components")
 			if err := h.manager.Deploy(manager.DeployTypeAll); err != nil {
-				h.manager.Log.Write(fmt.Sprintf("Automatic setup failed: %v", err))
+				h.manager.Logger("setup", 0).Error(fmt.Sprintf("Automatic setup failed: %v", err))
 				return
 			}
 			h.manager.CheckMissingComponents()
 			if remaining := h.manager.GetMissingComponents(); len(remaining) > 0 {
-				h.manager.Log.Write(fmt.Sprintf("Components still missing after setup: %v", remaining))
+				h.manager.Logger("setup", 0).Warn(fmt.Sprintf("Components still missing after setup: %v", remaining))
 				return
 			}
-			h.manager.Log.Write("Automatic setup completed successfully")
+			h.manager.Logger("setup", 0).Info("Automatic setup completed successfully")
 			return
 		}
 
@@ -120,24 +120,24 @@ func (h *ManagerHandlers) SetupInstallPOST(c *gin.Context) {
 		for i, dt := range deployList {
 			componentOrder[i] = string(dt)
 		}
-		h.manager.Log.Write(fmt.Sprintf("Automatic setup will deploy components: %s", strings.Join(componentOrder, ", ")))
+		h.manager.Logger("setup", 0).Info(fmt.Sprintf("Automatic setup will deploy components: %s", strings.Join(componentOrder, ", ")))
 
 		allSucceeded := true
 		for _, dt := range deployList {
 			if err := h.manager.Deploy(dt); err != nil {
-				h.manager.Log.Write(fmt.Sprintf("Automatic setup failed while deploying %s: %v", dt, err))
+				h.manager.Logger("setup", 0).Error(fmt.Sprintf("Automatic setup failed while deploying %s: %v", dt, err))
 				allSucceeded = false
 			}
 		}
 
 		h.manager.CheckMissingComponents()
 		if remaining := h.manager.GetMissingComponents(); len(remaining) > 0 {
-			h.manager.Log.Write(fmt.Sprintf("Components still missing after setup: %v", remaining))
+			h.manager.Logger("setup", 0).Warn(fmt.Sprintf("Components still missing after setup: %v", remaining))
 			allSucceeded = false
 		}
 
 		if allSucceeded {
-			h.manager.Log.Write("Automatic setup completed successfully")
+			h.manager.Logger("setup", 0).Info("Automatic setup completed successfully")
 		}
 	}(append([]string(nil), missing...), append([]manager.DeployType(nil), deployList...), fallbackAll)
 
@@ -180,10 +180,10 @@ func (h *ManagerHandlers) SetupProgressGET(c *gin.Context) {
 // SetupUpdatePOST starts a full deployment of all components.
 func (h *ManagerHandlers) SetupUpdatePOST(c *gin.Context) {
 	h.manager.NeedsUploadPrompt = false
-	h.manager.Log.Write("User requested auto-update for missing components")
+	h.manager.Logger("setup", 0).Info("User requested auto-update for missing components")
 
 	if err := h.startDeployAsync(manager.DeployTypeAll); err != nil {
-		h.manager.Log.Write(fmt.Sprintf("Unable to start setup update: %v", err))
+		h.manager.Logger("setup", 0).Error(fmt.Sprintf("Unable to start setup update: %v", err))
 		// JSON clients
 		if strings.Contains(c.GetHeader("Accept"), "application/json") {
 			c.JSON(http.StatusConflict, gin.H{"status": "error", "message": "Another deployment may already be running."})
@@ -22,3 +22,12 @@ func ToastSuccess(c *gin.Context, title, msg string) { SetToast(c, "success", ti
 func ToastInfo(c *gin.Context, title, msg string)    { SetToast(c, "info", title, msg) }
 func ToastWarn(c *gin.Context, title, msg string)    { SetToast(c, "warning", title, msg) }
 func ToastError(c *gin.Context, title, msg string)   { SetToast(c, "error", title, msg) }
+
+// triggerCardRefresh asks HTMX to fire sdsm:card-refresh for cardID once the response
+// is processed, so cards re-render after actions posted straight to the JSON API.
+func triggerCardRefresh(c *gin.Context, cardID string) {
+	if c == nil || c.GetHeader("HX-Request") != "true" {
+		return
+	}
+	c.Header("HX-Trigger", `{"sdsm:card-refresh":{"cardId":"`+cardID+`"}}`)
+}
@@ -17,12 +17,12 @@ func (h *ManagerHandlers) ApplyCoreChangeEffects(s *models.Server, origWorld, or
 		coreChanged = true
 		s.PendingSavePurge = true
 		if s.Logger != nil {
-			s.Logger.Write("Core start parameters changed; pending save purge flagged (stub, no deletion yet)")
+			s.Logger.Info("Core start parameters changed; pending save purge flagged (stub, no deletion yet)")
 		}
 	}
 	redeployed := false
 	if s.Beta != originalBeta {
-		h.manager.Log.Write(fmt.Sprintf("Server %s (ID: %d) game version changed; redeploying...", s.Name, s.ID))
+		h.manager.Logger("deploy", s.ID).Info(fmt.Sprintf("Server %s (ID: %d) game version changed; redeploying...", s.Name, s.ID))
 		if err := s.Deploy(); err != nil {
 			return coreChanged, false, err
 		}
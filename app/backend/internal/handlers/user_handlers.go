@@ -22,6 +22,7 @@ type UserHandlers struct {
 	authService *middleware.AuthService
 	logger      *utils.Logger
 	manager     *manager.Manager
+	audit       *manager.AuditLog
 }
 
 type userRowView struct {
@@ -32,6 +33,12 @@ type userRowView struct {
 	AccessState   string
 	AssignedCSV   string
 	CanEditAccess bool
+	RoleOptions   []roleOption
+}
+
+type roleOption struct {
+	Name  string
+	Label string
 }
 
 type serverOption struct {
@@ -167,7 +174,24 @@ func (h *UserHandlers) buildUserRow(u manager.User, lookup map[int]string) userR
 		AccessState:   state,
 		AssignedCSV:   joinInts(u.AssignedServers),
 		CanEditAccess: u.Role != manager.RoleAdmin,
+		RoleOptions:   h.roleOptions(),
+	}
+}
+
+// roleOptions lists the roles offered in the user role selector.
+func (h *UserHandlers) roleOptions() []roleOption {
+	if h.users == nil {
+		return nil
 	}
+	defs := h.users.Roles()
+	out := make([]roleOption, 0, len(defs))
+	for _, def := range defs {
+		if def.Name == manager.RoleUser {
+			continue
+		}
+		out = append(out, roleOption{Name: string(def.Name), Label: humanRole(def.Name)})
+	}
+	return out
 }
 
 func (h *UserHandlers) buildUsersCardRequest(c *gin.Context) (*cards.Request, gin.H, []userRowView) {
@@ -188,7 +212,7 @@ func (h *UserHandlers) buildUsersCardRequest(c *gin.Context) (*cards.Request, gi
 	if encoded, err := json.Marshal(serverOptions); err == nil {
 		serverOptionsJSON = string(encoded)
 	} else if h.logger != nil {
-		h.logger.Write(fmt.Sprintf("UsersGET: failed to marshal server options to JSON: %v", err))
+		h.logger.Error(fmt.Sprintf("UsersGET: failed to marshal server options to JSON: %v", err))
 	}
 	payload := gin.H{
 		"users":             rows,
@@ -202,10 +226,15 @@ func (h *UserHandlers) buildUsersCardRequest(c *gin.Context) (*cards.Request, gi
 		"cardIDs":           []string{},
 		"cardSlots":         map[string][]cards.Renderable{},
 	}
+	perms := requestPermissions(c, h.users, 0)
+	if perms.Has(manager.PermAuditView) {
+		payload["auditEntries"], payload["auditFilter"] = h.auditCardPayload(c)
+	}
 	req := &cards.Request{
-		Context: c,
-		Manager: h.manager,
-		Payload: payload,
+		Context:     c,
+		Manager:     h.manager,
+		Payload:     payload,
+		Permissions: perms,
 	}
 	return req, payload, rows
 }
@@ -294,12 +323,12 @@ func NewUserHandlers(store *manager.UserStore, auth *middleware.AuthService, log
 }
 
 func (h *UserHandlers) UsersGET(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
 		if h.logger != nil {
 			uname := strings.TrimSpace(c.GetString("username"))
-			h.logger.Write(fmt.Sprintf("UsersGET: forbidden for user '%s' (role=%s)", uname, c.GetString("role")))
+			h.logger.Warn(fmt.Sprintf("UsersGET: forbidden for user '%s' (role=%s)", uname, c.GetString("role")))
 		}
-		c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "Admin privileges required."})
+		c.HTML(http.StatusForbidden, "error.html", gin.H{"error": "You do not have permission to manage users."})
 		return
 	}
 	cardReq, data, rows := h.buildUsersCardRequest(c)
@@ -311,7 +340,7 @@ func (h *UserHandlers) UsersGET(c *gin.Context) {
 		for _, u := range rows {
 			usernames = append(usernames, u.Username+":"+string(u.Role))
 		}
-		h.logger.Write(fmt.Sprintf("UsersGET: returning %d user(s): %s", len(rows), strings.Join(usernames, ",")))
+		h.logger.Debug(fmt.Sprintf("UsersGET: returning %d user(s): %s", len(rows), strings.Join(usernames, ",")))
 	}
 	if cardReq != nil {
 		renderables := cards.BuildRenderables(cards.ScreenUsers, cardReq)
@@ -345,13 +374,13 @@ func (h *UserHandlers) UsersGET(c *gin.Context) {
 	data["page"] = "users"
 	data["title"] = "User Management"
 
-	c.HTML(http.StatusOK, "frame.html", data)
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.users, data))
 }
 
 // UsersCardGET renders a single users-page card for HTMX refresh requests.
 func (h *UserHandlers) UsersCardGET(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.String(http.StatusForbidden, "admin required")
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.String(http.StatusForbidden, "forbidden")
 		return
 	}
 	cardID := strings.TrimSpace(c.Param("card_id"))
@@ -383,7 +412,7 @@ func (h *UserHandlers) ProfileGET(c *gin.Context) {
 	}
 
 	// Otherwise, render the full frame, which will load the correct content via htmx
-	c.HTML(http.StatusOK, "frame.html", gin.H{
+	c.HTML(http.StatusOK, "frame.html", withPageAccess(c, h.users, gin.H{
 		"username":  c.GetString("username"),
 		"role":      c.GetString("role"),
 		"servers":   h.manager.Servers,
@@ -391,21 +420,21 @@ func (h *UserHandlers) ProfileGET(c *gin.Context) {
 		"active":    h.manager.IsActive(),
 		"page":      "profile",
 		"title":     "My Profile",
-	})
+	}))
 }
 
 // UsersPOST removed: legacy HTML form user management replaced by /api/users endpoints.
 
-// --- JSON API for user management (admin only) ---
+// --- JSON API for user management (requires users.manage) ---
 
 // APIUsersList returns users, optionally filtered by ?q=
 func (h *UserHandlers) APIUsersList(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
 		if h.logger != nil {
 			uname := strings.TrimSpace(c.GetString("username"))
-			h.logger.Write(fmt.Sprintf("APIUsersList: forbidden for user '%s' (role=%s)", uname, c.GetString("role")))
+			h.logger.Warn(fmt.Sprintf("APIUsersList: forbidden for user '%s' (role=%s)", uname, c.GetString("role")))
 		}
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	// Best-effort refresh from disk to reflect any external changes
@@ -423,6 +452,8 @@ func (h *UserHandlers) APIUsersList(c *gin.Context) {
 			"username":   u.Username,
 			"role":       u.Role,
 			"created_at": u.CreatedAt,
+			"steam_id":   u.SteamID,
+			"discord_id": u.DiscordID,
 		})
 	}
 	if h.logger != nil {
@@ -437,9 +468,9 @@ func (h *UserHandlers) APIUsersList(c *gin.Context) {
 			}
 		}
 		if q != "" {
-			h.logger.Write(fmt.Sprintf("APIUsersList: query='%s' matched %d user(s): %s", q, len(out), strings.Join(usernames, ",")))
+			h.logger.Debug(fmt.Sprintf("APIUsersList: query='%s' matched %d user(s): %s", q, len(out), strings.Join(usernames, ",")))
 		} else {
-			h.logger.Write(fmt.Sprintf("APIUsersList: returning %d user(s): %s", len(out), strings.Join(usernames, ",")))
+			h.logger.Debug(fmt.Sprintf("APIUsersList: returning %d user(s): %s", len(out), strings.Join(usernames, ",")))
 		}
 	}
 	c.JSON(http.StatusOK, gin.H{"users": out})
@@ -452,8 +483,8 @@ type apiCreateUserReq struct {
 }
 
 func (h *UserHandlers) APIUsersCreate(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	req, err := h.bindCreateUser(c)
@@ -469,15 +500,8 @@ func (h *UserHandlers) APIUsersCreate(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "username >=3 and password >=8 required"})
 		return
 	}
-	roleStr := strings.ToLower(strings.TrimSpace(req.Role))
-	role := manager.RoleOperator
-	switch roleStr {
-	case string(manager.RoleAdmin):
-		role = manager.RoleAdmin
-	case string(manager.RoleOperator), "":
-		role = manager.RoleOperator
-	default:
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+	role, ok := h.assignableRole(c, req.Role)
+	if !ok {
 		return
 	}
 	hash, err := h.authService.HashPassword(password)
@@ -501,27 +525,60 @@ func (h *UserHandlers) APIUsersCreate(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"status": "ok"})
 }
 
+// assignableRole resolves a requested role name. Empty means operator; any built-in
+// or custom role is accepted, but only admins may grant the admin role.
+func (h *UserHandlers) assignableRole(c *gin.Context, raw string) (manager.Role, bool) {
+	role := manager.Role(strings.ToLower(strings.TrimSpace(raw)))
+	if role == "" {
+		role = manager.RoleOperator
+	}
+	if !h.users.RoleExists(role) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+		return "", false
+	}
+	if role == manager.RoleAdmin && c.GetString("role") != string(manager.RoleAdmin) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only admins can grant the admin role"})
+		return "", false
+	}
+	return role, true
+}
+
+// guardAdminTarget stops non-admins holding users.manage from changing admin accounts.
+func (h *UserHandlers) guardAdminTarget(c *gin.Context, username string) bool {
+	if c.GetString("role") == string(manager.RoleAdmin) {
+		return true
+	}
+	if u, ok := h.users.Get(username); ok && u.Role == manager.RoleAdmin {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only admins can modify admin accounts"})
+		return false
+	}
+	return true
+}
+
 type apiSetRoleReq struct {
 	Role string `json:"role"`
 }
 
 func (h *UserHandlers) APIUsersSetRole(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
 	req, err := h.bindRoleRequest(c)
 	if err != nil {
 		ToastError(c, "Update Role Failed", "Invalid request payload.")
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
 		return
 	}
-	roleStr := strings.ToLower(strings.TrimSpace(req.Role))
-	if roleStr != string(manager.RoleAdmin) && roleStr != string(manager.RoleOperator) {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+	role, ok := h.assignableRole(c, req.Role)
+	if !ok {
 		return
 	}
+	roleStr := string(role)
 	// Prevent demoting the last admin
 	if roleStr != string(manager.RoleAdmin) {
 		if u, ok := h.users.Get(username); ok && u.Role == manager.RoleAdmin {
@@ -555,8 +612,8 @@ func (h *UserHandlers) APIUsersSetRole(c *gin.Context) {
 
 // API: get/set operator server assignments
 func (h *UserHandlers) APIUsersGetAssignments(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	username := strings.TrimSpace(c.Param("username"))
@@ -574,8 +631,8 @@ type apiSetAssignmentsReq struct {
 }
 
 func (h *UserHandlers) APIUsersSetAssignments(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	username := strings.TrimSpace(c.Param("username"))
@@ -602,11 +659,14 @@ func (h *UserHandlers) APIUsersSetAssignments(c *gin.Context) {
 }
 
 func (h *UserHandlers) APIUsersDelete(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
 	if u, ok := h.users.Get(username); ok && u.Role == manager.RoleAdmin {
 		admins := 0
 		for _, usr := range h.users.Users() {
@@ -637,11 +697,14 @@ type apiResetPasswordReq struct {
 }
 
 func (h *UserHandlers) APIUsersResetPassword(c *gin.Context) {
-	if c.GetString("role") != string(manager.RoleAdmin) {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin required"})
+	if !Can(c, h.users, 0, manager.PermUsersManage) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
 	username := strings.TrimSpace(c.Param("username"))
+	if !h.guardAdminTarget(c, username) {
+		return
+	}
 	req, err := h.bindResetPasswordRequest(c)
 	if err != nil {
 		ToastError(c, "Reset Failed", "Invalid request payload.")
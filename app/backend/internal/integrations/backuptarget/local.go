@@ -0,0 +1,86 @@
+package backuptarget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalTarget copies archives into a directory, typically a second disk or network mount.
+type LocalTarget struct {
+	cfg Config
+}
+
+func (t *LocalTarget) Name() string { return t.cfg.Name }
+
+func (t *LocalTarget) destination(key string) (string, error) {
+	rel, err := joinKey(t.cfg.Prefix, key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(t.cfg.Path, filepath.FromSlash(rel)), nil
+}
+
+// Upload writes to a temporary file and renames it into place.
+func (t *LocalTarget) Upload(ctx context.Context, key, localPath string, _ Checksum) error {
+	dst, err := t.destination(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	src, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	tmp := dst + ".partial"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, copyErr := io.Copy(out, &ctxReader{ctx: ctx, r: src})
+	if err := out.Close(); copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		os.Remove(tmp)
+		return copyErr
+	}
+	return os.Rename(tmp, dst)
+}
+
+// Verify re-reads the stored copy and compares its SHA-256.
+func (t *LocalTarget) Verify(_ context.Context, key string, sum Checksum) error {
+	dst, err := t.destination(key)
+	if err != nil {
+		return err
+	}
+	got, err := FileChecksum(dst)
+	if err != nil {
+		return fmt.Errorf("read back failed: %w", err)
+	}
+	if got.Size != sum.Size {
+		return mismatch("size", fmt.Sprint(sum.Size), fmt.Sprint(got.Size))
+	}
+	if got.SHA256 != sum.SHA256 {
+		return mismatch("sha256", sum.SHA256, got.SHA256)
+	}
+	return nil
+}
+
+// ctxReader aborts long copies when the context is cancelled.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,238 @@
+package backuptarget
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const s3Service = "s3"
+
+// S3Target uploads archives to an S3-compatible bucket (AWS, MinIO, Backblaze B2, ...)
+// using single-part PUTs signed with AWS Signature Version 4.
+type S3Target struct {
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func newS3Target(cfg Config) *S3Target {
+	if strings.TrimSpace(cfg.Region) == "" {
+		cfg.Region = "us-east-1"
+	}
+	if !strings.Contains(cfg.Endpoint, "://") {
+		cfg.Endpoint = "https://" + cfg.Endpoint
+	}
+	return &S3Target{cfg: cfg, client: &http.Client{Timeout: 30 * time.Minute}, now: time.Now}
+}
+
+func (t *S3Target) Name() string { return t.cfg.Name }
+
+// objectURL builds the path-style (endpoint/bucket/key) or virtual-hosted
+// (bucket.endpoint/key) URL for key.
+func (t *S3Target) objectURL(key string) (*url.URL, error) {
+	rel, err := joinKey(t.cfg.Prefix, key)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(strings.TrimRight(t.cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if t.cfg.PathStyle {
+		u.Path = "/" + t.cfg.Bucket + "/" + rel
+	} else {
+		u.Host = t.cfg.Bucket + "." + u.Host
+		u.Path = "/" + rel
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	return u, nil
+}
+
+// Upload PUTs the archive with Content-MD5 so the server rejects corrupted transfers, and
+// asks the store to keep its SHA-256 for Verify.
+func (t *S3Target) Upload(ctx context.Context, key, localPath string, sum Checksum) error {
+	u, err := t.objectURL(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = sum.Size
+	req.Header.Set("Content-Type", "application/zip")
+	if md5Raw, err := hex.DecodeString(sum.MD5); err == nil && len(md5Raw) > 0 {
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Raw))
+	}
+	if shaRaw, err := hex.DecodeString(sum.SHA256); err == nil && len(shaRaw) > 0 {
+		req.Header.Set("x-amz-checksum-sha256", base64.StdEncoding.EncodeToString(shaRaw))
+	}
+	t.sign(req, sum.SHA256)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+// Verify HEADs the object and compares its size and, when the store reports one, its
+// SHA-256. The ETag is not used: with SSE-KMS or SSE-C it is not the MD5 of the content.
+func (t *S3Target) Verify(ctx context.Context, key string, sum Checksum) error {
+	u, err := t.objectURL(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-amz-checksum-mode", "ENABLED")
+	t.sign(req, emptyPayloadHash)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return s3Error(resp)
+	}
+	if resp.ContentLength != sum.Size {
+		return mismatch("size", fmt.Sprint(sum.Size), fmt.Sprint(resp.ContentLength))
+	}
+	if stored := resp.Header.Get("x-amz-checksum-sha256"); stored != "" {
+		raw, err := base64.StdEncoding.DecodeString(stored)
+		if got := hex.EncodeToString(raw); err != nil || got != sum.SHA256 {
+			return mismatch("sha256", sum.SHA256, got)
+		}
+	}
+	return nil
+}
+
+var emptyPayloadHash = hex.EncodeToString(sha256.New().Sum(nil))
+
+// sign adds SigV4 headers to req. payloadHash is the hex SHA-256 of the request body.
+func (t *S3Target) sign(req *http.Request, payloadHash string) {
+	now := t.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-md5" || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + t.cfg.Region + "/" + s3Service + "/aws4_request"
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(crHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+t.cfg.SecretKey), day)
+	key = hmacSHA256(key, t.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func s3EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = s3Escape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+	msg := strings.TrimSpace(string(body))
+	if start := strings.Index(msg, "<Message>"); start >= 0 {
+		if end := strings.Index(msg[start:], "</Message>"); end > 0 {
+			msg = msg[start+len("<Message>") : start+end]
+		}
+	}
+	if msg == "" {
+		return fmt.Errorf("s3 request failed: %s", resp.Status)
+	}
+	return fmt.Errorf("s3 request failed: %s: %s", resp.Status, msg)
+}
@@ -0,0 +1,174 @@
+package backuptarget
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTPTarget uploads archives to an SFTP server over SSH.
+type SFTPTarget struct {
+	cfg       Config
+	sshConfig *ssh.ClientConfig
+	addr      string
+}
+
+func newSFTPTarget(cfg Config) (*SFTPTarget, error) {
+	var auth []ssh.AuthMethod
+	if cfg.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if strings.TrimSpace(cfg.HostKey) != "" {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid host key: %w", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(key)
+	}
+	port := cfg.Port
+	if port <= 0 {
+		port = 22
+	}
+	return &SFTPTarget{
+		cfg: cfg,
+		sshConfig: &ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         15 * time.Second,
+		},
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+	}, nil
+}
+
+func (t *SFTPTarget) Name() string { return t.cfg.Name }
+
+// session dials the server, starts the sftp subsystem and runs fn with the client.
+func (t *SFTPTarget) session(ctx context.Context, fn func(*sftp.Client) error) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, t.addr, t.sshConfig)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	sc, err := sftp.NewClient(client)
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+	return fn(sc)
+}
+
+// remotePath resolves key under the prefix. Relative prefixes land in the login directory.
+func (t *SFTPTarget) remotePath(key string) (string, error) {
+	rel, err := joinKey(t.cfg.Prefix, key)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(t.cfg.Prefix, "/") {
+		return "/" + rel, nil
+	}
+	return rel, nil
+}
+
+// Upload writes to "<key>.partial" and renames it into place once complete.
+func (t *SFTPTarget) Upload(ctx context.Context, key, localPath string, _ Checksum) error {
+	remote, err := t.remotePath(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return t.session(ctx, func(c *sftp.Client) error {
+		if err := c.MkdirAll(path.Dir(remote)); err != nil {
+			return fmt.Errorf("create remote directory: %w", err)
+		}
+		tmp := remote + ".partial"
+		out, err := c.Create(tmp)
+		if err != nil {
+			return err
+		}
+		if _, err := out.ReadFrom(&ctxReader{ctx: ctx, r: f}); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		// SFTP v3 rename fails when the destination exists
+		if err := c.Remove(remote); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return c.Rename(tmp, remote)
+	})
+}
+
+// Verify reads the remote copy back and compares size and SHA-256.
+func (t *SFTPTarget) Verify(ctx context.Context, key string, sum Checksum) error {
+	remote, err := t.remotePath(key)
+	if err != nil {
+		return err
+	}
+	return t.session(ctx, func(c *sftp.Client) error {
+		in, err := c.Open(remote)
+		if err != nil {
+			return fmt.Errorf("read back failed: %w", err)
+		}
+		defer in.Close()
+		h := sha256.New()
+		counter := &countingWriter{w: h}
+		if _, err := in.WriteTo(counter); err != nil {
+			return fmt.Errorf("read back failed: %w", err)
+		}
+		if counter.n != sum.Size {
+			return mismatch("size", fmt.Sprint(sum.Size), fmt.Sprint(counter.n))
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != sum.SHA256 {
+			return mismatch("sha256", sum.SHA256, got)
+		}
+		return nil
+	})
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,187 @@
+// Package backuptarget pushes world backup archives to off-host storage (another local
+// directory/mount, S3-compatible object storage, or an SFTP server) and verifies the copies.
+package backuptarget
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Target types accepted in Config.Type.
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+	TypeSFTP  = "sftp"
+)
+
+// BackupTarget stores backup archives remotely. Keys are slash-separated relative paths
+// (e.g. "Server1/backup_20240101_120000_scheduled.zip").
+type BackupTarget interface {
+	// Name returns the configured display name.
+	Name() string
+	// Upload copies the local file to key. sum describes the local file.
+	Upload(ctx context.Context, key, localPath string, sum Checksum) error
+	// Verify confirms the stored object at key matches sum.
+	Verify(ctx context.Context, key string, sum Checksum) error
+}
+
+// Config is the persisted definition of a backup target. Fields not used by a type are ignored.
+type Config struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	// Prefix is prepended to every key (S3 key prefix / SFTP or local subdirectory).
+	Prefix string `json:"prefix,omitempty"`
+	// Local
+	Path string `json:"path,omitempty"`
+	// S3-compatible
+	Endpoint  string `json:"endpoint,omitempty"`
+	Region    string `json:"region,omitempty"`
+	Bucket    string `json:"bucket,omitempty"`
+	AccessKey string `json:"access_key,omitempty"`
+	SecretKey string `json:"secret_key,omitempty"`
+	PathStyle bool   `json:"path_style,omitempty"`
+	// SFTP
+	Host       string `json:"host,omitempty"`
+	Port       int    `json:"port,omitempty"`
+	User       string `json:"user,omitempty"`
+	Password   string `json:"password,omitempty"`
+	PrivateKey string `json:"private_key,omitempty"`
+	// HostKey is the server's public key in authorized_keys format. Required unless
+	// InsecureIgnoreHostKey is set.
+	HostKey               string `json:"host_key,omitempty"`
+	InsecureIgnoreHostKey bool   `json:"insecure_ignore_host_key,omitempty"`
+}
+
+// HasSecret reports whether the config carries a credential that should not be echoed back.
+func (c Config) HasSecret() bool {
+	return c.SecretKey != "" || c.Password != "" || c.PrivateKey != ""
+}
+
+// Redacted returns a copy with credentials cleared, for API responses.
+func (c Config) Redacted() Config {
+	c.SecretKey = ""
+	c.Password = ""
+	c.PrivateKey = ""
+	return c
+}
+
+// SameConnection reports whether c sends its credentials to the same place as prev.
+// Stored secrets may only be carried over when this holds; otherwise a caller could
+// redirect them to a host of its choosing.
+func (c Config) SameConnection(prev Config) bool {
+	return c.Type == prev.Type &&
+		strings.TrimSpace(c.Endpoint) == strings.TrimSpace(prev.Endpoint) &&
+		strings.TrimSpace(c.Region) == strings.TrimSpace(prev.Region) &&
+		strings.TrimSpace(c.Bucket) == strings.TrimSpace(prev.Bucket) &&
+		c.AccessKey == prev.AccessKey &&
+		c.PathStyle == prev.PathStyle &&
+		strings.TrimSpace(c.Host) == strings.TrimSpace(prev.Host) &&
+		c.Port == prev.Port &&
+		c.User == prev.User &&
+		strings.TrimSpace(c.HostKey) == strings.TrimSpace(prev.HostKey) &&
+		c.InsecureIgnoreHostKey == prev.InsecureIgnoreHostKey
+}
+
+// Validate checks the fields required by the target type.
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("target name is required")
+	}
+	switch c.Type {
+	case TypeLocal:
+		if strings.TrimSpace(c.Path) == "" {
+			return errors.New("local target requires a path")
+		}
+	case TypeS3:
+		if strings.TrimSpace(c.Endpoint) == "" || strings.TrimSpace(c.Bucket) == "" {
+			return errors.New("s3 target requires endpoint and bucket")
+		}
+		if c.AccessKey == "" || c.SecretKey == "" {
+			return errors.New("s3 target requires access and secret keys")
+		}
+	case TypeSFTP:
+		if strings.TrimSpace(c.Host) == "" || strings.TrimSpace(c.User) == "" {
+			return errors.New("sftp target requires host and user")
+		}
+		if c.Password == "" && c.PrivateKey == "" {
+			return errors.New("sftp target requires a password or private key")
+		}
+		if strings.TrimSpace(c.HostKey) == "" && !c.InsecureIgnoreHostKey {
+			return errors.New("sftp target requires a host key")
+		}
+	default:
+		return fmt.Errorf("unsupported target type %q", c.Type)
+	}
+	return nil
+}
+
+// New constructs the target described by cfg.
+func New(cfg Config) (BackupTarget, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case TypeLocal:
+		return &LocalTarget{cfg: cfg}, nil
+	case TypeS3:
+		return newS3Target(cfg), nil
+	default:
+		return newSFTPTarget(cfg)
+	}
+}
+
+// Checksum identifies the content of a backup archive.
+type Checksum struct {
+	Size   int64
+	SHA256 string
+	MD5    string
+}
+
+// FileChecksum computes the size, SHA-256 and MD5 of a local file.
+func FileChecksum(localPath string) (Checksum, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return Checksum{}, err
+	}
+	defer f.Close()
+	return readerChecksum(f)
+}
+
+func readerChecksum(r io.Reader) (Checksum, error) {
+	sh := sha256.New()
+	mh := md5.New()
+	n, err := io.Copy(io.MultiWriter(sh, mh), r)
+	if err != nil {
+		return Checksum{}, err
+	}
+	return Checksum{Size: n, SHA256: hex.EncodeToString(sh.Sum(nil)), MD5: hex.EncodeToString(mh.Sum(nil))}, nil
+}
+
+// ErrChecksumMismatch is returned by Verify when the remote copy differs.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+func mismatch(what, want, got string) error {
+	return fmt.Errorf("%w: %s expected %s, got %s", ErrChecksumMismatch, what, want, got)
+}
+
+// joinKey applies the configured prefix and rejects keys that try to escape it.
+func joinKey(prefix, key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if key == "" || key == "." {
+		return "", errors.New("empty key")
+	}
+	prefix = strings.Trim(strings.ReplaceAll(prefix, "\\", "/"), "/")
+	if prefix == "" {
+		return key, nil
+	}
+	return prefix + "/" + key, nil
+}
@@ -0,0 +1,566 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultAPIBase is the Discord REST API root used when BotConfig.APIBase is empty.
+const DefaultAPIBase = "https://discord.com/api/v10"
+
+// Gateway opcodes and intents used by the bot.
+// See: https://discord.com/developers/docs/topics/opcodes-and-status-codes
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+	opHeartbeatAck   = 11
+
+	intentGuilds         = 1 << 0
+	intentGuildMessages  = 1 << 9
+	intentMessageContent = 1 << 15
+
+	// Flag 64 makes an interaction reply visible only to the invoking user.
+	messageFlagEphemeral = 1 << 6
+
+	maxMessageLength = 2000
+	maxBackoff       = time.Minute
+)
+
+// BotConfig configures the optional two-way bridge that runs as a Discord bot.
+type BotConfig struct {
+	Enabled bool   `json:"enabled"`
+	Token   string `json:"token,omitempty"`
+	// GuildID registers slash commands on one guild (available immediately) instead of globally.
+	GuildID string `json:"guild_id,omitempty"`
+	// Bridges pair an SDSM server with the Discord channel its chat is relayed to.
+	Bridges []Bridge `json:"bridges,omitempty"`
+	// APIBase overrides DefaultAPIBase; tests point it at discordtest.Gateway. It is never
+	// persisted or accepted from the API, so the token cannot be sent anywhere but Discord.
+	APIBase string `json:"-"`
+}
+
+// Bridge relays chat between one server and one Discord channel.
+type Bridge struct {
+	ServerID  int    `json:"server_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+// Validate reports configuration problems for an enabled bot.
+func (c BotConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if strings.TrimSpace(c.Token) == "" {
+		return errors.New("discord bot: token is required")
+	}
+	seen := make(map[string]bool, len(c.Bridges))
+	for _, b := range c.Bridges {
+		if b.ServerID <= 0 || strings.TrimSpace(b.ChannelID) == "" {
+			return errors.New("discord bot: bridges need a server_id and channel_id")
+		}
+		if seen[b.ChannelID] {
+			return fmt.Errorf("discord bot: channel %s is bridged twice", b.ChannelID)
+		}
+		seen[b.ChannelID] = true
+	}
+	return nil
+}
+
+// Redacted returns a copy without the bot token.
+func (c BotConfig) Redacted() BotConfig {
+	c.Token = ""
+	c.Bridges = append([]Bridge(nil), c.Bridges...)
+	return c
+}
+
+// ChannelFor returns the channel bridged to serverID.
+func (c BotConfig) ChannelFor(serverID int) (string, bool) {
+	for _, b := range c.Bridges {
+		if b.ServerID == serverID {
+			return b.ChannelID, true
+		}
+	}
+	return "", false
+}
+
+// ServerFor returns the server bridged to channelID.
+func (c BotConfig) ServerFor(channelID string) (int, bool) {
+	for _, b := range c.Bridges {
+		if b.ChannelID == channelID {
+			return b.ServerID, true
+		}
+	}
+	return 0, false
+}
+
+func (c BotConfig) apiBase() string {
+	if base := strings.TrimRight(strings.TrimSpace(c.APIBase), "/"); base != "" {
+		return base
+	}
+	return DefaultAPIBase
+}
+
+// Message is a channel message received from the gateway.
+type Message struct {
+	ID        string
+	ChannelID string
+	AuthorID  string
+	Author    string
+	Bot       bool
+	Content   string
+}
+
+// Interaction is a slash command invocation.
+type Interaction struct {
+	ID        string
+	Token     string
+	ChannelID string
+	UserID    string
+	User      string
+	Command   string
+	Options   map[string]string
+}
+
+// SlashCommand describes a command registered with Discord. Every command takes an optional
+// "server" string option.
+type SlashCommand struct {
+	Name        string
+	Description string
+}
+
+// Bot maintains a gateway connection, dispatching messages and slash commands to the
+// callbacks, and posts replies over the REST API. The connection is re-established with
+// backoff until the Run context ends.
+type Bot struct {
+	cfg      BotConfig
+	commands []SlashCommand
+	client   *http.Client
+
+	OnMessage     func(Message)
+	OnInteraction func(Interaction)
+	Log           Logger
+
+	mu        sync.Mutex
+	writeMu   sync.Mutex
+	conn      *websocket.Conn
+	seq       *int64
+	appID     string
+	botUserID string
+	ready     bool
+}
+
+// NewBot returns a bot for cfg that registers commands once connected.
+func NewBot(cfg BotConfig, commands []SlashCommand) *Bot {
+	return &Bot{cfg: cfg, commands: commands, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Ready reports whether the gateway session is established.
+func (b *Bot) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ready
+}
+
+// Logger receives the bot's connection events; *utils.Logger satisfies it.
+type Logger interface {
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+}
+
+func (b *Bot) logInfo(format string, args ...interface{}) {
+	if b.Log != nil {
+		b.Log.Info(fmt.Sprintf(format, args...))
+	}
+}
+
+func (b *Bot) logWarn(format string, args ...interface{}) {
+	if b.Log != nil {
+		b.Log.Warn(fmt.Sprintf(format, args...))
+	}
+}
+
+// Run connects and keeps the session alive until ctx is cancelled.
+func (b *Bot) Run(ctx context.Context) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		started := time.Now()
+		err := b.session(ctx)
+		b.mu.Lock()
+		b.ready = false
+		b.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > 2*maxBackoff {
+			backoff = time.Second
+		}
+		b.logWarn("Discord gateway disconnected: %v; reconnecting in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+type gatewayPayload struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d,omitempty"`
+	S  *int64          `json:"s,omitempty"`
+	T  string          `json:"t,omitempty"`
+}
+
+func (b *Bot) session(ctx context.Context) error {
+	var gw struct {
+		URL string `json:"url"`
+	}
+	if err := b.rest(ctx, http.MethodGet, "/gateway/bot", nil, &gw); err != nil {
+		return err
+	}
+	u, err := url.Parse(gw.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid gateway url %q", gw.URL)
+	}
+	q := u.Query()
+	q.Set("v", "10")
+	q.Set("encoding", "json")
+	u.RawQuery = q.Encode()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	b.mu.Lock()
+	b.conn = conn
+	b.mu.Unlock()
+	// Unblock the read loop when the context ends.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	var hello gatewayPayload
+	if err := conn.ReadJSON(&hello); err != nil {
+		return err
+	}
+	var helloData struct {
+		HeartbeatInterval int `json:"heartbeat_interval"`
+	}
+	if hello.Op != opHello || json.Unmarshal(hello.D, &helloData) != nil || helloData.HeartbeatInterval <= 0 {
+		return errors.New("expected HELLO from gateway")
+	}
+	hbCtx, hbCancel := context.WithCancel(ctx)
+	defer hbCancel()
+	go b.heartbeat(hbCtx, time.Duration(helloData.HeartbeatInterval)*time.Millisecond)
+
+	identify := map[string]interface{}{
+		"token":   b.cfg.Token,
+		"intents": intentGuilds | intentGuildMessages | intentMessageContent,
+		"properties": map[string]string{
+			"os":      "linux",
+			"browser": "sdsm",
+			"device":  "sdsm",
+		},
+	}
+	if err := b.send(opIdentify, identify); err != nil {
+		return err
+	}
+
+	for {
+		var p gatewayPayload
+		if err := conn.ReadJSON(&p); err != nil {
+			return err
+		}
+		if p.S != nil {
+			b.mu.Lock()
+			b.seq = p.S
+			b.mu.Unlock()
+		}
+		switch p.Op {
+		case opDispatch:
+			b.dispatch(ctx, p.T, p.D)
+		case opHeartbeat:
+			if err := b.sendHeartbeat(); err != nil {
+				return err
+			}
+		case opReconnect:
+			return errors.New("gateway requested reconnect")
+		case opInvalidSession:
+			return errors.New("gateway invalidated the session")
+		case opHeartbeatAck:
+		}
+	}
+}
+
+func (b *Bot) heartbeat(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := b.sendHeartbeat(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (b *Bot) sendHeartbeat() error {
+	b.mu.Lock()
+	seq := b.seq
+	b.mu.Unlock()
+	return b.send(opHeartbeat, seq)
+}
+
+func (b *Bot) send(op int, d interface{}) error {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	conn := b.conn
+	b.mu.Unlock()
+	if conn == nil {
+		return errors.New("not connected")
+	}
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+	return conn.WriteJSON(gatewayPayload{Op: op, D: raw})
+}
+
+type gatewayUser struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name"`
+	Bot        bool   `json:"bot"`
+}
+
+func (u gatewayUser) display(nick string) string {
+	switch {
+	case nick != "":
+		return nick
+	case u.GlobalName != "":
+		return u.GlobalName
+	default:
+		return u.Username
+	}
+}
+
+type gatewayMember struct {
+	Nick string       `json:"nick"`
+	User *gatewayUser `json:"user"`
+}
+
+func (b *Bot) dispatch(ctx context.Context, event string, data json.RawMessage) {
+	switch event {
+	case "READY":
+		var ready struct {
+			User        gatewayUser `json:"user"`
+			Application struct {
+				ID string `json:"id"`
+			} `json:"application"`
+		}
+		if err := json.Unmarshal(data, &ready); err != nil {
+			b.logWarn("Discord READY payload invalid: %v", err)
+			return
+		}
+		b.mu.Lock()
+		b.appID = ready.Application.ID
+		b.botUserID = ready.User.ID
+		b.ready = true
+		b.mu.Unlock()
+		b.logInfo("Discord bot connected as %s", ready.User.Username)
+		if err := b.registerCommands(ctx); err != nil {
+			b.logWarn("Discord slash command registration failed: %v", err)
+		}
+	case "MESSAGE_CREATE":
+		var msg struct {
+			ID        string         `json:"id"`
+			ChannelID string         `json:"channel_id"`
+			Content   string         `json:"content"`
+			Author    gatewayUser    `json:"author"`
+			Member    *gatewayMember `json:"member"`
+		}
+		if err := json.Unmarshal(data, &msg); err != nil || b.OnMessage == nil {
+			return
+		}
+		b.mu.Lock()
+		self := msg.Author.ID == b.botUserID
+		b.mu.Unlock()
+		if self {
+			return
+		}
+		nick := ""
+		if msg.Member != nil {
+			nick = msg.Member.Nick
+		}
+		b.OnMessage(Message{
+			ID:        msg.ID,
+			ChannelID: msg.ChannelID,
+			AuthorID:  msg.Author.ID,
+			Author:    msg.Author.display(nick),
+			Bot:       msg.Author.Bot,
+			Content:   msg.Content,
+		})
+	case "INTERACTION_CREATE":
+		var it struct {
+			ID        string         `json:"id"`
+			Token     string         `json:"token"`
+			Type      int            `json:"type"`
+			ChannelID string         `json:"channel_id"`
+			Member    *gatewayMember `json:"member"`
+			User      *gatewayUser   `json:"user"`
+			Data      struct {
+				Name    string `json:"name"`
+				Options []struct {
+					Name  string      `json:"name"`
+					Value interface{} `json:"value"`
+				} `json:"options"`
+			} `json:"data"`
+		}
+		// Type 2 is APPLICATION_COMMAND.
+		if err := json.Unmarshal(data, &it); err != nil || it.Type != 2 || b.OnInteraction == nil {
+			return
+		}
+		out := Interaction{
+			ID:        it.ID,
+			Token:     it.Token,
+			ChannelID: it.ChannelID,
+			Command:   it.Data.Name,
+			Options:   make(map[string]string, len(it.Data.Options)),
+		}
+		switch {
+		case it.Member != nil && it.Member.User != nil:
+			out.UserID, out.User = it.Member.User.ID, it.Member.User.display(it.Member.Nick)
+		case it.User != nil:
+			out.UserID, out.User = it.User.ID, it.User.display("")
+		}
+		for _, opt := range it.Data.Options {
+			out.Options[opt.Name] = fmt.Sprint(opt.Value)
+		}
+		b.OnInteraction(out)
+	}
+}
+
+func (b *Bot) registerCommands(ctx context.Context) error {
+	if len(b.commands) == 0 {
+		return nil
+	}
+	b.mu.Lock()
+	appID := b.appID
+	b.mu.Unlock()
+	if appID == "" {
+		return errors.New("application id unknown")
+	}
+	type option struct {
+		Type        int    `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Required    bool   `json:"required"`
+	}
+	type command struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Options     []option `json:"options"`
+	}
+	body := make([]command, 0, len(b.commands))
+	for _, c := range b.commands {
+		// Option type 3 is STRING.
+		body = append(body, command{
+			Name:        c.Name,
+			Description: c.Description,
+			Options:     []option{{Type: 3, Name: "server", Description: "Server name or ID"}},
+		})
+	}
+	path := "/applications/" + appID + "/commands"
+	if guild := strings.TrimSpace(b.cfg.GuildID); guild != "" {
+		path = "/applications/" + appID + "/guilds/" + guild + "/commands"
+	}
+	return b.rest(ctx, http.MethodPut, path, body, nil)
+}
+
+// noMentions stops relayed text from pinging users, roles or @everyone.
+var noMentions = map[string][]string{"parse": {}}
+
+// SendMessage posts content to a channel.
+func (b *Bot) SendMessage(ctx context.Context, channelID, content string) error {
+	body := map[string]interface{}{
+		"content":          truncate(content, maxMessageLength),
+		"allowed_mentions": noMentions,
+	}
+	return b.rest(ctx, http.MethodPost, "/channels/"+url.PathEscape(channelID)+"/messages", body, nil)
+}
+
+// Respond answers a slash command; ephemeral replies are only shown to the invoking user.
+func (b *Bot) Respond(ctx context.Context, it Interaction, content string, ephemeral bool) error {
+	data := map[string]interface{}{
+		"content":          truncate(content, maxMessageLength),
+		"allowed_mentions": noMentions,
+	}
+	if ephemeral {
+		data["flags"] = messageFlagEphemeral
+	}
+	// Callback type 4 is CHANNEL_MESSAGE_WITH_SOURCE.
+	body := map[string]interface{}{"type": 4, "data": data}
+	path := "/interactions/" + url.PathEscape(it.ID) + "/" + url.PathEscape(it.Token) + "/callback"
+	return b.rest(ctx, http.MethodPost, path, body, nil)
+}
+
+func (b *Bot) rest(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.cfg.apiBase()+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+b.cfg.Token)
+	req.Header.Set("User-Agent", "DiscordBot (https://github.com/sdsm, 1)")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: HTTP %d %s", method, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -0,0 +1,290 @@
+// Package discordtest runs an in-process Discord gateway and REST API for tests. It
+// accepts a bot's IDENTIFY, answers heartbeats, lets tests push MESSAGE_CREATE and
+// INTERACTION_CREATE events, and records the messages, interaction replies and command
+// registrations the bot sends back.
+package discordtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ApplicationID and BotUserID are reported in the READY event.
+const (
+	ApplicationID = "100000000000000001"
+	BotUserID     = "100000000000000002"
+)
+
+// SentMessage is a message the bot posted to a channel.
+type SentMessage struct {
+	ChannelID string
+	Content   string
+}
+
+// Response is a bot reply to an interaction.
+type Response struct {
+	InteractionID string
+	Content       string
+	Ephemeral     bool
+}
+
+// Gateway is a fake Discord. Close it when done.
+type Gateway struct {
+	*httptest.Server
+	Token string
+
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	seq       int64
+	ready     chan struct{}
+	readyOnce sync.Once
+	messages  []SentMessage
+	responses []Response
+	commands  []string
+	nextID    int
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// NewGateway starts a fake Discord that accepts the given bot token.
+func NewGateway(token string) *Gateway {
+	g := &Gateway{Token: token, ready: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gateway/bot", g.gatewayBot)
+	mux.HandleFunc("/ws", g.websocket)
+	mux.HandleFunc("/channels/", g.channelMessage)
+	mux.HandleFunc("/interactions/", g.interactionCallback)
+	mux.HandleFunc("/applications/", g.registerCommands)
+	g.Server = httptest.NewServer(mux)
+	return g
+}
+
+// WaitReady blocks until a bot has identified, or the timeout passes.
+func (g *Gateway) WaitReady(timeout time.Duration) bool {
+	select {
+	case <-g.ready:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (g *Gateway) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bot "+g.Token {
+		http.Error(w, `{"message": "401: Unauthorized"}`, http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func (g *Gateway) gatewayBot(w http.ResponseWriter, r *http.Request) {
+	if !g.authorized(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"url": "ws" + strings.TrimPrefix(g.URL, "http") + "/ws"})
+}
+
+func (g *Gateway) websocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if conn.WriteJSON(map[string]interface{}{"op": 10, "d": map[string]int{"heartbeat_interval": 1000}}) != nil {
+		return
+	}
+	var identify struct {
+		Op int `json:"op"`
+		D  struct {
+			Token string `json:"token"`
+		} `json:"d"`
+	}
+	if conn.ReadJSON(&identify) != nil || identify.Op != 2 {
+		return
+	}
+	if identify.D.Token != g.Token {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4004, "Authentication failed."))
+		return
+	}
+	g.mu.Lock()
+	g.conn = conn
+	g.mu.Unlock()
+	g.dispatch("READY", map[string]interface{}{
+		"session_id":  "session",
+		"user":        map[string]interface{}{"id": BotUserID, "username": "sdsm", "bot": true},
+		"application": map[string]string{"id": ApplicationID},
+	})
+	g.readyOnce.Do(func() { close(g.ready) })
+
+	for {
+		var p struct {
+			Op int `json:"op"`
+		}
+		if conn.ReadJSON(&p) != nil {
+			return
+		}
+		if p.Op == 1 {
+			g.write(map[string]interface{}{"op": 11})
+		}
+	}
+}
+
+func (g *Gateway) write(v interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.conn != nil {
+		_ = g.conn.WriteJSON(v)
+	}
+}
+
+func (g *Gateway) dispatch(event string, d interface{}) {
+	g.mu.Lock()
+	g.seq++
+	seq := g.seq
+	g.mu.Unlock()
+	g.write(map[string]interface{}{"op": 0, "s": seq, "t": event, "d": d})
+}
+
+func (g *Gateway) id() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nextID++
+	return strconv.Itoa(900000000000000000 + g.nextID)
+}
+
+// PostMessage delivers a MESSAGE_CREATE from a guild member.
+func (g *Gateway) PostMessage(channelID, userID, username, content string) {
+	g.dispatch("MESSAGE_CREATE", map[string]interface{}{
+		"id":         g.id(),
+		"channel_id": channelID,
+		"content":    content,
+		"author":     map[string]interface{}{"id": userID, "username": username},
+		"member":     map[string]interface{}{},
+	})
+}
+
+// RunCommand delivers an INTERACTION_CREATE for a slash command and returns the
+// interaction ID the reply will be recorded under.
+func (g *Gateway) RunCommand(channelID, userID, username, command string, options map[string]string) string {
+	id := g.id()
+	opts := make([]map[string]string, 0, len(options))
+	for name, value := range options {
+		opts = append(opts, map[string]string{"name": name, "value": value})
+	}
+	g.dispatch("INTERACTION_CREATE", map[string]interface{}{
+		"id":         id,
+		"token":      "token-" + id,
+		"type":       2,
+		"channel_id": channelID,
+		"member": map[string]interface{}{
+			"user": map[string]interface{}{"id": userID, "username": username},
+		},
+		"data": map[string]interface{}{"name": command, "options": opts},
+	})
+	return id
+}
+
+func (g *Gateway) channelMessage(w http.ResponseWriter, r *http.Request) {
+	if !g.authorized(w, r) {
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodPost || len(parts) != 3 || parts[2] != "messages" {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		Content string `json:"content"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	g.mu.Lock()
+	g.messages = append(g.messages, SentMessage{ChannelID: parts[1], Content: body.Content})
+	g.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (g *Gateway) interactionCallback(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodPost || len(parts) != 4 || parts[3] != "callback" || parts[2] != "token-"+parts[1] {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		Type int `json:"type"`
+		Data struct {
+			Content string `json:"content"`
+			Flags   int    `json:"flags"`
+		} `json:"data"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil || body.Type != 4 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	g.mu.Lock()
+	g.responses = append(g.responses, Response{InteractionID: parts[1], Content: body.Data.Content, Ephemeral: body.Data.Flags&64 != 0})
+	g.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *Gateway) registerCommands(w http.ResponseWriter, r *http.Request) {
+	if !g.authorized(w, r) {
+		return
+	}
+	if r.Method != http.MethodPut || !strings.HasPrefix(r.URL.Path, "/applications/"+ApplicationID+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	var body []struct {
+		Name string `json:"name"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	g.mu.Lock()
+	g.commands = g.commands[:0]
+	for _, c := range body {
+		g.commands = append(g.commands, c.Name)
+	}
+	g.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`[]`))
+}
+
+// Messages returns the channel messages the bot has sent.
+func (g *Gateway) Messages() []SentMessage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]SentMessage(nil), g.messages...)
+}
+
+// Response returns the bot's reply to an interaction, if any.
+func (g *Gateway) Response(interactionID string) (Response, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, r := range g.responses {
+		if r.InteractionID == interactionID {
+			return r, true
+		}
+	}
+	return Response{}, false
+}
+
+// Commands returns the names of the registered slash commands.
+func (g *Gateway) Commands() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.commands...)
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var matrixTxnSeq atomic.Uint64
+
+// Matrix sends an m.text message to a room the access token's user has joined.
+type Matrix struct {
+	cfg Config
+}
+
+func (m *Matrix) Name() string { return m.cfg.Name }
+
+func (m *Matrix) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"msgtype": "m.text",
+		"body":    plainText(msg),
+	})
+	if err != nil {
+		return err
+	}
+	// Transaction IDs only need to be unique per access token; they make retries idempotent.
+	txn := fmt.Sprintf("sdsm-%d-%d", time.Now().UnixNano(), matrixTxnSeq.Add(1))
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(m.cfg.Homeserver, "/"), url.PathEscape(m.cfg.RoomID), txn)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.cfg.Token)
+	return do(req)
+}
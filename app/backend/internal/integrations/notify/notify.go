@@ -0,0 +1,221 @@
+// Package notify delivers SDSM event notifications to channels other than Discord:
+// signed JSON webhooks, Slack-compatible webhooks, ntfy, Gotify, Matrix rooms and email.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Channel types accepted in Config.Type.
+const (
+	TypeWebhook = "webhook"
+	TypeSlack   = "slack"
+	TypeNtfy    = "ntfy"
+	TypeGotify  = "gotify"
+	TypeMatrix  = "matrix"
+	TypeSMTP    = "smtp"
+)
+
+// Notifier sends a single message to one configured channel.
+type Notifier interface {
+	// Name returns the configured display name.
+	Name() string
+	// Send delivers msg. Implementations honour ctx for cancellation and timeouts.
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is the channel-neutral notification. Text is already rendered for the channel;
+// for webhook channels with a custom template it is the complete JSON request body.
+type Message struct {
+	Event      string    `json:"event"`
+	Kind       string    `json:"kind"`
+	Title      string    `json:"title"`
+	Text       string    `json:"message"`
+	ServerID   int       `json:"server_id,omitempty"`
+	ServerName string    `json:"server_name,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	// Raw marks Text as a pre-built payload that must be sent verbatim (webhook templates).
+	Raw bool `json:"-"`
+}
+
+// Config is the persisted definition of a notification channel. Fields not used by a type are ignored.
+type Config struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	// Events limits the channel to these event names ("started", "crashed", "deploy-failed", ...).
+	// A trailing "*" matches a prefix ("deploy-*"); an empty list matches every event.
+	Events []string `json:"events,omitempty"`
+	// ServerIDs limits server events to these servers; manager events are always eligible.
+	ServerIDs []int `json:"server_ids,omitempty"`
+	// Template overrides the message using {{token}} placeholders. For webhook channels it
+	// renders the whole JSON body (token values are JSON-escaped).
+	Template string `json:"template,omitempty"`
+	// Webhook, Slack, ntfy (topic URL), Gotify (server URL)
+	URL string `json:"url,omitempty"`
+	// Secret signs webhook bodies with HMAC-SHA256 (X-SDSM-Signature header).
+	Secret string `json:"secret,omitempty"`
+	// Token is the ntfy access token, Gotify application token or Matrix access token.
+	Token    string `json:"token,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	// Matrix
+	Homeserver string `json:"homeserver,omitempty"`
+	RoomID     string `json:"room_id,omitempty"`
+	// SMTP
+	Host     string   `json:"host,omitempty"`
+	Port     int      `json:"port,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+}
+
+// HasSecret reports whether the config carries a credential that should not be echoed back.
+func (c Config) HasSecret() bool {
+	return c.Secret != "" || c.Token != "" || c.Password != ""
+}
+
+// Redacted returns a copy with credentials cleared, for API responses.
+func (c Config) Redacted() Config {
+	c.Secret = ""
+	c.Token = ""
+	c.Password = ""
+	return c
+}
+
+// SameConnection reports whether c sends its credentials to the same place as prev.
+// Stored secrets may only be carried over when this holds; otherwise a caller could
+// redirect them to a server of its choosing.
+func (c Config) SameConnection(prev Config) bool {
+	return c.Type == prev.Type &&
+		strings.TrimSpace(c.URL) == strings.TrimSpace(prev.URL) &&
+		strings.TrimSpace(c.Homeserver) == strings.TrimSpace(prev.Homeserver) &&
+		strings.TrimSpace(c.Host) == strings.TrimSpace(prev.Host) &&
+		c.Port == prev.Port &&
+		c.Username == prev.Username
+}
+
+// Wants reports whether the channel subscribes to event for serverID (0 for manager events).
+func (c Config) Wants(event string, serverID int) bool {
+	if !c.Enabled {
+		return false
+	}
+	if serverID != 0 && len(c.ServerIDs) > 0 {
+		found := false
+		for _, id := range c.ServerIDs {
+			if id == serverID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, pattern := range c.Events {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "*" || pattern == event {
+			return true
+		}
+		if strings.HasSuffix(pattern, "*") && strings.HasPrefix(event, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the fields required by the channel type.
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("channel name is required")
+	}
+	switch c.Type {
+	case TypeWebhook, TypeSlack, TypeNtfy, TypeGotify:
+		if err := validateURL(c.URL); err != nil {
+			return fmt.Errorf("%s channel: %w", c.Type, err)
+		}
+		if c.Type == TypeGotify && c.Token == "" {
+			return errors.New("gotify channel requires an application token")
+		}
+	case TypeMatrix:
+		if err := validateURL(c.Homeserver); err != nil {
+			return fmt.Errorf("matrix channel: %w", err)
+		}
+		if strings.TrimSpace(c.RoomID) == "" || c.Token == "" {
+			return errors.New("matrix channel requires a room id and access token")
+		}
+	case TypeSMTP:
+		if strings.TrimSpace(c.Host) == "" || strings.TrimSpace(c.From) == "" || len(c.To) == 0 {
+			return errors.New("smtp channel requires host, from and at least one recipient")
+		}
+	default:
+		return fmt.Errorf("unsupported channel type %q", c.Type)
+	}
+	return nil
+}
+
+// New constructs the notifier described by cfg.
+func New(cfg Config) (Notifier, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case TypeWebhook:
+		return &Webhook{cfg: cfg}, nil
+	case TypeSlack:
+		return &Slack{cfg: cfg}, nil
+	case TypeNtfy:
+		return &Ntfy{cfg: cfg}, nil
+	case TypeGotify:
+		return &Gotify{cfg: cfg}, nil
+	case TypeMatrix:
+		return &Matrix{cfg: cfg}, nil
+	default:
+		return &SMTP{cfg: cfg}, nil
+	}
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("a valid http(s) URL is required")
+	}
+	return nil
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// do sends req and converts non-2xx responses into errors that include a short body excerpt.
+func do(req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(excerpt)))
+	}
+	return nil
+}
+
+// plainText joins the title and text for channels without a separate title field.
+func plainText(msg Message) string {
+	if msg.Title == "" {
+		return msg.Text
+	}
+	if msg.Text == "" {
+		return msg.Title
+	}
+	return msg.Title + "\n" + msg.Text
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type captured struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+func captureServer(t *testing.T) (*httptest.Server, <-chan captured) {
+	t.Helper()
+	ch := make(chan captured, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		ch <- captured{method: r.Method, path: r.URL.EscapedPath(), header: r.Header.Clone(), body: string(b)}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func testMessage() Message {
+	return Message{Event: "crashed", Kind: "danger", Title: "Server Mars: crashed", Text: "exit code 1", ServerID: 3, ServerName: "Mars", Timestamp: time.Unix(1_700_000_000, 0).UTC()}
+}
+
+func send(t *testing.T, cfg Config, msg Message) {
+	t.Helper()
+	n, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Send(context.Background(), msg); err != nil {
+		t.Fatalf("%s send: %v", cfg.Type, err)
+	}
+}
+
+func TestWebhookSignsBody(t *testing.T) {
+	srv, got := captureServer(t)
+	send(t, Config{Name: "hook", Type: TypeWebhook, URL: srv.URL, Secret: "s3cret"}, testMessage())
+	req := <-got
+	if sig := req.header.Get(SignatureHeader); sig != Sign("s3cret", []byte(req.body)) {
+		t.Fatalf("bad signature %q", sig)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(req.body), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["event"] != "crashed" || payload["server_name"] != "Mars" || payload["message"] != "exit code 1" {
+		t.Fatalf("unexpected payload %v", payload)
+	}
+
+	raw := testMessage()
+	raw.Raw, raw.Text = true, `{"custom":true}`
+	send(t, Config{Name: "hook", Type: TypeWebhook, URL: srv.URL}, raw)
+	if req := <-got; req.body != `{"custom":true}` || req.header.Get(SignatureHeader) != "" {
+		t.Fatalf("raw body not sent verbatim: %+v", req)
+	}
+}
+
+func TestHTTPChannels(t *testing.T) {
+	srv, got := captureServer(t)
+	msg := testMessage()
+
+	send(t, Config{Name: "slack", Type: TypeSlack, URL: srv.URL}, msg)
+	if req := <-got; !strings.Contains(req.body, `"text":"*Server Mars: crashed*\nexit code 1"`) {
+		t.Fatalf("slack body %s", req.body)
+	}
+
+	send(t, Config{Name: "ntfy", Type: TypeNtfy, URL: srv.URL + "/alerts", Token: "tk", Priority: 4}, msg)
+	if req := <-got; req.path != "/alerts" || req.body != "exit code 1" || req.header.Get("Title") != msg.Title ||
+		req.header.Get("Priority") != "4" || req.header.Get("Authorization") != "Bearer tk" {
+		t.Fatalf("ntfy request %+v", req)
+	}
+
+	send(t, Config{Name: "gotify", Type: TypeGotify, URL: srv.URL + "/", Token: "app"}, msg)
+	if req := <-got; req.path != "/message" || req.header.Get("X-Gotify-Key") != "app" || !strings.Contains(req.body, `"priority":5`) {
+		t.Fatalf("gotify request %+v", req)
+	}
+
+	send(t, Config{Name: "matrix", Type: TypeMatrix, Homeserver: srv.URL, RoomID: "!room:example.org", Token: "mx"}, msg)
+	req := <-got
+	if req.method != http.MethodPut || !strings.HasPrefix(req.path, "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/") {
+		t.Fatalf("matrix request %s %s", req.method, req.path)
+	}
+	if req.header.Get("Authorization") != "Bearer mx" || !strings.Contains(req.body, `"msgtype":"m.text"`) {
+		t.Fatalf("matrix request %+v", req)
+	}
+}
+
+func TestHTTPChannelReportsFailureStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	n, _ := New(Config{Name: "slack", Type: TypeSlack, URL: srv.URL})
+	if err := n.Send(context.Background(), testMessage()); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected 403 error, got %v", err)
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 fake")
+			case strings.HasPrefix(cmd, "DATA"):
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				data <- b.String()
+				reply("250 queued")
+			case strings.HasPrefix(cmd, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	send(t, Config{Name: "mail", Type: TypeSMTP, Host: "127.0.0.1", Port: port, From: "sdsm@example.org", To: []string{"ops@example.org"}}, testMessage())
+	mail := <-data
+	for _, want := range []string{"To: ops@example.org\r\n", "Subject: Server Mars: crashed\r\n", "\r\n\r\nexit code 1\r\n"} {
+		if !strings.Contains(mail, want) {
+			t.Fatalf("mail missing %q:\n%s", want, mail)
+		}
+	}
+}
+
+func TestConfigWants(t *testing.T) {
+	cfg := Config{Enabled: true, Events: []string{"crashed", "deploy-*"}, ServerIDs: []int{3}}
+	cases := []struct {
+		event    string
+		serverID int
+		want     bool
+	}{
+		{"crashed", 3, true},
+		{"crashed", 4, false},
+		{"started", 3, false},
+		{"deploy-failed", 0, true},
+	}
+	for _, tc := range cases {
+		if got := cfg.Wants(tc.event, tc.serverID); got != tc.want {
+			t.Fatalf("Wants(%q, %d) = %v", tc.event, tc.serverID, got)
+		}
+	}
+	if (Config{Events: nil}).Wants("started", 1) {
+		t.Fatal("disabled channel should not match")
+	}
+	if err := (Config{Name: "x", Type: TypeMatrix, Homeserver: "https://m.org"}).Validate(); err == nil {
+		t.Fatal("expected matrix validation error")
+	}
+}
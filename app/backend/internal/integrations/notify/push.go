@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Ntfy publishes to an ntfy topic URL (e.g. https://ntfy.sh/sdsm-alerts).
+type Ntfy struct {
+	cfg Config
+}
+
+func (n *Ntfy) Name() string { return n.cfg.Name }
+
+func (n *Ntfy) Send(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, strings.NewReader(msg.Text))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if msg.Title != "" {
+		req.Header.Set("Title", msg.Title)
+	}
+	if n.cfg.Priority > 0 {
+		req.Header.Set("Priority", strconv.Itoa(n.cfg.Priority))
+	}
+	if tag := ntfyTag(msg.Kind); tag != "" {
+		req.Header.Set("Tags", tag)
+	}
+	if n.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.cfg.Token)
+	}
+	return do(req)
+}
+
+func ntfyTag(kind string) string {
+	switch kind {
+	case "success":
+		return "white_check_mark"
+	case "warning":
+		return "warning"
+	case "danger":
+		return "rotating_light"
+	default:
+		return ""
+	}
+}
+
+// Gotify posts to a Gotify server's /message endpoint using an application token.
+type Gotify struct {
+	cfg Config
+}
+
+func (g *Gotify) Name() string { return g.cfg.Name }
+
+func (g *Gotify) Send(ctx context.Context, msg Message) error {
+	priority := g.cfg.Priority
+	if priority <= 0 {
+		priority = 5
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"title":    msg.Title,
+		"message":  msg.Text,
+		"priority": priority,
+	})
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimRight(g.cfg.URL, "/") + "/message"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", g.cfg.Token)
+	return do(req)
+}
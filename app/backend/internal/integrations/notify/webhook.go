@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>" when a webhook secret is configured.
+const SignatureHeader = "X-SDSM-Signature"
+
+// Webhook posts the message as JSON to an arbitrary endpoint.
+type Webhook struct {
+	cfg Config
+}
+
+func (w *Webhook) Name() string { return w.cfg.Name }
+
+// Send posts msg as JSON (or the rendered template body) and signs it when a secret is set.
+func (w *Webhook) Send(ctx context.Context, msg Message) error {
+	var body []byte
+	if msg.Raw {
+		body = []byte(msg.Text)
+	} else {
+		var err error
+		if body, err = json.Marshal(msg); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-SDSM-Event", msg.Event)
+	req.Header.Set("X-SDSM-Timestamp", strconv.FormatInt(msg.Timestamp.Unix(), 10))
+	if w.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.cfg.Secret, body))
+	}
+	return do(req)
+}
+
+// Sign returns the X-SDSM-Signature value for body so receivers can verify deliveries.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Slack posts to a Slack-compatible incoming webhook (Slack, Mattermost, Rocket.Chat).
+type Slack struct {
+	cfg Config
+}
+
+func (s *Slack) Name() string { return s.cfg.Name }
+
+func (s *Slack) Send(ctx context.Context, msg Message) error {
+	text := msg.Text
+	if msg.Title != "" {
+		text = "*" + msg.Title + "*\n" + msg.Text
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(req)
+}
@@ -0,0 +1,443 @@
+// Package oidc implements the OpenID Connect authorization code flow (with PKCE) used for
+// single sign-on: provider discovery, the authorization redirect, the code exchange and
+// ID token verification against the provider's published signing keys.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// discoveryTTL is how long provider metadata and signing keys are cached.
+const discoveryTTL = time.Hour
+
+// Config is the sdsm.config "oidc" section.
+type Config struct {
+	Enabled      bool   `json:"enabled"`
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	// RedirectURL defaults to <request origin>/auth/oidc/callback when empty.
+	RedirectURL string `json:"redirect_url,omitempty"`
+	// DisplayName labels the login button (default "Single Sign-On").
+	DisplayName string `json:"display_name,omitempty"`
+	// Scopes requested in addition to "openid" (default: profile, email).
+	Scopes []string `json:"scopes,omitempty"`
+	// UsernameClaim picks the SDSM username (default "preferred_username", falling back to email then sub).
+	UsernameClaim string `json:"username_claim,omitempty"`
+	// RoleClaim is the claim holding groups/roles matched by RoleMappings (default "groups").
+	RoleClaim string `json:"role_claim,omitempty"`
+	// RoleMappings are checked in order; the first claim value that matches selects the role.
+	RoleMappings []RoleMapping `json:"role_mappings,omitempty"`
+	// DefaultRole applies when no mapping matches. Empty refuses the login.
+	DefaultRole string `json:"default_role,omitempty"`
+}
+
+// RoleMapping maps one claim value (e.g. a Keycloak group) to an SDSM role.
+type RoleMapping struct {
+	Value string `json:"value"`
+	Role  string `json:"role"`
+}
+
+// Validate reports configuration problems for an enabled provider.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(c.Issuer))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("oidc: issuer must be an absolute URL")
+	}
+	if strings.TrimSpace(c.ClientID) == "" {
+		return errors.New("oidc: client_id is required")
+	}
+	for _, m := range c.RoleMappings {
+		if strings.TrimSpace(m.Value) == "" || strings.TrimSpace(m.Role) == "" {
+			return errors.New("oidc: role mappings need both value and role")
+		}
+	}
+	return nil
+}
+
+// Label returns the login button text.
+func (c Config) Label() string {
+	if s := strings.TrimSpace(c.DisplayName); s != "" {
+		return s
+	}
+	return "Single Sign-On"
+}
+
+// Identity is the verified result of a login.
+type Identity struct {
+	Issuer   string
+	Subject  string
+	Username string
+	Email    string
+	Claims   map[string]interface{}
+}
+
+// Role resolves the SDSM role for the identity using the configured mappings.
+func (c Config) Role(id *Identity) (string, bool) {
+	claim := strings.TrimSpace(c.RoleClaim)
+	if claim == "" {
+		claim = "groups"
+	}
+	values := claimStrings(id.Claims[claim])
+	for _, m := range c.RoleMappings {
+		for _, v := range values {
+			if v == m.Value || strings.TrimPrefix(v, "/") == strings.TrimPrefix(m.Value, "/") {
+				return strings.TrimSpace(m.Role), true
+			}
+		}
+	}
+	if r := strings.TrimSpace(c.DefaultRole); r != "" {
+		return r, true
+	}
+	return "", false
+}
+
+func claimStrings(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return val
+	}
+	return nil
+}
+
+// AuthRequest holds the per-login secrets that must survive the round trip to the provider.
+type AuthRequest struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewAuthRequest generates fresh state, nonce and PKCE verifier values.
+func NewAuthRequest() (AuthRequest, error) {
+	var r AuthRequest
+	for _, dst := range []*string{&r.State, &r.Nonce, &r.Verifier} {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return r, err
+		}
+		*dst = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return r, nil
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider talks to one OpenID provider. It is safe for concurrent use.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu        sync.Mutex
+	meta      *metadata
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewProvider returns a provider for cfg. A nil client uses a 10 second timeout client.
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.Issuer = strings.TrimRight(strings.TrimSpace(cfg.Issuer), "/")
+	return &Provider{cfg: cfg, client: client}
+}
+
+// Config returns the provider configuration.
+func (p *Provider) Config() Config { return p.cfg }
+
+// AuthCodeURL returns the provider authorization URL for req.
+func (p *Provider) AuthCodeURL(ctx context.Context, req AuthRequest, redirectURL string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := []string{"openid"}
+	if len(p.cfg.Scopes) == 0 {
+		scopes = append(scopes, "profile", "email")
+	}
+	for _, s := range p.cfg.Scopes {
+		if s = strings.TrimSpace(s); s != "" && s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+	challenge := sha256.Sum256([]byte(req.Verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {req.State},
+		"nonce":                 {req.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems the authorization code and verifies the returned ID token.
+func (p *Provider) Exchange(ctx context.Context, code string, req AuthRequest, redirectURL string) (*Identity, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {req.Verifier},
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		httpReq.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: token request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: token endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var tok struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return nil, fmt.Errorf("oidc: decode token response: %w", err)
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+	return p.verify(ctx, tok.IDToken, req.Nonce)
+}
+
+// verify checks the ID token signature, issuer, audience, expiry and nonce.
+func (p *Provider) verify(ctx context.Context, raw, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256"}),
+		jwt.WithIssuer(p.issuer()),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid id_token: %w", err)
+	}
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return nil, errors.New("oidc: id_token nonce mismatch")
+	}
+	id := &Identity{Issuer: p.issuer(), Claims: claims}
+	id.Subject, _ = claims["sub"].(string)
+	id.Email, _ = claims["email"].(string)
+	if id.Subject == "" {
+		return nil, errors.New("oidc: id_token has no subject")
+	}
+	userClaim := strings.TrimSpace(p.cfg.UsernameClaim)
+	if userClaim == "" {
+		userClaim = "preferred_username"
+	}
+	id.Username, _ = claims[userClaim].(string)
+	if id.Username == "" {
+		id.Username = id.Email
+	}
+	if id.Username == "" {
+		id.Username = id.Subject
+	}
+	return id, nil
+}
+
+func (p *Provider) issuer() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil && p.meta.Issuer != "" {
+		return p.meta.Issuer
+	}
+	return p.cfg.Issuer
+}
+
+func (p *Provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil && time.Since(p.fetchedAt) < discoveryTTL {
+		return p.meta, nil
+	}
+	if err := p.refreshLocked(ctx); err != nil {
+		return nil, err
+	}
+	return p.meta, nil
+}
+
+// key returns the signing key for kid, refetching the key set once on a miss (key rotation).
+func (p *Provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	lookup := func() (crypto.PublicKey, bool) {
+		if kid == "" && len(p.keys) == 1 {
+			for _, k := range p.keys {
+				return k, true
+			}
+		}
+		k, ok := p.keys[kid]
+		return k, ok
+	}
+	if k, ok := lookup(); ok && time.Since(p.fetchedAt) < discoveryTTL {
+		return k, nil
+	}
+	if err := p.refreshLocked(ctx); err != nil {
+		return nil, err
+	}
+	if k, ok := lookup(); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+}
+
+// refreshLocked reloads discovery metadata and the key set. Caller MUST hold p.mu.
+func (p *Provider) refreshLocked(ctx context.Context) error {
+	var meta metadata
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return fmt.Errorf("oidc: discovery: %w", err)
+	}
+	if strings.TrimRight(meta.Issuer, "/") != p.cfg.Issuer {
+		return fmt.Errorf("oidc: discovery issuer %q does not match configured issuer %q", meta.Issuer, p.cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return errors.New("oidc: discovery document is missing endpoints")
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return fmt.Errorf("oidc: fetch jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("oidc: provider published no usable signing keys")
+	}
+	p.meta, p.keys, p.fetchedAt = &meta, keys, time.Now()
+	return nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, rawURL string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", rawURL, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -0,0 +1,116 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"sdsm/app/backend/internal/integrations/oidc/oidctest"
+)
+
+// login drives the authorization endpoint and returns the code sent back to redirectURL.
+func login(t *testing.T, p *Provider, req AuthRequest, redirectURL string) string {
+	t.Helper()
+	authURL, err := p.AuthCodeURL(context.Background(), req, redirectURL)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || !strings.HasPrefix(loc.String(), redirectURL) {
+		t.Fatalf("unexpected authorize redirect %q (%d)", resp.Header.Get("Location"), resp.StatusCode)
+	}
+	if loc.Query().Get("state") != req.State {
+		t.Fatalf("state not echoed")
+	}
+	return loc.Query().Get("code")
+}
+
+func TestProviderLoginFlow(t *testing.T) {
+	mock := oidctest.NewProvider("sdsm", "s3cret")
+	defer mock.Close()
+	mock.SetClaims(map[string]interface{}{"sub": "u-1", "preferred_username": "alice", "groups": []string{"/players", "/sdsm-mods"}})
+
+	cfg := Config{Enabled: true, Issuer: mock.Issuer() + "/", ClientID: "sdsm", ClientSecret: "s3cret",
+		RoleMappings: []RoleMapping{{Value: "sdsm-admins", Role: "admin"}, {Value: "sdsm-mods", Role: "moderator"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	p := NewProvider(cfg, nil)
+	req, err := NewAuthRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const redirect = "http://sdsm.local/auth/oidc/callback"
+	code := login(t, p, req, redirect)
+
+	id, err := p.Exchange(context.Background(), code, req, redirect)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if id.Subject != "u-1" || id.Username != "alice" || id.Issuer != mock.Issuer() {
+		t.Fatalf("unexpected identity %+v", id)
+	}
+	if role, ok := cfg.Role(id); !ok || role != "moderator" {
+		t.Fatalf("expected moderator role, got %q %v", role, ok)
+	}
+	if _, err := p.Exchange(context.Background(), code, req, redirect); err == nil {
+		t.Fatalf("expected a used code to be rejected")
+	}
+}
+
+func TestProviderRejectsBadTokens(t *testing.T) {
+	mock := oidctest.NewProvider("sdsm", "s3cret")
+	defer mock.Close()
+	mock.SetClaims(map[string]interface{}{"sub": "u-2"})
+	const redirect = "http://sdsm.local/cb"
+
+	p := NewProvider(Config{Enabled: true, Issuer: mock.Issuer(), ClientID: "sdsm", ClientSecret: "s3cret"}, nil)
+	req, _ := NewAuthRequest()
+	mock.SetNonceOverride("replayed")
+	if _, err := p.Exchange(context.Background(), login(t, p, req, redirect), req, redirect); err == nil || !strings.Contains(err.Error(), "nonce") {
+		t.Fatalf("expected nonce mismatch, got %v", err)
+	}
+	mock.SetNonceOverride("")
+
+	tampered := req
+	tampered.Verifier = "wrong"
+	if _, err := p.Exchange(context.Background(), login(t, p, req, redirect), tampered, redirect); err == nil {
+		t.Fatalf("expected PKCE verifier mismatch to fail")
+	}
+
+	wrongSecret := NewProvider(Config{Enabled: true, Issuer: mock.Issuer(), ClientID: "sdsm", ClientSecret: "nope"}, nil)
+	if _, err := wrongSecret.Exchange(context.Background(), login(t, wrongSecret, req, redirect), req, redirect); err == nil {
+		t.Fatalf("expected invalid client to fail")
+	}
+
+	noMapping := Config{RoleMappings: []RoleMapping{{Value: "admins", Role: "admin"}}}
+	if _, ok := noMapping.Role(&Identity{Claims: map[string]interface{}{"groups": "players"}}); ok {
+		t.Fatalf("expected unmapped identity to be refused without a default role")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		cfg Config
+		ok  bool
+	}{
+		{Config{}, true},
+		{Config{Enabled: true, Issuer: "https://id.example.com/realms/game", ClientID: "sdsm"}, true},
+		{Config{Enabled: true, Issuer: "id.example.com", ClientID: "sdsm"}, false},
+		{Config{Enabled: true, Issuer: "https://id.example.com"}, false},
+		{Config{Enabled: true, Issuer: "https://id.example.com", ClientID: "x", RoleMappings: []RoleMapping{{Value: "g"}}}, false},
+	}
+	for i, tc := range cases {
+		if err := tc.cfg.Validate(); (err == nil) != tc.ok {
+			t.Fatalf("case %d: Validate() = %v", i, err)
+		}
+	}
+}
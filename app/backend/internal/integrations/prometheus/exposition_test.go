@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestWriteFamilies(t *testing.T) {
+	players := &Family{Name: "sdsm_server_players", Help: "Connected players.\nPer server.", Type: TypeGauge}
+	players.Add(3, "server_id", "1", "server_name", `Mars "Hard"`)
+	players.Add(0, "server_id", "2", "server_name", `C:\games`)
+	empty := &Family{Name: "sdsm_unused", Help: "Never sampled."}
+	deploys := &Family{Name: "sdsm_deploy_runs_total", Type: TypeCounter}
+	deploys.Add(12)
+	nan := &Family{Name: "sdsm_nan"}
+	nan.Add(math.NaN())
+
+	var buf bytes.Buffer
+	if err := Write(&buf, []*Family{players, empty, deploys, nil, nan}); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP sdsm_server_players Connected players.\nPer server.
+# TYPE sdsm_server_players gauge
+sdsm_server_players{server_id="1",server_name="Mars \"Hard\""} 3
+sdsm_server_players{server_id="2",server_name="C:\\games"} 0
+# TYPE sdsm_deploy_runs_total counter
+sdsm_deploy_runs_total 12
+# TYPE sdsm_nan gauge
+sdsm_nan NaN
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected exposition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	cases := map[float64]string{
+		1:            "1",
+		0.25:         "0.25",
+		1.5e12:       "1.5e+12",
+		math.Inf(1):  "+Inf",
+		math.Inf(-1): "-Inf",
+	}
+	for in, want := range cases {
+		if got := formatValue(in); got != want {
+			t.Fatalf("formatValue(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/integrations/notify"
+	"sdsm/app/backend/internal/models"
+)
+
+// Alert rule kinds. Threshold units depend on the kind.
+const (
+	AlertHostDiskPercent   = "host_disk_percent"   // threshold: percent used
+	AlertHostCPUPercent    = "host_cpu_percent"    // threshold: percent
+	AlertHostMemoryPercent = "host_memory_percent" // threshold: percent used
+	AlertServerRSSGB       = "server_rss_gb"       // threshold: resident memory in GiB
+	AlertServerCPUPercent  = "server_cpu_percent"  // threshold: percent (100 = one core)
+	AlertWorldSaveStale    = "world_save_stale"    // threshold: minutes without a "World Saved" line
+	AlertSCONUnhealthy     = "scon_unhealthy"      // SCON HTTP API unreachable while running
+	AlertPortForwardLost   = "port_forward_lost"   // automatic port forwarding holds no mapping
+	AlertUpdateAvailable   = "update_available"    // a component has a newer version available
+)
+
+// Alert lifecycle states.
+const (
+	AlertStatePending  = "pending"
+	AlertStateFiring   = "firing"
+	AlertStateResolved = "resolved"
+)
+
+const (
+	maxResolvedAlerts        = 50
+	alertUpdateCheckInterval = 15 * time.Minute
+	alertDefaultSeverity     = models.NotificationKindWarning
+)
+
+// AlertRule is a persisted threshold rule evaluated on every telemetry sample.
+type AlertRule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Enabled bool   `json:"enabled"`
+	// Threshold is compared with ">" for numeric kinds; ignored for boolean kinds.
+	Threshold float64 `json:"threshold,omitempty"`
+	// ForSeconds is how long the condition must hold before the alert fires.
+	ForSeconds int `json:"for_seconds,omitempty"`
+	// Severity is "warning" (default) or "danger".
+	Severity string `json:"severity,omitempty"`
+	// ServerIDs limits server rules to these servers; empty means all servers.
+	ServerIDs []int `json:"server_ids,omitempty"`
+}
+
+// Alert is one rule firing (or about to fire) for one target.
+type Alert struct {
+	Key        string     `json:"key"`
+	RuleID     string     `json:"rule_id"`
+	RuleName   string     `json:"rule_name"`
+	Kind       string     `json:"kind"`
+	Severity   string     `json:"severity"`
+	ServerID   int        `json:"server_id,omitempty"`
+	Target     string     `json:"target"`
+	State      string     `json:"state"`
+	Value      float64    `json:"value"`
+	Detail     string     `json:"detail"`
+	Since      time.Time  `json:"since"`
+	FiredAt    *time.Time `json:"fired_at,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// DefaultAlertRules is the rule set used when sdsm.config has no alert_rules key.
+func DefaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{ID: "host-disk", Name: "Host disk almost full", Kind: AlertHostDiskPercent, Enabled: true, Threshold: 90, ForSeconds: 60, Severity: models.NotificationKindDanger},
+		{ID: "server-memory", Name: "Server memory high", Kind: AlertServerRSSGB, Enabled: true, Threshold: 8, ForSeconds: 300},
+		{ID: "world-save-stale", Name: "World not saved", Kind: AlertWorldSaveStale, Enabled: true, Threshold: 30},
+		{ID: "scon-unhealthy", Name: "SCON not responding", Kind: AlertSCONUnhealthy, Enabled: true, ForSeconds: 120},
+		{ID: "port-forward-lost", Name: "Port forward lost", Kind: AlertPortForwardLost, Enabled: true, ForSeconds: 300},
+		{ID: "update-available", Name: "Update pending", Kind: AlertUpdateAvailable, Enabled: true, ForSeconds: 86400},
+	}
+}
+
+func isServerAlertKind(kind string) bool {
+	switch kind {
+	case AlertServerRSSGB, AlertServerCPUPercent, AlertWorldSaveStale, AlertSCONUnhealthy, AlertPortForwardLost:
+		return true
+	}
+	return false
+}
+
+// Validate checks the rule's kind, threshold and severity.
+func (r AlertRule) Validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return errors.New("rule id is required")
+	}
+	switch r.Kind {
+	case AlertHostDiskPercent, AlertHostCPUPercent, AlertHostMemoryPercent, AlertServerCPUPercent, AlertServerRSSGB, AlertWorldSaveStale:
+		if r.Threshold <= 0 {
+			return errors.New("threshold must be greater than zero")
+		}
+	case AlertSCONUnhealthy, AlertPortForwardLost, AlertUpdateAvailable:
+	default:
+		return fmt.Errorf("unsupported alert kind %q", r.Kind)
+	}
+	if r.ForSeconds < 0 {
+		return errors.New("for_seconds cannot be negative")
+	}
+	switch r.Severity {
+	case "", models.NotificationKindWarning, models.NotificationKindDanger:
+	default:
+		return fmt.Errorf("unsupported severity %q", r.Severity)
+	}
+	return nil
+}
+
+// AlertRuleConfigs returns a copy of the configured alert rules.
+func (m *Manager) AlertRuleConfigs() []AlertRule {
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	return append([]AlertRule(nil), m.AlertRules...)
+}
+
+// SetAlertRules validates and replaces the rule list. Alerts belonging to removed or
+// disabled rules resolve on the next evaluation.
+func (m *Manager) SetAlertRules(rules []AlertRule) error {
+	seen := make(map[string]bool, len(rules))
+	out := make([]AlertRule, 0, len(rules))
+	for _, r := range rules {
+		r.ID = strings.TrimSpace(r.ID)
+		r.Name = strings.TrimSpace(r.Name)
+		r.Kind = strings.ToLower(strings.TrimSpace(r.Kind))
+		r.Severity = strings.ToLower(strings.TrimSpace(r.Severity))
+		if seen[r.ID] {
+			return fmt.Errorf("duplicate rule id %q", r.ID)
+		}
+		seen[r.ID] = true
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", r.ID, err)
+		}
+		if r.Name == "" {
+			r.Name = r.ID
+		}
+		out = append(out, r)
+	}
+	m.alertsMu.Lock()
+	m.AlertRules = out
+	m.alertsMu.Unlock()
+	m.Save()
+	return nil
+}
+
+// ActiveAlerts returns pending and firing alerts, firing first, newest first.
+func (m *Manager) ActiveAlerts() []Alert {
+	if m == nil {
+		return nil
+	}
+	m.alertsMu.Lock()
+	out := make([]Alert, 0, len(m.alerts))
+	for _, a := range m.alerts {
+		out = append(out, *a)
+	}
+	m.alertsMu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].State != out[j].State {
+			return out[i].State == AlertStateFiring
+		}
+		return out[i].Since.After(out[j].Since)
+	})
+	return out
+}
+
+// ResolvedAlerts returns up to limit recently resolved alerts, newest first.
+func (m *Manager) ResolvedAlerts(limit int) []Alert {
+	if m == nil {
+		return nil
+	}
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	if limit <= 0 || limit > len(m.resolvedAlerts) {
+		limit = len(m.resolvedAlerts)
+	}
+	return append([]Alert(nil), m.resolvedAlerts[:limit]...)
+}
+
+// alertObservation is a condition that currently holds for one rule and target.
+type alertObservation struct {
+	rule     AlertRule
+	serverID int
+	target   string
+	value    float64
+	detail   string
+}
+
+// key dedups alerts: one per rule and host, server (by ID, so renames keep state) or component.
+func (o alertObservation) key() string {
+	if o.serverID != 0 {
+		return fmt.Sprintf("%s/server-%d", o.rule.ID, o.serverID)
+	}
+	return o.rule.ID + "/" + o.target
+}
+
+// evaluateAlerts advances every alert's state machine using the latest telemetry. An alert
+// is pending while its condition holds for less than ForSeconds, fires once it has held
+// long enough, and resolves when the condition clears. Only the firing and resolved
+// transitions notify, so a condition that persists is reported exactly once.
+func (m *Manager) evaluateAlerts(snapshot *models.SystemTelemetry, now time.Time) {
+	if m == nil {
+		return
+	}
+	rules := m.AlertRuleConfigs()
+	var observed []alertObservation
+	for _, rule := range rules {
+		if rule.Enabled {
+			observed = append(observed, m.observeAlertRule(rule, snapshot, now)...)
+		}
+	}
+
+	var fired, resolved []Alert
+	m.alertsMu.Lock()
+	if m.alerts == nil {
+		m.alerts = make(map[string]*Alert)
+	}
+	active := make(map[string]bool, len(observed))
+	for _, o := range observed {
+		key := o.key()
+		active[key] = true
+		a, ok := m.alerts[key]
+		if !ok {
+			severity := o.rule.Severity
+			if severity == "" {
+				severity = alertDefaultSeverity
+			}
+			a = &Alert{
+				Key:      key,
+				RuleID:   o.rule.ID,
+				RuleName: o.rule.Name,
+				Kind:     o.rule.Kind,
+				Severity: severity,
+				ServerID: o.serverID,
+				Target:   o.target,
+				State:    AlertStatePending,
+				Since:    now,
+			}
+			m.alerts[key] = a
+		}
+		a.Value = o.value
+		a.Detail = o.detail
+		if a.State == AlertStatePending && now.Sub(a.Since) >= time.Duration(o.rule.ForSeconds)*time.Second {
+			a.State = AlertStateFiring
+			firedAt := now
+			a.FiredAt = &firedAt
+			fired = append(fired, *a)
+		}
+	}
+	for key, a := range m.alerts {
+		if active[key] {
+			continue
+		}
+		delete(m.alerts, key)
+		if a.State != AlertStateFiring {
+			continue
+		}
+		a.State = AlertStateResolved
+		resolvedAt := now
+		a.ResolvedAt = &resolvedAt
+		resolved = append(resolved, *a)
+		m.resolvedAlerts = append([]Alert{*a}, m.resolvedAlerts...)
+		if len(m.resolvedAlerts) > maxResolvedAlerts {
+			m.resolvedAlerts = m.resolvedAlerts[:maxResolvedAlerts]
+		}
+	}
+	m.alertsMu.Unlock()
+
+	for _, a := range fired {
+		m.notifyAlert(a)
+	}
+	for _, a := range resolved {
+		m.notifyAlert(a)
+	}
+}
+
+func (m *Manager) observeAlertRule(rule AlertRule, snapshot *models.SystemTelemetry, now time.Time) []alertObservation {
+	threshold := rule.Threshold
+	host := func(value float64, label string) []alertObservation {
+		if value <= threshold {
+			return nil
+		}
+		return []alertObservation{{rule: rule, target: "host", value: value,
+			detail: fmt.Sprintf("%s at %.1f%% (threshold %.0f%%)", label, value, threshold)}}
+	}
+	switch rule.Kind {
+	case AlertHostDiskPercent:
+		if snapshot != nil {
+			return host(snapshot.DiskPercent, "Disk usage")
+		}
+	case AlertHostCPUPercent:
+		if snapshot != nil {
+			return host(snapshot.CPUPercent, "CPU usage")
+		}
+	case AlertHostMemoryPercent:
+		if snapshot != nil {
+			return host(snapshot.MemoryPercent, "Memory usage")
+		}
+	case AlertUpdateAvailable:
+		var out []alertObservation
+		for _, dt := range m.alertOutdatedComponents(now) {
+			out = append(out, alertObservation{rule: rule, target: string(dt), value: 1,
+				detail: fmt.Sprintf("An update for %s is available", dt)})
+		}
+		return out
+	}
+	if !isServerAlertKind(rule.Kind) {
+		return nil
+	}
+	var out []alertObservation
+	for _, s := range m.Servers {
+		if s == nil || !s.IsRunning() || s.Starting || !alertRuleCoversServer(rule, s.ID) {
+			continue
+		}
+		o := alertObservation{rule: rule, serverID: s.ID, target: s.Name}
+		switch rule.Kind {
+		case AlertServerRSSGB, AlertServerCPUPercent:
+			usage := s.ResourceUsage()
+			if usage == nil {
+				continue
+			}
+			if rule.Kind == AlertServerRSSGB {
+				o.value = float64(usage.MemoryRSSBytes) / (1 << 30)
+				o.detail = fmt.Sprintf("%s is using %.1f GiB of memory (threshold %.1f GiB)", s.Name, o.value, threshold)
+			} else {
+				o.value = usage.CPUPercent
+				o.detail = fmt.Sprintf("%s is using %.0f%% CPU (threshold %.0f%%)", s.Name, o.value, threshold)
+			}
+			if o.value <= threshold {
+				continue
+			}
+		case AlertWorldSaveStale:
+			ref := s.ServerStarted
+			if s.ServerSaved != nil && (ref == nil || s.ServerSaved.After(*ref)) {
+				ref = s.ServerSaved
+			}
+			if ref == nil {
+				continue
+			}
+			age := now.Sub(*ref)
+			if age < time.Duration(threshold*float64(time.Minute)) {
+				continue
+			}
+			o.value = age.Minutes()
+			o.detail = fmt.Sprintf("%s has not reported \"World Saved\" for %.0f minutes", s.Name, o.value)
+		case AlertSCONUnhealthy:
+			probe, ok := m.SCONHealth(s.ID)
+			if !ok || probe.Reachable {
+				continue
+			}
+			o.value = 1
+			o.detail = fmt.Sprintf("SCON on %s is not responding: %s", s.Name, probe.Error)
+		case AlertPortForwardLost:
+			if !s.AutoPortForward || s.PortForwardActive {
+				continue
+			}
+			o.value = 1
+			o.detail = fmt.Sprintf("Automatic port forwarding for %s holds no mapping", s.Name)
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func alertRuleCoversServer(rule AlertRule, serverID int) bool {
+	if len(rule.ServerIDs) == 0 {
+		return true
+	}
+	for _, id := range rule.ServerIDs {
+		if id == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// alertOutdatedComponents returns the cached result of ComponentsNeedingUpdate, refreshing it
+// in the background at most every alertUpdateCheckInterval since the check may hit the network.
+func (m *Manager) alertOutdatedComponents(now time.Time) []DeployType {
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	if !m.alertUpdateCheckRunning && now.Sub(m.alertUpdateCheckedAt) >= alertUpdateCheckInterval {
+		m.alertUpdateCheckRunning = true
+		go func() {
+			var out []DeployType
+			for _, dt := range m.ComponentsNeedingUpdate() {
+				// Servers are listed whenever any component is outdated; skip the duplicate.
+				if dt != DeployTypeServers {
+					out = append(out, dt)
+				}
+			}
+			m.alertsMu.Lock()
+			m.alertOutdated = out
+			m.alertUpdateCheckedAt = time.Now()
+			m.alertUpdateCheckRunning = false
+			m.alertsMu.Unlock()
+		}()
+	}
+	return append([]DeployType(nil), m.alertOutdated...)
+}
+
+// notifyAlert reports a firing or resolved alert on the dashboard, Discord and notification channels.
+func (m *Manager) notifyAlert(a Alert) {
+	event, kind, color := "alert-firing", a.Severity, 0xF59E0B
+	title := fmt.Sprintf("Alert: %s (%s)", a.RuleName, a.Target)
+	if a.Severity == models.NotificationKindDanger {
+		color = 0xDC2626
+	}
+	if a.State == AlertStateResolved {
+		event, kind, color = "alert-resolved", models.NotificationKindSuccess, 0x16A34A
+		title = fmt.Sprintf("Resolved: %s (%s)", a.RuleName, a.Target)
+	}
+	m.enqueueDashboardNotification(kind, event, title, a.Detail, a.ServerID, "alert")
+	m.Logger("alerts", a.ServerID).Warn(fmt.Sprintf("%s - %s", title, a.Detail))
+	m.DiscordNotify("", discord.NewEmbed(title, a.Detail, color, "SDSM"))
+
+	var serverName string
+	if a.ServerID != 0 {
+		serverName = a.Target
+	}
+	m.fanOutNotification(notify.Message{
+		Event:      event,
+		Kind:       kind,
+		Title:      title,
+		Text:       a.Detail,
+		ServerID:   a.ServerID,
+		ServerName: serverName,
+		Timestamp:  time.Now().UTC(),
+	}, map[string]string{
+		"rule":      a.RuleName,
+		"target":    a.Target,
+		"state":     a.State,
+		"detail":    a.Detail,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
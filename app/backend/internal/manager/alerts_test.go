@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestAlertRuleLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		AlertRules: []AlertRule{{ID: "disk", Name: "Disk full", Kind: AlertHostDiskPercent, Enabled: true, Threshold: 90, ForSeconds: 60}},
+	}
+	defer mgr.Log.Close()
+	start := time.Now()
+	full := &models.SystemTelemetry{DiskPercent: 95}
+
+	mgr.evaluateAlerts(full, start)
+	active := mgr.ActiveAlerts()
+	if len(active) != 1 || active[0].State != AlertStatePending {
+		t.Fatalf("expected one pending alert, got %+v", active)
+	}
+	if n := len(mgr.RecentNotifications(0)); n != 0 {
+		t.Fatalf("pending alert should not notify, got %d notifications", n)
+	}
+
+	mgr.evaluateAlerts(full, start.Add(61*time.Second))
+	mgr.evaluateAlerts(full, start.Add(120*time.Second))
+	active = mgr.ActiveAlerts()
+	if len(active) != 1 || active[0].State != AlertStateFiring || active[0].Severity != models.NotificationKindWarning {
+		t.Fatalf("expected one firing warning, got %+v", active)
+	}
+	notes := mgr.RecentNotifications(0)
+	if len(notes) != 1 || notes[0].Event != "alert-firing" {
+		t.Fatalf("expected exactly one firing notification, got %+v", notes)
+	}
+
+	mgr.evaluateAlerts(&models.SystemTelemetry{DiskPercent: 50}, start.Add(180*time.Second))
+	if active := mgr.ActiveAlerts(); len(active) != 0 {
+		t.Fatalf("expected alert to clear, got %+v", active)
+	}
+	resolved := mgr.ResolvedAlerts(0)
+	if len(resolved) != 1 || resolved[0].State != AlertStateResolved || resolved[0].ResolvedAt == nil {
+		t.Fatalf("expected one resolved alert, got %+v", resolved)
+	}
+	if notes := mgr.RecentNotifications(0); len(notes) != 2 || notes[0].Event != "alert-resolved" {
+		t.Fatalf("expected resolve notification, got %+v", notes)
+	}
+
+	// A condition that clears while still pending never notifies.
+	mgr.evaluateAlerts(full, start.Add(200*time.Second))
+	mgr.evaluateAlerts(&models.SystemTelemetry{DiskPercent: 10}, start.Add(210*time.Second))
+	if notes := mgr.RecentNotifications(0); len(notes) != 2 {
+		t.Fatalf("flapping pending alert should stay silent, got %d notifications", len(notes))
+	}
+}
+
+func TestSetAlertRulesValidates(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+	}
+	defer mgr.Log.Close()
+	if err := mgr.SetAlertRules([]AlertRule{{ID: "rss", Kind: AlertServerRSSGB}}); err == nil {
+		t.Fatal("expected error for missing threshold")
+	}
+	if err := mgr.SetAlertRules([]AlertRule{{ID: "x", Kind: "nope"}}); err == nil {
+		t.Fatal("expected error for unknown kind")
+	}
+	if err := mgr.SetAlertRules(DefaultAlertRules()); err != nil {
+		t.Fatalf("default rules should validate: %v", err)
+	}
+	if got := len(mgr.AlertRuleConfigs()); got != len(DefaultAlertRules()) {
+		t.Fatalf("expected %d rules, got %d", len(DefaultAlertRules()), got)
+	}
+}
@@ -0,0 +1,256 @@
+package manager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TokenScope is an action class a personal access token may perform.
+type TokenScope string
+
+const (
+	// ScopeRead allows GET requests: status, logs, saves, listings.
+	ScopeRead TokenScope = "read"
+	// ScopeControl allows server actions such as start/stop/restart, saves and settings.
+	ScopeControl TokenScope = "control"
+	// ScopeConsole allows sending console commands and chat.
+	ScopeConsole TokenScope = "console"
+	// ScopeAdmin allows manager-wide administration (users, targets, manager logs).
+	ScopeAdmin TokenScope = "admin"
+)
+
+// APITokenPrefix marks personal access tokens so they can be told apart from session JWTs.
+const APITokenPrefix = "sdsm_"
+
+// apiTokenTouchInterval throttles persisting LastUsedAt so every API call doesn't rewrite users.json.
+const apiTokenTouchInterval = 5 * time.Minute
+
+var (
+	ErrAPITokenInvalid  = errors.New("invalid API token")
+	ErrAPITokenExpired  = errors.New("API token expired")
+	ErrAPITokenNotFound = errors.New("API token not found")
+)
+
+// APIToken is a long-lived personal access token. Only a SHA-256 hash of the secret is stored.
+type APIToken struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Hash       string       `json:"hash"`
+	Scopes     []TokenScope `json:"scopes"`
+	AllServers bool         `json:"all_servers,omitempty"`
+	Servers    []int        `json:"servers,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+}
+
+// ParseTokenScope normalizes a scope name, reporting whether it is known.
+func ParseTokenScope(s string) (TokenScope, bool) {
+	switch scope := TokenScope(strings.ToLower(strings.TrimSpace(s))); scope {
+	case ScopeRead, ScopeControl, ScopeConsole, ScopeAdmin:
+		return scope, true
+	}
+	return "", false
+}
+
+// HasScope reports whether the token grants scope. Admin implies every scope, and
+// control and console imply read.
+func (t APIToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+		if scope == ScopeRead && (s == ScopeControl || s == ScopeConsole) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsServer reports whether the token is limited to a set that includes serverID.
+func (t APIToken) AllowsServer(serverID int) bool {
+	if t.AllServers {
+		return true
+	}
+	for _, id := range t.Servers {
+		if id == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the token has passed its expiry.
+func (t APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// Redacted returns the token without its hash, for listing.
+func (t APIToken) Redacted() APIToken {
+	t.Hash = ""
+	t.Scopes = append([]TokenScope(nil), t.Scopes...)
+	t.Servers = append([]int(nil), t.Servers...)
+	return t
+}
+
+func hashAPIToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiTokenID extracts the public id from "sdsm_<id>_<secret>".
+func apiTokenID(raw string) (string, bool) {
+	if !strings.HasPrefix(raw, APITokenPrefix) {
+		return "", false
+	}
+	id, secret, ok := strings.Cut(strings.TrimPrefix(raw, APITokenPrefix), "_")
+	if !ok || id == "" || secret == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// CreateAPIToken issues a new token for username and returns the plaintext once; only its hash is kept.
+func (s *UserStore) CreateAPIToken(username, name string, scopes []TokenScope, allServers bool, servers []int, expiresAt *time.Time) (string, APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", APIToken{}, errors.New("token name required")
+	}
+	if len(scopes) == 0 {
+		return "", APIToken{}, errors.New("at least one scope required")
+	}
+	if !allServers && len(servers) == 0 {
+		return "", APIToken{}, errors.New("select at least one server or all servers")
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return "", APIToken{}, errors.New("expiry must be in the future")
+	}
+
+	idBytes := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", APIToken{}, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", APIToken{}, err
+	}
+	id := hex.EncodeToString(idBytes)
+	raw := APITokenPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return "", APIToken{}, errors.New("user not found")
+	}
+	uniqScopes := make([]TokenScope, 0, len(scopes))
+	seenScope := make(map[TokenScope]bool, len(scopes))
+	for _, scope := range scopes {
+		if scope == ScopeAdmin && u.Role != RoleAdmin {
+			return "", APIToken{}, errors.New("admin scope requires an admin account")
+		}
+		if !seenScope[scope] {
+			seenScope[scope] = true
+			uniqScopes = append(uniqScopes, scope)
+		}
+	}
+	var serverList []int
+	if !allServers {
+		seen := make(map[int]bool, len(servers))
+		for _, sid := range servers {
+			if sid > 0 && !seen[sid] {
+				seen[sid] = true
+				serverList = append(serverList, sid)
+			}
+		}
+		sort.Ints(serverList)
+	}
+	tok := APIToken{
+		ID:         id,
+		Name:       name,
+		Hash:       hashAPIToken(raw),
+		Scopes:     uniqScopes,
+		AllServers: allServers,
+		Servers:    serverList,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  expiresAt,
+	}
+	u.APITokens = append(u.APITokens, tok)
+	if err := s.saveLocked(); err != nil {
+		u.APITokens = u.APITokens[:len(u.APITokens)-1]
+		return "", APIToken{}, err
+	}
+	return raw, tok.Redacted(), nil
+}
+
+// APITokens returns the user's tokens without hashes.
+func (s *UserStore) APITokens(username string) ([]APIToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.users[username]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	out := make([]APIToken, 0, len(u.APITokens))
+	for _, t := range u.APITokens {
+		out = append(out, t.Redacted())
+	}
+	return out, nil
+}
+
+// RevokeAPIToken deletes one of the user's tokens by id.
+func (s *UserStore) RevokeAPIToken(username, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return errors.New("user not found")
+	}
+	for i, t := range u.APITokens {
+		if t.ID == id {
+			u.APITokens = append(u.APITokens[:i], u.APITokens[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return ErrAPITokenNotFound
+}
+
+// AuthenticateAPIToken resolves a plaintext token to its owner and token record.
+func (s *UserStore) AuthenticateAPIToken(raw string) (string, APIToken, error) {
+	id, ok := apiTokenID(raw)
+	if !ok {
+		return "", APIToken{}, ErrAPITokenInvalid
+	}
+	hash := hashAPIToken(raw)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		for i := range u.APITokens {
+			t := &u.APITokens[i]
+			if t.ID != id {
+				continue
+			}
+			if subtle.ConstantTimeCompare([]byte(t.Hash), []byte(hash)) != 1 {
+				return "", APIToken{}, ErrAPITokenInvalid
+			}
+			if t.Expired(now) {
+				return "", APIToken{}, ErrAPITokenExpired
+			}
+			if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= apiTokenTouchInterval {
+				t.LastUsedAt = &now
+				_ = s.saveLocked()
+			}
+			return u.Username, t.Redacted(), nil
+		}
+	}
+	return "", APIToken{}, ErrAPITokenInvalid
+}
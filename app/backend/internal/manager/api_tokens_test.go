@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestAPITokenLifecycle(t *testing.T) {
+	paths := utils.NewPaths(t.TempDir())
+	store := NewUserStore(paths)
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateUser("ops", "hash", RoleOperator); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.CreateAPIToken("ops", "bot", []TokenScope{ScopeAdmin}, true, nil, nil); err == nil {
+		t.Fatalf("expected admin scope to be refused for an operator")
+	}
+
+	raw, info, err := store.CreateAPIToken("ops", "bot", []TokenScope{ScopeRead, ScopeRead}, false, []int{3, 1, 3}, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+	if !strings.HasPrefix(raw, APITokenPrefix+info.ID+"_") || info.Hash != "" {
+		t.Fatalf("unexpected token %q / %+v", raw, info)
+	}
+	if len(info.Scopes) != 1 || len(info.Servers) != 2 || info.Servers[0] != 1 {
+		t.Fatalf("expected scopes and servers to be de-duplicated, got %+v", info)
+	}
+	data, err := os.ReadFile(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), raw) || !strings.Contains(string(data), hashAPIToken(raw)) {
+		t.Fatalf("expected only the token hash to be persisted")
+	}
+
+	// Reload from disk to confirm tokens persist.
+	store = NewUserStore(paths)
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	user, tok, err := store.AuthenticateAPIToken(raw)
+	if err != nil || user != "ops" || tok.ID != info.ID {
+		t.Fatalf("AuthenticateAPIToken = %q, %+v, %v", user, tok, err)
+	}
+	if !tok.HasScope(ScopeRead) || tok.HasScope(ScopeControl) || !tok.AllowsServer(3) || tok.AllowsServer(2) {
+		t.Fatalf("unexpected token permissions %+v", tok)
+	}
+	if _, _, err := store.AuthenticateAPIToken(raw[:len(raw)-1] + "A"); !errors.Is(err, ErrAPITokenInvalid) {
+		t.Fatalf("expected tampered token to be rejected, got %v", err)
+	}
+
+	if err := store.RevokeAPIToken("ops", info.ID); err != nil {
+		t.Fatalf("RevokeAPIToken: %v", err)
+	}
+	if _, _, err := store.AuthenticateAPIToken(raw); !errors.Is(err, ErrAPITokenInvalid) {
+		t.Fatalf("expected revoked token to be rejected, got %v", err)
+	}
+}
+
+func TestAPITokenExpiry(t *testing.T) {
+	store := NewUserStore(utils.NewPaths(t.TempDir()))
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateUser("admin", "hash", RoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour)
+	raw, info, err := store.CreateAPIToken("admin", "short", []TokenScope{ScopeAdmin}, true, nil, &exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.mu.Lock()
+	past := time.Now().Add(-time.Minute)
+	store.users["admin"].APITokens[0].ExpiresAt = &past
+	store.mu.Unlock()
+	if _, _, err := store.AuthenticateAPIToken(raw); !errors.Is(err, ErrAPITokenExpired) {
+		t.Fatalf("expected expired token %s to be rejected, got %v", info.ID, err)
+	}
+}
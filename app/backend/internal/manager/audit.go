@@ -0,0 +1,240 @@
+package manager
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+const (
+	auditMaxBytes   = 10 << 20
+	auditKeepFiles  = 5
+	auditRedacted   = "[redacted]"
+	auditQueryLimit = 1000
+)
+
+// AuditEntry is one privileged action recorded in the audit log.
+type AuditEntry struct {
+	Time     time.Time              `json:"time"`
+	User     string                 `json:"user"`
+	Role     string                 `json:"role,omitempty"`
+	TokenID  string                 `json:"token_id,omitempty"`
+	IP       string                 `json:"ip,omitempty"`
+	Method   string                 `json:"method"`
+	Route    string                 `json:"route"`
+	Path     string                 `json:"path"`
+	ServerID int                    `json:"server_id,omitempty"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Status   int                    `json:"status"`
+	Result   string                 `json:"result"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// AuditFilter narrows an audit query. Zero values match everything.
+type AuditFilter struct {
+	User     string
+	ServerID int
+	Route    string
+	Result   string
+	Query    string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+// AuditLog is an append-only JSON-lines log of privileged actions, rotated by size.
+type AuditLog struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	keep     int
+}
+
+// NewAuditLog returns an audit log stored under the SDSM logs directory.
+func NewAuditLog(paths *utils.Paths) *AuditLog {
+	return &AuditLog{path: paths.AuditLogFile(), maxBytes: auditMaxBytes, keep: auditKeepFiles}
+}
+
+// Path returns the active audit log file.
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+// Record appends an entry, rotating the file first when it has grown past the size limit.
+func (a *AuditLog) Record(e AuditEntry) error {
+	if a == nil || a.path == "" {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(a.path); err == nil && info.Size()+int64(len(line)) >= a.maxBytes {
+		a.rotateLocked()
+	}
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// rotateLocked shifts audit.jsonl -> audit.jsonl.1 -> ... dropping the oldest. Caller MUST hold a.mu.
+func (a *AuditLog) rotateLocked() {
+	_ = os.Remove(a.rotatedPath(a.keep))
+	for i := a.keep - 1; i >= 1; i-- {
+		_ = os.Rename(a.rotatedPath(i), a.rotatedPath(i+1))
+	}
+	_ = os.Rename(a.path, a.rotatedPath(1))
+}
+
+func (a *AuditLog) rotatedPath(n int) string {
+	return fmt.Sprintf("%s.%d", a.path, n)
+}
+
+// Query returns matching entries newest first, reading rotated files as needed.
+func (a *AuditLog) Query(f AuditFilter) ([]AuditEntry, error) {
+	if a == nil || a.path == "" {
+		return nil, nil
+	}
+	limit := f.Limit
+	if limit <= 0 || limit > auditQueryLimit {
+		limit = auditQueryLimit
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]AuditEntry, 0, 64)
+	files := []string{a.path}
+	for i := 1; i <= a.keep; i++ {
+		files = append(files, a.rotatedPath(i))
+	}
+	for _, file := range files {
+		matches, err := readAuditFile(file, f)
+		if err != nil {
+			return out, err
+		}
+		for i := len(matches) - 1; i >= 0; i-- {
+			out = append(out, matches[i])
+			if len(out) >= limit {
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+func readAuditFile(path string, f AuditFilter) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var matches []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if f.matches(e, scanner.Text()) {
+			matches = append(matches, e)
+		}
+	}
+	return matches, scanner.Err()
+}
+
+func (f AuditFilter) matches(e AuditEntry, raw string) bool {
+	if f.User != "" && !strings.EqualFold(e.User, f.User) {
+		return false
+	}
+	if f.ServerID > 0 && e.ServerID != f.ServerID {
+		return false
+	}
+	if f.Route != "" && !strings.Contains(e.Route, f.Route) {
+		return false
+	}
+	if f.Result != "" && e.Result != f.Result {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+	if f.Query != "" && !strings.Contains(strings.ToLower(raw), strings.ToLower(f.Query)) {
+		return false
+	}
+	return true
+}
+
+// auditSecretKeys are substrings of parameter names whose values are never logged.
+var auditSecretKeys = []string{"password", "secret", "token", "apikey", "api_key", "access_key", "private_key", "webhook", "authorization", "credential", "passphrase"}
+
+// RedactAuditParams returns a copy of params with secret-looking values replaced.
+// Nested objects and arrays are walked; personal access tokens are redacted wherever they appear.
+func RedactAuditParams(params map[string]interface{}) map[string]interface{} {
+	if len(params) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if auditSecretKey(k) {
+			out[k] = auditRedacted
+			continue
+		}
+		out[k] = redactAuditValue(v)
+	}
+	return out
+}
+
+func redactAuditValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return RedactAuditParams(val)
+	case []interface{}:
+		cp := make([]interface{}, len(val))
+		for i, item := range val {
+			cp[i] = redactAuditValue(item)
+		}
+		return cp
+	case string:
+		if strings.HasPrefix(val, APITokenPrefix) {
+			return auditRedacted
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+func auditSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range auditSecretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestAuditLogRotationAndQuery(t *testing.T) {
+	audit := NewAuditLog(utils.NewPaths(t.TempDir()))
+	audit.maxBytes = 400
+	audit.keep = 2
+	base := time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		user := "alice"
+		if i%2 == 1 {
+			user = "bob"
+		}
+		if err := audit.Record(AuditEntry{Time: base.Add(time.Duration(i) * time.Minute), User: user, Method: "POST", Route: "/api/servers/:server_id/start", ServerID: 1 + i%3, Status: 200, Result: "ok"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(audit.Path() + ".1"); err != nil {
+		t.Fatalf("expected a rotated file: %v", err)
+	}
+	if _, err := os.Stat(audit.Path() + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected only %d rotated files to be kept", audit.keep)
+	}
+
+	all, err := audit.Query(AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 || len(all) >= 12 {
+		t.Fatalf("expected oldest entries to be pruned, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Time.After(all[i-1].Time) {
+			t.Fatalf("entries not newest first at %d", i)
+		}
+	}
+	if !all[0].Time.Equal(base.Add(11 * time.Minute)) {
+		t.Fatalf("newest entry missing: %v", all[0].Time)
+	}
+
+	bob, _ := audit.Query(AuditFilter{User: "BOB", ServerID: 3, Limit: 1})
+	if len(bob) != 1 || bob[0].User != "bob" || bob[0].ServerID != 3 {
+		t.Fatalf("unexpected filtered result %+v", bob)
+	}
+	since, _ := audit.Query(AuditFilter{Since: base.Add(10 * time.Minute)})
+	if len(since) != 2 {
+		t.Fatalf("expected 2 entries since 02:10, got %d", len(since))
+	}
+}
+
+func TestRedactAuditParams(t *testing.T) {
+	in := map[string]interface{}{
+		"name":        "s3",
+		"Password":    "x",
+		"webhook_url": "https://discord",
+		"config":      map[string]interface{}{"secret_access_key": "abc", "bucket": "b"},
+		"notes":       []interface{}{APITokenPrefix + "0123_abc"},
+		"expires_in":  5.0,
+	}
+	out := RedactAuditParams(in)
+	if out["name"] != "s3" || out["Password"] != auditRedacted || out["webhook_url"] != auditRedacted || out["expires_in"] != 5.0 {
+		t.Fatalf("unexpected redaction %+v", out)
+	}
+	cfg := out["config"].(map[string]interface{})
+	if cfg["secret_access_key"] != auditRedacted || cfg["bucket"] != "b" {
+		t.Fatalf("nested values not redacted: %+v", cfg)
+	}
+	if out["notes"].([]interface{})[0] != auditRedacted {
+		t.Fatalf("token value not redacted")
+	}
+	if in["Password"] != "x" {
+		t.Fatalf("input must not be modified")
+	}
+}
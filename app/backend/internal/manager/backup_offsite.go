@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/backuptarget"
+	"sdsm/app/backend/internal/models"
+)
+
+const offsiteBackupTimeout = 30 * time.Minute
+
+// BackupTargetConfigs returns a copy of the configured off-host backup targets.
+func (m *Manager) BackupTargetConfigs() []backuptarget.Config {
+	m.backupTargetsMu.RLock()
+	defer m.backupTargetsMu.RUnlock()
+	return append([]backuptarget.Config(nil), m.BackupTargets...)
+}
+
+// SetBackupTargets validates and replaces the target list. A target submitted without
+// credentials keeps the stored credentials of the existing target with the same name,
+// so redacted configs can be round-tripped through the API, but only while its
+// connection fields are unchanged; a moved target must be given its secret again.
+func (m *Manager) SetBackupTargets(cfgs []backuptarget.Config) error {
+	m.backupTargetsMu.Lock()
+	existing := make(map[string]backuptarget.Config, len(m.BackupTargets))
+	for _, cfg := range m.BackupTargets {
+		existing[strings.ToLower(cfg.Name)] = cfg
+	}
+	seen := make(map[string]bool, len(cfgs))
+	out := make([]backuptarget.Config, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		cfg.Name = strings.TrimSpace(cfg.Name)
+		cfg.Type = strings.ToLower(strings.TrimSpace(cfg.Type))
+		key := strings.ToLower(cfg.Name)
+		if seen[key] {
+			m.backupTargetsMu.Unlock()
+			return fmt.Errorf("duplicate target name %q", cfg.Name)
+		}
+		seen[key] = true
+		if prev, ok := existing[key]; ok && !cfg.HasSecret() && cfg.SameConnection(prev) {
+			cfg.SecretKey = prev.SecretKey
+			cfg.Password = prev.Password
+			cfg.PrivateKey = prev.PrivateKey
+		}
+		if err := cfg.Validate(); err != nil {
+			m.backupTargetsMu.Unlock()
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		out = append(out, cfg)
+	}
+	m.BackupTargets = out
+	m.backupTargetsMu.Unlock()
+	m.Save()
+	return nil
+}
+
+// TestBackupTarget uploads and verifies a small probe file against the named target.
+func (m *Manager) TestBackupTarget(ctx context.Context, name string) error {
+	var cfg *backuptarget.Config
+	for _, c := range m.BackupTargetConfigs() {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			cfg = &c
+			break
+		}
+	}
+	if cfg == nil {
+		return errors.New("backup target not found")
+	}
+	target, err := backuptarget.New(*cfg)
+	if err != nil {
+		return err
+	}
+	probe, err := os.CreateTemp("", "sdsm-probe-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(probe.Name())
+	_, werr := probe.WriteString("SDSM backup target probe " + time.Now().UTC().Format(time.RFC3339) + "\n")
+	if cerr := probe.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		return werr
+	}
+	sum, err := backuptarget.FileChecksum(probe.Name())
+	if err != nil {
+		return err
+	}
+	if err := target.Upload(ctx, "sdsm-probe.txt", probe.Name(), sum); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	if err := target.Verify(ctx, "sdsm-probe.txt", sum); err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	return nil
+}
+
+// pushBackupOffsite copies a backup archive to every enabled target, verifies each copy by
+// checksum, and reports the outcome through NotifyServerEvent.
+func (m *Manager) pushBackupOffsite(s *models.Server, b WorldBackup) {
+	var targets []backuptarget.Config
+	for _, cfg := range m.BackupTargetConfigs() {
+		if cfg.Enabled {
+			targets = append(targets, cfg)
+		}
+	}
+	if len(targets) == 0 || s == nil {
+		return
+	}
+	paths := m.serverPaths(s)
+	if paths == nil {
+		return
+	}
+	local := filepath.Join(paths.ServerBackupsDir(s.ID), b.Name)
+	sum, err := backuptarget.FileChecksum(local)
+	if err != nil {
+		m.NotifyServerEvent(s, "backup-offsite-failed", fmt.Sprintf("Could not read backup %s: %v", b.Name, err))
+		return
+	}
+	key := paths.ServerName(s.ID) + "/" + b.Name
+
+	var ok, failed []string
+	for _, cfg := range targets {
+		err := m.copyBackupToTarget(cfg, key, local, sum)
+		if err != nil {
+			m.Logger("backup", s.ID).Error(fmt.Sprintf("Offsite backup of %s to %s failed: %v", s.Name, cfg.Name, err))
+			failed = append(failed, fmt.Sprintf("%s (%v)", cfg.Name, err))
+			continue
+		}
+		ok = append(ok, cfg.Name)
+	}
+	if len(failed) > 0 {
+		detail := fmt.Sprintf("Backup %s failed to copy to: %s.", b.Name, strings.Join(failed, "; "))
+		if len(ok) > 0 {
+			detail += " Verified on: " + strings.Join(ok, ", ") + "."
+		}
+		m.NotifyServerEvent(s, "backup-offsite-failed", detail)
+		return
+	}
+	m.NotifyServerEvent(s, "backup-offsite", fmt.Sprintf("Backup %s copied and verified on: %s.", b.Name, strings.Join(ok, ", ")))
+}
+
+func (m *Manager) copyBackupToTarget(cfg backuptarget.Config, key, local string, sum backuptarget.Checksum) error {
+	target, err := backuptarget.New(cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), offsiteBackupTimeout)
+	defer cancel()
+	if err := target.Upload(ctx, key, local, sum); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	if err := target.Verify(ctx, key, sum); err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	return nil
+}
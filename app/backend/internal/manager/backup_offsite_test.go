@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/backuptarget"
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestPushBackupOffsiteToLocalTarget(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	remote := filepath.Join(dir, "offsite")
+	mgr := &Manager{
+		Log:           utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Paths:         paths,
+		Servers:       []*models.Server{{ID: 1, Name: "Alpha", Paths: paths}},
+		BackupTargets: []backuptarget.Config{{Name: "nas", Type: backuptarget.TypeLocal, Enabled: true, Path: remote}},
+	}
+	defer mgr.Log.Close()
+
+	head := filepath.Join(paths.ServerSavesDir(1), "Alpha", "Alpha.save")
+	if err := os.MkdirAll(filepath.Dir(head), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(head, []byte("world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mgr.backupMu.Lock()
+	b, err := mgr.createBackupLocked(mgr.Servers[0], BackupReasonManual, time.Now())
+	mgr.backupMu.Unlock()
+	if err != nil {
+		t.Fatalf("backup failed: %v", err)
+	}
+
+	mgr.pushBackupOffsite(mgr.Servers[0], b)
+
+	copied := filepath.Join(remote, paths.ServerName(1), b.Name)
+	want, err := backuptarget.FileChecksum(filepath.Join(paths.ServerBackupsDir(1), b.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := backuptarget.FileChecksum(copied)
+	if err != nil {
+		t.Fatalf("expected offsite copy at %s: %v", copied, err)
+	}
+	if got != want {
+		t.Fatalf("offsite copy checksum mismatch: got %+v want %+v", got, want)
+	}
+}
+
+func TestSetBackupTargetsKeepsStoredSecrets(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Paths:      utils.NewPaths(dir),
+		BackupTargets: []backuptarget.Config{{
+			Name: "s3", Type: backuptarget.TypeS3, Endpoint: "https://s3.example.com", Bucket: "b", AccessKey: "AK", SecretKey: "SK",
+		}},
+	}
+	defer mgr.Log.Close()
+
+	redacted := mgr.BackupTargetConfigs()[0].Redacted()
+	redacted.Enabled = true
+	if err := mgr.SetBackupTargets([]backuptarget.Config{redacted}); err != nil {
+		t.Fatalf("SetBackupTargets: %v", err)
+	}
+	cfg := mgr.BackupTargetConfigs()[0]
+	if cfg.SecretKey != "SK" || !cfg.Enabled {
+		t.Fatalf("expected stored secret to survive a redacted update, got %+v", cfg)
+	}
+	if err := mgr.SetBackupTargets([]backuptarget.Config{redacted, redacted}); err == nil {
+		t.Fatalf("expected duplicate target names to be rejected")
+	}
+
+	moved := redacted
+	moved.Endpoint = "https://attacker.example.net"
+	if err := mgr.SetBackupTargets([]backuptarget.Config{moved}); err == nil {
+		t.Fatalf("expected a moved target without a secret to be rejected")
+	}
+	if cfg := mgr.BackupTargetConfigs()[0]; cfg.Endpoint != "https://s3.example.com" || cfg.SecretKey != "SK" {
+		t.Fatalf("rejected update must leave the stored target untouched, got %+v", cfg)
+	}
+}
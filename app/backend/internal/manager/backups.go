@@ -0,0 +1,495 @@
+package manager
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+const (
+	backupSchedulerInterval = time.Minute
+	backupTimeLayout        = "20060102_150405"
+
+	BackupReasonScheduled    = "scheduled"
+	BackupReasonManual       = "manual"
+	BackupReasonPreUpdate    = "pre-update"
+	BackupReasonPreReinstall = "pre-reinstall"
+	BackupReasonPreRestore   = "pre-restore"
+)
+
+var (
+	ErrBackupNotFound  = errors.New("backup not found")
+	errNothingToBackup = errors.New("no save files to back up")
+	backupNamePattern  = regexp.MustCompile(`^backup_(\d{8}_\d{6})_([a-z-]+)\.zip$`)
+)
+
+// WorldBackup describes one archive of a server's saves directory.
+type WorldBackup struct {
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+// StartBackupScheduler launches the loop that takes scheduled world backups.
+func (m *Manager) StartBackupScheduler() {
+	if m == nil {
+		return
+	}
+	m.backupSchedulerMu.Lock()
+	if m.backupSchedulerStop != nil {
+		m.backupSchedulerMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	m.backupSchedulerStop = stop
+	m.backupSchedulerMu.Unlock()
+
+	m.backupSchedulerWG.Add(1)
+	go func() {
+		defer m.backupSchedulerWG.Done()
+		ticker := time.NewTicker(backupSchedulerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				m.runDueBackups(now)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopBackupScheduler stops the scheduled backup loop, waiting for an in-flight backup.
+func (m *Manager) StopBackupScheduler() {
+	if m == nil {
+		return
+	}
+	m.backupSchedulerMu.Lock()
+	stop := m.backupSchedulerStop
+	m.backupSchedulerStop = nil
+	m.backupSchedulerMu.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+	m.backupSchedulerWG.Wait()
+}
+
+// runDueBackups backs up every running server whose newest archive is older than its interval.
+// Stopped servers are skipped since their world cannot change.
+func (m *Manager) runDueBackups(now time.Time) {
+	for _, srv := range m.Servers {
+		if srv == nil || !srv.BackupEnabled || !srv.IsRunning() || m.IsServerUpdateRunning(srv.ID) {
+			continue
+		}
+		backups, err := m.ServerBackups(srv.ID)
+		if err != nil {
+			continue
+		}
+		if len(backups) > 0 && now.Sub(backups[0].CreatedAt) < srv.BackupInterval() {
+			continue
+		}
+		if _, err := m.CreateServerBackup(srv.ID, BackupReasonScheduled); err != nil && !errors.Is(err, errNothingToBackup) {
+			m.Logger("backup", srv.ID).Error(fmt.Sprintf("Scheduled backup of %s failed: %v", srv.Name, err))
+		}
+	}
+}
+
+// BackupBeforeDeploy archives the server's saves ahead of an update or reinstall. Failures are
+// logged but do not block the deploy.
+func (m *Manager) BackupBeforeDeploy(s *models.Server, reason string) {
+	if m == nil || s == nil {
+		return
+	}
+	if _, err := m.CreateServerBackup(s.ID, reason); err != nil && !errors.Is(err, errNothingToBackup) {
+		m.Logger("backup", s.ID).Error(fmt.Sprintf("Backup of %s before deploy failed: %v", s.Name, err))
+		if s.Logger != nil {
+			s.Logger.Warn("Backup before deploy failed: " + err.Error())
+		}
+	}
+}
+
+// CreateServerBackup writes a timestamped zip of the server's saves directory, prunes older
+// archives according to the server's retention policy, and copies the new archive to any
+// configured off-host targets in the background.
+func (m *Manager) CreateServerBackup(serverID int, reason string) (WorldBackup, error) {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return WorldBackup{}, ErrServerNotFound
+	}
+	m.backupMu.Lock()
+	defer m.backupMu.Unlock()
+	b, err := m.createBackupLocked(s, reason, time.Now())
+	if err != nil {
+		return b, err
+	}
+	if removed, err := m.pruneBackupsLocked(s, time.Now()); err != nil {
+		m.Logger("backup", s.ID).Error(fmt.Sprintf("Backup pruning for %s failed: %v", s.Name, err))
+	} else if removed > 0 {
+		m.Logger("backup", s.ID).Info(fmt.Sprintf("Pruned %d old backups of %s", removed, s.Name))
+	}
+	go m.pushBackupOffsite(s, b)
+	return b, nil
+}
+
+func (m *Manager) createBackupLocked(s *models.Server, reason string, now time.Time) (WorldBackup, error) {
+	paths := m.serverPaths(s)
+	if paths == nil {
+		return WorldBackup{}, errors.New("paths are not configured")
+	}
+	savesDir := paths.ServerSavesDir(s.ID)
+	if n, _ := countRegularFiles(savesDir); n == 0 {
+		return WorldBackup{}, errNothingToBackup
+	}
+	backupsDir := paths.ServerBackupsDir(s.ID)
+	if err := os.MkdirAll(backupsDir, 0o755); err != nil {
+		return WorldBackup{}, err
+	}
+	name := fmt.Sprintf("backup_%s_%s.zip", now.Format(backupTimeLayout), reason)
+	target := filepath.Join(backupsDir, name)
+	tmp := target + ".partial"
+	if err := zipDirectory(savesDir, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return WorldBackup{}, err
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		_ = os.Remove(tmp)
+		return WorldBackup{}, err
+	}
+	b := WorldBackup{Name: name, Reason: reason, CreatedAt: now.Truncate(time.Second)}
+	if info, err := os.Stat(target); err == nil {
+		b.Size = info.Size()
+	}
+	if s.Logger != nil {
+		s.Logger.Info(fmt.Sprintf("Created %s backup %s", reason, name))
+	}
+	return b, nil
+}
+
+// ServerBackups lists the server's backup archives, newest first.
+func (m *Manager) ServerBackups(serverID int) ([]WorldBackup, error) {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return nil, ErrServerNotFound
+	}
+	paths := m.serverPaths(s)
+	if paths == nil {
+		return nil, errors.New("paths are not configured")
+	}
+	entries, err := os.ReadDir(paths.ServerBackupsDir(s.ID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []WorldBackup{}, nil
+		}
+		return nil, err
+	}
+	out := make([]WorldBackup, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		b, ok := parseBackupName(entry.Name())
+		if !ok {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			b.Size = info.Size()
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// DeleteServerBackup removes a single backup archive.
+func (m *Manager) DeleteServerBackup(serverID int, name string) error {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return ErrServerNotFound
+	}
+	path, err := m.serverBackupPath(s, name)
+	if err != nil {
+		return err
+	}
+	m.backupMu.Lock()
+	defer m.backupMu.Unlock()
+	return os.Remove(path)
+}
+
+// RestoreServerBackup replaces the server's saves directory with the contents of a backup.
+// A running server is stopped first and started again afterwards; the current saves are
+// archived as a pre-restore backup so the restore itself can be undone.
+func (m *Manager) RestoreServerBackup(serverID int, name string) error {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return ErrServerNotFound
+	}
+	archive, err := m.serverBackupPath(s, name)
+	if err != nil {
+		return err
+	}
+	if m.IsServerUpdateRunning(s.ID) {
+		return errors.New("server update in progress")
+	}
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("backup archive is unreadable: %w", err)
+	}
+
+	m.backupMu.Lock()
+	defer m.backupMu.Unlock()
+	defer zr.Close()
+
+	wasRunning := s.IsRunning()
+	if wasRunning {
+		if s.Logger != nil {
+			s.Logger.Info("Restore: stopping server")
+		}
+		s.StopForUpdate()
+		m.notifyServerStatusChanged(s)
+	}
+
+	if _, err := m.createBackupLocked(s, BackupReasonPreRestore, time.Now()); err != nil && !errors.Is(err, errNothingToBackup) {
+		m.Logger("backup", s.ID).Error(fmt.Sprintf("Pre-restore backup of %s failed: %v", s.Name, err))
+	}
+	restoreErr := swapSavesDirectory(&zr.Reader, m.serverPaths(s).ServerSavesDir(s.ID))
+	if restoreErr != nil {
+		m.Logger("backup", s.ID).Error(fmt.Sprintf("Restore of %s from %s failed: %v", s.Name, name, restoreErr))
+	} else {
+		m.Logger("backup", s.ID).Info(fmt.Sprintf("Restored %s from backup %s", s.Name, name))
+	}
+
+	if wasRunning {
+		s.Start()
+		m.notifyServerStatusChanged(s)
+	}
+	return restoreErr
+}
+
+// swapSavesDirectory extracts the archive next to savesDir and then swaps the directories
+// with renames so the game never sees a partially restored tree.
+func swapSavesDirectory(zr *zip.Reader, savesDir string) error {
+	stamp := time.Now().Format(backupTimeLayout)
+	staging := savesDir + ".restore-" + stamp
+	previous := savesDir + ".previous-" + stamp
+	if err := os.MkdirAll(staging, 0o755); err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		target, err := utils.SecureJoin(staging, filepath.FromSlash(f.Name))
+		if err != nil || target == filepath.Clean(staging) {
+			continue
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				os.RemoveAll(staging)
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			os.RemoveAll(staging)
+			return err
+		}
+		if err := extractZipFile(f, target); err != nil {
+			os.RemoveAll(staging)
+			return err
+		}
+	}
+
+	hadPrevious := true
+	if err := os.Rename(savesDir, previous); err != nil {
+		if !os.IsNotExist(err) {
+			os.RemoveAll(staging)
+			return err
+		}
+		hadPrevious = false
+	}
+	if err := os.Rename(staging, savesDir); err != nil {
+		if hadPrevious {
+			_ = os.Rename(previous, savesDir)
+		}
+		os.RemoveAll(staging)
+		return err
+	}
+	if hadPrevious {
+		_ = os.RemoveAll(previous)
+	}
+	return nil
+}
+
+// pruneBackupsLocked deletes archives that fall outside the GFS retention policy. Manual
+// backups are never pruned automatically.
+func (m *Manager) pruneBackupsLocked(s *models.Server, now time.Time) (int, error) {
+	backups, err := m.ServerBackups(s.ID)
+	if err != nil {
+		return 0, err
+	}
+	hourly, daily, weekly := s.BackupRetention()
+	keep := backupsToKeep(backups, hourly, daily, weekly)
+	dir := m.serverPaths(s).ServerBackupsDir(s.ID)
+	removed := 0
+	var errs []error
+	for _, b := range backups {
+		if keep[b.Name] || b.Reason == BackupReasonManual {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, b.Name)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed++
+	}
+	return removed, errors.Join(errs...)
+}
+
+// backupsToKeep applies grandfather-father-son retention to backups sorted newest first:
+// the newest archive in each of the most recent hourly, daily and weekly buckets is kept.
+func backupsToKeep(backups []WorldBackup, hourly, daily, weekly int) map[string]bool {
+	keep := make(map[string]bool)
+	mark := func(limit int, bucket func(time.Time) string) {
+		seen := make(map[string]bool)
+		for _, b := range backups {
+			if len(seen) >= limit {
+				return
+			}
+			key := bucket(b.CreatedAt)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keep[b.Name] = true
+		}
+	}
+	mark(hourly, func(t time.Time) string { return t.Format("2006010215") })
+	mark(daily, func(t time.Time) string { return t.Format("20060102") })
+	mark(weekly, func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-%02d", y, w)
+	})
+	return keep
+}
+
+func parseBackupName(name string) (WorldBackup, bool) {
+	match := backupNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return WorldBackup{}, false
+	}
+	created, err := time.ParseInLocation(backupTimeLayout, match[1], time.Local)
+	if err != nil {
+		return WorldBackup{}, false
+	}
+	return WorldBackup{Name: name, Reason: match[2], CreatedAt: created}, true
+}
+
+func (m *Manager) serverBackupPath(s *models.Server, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if _, ok := parseBackupName(name); !ok || filepath.Base(name) != name {
+		return "", ErrBackupNotFound
+	}
+	paths := m.serverPaths(s)
+	if paths == nil {
+		return "", ErrBackupNotFound
+	}
+	path := filepath.Join(paths.ServerBackupsDir(s.ID), name)
+	if !fileExistsRegular(path) {
+		return "", ErrBackupNotFound
+	}
+	return path, nil
+}
+
+func (m *Manager) serverPaths(s *models.Server) *utils.Paths {
+	if s != nil && s.Paths != nil {
+		return s.Paths
+	}
+	return m.Paths
+}
+
+func zipDirectory(src, dst string) error {
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(out)
+	walkErr := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			_, err := zw.Create(name + "/")
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		// .save files are already compressed archives
+		hdr.Method = zip.Store
+		if !strings.HasSuffix(strings.ToLower(name), ".save") {
+			hdr.Method = zip.Deflate
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, f)
+		f.Close()
+		return err
+	})
+	closeErr := zw.Close()
+	if err := out.Close(); closeErr == nil {
+		closeErr = err
+	}
+	if walkErr != nil {
+		return walkErr
+	}
+	return closeErr
+}
+
+func countRegularFiles(root string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func fileExistsRegular(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,106 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestBackupsToKeepGFS(t *testing.T) {
+	base := time.Date(2024, 3, 20, 12, 0, 0, 0, time.Local) // Wednesday
+	var backups []WorldBackup
+	// One backup every 30 minutes for 21 days, newest first.
+	for i := 0; i < 21*48; i++ {
+		at := base.Add(-time.Duration(i) * 30 * time.Minute)
+		backups = append(backups, WorldBackup{Name: fmt.Sprintf("b%04d", i), CreatedAt: at})
+	}
+
+	keep := backupsToKeep(backups, 3, 2, 2)
+	want := map[string]bool{
+		"b0000": true, // 12:00 -> newest hourly, daily and weekly
+		"b0001": true, // 11:30 -> newest in the 11:00 hour
+		"b0003": true, // 10:30
+		"b0025": true, // newest on Mar 19 (23:30)
+		"b0121": true, // newest in the previous ISO week (Sun Mar 17 23:30)
+	}
+	if len(keep) != len(want) {
+		t.Fatalf("expected %d kept backups, got %d: %v", len(want), len(keep), keep)
+	}
+	for name := range want {
+		if !keep[name] {
+			t.Fatalf("expected %s to be kept; kept=%v", name, keep)
+		}
+	}
+}
+
+func TestServerBackupCreateRestoreAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	mgr := &Manager{
+		Log:     utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Paths:   paths,
+		Servers: []*models.Server{{ID: 1, Name: "Alpha", Paths: paths, BackupKeepHourly: 1, BackupKeepDaily: 1, BackupKeepWeekly: 1}},
+	}
+	defer mgr.Log.Close()
+
+	if _, err := mgr.CreateServerBackup(1, BackupReasonScheduled); !errors.Is(err, errNothingToBackup) {
+		t.Fatalf("expected errNothingToBackup for empty saves, got %v", err)
+	}
+
+	head := filepath.Join(paths.ServerSavesDir(1), "Alpha", "Alpha.save")
+	if err := os.MkdirAll(filepath.Dir(head), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(head, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Seed an older scheduled backup that the retention policy should prune.
+	mgr.backupMu.Lock()
+	old, err := mgr.createBackupLocked(mgr.Servers[0], BackupReasonScheduled, time.Now().Add(-3*time.Hour))
+	mgr.backupMu.Unlock()
+	if err != nil {
+		t.Fatalf("seed backup failed: %v", err)
+	}
+	manual, err := mgr.CreateServerBackup(1, BackupReasonManual)
+	if err != nil {
+		t.Fatalf("manual backup failed: %v", err)
+	}
+	backups, err := mgr.ServerBackups(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 || backups[0].Name != manual.Name {
+		t.Fatalf("expected only the manual backup after pruning (old=%s), got %+v", old.Name, backups)
+	}
+
+	if err := os.WriteFile(head, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.RestoreServerBackup(1, manual.Name); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(head); string(data) != "original" {
+		t.Fatalf("expected restored save contents, got %q", data)
+	}
+	backups, _ = mgr.ServerBackups(1)
+	foundPreRestore := false
+	for _, b := range backups {
+		if b.Reason == BackupReasonPreRestore {
+			foundPreRestore = true
+		}
+	}
+	if !foundPreRestore {
+		t.Fatalf("expected a pre-restore backup, got %+v", backups)
+	}
+
+	if err := mgr.RestoreServerBackup(1, "../../etc/passwd"); !errors.Is(err, ErrBackupNotFound) {
+		t.Fatalf("expected ErrBackupNotFound for invalid name, got %v", err)
+	}
+}
@@ -0,0 +1,640 @@
+package manager
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// BanScope selects which servers a ban record applies to.
+type BanScope string
+
+const (
+	BanScopeServer BanScope = "server"
+	BanScopeGroup  BanScope = "group"
+	BanScopeGlobal BanScope = "global"
+)
+
+const (
+	maxBanReasonLength = 500
+	// Global and group bans are re-applied to every server this often so new servers and
+	// hand-edited blacklists pick them up.
+	banReconcileInterval = 5 * time.Minute
+)
+
+// BanRecord is a ban with its provenance. The per-server Blacklist.txt files remain the
+// source the game reads; records decide what SDSM writes into them and when to lift it.
+type BanRecord struct {
+	ID        string     `json:"id"`
+	SteamID   string     `json:"steam_id"`
+	Name      string     `json:"name,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	Author    string     `json:"author,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scope     BanScope   `json:"scope"`
+	// ServerIDs lists the target server for "server" scope and the members for "group" scope.
+	ServerIDs []int `json:"server_ids,omitempty"`
+	// LiftedAt is set when the ban expired or was removed; lifted records are kept as history.
+	LiftedAt *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy string     `json:"lifted_by,omitempty"`
+}
+
+// Active reports whether the ban is in force at now.
+func (b BanRecord) Active(now time.Time) bool {
+	return b.LiftedAt == nil && (b.ExpiresAt == nil || now.Before(*b.ExpiresAt))
+}
+
+// Covers reports whether the ban applies to serverID.
+func (b BanRecord) Covers(serverID int) bool {
+	if b.Scope == BanScopeGlobal {
+		return true
+	}
+	for _, id := range b.ServerIDs {
+		if id == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate normalises the record and checks its scope.
+func (b *BanRecord) Validate() error {
+	b.SteamID = strings.TrimSpace(b.SteamID)
+	b.Name = strings.TrimSpace(b.Name)
+	b.Reason = strings.TrimSpace(b.Reason)
+	if b.SteamID == "" {
+		return errors.New("steam_id is required")
+	}
+	if strings.ContainsAny(b.SteamID, ",\r\n") {
+		return fmt.Errorf("invalid steam_id %q", b.SteamID)
+	}
+	if len(b.Reason) > maxBanReasonLength {
+		return fmt.Errorf("reason too long (max %d characters)", maxBanReasonLength)
+	}
+	if b.Scope == "" {
+		b.Scope = BanScopeServer
+	}
+	ids := make([]int, 0, len(b.ServerIDs))
+	seen := make(map[int]bool)
+	for _, id := range b.ServerIDs {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	b.ServerIDs = ids
+	switch b.Scope {
+	case BanScopeGlobal:
+		b.ServerIDs = nil
+	case BanScopeServer:
+		if len(b.ServerIDs) != 1 {
+			return errors.New("server scope requires exactly one server id")
+		}
+	case BanScopeGroup:
+		if len(b.ServerIDs) == 0 {
+			return errors.New("group scope requires at least one server id")
+		}
+	default:
+		return fmt.Errorf("unsupported scope %q", b.Scope)
+	}
+	if b.ExpiresAt != nil && !b.CreatedAt.IsZero() && !b.ExpiresAt.After(b.CreatedAt) {
+		return errors.New("expiry must be after the creation time")
+	}
+	return nil
+}
+
+// ParseBanDuration converts values such as "30m", "12h", "7d" or "2w" into a duration.
+// An empty value means a permanent ban and returns 0.
+func ParseBanDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" || value == "permanent" {
+		return 0, nil
+	}
+	var d time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			d *= 7
+		}
+	default:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+// BanStore persists ban records in config/bans.json.
+type BanStore struct {
+	mu   sync.Mutex
+	path string
+	bans []BanRecord
+}
+
+// NewBanStore loads records from path; a missing file starts empty.
+func NewBanStore(path string) (*BanStore, error) {
+	st := &BanStore{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return st, nil
+		}
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st.bans); err != nil {
+		return st, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return st, nil
+}
+
+// List returns copies of the records matching serverID (0 for all) ordered newest first.
+// Lifted and expired records are only included when includeInactive is set.
+func (st *BanStore) List(serverID int, includeInactive bool, now time.Time) []BanRecord {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	out := make([]BanRecord, 0, len(st.bans))
+	for _, b := range st.bans {
+		if serverID > 0 && !b.Covers(serverID) {
+			continue
+		}
+		if !includeInactive && !b.Active(now) {
+			continue
+		}
+		out = append(out, cloneBan(b))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// ActiveFor returns the active records for steamID that cover serverID.
+func (st *BanStore) ActiveFor(serverID int, steamID string, now time.Time) []BanRecord {
+	var out []BanRecord
+	for _, b := range st.List(serverID, false, now) {
+		if b.SteamID == steamID {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (st *BanStore) add(recs []BanRecord) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.bans = append(st.bans, recs...)
+	return st.saveLocked()
+}
+
+// lift marks matching active records lifted and returns them.
+func (st *BanStore) lift(match func(BanRecord) bool, by string, now time.Time) ([]BanRecord, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var lifted []BanRecord
+	for i := range st.bans {
+		b := &st.bans[i]
+		if b.LiftedAt != nil || !match(*b) {
+			continue
+		}
+		at := now
+		if b.ExpiresAt != nil && b.ExpiresAt.Before(now) {
+			at = *b.ExpiresAt
+		}
+		b.LiftedAt = &at
+		b.LiftedBy = by
+		lifted = append(lifted, cloneBan(*b))
+	}
+	if len(lifted) == 0 {
+		return nil, nil
+	}
+	return lifted, st.saveLocked()
+}
+
+// saveLocked writes the store atomically. Caller MUST hold st.mu.
+func (st *BanStore) saveLocked() error {
+	if st.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(st.bans, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o755); err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
+
+func cloneBan(b BanRecord) BanRecord {
+	b.ServerIDs = append([]int(nil), b.ServerIDs...)
+	return b
+}
+
+func newBanID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// Bans returns the manager's ban store, loading it on first use.
+func (m *Manager) Bans() *BanStore {
+	if m == nil {
+		return nil
+	}
+	m.bansOnce.Do(func() {
+		path := ""
+		if m.Paths != nil {
+			path = m.Paths.BansFile()
+		}
+		st, err := NewBanStore(path)
+		if err != nil {
+			m.Logger("bans", 0).Error(fmt.Sprintf("Failed to load ban records: %v", err))
+		}
+		m.bans = st
+	})
+	return m.bans
+}
+
+// chatModerationBan records a temporary server ban issued by chat moderation.
+func (m *Manager) chatModerationBan(srv *models.Server, steamID, name, reason, duration string) error {
+	d, err := ParseBanDuration(duration)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("chat moderation bans need a duration")
+	}
+	expires := time.Now().UTC().Add(d)
+	_, err = m.AddBans([]BanRecord{{
+		SteamID:   steamID,
+		Name:      name,
+		Reason:    reason,
+		Author:    "chat moderation",
+		Scope:     BanScopeServer,
+		ServerIDs: []int{srv.ID},
+		ExpiresAt: &expires,
+	}})
+	return err
+}
+
+// AddBans validates and stores the records, then writes them into the blacklist of every
+// covered server. Running servers receive a console BAN so online players are removed.
+func (m *Manager) AddBans(recs []BanRecord) ([]BanRecord, error) {
+	now := time.Now().UTC()
+	for i := range recs {
+		b := &recs[i]
+		b.ID = newBanID()
+		if b.CreatedAt.IsZero() {
+			b.CreatedAt = now
+		}
+		b.LiftedAt, b.LiftedBy = nil, ""
+		if err := b.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", b.SteamID, err)
+		}
+		if b.Name == "" {
+			b.Name = m.resolvePlayerName(b.SteamID)
+		}
+	}
+	if err := m.Bans().add(recs); err != nil {
+		return nil, err
+	}
+	for _, b := range recs {
+		if !b.Active(now) {
+			continue
+		}
+		for _, s := range m.Servers {
+			if s != nil && b.Covers(s.ID) {
+				m.enforceBan(s, b.SteamID)
+			}
+		}
+	}
+	return recs, nil
+}
+
+// ImportBans adds shared records. When scope is set every record is re-targeted to it
+// (serverIDs for "server"/"group"); otherwise records must name servers that exist here.
+// Expired records and bans already active with the same scope are skipped. It returns
+// the records added and the number skipped.
+func (m *Manager) ImportBans(recs []BanRecord, author string, scope BanScope, serverIDs []int) ([]BanRecord, int, error) {
+	now := time.Now().UTC()
+	existing := make(map[string]bool)
+	key := func(b BanRecord) string { return fmt.Sprintf("%s|%s|%v", b.SteamID, b.Scope, b.ServerIDs) }
+	for _, b := range m.Bans().List(0, false, now) {
+		existing[key(b)] = true
+	}
+	known := make(map[int]bool, len(m.Servers))
+	for _, s := range m.Servers {
+		if s != nil {
+			known[s.ID] = true
+		}
+	}
+	add := make([]BanRecord, 0, len(recs))
+	skipped := 0
+	for _, b := range recs {
+		if scope != "" {
+			b.Scope = scope
+			b.ServerIDs = append([]int(nil), serverIDs...)
+		}
+		if b.Author == "" {
+			b.Author = author
+		}
+		if err := b.Validate(); err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", b.SteamID, err)
+		}
+		for _, id := range b.ServerIDs {
+			if !known[id] {
+				return nil, 0, fmt.Errorf("%s: server %d does not exist; import with a scope override", b.SteamID, id)
+			}
+		}
+		if !b.Active(now) || existing[key(b)] {
+			skipped++
+			continue
+		}
+		existing[key(b)] = true
+		add = append(add, b)
+	}
+	if len(add) == 0 {
+		return nil, skipped, nil
+	}
+	added, err := m.AddBans(add)
+	return added, skipped, err
+}
+
+// LiftBan lifts the record with id and removes the SteamID from every blacklist no other
+// active record still covers.
+func (m *Manager) LiftBan(id, by string) (BanRecord, error) {
+	lifted, err := m.Bans().lift(func(b BanRecord) bool { return b.ID == id }, by, time.Now().UTC())
+	if err != nil {
+		return BanRecord{}, err
+	}
+	if len(lifted) == 0 {
+		return BanRecord{}, errors.New("ban not found or already lifted")
+	}
+	m.releaseBans(lifted)
+	return lifted[0], nil
+}
+
+// LiftServerBans lifts the server-scoped records for steamID on serverID. It returns the
+// wider-scope records that still ban the player there, if any.
+func (m *Manager) LiftServerBans(serverID int, steamID, by string) ([]BanRecord, error) {
+	steamID = strings.TrimSpace(steamID)
+	lifted, err := m.Bans().lift(func(b BanRecord) bool {
+		return b.Scope == BanScopeServer && b.SteamID == steamID && b.Covers(serverID)
+	}, by, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	m.releaseBans(lifted)
+	return m.Bans().ActiveFor(serverID, steamID, time.Now()), nil
+}
+
+// BanRecordFor returns the newest active record banning steamID on serverID.
+func (m *Manager) BanRecordFor(serverID int, steamID string) (BanRecord, bool) {
+	recs := m.Bans().ActiveFor(serverID, steamID, time.Now())
+	if len(recs) == 0 {
+		return BanRecord{}, false
+	}
+	return recs[0], true
+}
+
+// AnnotateBannedEntries fills reason, author and expiry from the active ban records.
+func (m *Manager) AnnotateBannedEntries(serverID int, entries []models.BannedEntry) []models.BannedEntry {
+	if m == nil || len(entries) == 0 {
+		return entries
+	}
+	for i := range entries {
+		if rec, ok := m.BanRecordFor(serverID, entries[i].SteamID); ok {
+			entries[i].Reason = rec.Reason
+			entries[i].Author = rec.Author
+			entries[i].Scope = string(rec.Scope)
+			entries[i].ExpiresAt = rec.ExpiresAt
+		}
+	}
+	return entries
+}
+
+// sweepBans lifts expired records and periodically re-applies active global and group
+// bans. It is driven by the telemetry loop.
+func (m *Manager) sweepBans(now time.Time) {
+	st := m.Bans()
+	if st == nil {
+		return
+	}
+	expired, err := st.lift(func(b BanRecord) bool { return !b.Active(now) }, "expired", now)
+	if err != nil {
+		m.Logger("bans", 0).Error(fmt.Sprintf("Failed to save ban records: %v", err))
+	}
+	for _, b := range expired {
+		m.Logger("bans", 0).Info(fmt.Sprintf("Ban on %s (%s) expired; lifting", b.SteamID, b.Scope))
+	}
+	m.releaseBans(expired)
+
+	m.bansReconcileMu.Lock()
+	due := now.Sub(m.bansReconciledAt) >= banReconcileInterval
+	if due {
+		m.bansReconciledAt = now
+	}
+	m.bansReconcileMu.Unlock()
+	if !due {
+		return
+	}
+	for _, s := range m.Servers {
+		if s == nil {
+			continue
+		}
+		present := make(map[string]bool)
+		for _, id := range s.ReadBlacklistIDs() {
+			present[id] = true
+		}
+		var missing []string
+		for _, b := range st.List(s.ID, false, now) {
+			if b.Scope != BanScopeServer && !present[b.SteamID] {
+				present[b.SteamID] = true
+				missing = append(missing, b.SteamID)
+			}
+		}
+		if len(missing) > 0 {
+			if err := s.WriteBlacklistIDs(append(s.ReadBlacklistIDs(), missing...)); err != nil {
+				m.Logger("bans", s.ID).Error(fmt.Sprintf("Failed to apply bans to server %d: %v", s.ID, err))
+			}
+		}
+	}
+}
+
+// enforceBan mirrors APIServerBan: a console BAN on running servers, otherwise the
+// blacklist file is written directly.
+func (m *Manager) enforceBan(s *models.Server, steamID string) {
+	if s.Running {
+		if err := s.SendCommand("console", "BAN "+steamID); err == nil {
+			return
+		}
+	}
+	if err := s.AddBlacklistID(steamID); err != nil {
+		m.Logger("bans", s.ID).Error(fmt.Sprintf("Failed to write ban for %s on server %d: %v", steamID, s.ID, err))
+	}
+}
+
+// releaseBans removes lifted SteamIDs from every server blacklist no active record covers.
+func (m *Manager) releaseBans(lifted []BanRecord) {
+	if len(lifted) == 0 {
+		return
+	}
+	now := time.Now()
+	for _, s := range m.Servers {
+		if s == nil {
+			continue
+		}
+		for _, b := range lifted {
+			if !b.Covers(s.ID) || len(m.Bans().ActiveFor(s.ID, b.SteamID, now)) > 0 {
+				continue
+			}
+			if err := s.RemoveBlacklistID(b.SteamID); err != nil {
+				m.Logger("bans", s.ID).Error(fmt.Sprintf("Failed to lift ban for %s on server %d: %v", b.SteamID, s.ID, err))
+			}
+		}
+	}
+}
+
+func (m *Manager) resolvePlayerName(steamID string) string {
+	if p, ok := m.Players().Get(steamID); ok && p.Name != "" {
+		return p.Name
+	}
+	for _, s := range m.Servers {
+		if s == nil {
+			continue
+		}
+		if name := s.ResolveNameForSteamID(steamID); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+var banCSVHeader = []string{"steam_id", "name", "reason", "author", "created_at", "expires_at", "scope", "server_ids"}
+
+// WriteBansCSV encodes records as CSV with a header row. server_ids are ';'-separated.
+func WriteBansCSV(w io.Writer, recs []BanRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(banCSVHeader); err != nil {
+		return err
+	}
+	for _, b := range recs {
+		expires := ""
+		if b.ExpiresAt != nil {
+			expires = b.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		ids := make([]string, 0, len(b.ServerIDs))
+		for _, id := range b.ServerIDs {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		row := []string{b.SteamID, b.Name, b.Reason, b.Author, b.CreatedAt.UTC().Format(time.RFC3339), expires, string(b.Scope), strings.Join(ids, ";")}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ParseBansCSV decodes CSV written by WriteBansCSV. Columns are matched by header name,
+// so only steam_id is required; a file without a header is read as bare SteamIDs.
+func ParseBansCSV(r io.Reader) ([]BanRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	col := map[string]int{"steam_id": 0}
+	if containsFold(rows[0], "steam_id") {
+		col = make(map[string]int)
+		for i, name := range rows[0] {
+			col[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		rows = rows[1:]
+	}
+	field := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	out := make([]BanRecord, 0, len(rows))
+	for n, row := range rows {
+		b := BanRecord{
+			SteamID: field(row, "steam_id"),
+			Name:    field(row, "name"),
+			Reason:  field(row, "reason"),
+			Author:  field(row, "author"),
+			Scope:   BanScope(strings.ToLower(field(row, "scope"))),
+		}
+		if b.SteamID == "" {
+			continue
+		}
+		if v := field(row, "created_at"); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid created_at %q", n+1, v)
+			}
+			b.CreatedAt = t
+		}
+		if v := field(row, "expires_at"); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid expires_at %q", n+1, v)
+			}
+			b.ExpiresAt = &t
+		}
+		for _, part := range strings.FieldsFunc(field(row, "server_ids"), func(r rune) bool { return r == ';' || r == ' ' }) {
+			id, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid server id %q", n+1, part)
+			}
+			b.ServerIDs = append(b.ServerIDs, id)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func containsFold(values []string, want string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestBanExpiryRewritesBlacklists(t *testing.T) {
+	paths := utils.NewPaths(t.TempDir())
+	alpha := &models.Server{ID: 1, Name: "Alpha", Paths: paths}
+	beta := &models.Server{ID: 2, Name: "Beta", Paths: paths}
+	mgr := &Manager{Paths: paths, Servers: []*models.Server{alpha, beta}}
+	const player = "76561198000000001"
+
+	if err := beta.WriteBlacklistIDs([]string{"manual"}); err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Now().UTC().Add(time.Hour)
+	if _, err := mgr.AddBans([]BanRecord{
+		{SteamID: player, Reason: "griefing", Author: "mod", Scope: BanScopeGlobal, ExpiresAt: &expires},
+		{SteamID: player, Reason: "cheating", Author: "mod", Scope: BanScopeServer, ServerIDs: []int{1}},
+	}); err != nil {
+		t.Fatalf("add bans: %v", err)
+	}
+	if got := beta.ReadBlacklistIDs(); len(got) != 2 || got[1] != player {
+		t.Fatalf("beta blacklist = %v", got)
+	}
+
+	mgr.sweepBans(expires.Add(time.Minute))
+
+	if got := beta.ReadBlacklistIDs(); len(got) != 1 || got[0] != "manual" {
+		t.Fatalf("beta blacklist after expiry = %v", got)
+	}
+	if got := alpha.ReadBlacklistIDs(); len(got) != 1 || got[0] != player {
+		t.Fatalf("alpha blacklist after expiry = %v (server ban must survive)", got)
+	}
+	if rec, ok := mgr.BanRecordFor(1, player); !ok || rec.Reason != "cheating" {
+		t.Fatalf("active record = %+v, %v", rec, ok)
+	}
+	if all := mgr.Bans().List(0, true, time.Now()); len(all) != 2 {
+		t.Fatalf("history = %d records, want 2", len(all))
+	}
+
+	remaining, err := mgr.LiftServerBans(1, player, "admin")
+	if err != nil || len(remaining) != 0 {
+		t.Fatalf("lift server bans: %v %v", remaining, err)
+	}
+	if got := alpha.ReadBlacklistIDs(); len(got) != 0 {
+		t.Fatalf("alpha blacklist after unban = %v", got)
+	}
+}
+
+func TestBansCSVRoundTrip(t *testing.T) {
+	expires := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	in := []BanRecord{
+		{SteamID: "1", Name: "Rook", Reason: "spam, repeatedly", Author: "mod", CreatedAt: expires.Add(-time.Hour), ExpiresAt: &expires, Scope: BanScopeGroup, ServerIDs: []int{1, 3}},
+		{SteamID: "2", CreatedAt: expires, Scope: BanScopeGlobal},
+	}
+	var buf bytes.Buffer
+	if err := WriteBansCSV(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ParseBansCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[0].Reason != "spam, repeatedly" || len(out[0].ServerIDs) != 2 || out[0].ExpiresAt == nil || !out[0].ExpiresAt.Equal(expires) || out[1].Scope != BanScopeGlobal {
+		t.Fatalf("round trip = %+v", out)
+	}
+
+	bare, err := ParseBansCSV(bytes.NewBufferString("76561198000000001\n76561198000000002\n"))
+	if err != nil || len(bare) != 2 || bare[1].SteamID != "76561198000000002" {
+		t.Fatalf("bare list = %+v, %v", bare, err)
+	}
+}
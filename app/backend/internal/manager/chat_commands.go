@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// The manager adds chat commands that need scheduler state to the models command table.
+func init() {
+	models.RegisterChatCommand(models.ChatCommand{
+		Name:  "nextrestart",
+		Usage: "nextrestart",
+		Help:  "Time until the next scheduled restart",
+		Run:   chatCommandNextRestart,
+	})
+}
+
+// SetUserStore lets the manager authorize in-game chat commands against SDSM users
+// linked to a SteamID.
+func (m *Manager) SetUserStore(users *UserStore) {
+	m.users = users
+}
+
+func (m *Manager) chatCommandAllowed(srv *models.Server, steamID, permission string) bool {
+	return m.users != nil && m.users.SteamIDHasPermission(steamID, srv.ID, Permission(permission))
+}
+
+// restartFromChat restarts a server for a chat vote or admin command with the same
+// notifications as a scheduled restart.
+func (m *Manager) restartFromChat(s *models.Server, reason string) {
+	if !s.IsRunning() || s.Stopping {
+		return
+	}
+	m.NotifyServerEvent(s, "restarting", reason)
+	s.Restart()
+	m.notifyServerStatusChanged(s)
+	m.NotifyServerEvent(s, "started", "Restart complete.")
+}
+
+// nextScheduledRestart returns the earliest upcoming enabled restart job, or zero.
+func nextScheduledRestart(s *models.Server, now time.Time) time.Time {
+	var next time.Time
+	for _, job := range s.Schedules {
+		if job.Action != models.ScheduleActionRestart {
+			continue
+		}
+		if t := NextScheduleRun(job, now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+func chatCommandNextRestart(s *models.Server, _ *models.ChatCommandContext) string {
+	now := time.Now()
+	next := nextScheduledRestart(s, now)
+	if next.IsZero() {
+		return "No restart is scheduled."
+	}
+	in := next.Sub(now).Round(time.Minute)
+	return fmt.Sprintf("Next restart in %dh %dm (%s).", int(in.Hours()), int(in.Minutes())%60, next.Format("15:04 MST"))
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// superviseServer wires crash handling for a managed server.
+func (m *Manager) superviseServer(srv *models.Server) {
+	if m == nil || srv == nil {
+		return
+	}
+	srv.OnUnexpectedExit = m.handleServerCrash
+}
+
+// handleServerCrash reports an unexpected exit and, when the server's crash policy allows,
+// schedules an automatic restart with exponential backoff.
+func (m *Manager) handleServerCrash(s *models.Server) {
+	if m == nil || s == nil {
+		return
+	}
+	summary := crashSummary(s.LastError)
+	m.Logger("crash", s.ID).Error(fmt.Sprintf("Server %s (ID:%d) crashed: %s", s.Name, s.ID, summary))
+	m.NotifyServerEvent(s, "crashed", s.LastError)
+	m.notifyServerStatusChanged(s)
+
+	if !s.CrashAutoRestart || !m.Active {
+		return
+	}
+	attempt := s.RecordCrash(time.Now())
+	limit := s.CrashRestartLimit()
+	if attempt > limit {
+		// Give up until someone starts the server by hand; the next crash starts a fresh window.
+		s.ResetCrashHistory()
+		detail := fmt.Sprintf("Automatic restart disabled: %d crashes within %s.", attempt, s.CrashWindow())
+		if s.Logger != nil {
+			s.Logger.Warn(detail)
+		}
+		m.NotifyServerEvent(s, "crashed", detail)
+		return
+	}
+
+	delay := s.CrashBackoff(attempt)
+	if s.Logger != nil {
+		s.Logger.Info(fmt.Sprintf("Automatic restart %d/%d in %s", attempt, limit, delay))
+	}
+	go func() {
+		time.Sleep(delay)
+		if s.IsRunning() || s.Starting || !m.Active {
+			// Started by someone else (or manager shutting down) while we were backing off.
+			return
+		}
+		if m.ServerByID(s.ID) != s {
+			// Server was deleted during backoff.
+			return
+		}
+		if s.CrashRestoreAutosave {
+			if name, err := s.RestoreLatestAutosave(); err != nil {
+				m.Logger("crash", s.ID).Warn(fmt.Sprintf("Server %s: autosave restore skipped: %v", s.Name, err))
+			} else {
+				m.Logger("crash", s.ID).Info(fmt.Sprintf("Server %s: restored autosave %s before restart", s.Name, name))
+			}
+		}
+		m.NotifyServerEvent(s, "restarting", fmt.Sprintf("Automatic restart after crash (attempt %d of %d).", attempt, limit))
+		s.Start()
+		m.notifyServerStatusChanged(s)
+	}()
+}
+
+func (m *Manager) notifyServerStatusChanged(s *models.Server) {
+	if m != nil && m.OnServerStatusChanged != nil && s != nil {
+		m.OnServerStatusChanged(s)
+	}
+}
+
+// crashSummary returns the first line of a crash description for compact log output.
+func crashSummary(lastError string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(lastError), "\n")
+	line = strings.TrimSuffix(line, ":")
+	if line == "" {
+		return "unknown cause"
+	}
+	return line
+}
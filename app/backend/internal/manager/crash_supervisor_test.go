@@ -0,0 +1,35 @@
+package manager
+
+import (
+	"testing"
+
+	"sdsm/app/backend/internal/models"
+)
+
+func TestHandleServerCrash_NotifiesDashboard(t *testing.T) {
+	mgr := &Manager{}
+	srv := &models.Server{ID: 3, Name: "Alpha", LastError: "Server exited unexpectedly (exit status 1):\nSegmentation fault"}
+
+	mgr.handleServerCrash(srv)
+
+	notes := mgr.RecentNotifications(1)
+	if len(notes) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notes))
+	}
+	if notes[0].Event != "crashed" || notes[0].Kind != models.NotificationKindDanger {
+		t.Fatalf("unexpected notification: %+v", notes[0])
+	}
+	if notes[0].ServerID != 3 {
+		t.Fatalf("expected server id 3, got %d", notes[0].ServerID)
+	}
+}
+
+func TestCrashSummary(t *testing.T) {
+	got := crashSummary("Server exited unexpectedly (exit status 139):\nline one\nline two")
+	if got != "Server exited unexpectedly (exit status 139)" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+	if crashSummary("   ") != "unknown cause" {
+		t.Fatalf("expected fallback summary for empty error")
+	}
+}
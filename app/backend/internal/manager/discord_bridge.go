@@ -0,0 +1,270 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/models"
+)
+
+const (
+	discordSendTimeout = 10 * time.Second
+	// In-game chat lines are short; longer Discord messages are cut before SAY.
+	discordToGameMaxLength = 200
+)
+
+// discordSlashCommands are registered when the bot connects. Each takes an optional
+// "server" option and otherwise acts on the server bridged to the channel.
+var discordSlashCommands = []discord.SlashCommand{
+	{Name: "status", Description: "Show a server's status"},
+	{Name: "players", Description: "List the players online"},
+	{Name: "restart", Description: "Restart a server"},
+}
+
+// discordCommandPermissions maps slash commands to the SDSM permission they need.
+var discordCommandPermissions = map[string]Permission{
+	"status":  PermServerView,
+	"players": PermServerView,
+	"restart": PermServerRestart,
+}
+
+// DiscordBotConfig returns a copy of the bot configuration.
+func (m *Manager) DiscordBotConfig() discord.BotConfig {
+	m.discordBotMu.Lock()
+	defer m.discordBotMu.Unlock()
+	cfg := m.DiscordBot
+	cfg.Bridges = append([]discord.Bridge(nil), cfg.Bridges...)
+	return cfg
+}
+
+// SetDiscordBot validates and stores the bot configuration, then restarts the bridge. A config
+// submitted without a token keeps the stored token so redacted configs can be round-tripped,
+// unless the config would send it to a different API base.
+func (m *Manager) SetDiscordBot(cfg discord.BotConfig) error {
+	cfg.Token = strings.TrimSpace(cfg.Token)
+	cfg.GuildID = strings.TrimSpace(cfg.GuildID)
+	for i := range cfg.Bridges {
+		cfg.Bridges[i].ChannelID = strings.TrimSpace(cfg.Bridges[i].ChannelID)
+	}
+	m.discordBotMu.Lock()
+	if cfg.Token == "" && cfg.APIBase == m.DiscordBot.APIBase {
+		cfg.Token = m.DiscordBot.Token
+	}
+	if err := cfg.Validate(); err != nil {
+		m.discordBotMu.Unlock()
+		return err
+	}
+	for _, b := range cfg.Bridges {
+		if m.ServerByID(b.ServerID) == nil {
+			m.discordBotMu.Unlock()
+			return fmt.Errorf("discord bot: server %d not found", b.ServerID)
+		}
+	}
+	m.DiscordBot = cfg
+	m.discordBotMu.Unlock()
+	m.Save()
+	m.StopDiscordBridge()
+	m.StartDiscordBridge()
+	return nil
+}
+
+// StartDiscordBridge connects the Discord bot when it is enabled. It is a no-op when the bot
+// is disabled or already running.
+func (m *Manager) StartDiscordBridge() {
+	if m == nil {
+		return
+	}
+	cfg := m.DiscordBotConfig()
+	if !cfg.Enabled || cfg.Validate() != nil {
+		return
+	}
+	m.discordBotMu.Lock()
+	defer m.discordBotMu.Unlock()
+	if m.discordBot != nil {
+		return
+	}
+	bot := discord.NewBot(cfg, discordSlashCommands)
+	bot.OnMessage = m.discordMessage
+	bot.OnInteraction = m.discordInteraction
+	bot.Log = m.Logger("discord", 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	m.discordBot, m.discordBotStop, m.discordBotDone = bot, cancel, done
+	go func() {
+		defer close(done)
+		bot.Run(ctx)
+	}()
+}
+
+// StopDiscordBridge disconnects the Discord bot and waits for it to exit.
+func (m *Manager) StopDiscordBridge() {
+	if m == nil {
+		return
+	}
+	m.discordBotMu.Lock()
+	stop, done := m.discordBotStop, m.discordBotDone
+	m.discordBot, m.discordBotStop, m.discordBotDone = nil, nil, nil
+	m.discordBotMu.Unlock()
+	if stop != nil {
+		stop()
+		<-done
+	}
+}
+
+// DiscordBotConnected reports whether the bot has an established gateway session.
+func (m *Manager) DiscordBotConnected() bool {
+	bot, _ := m.runningDiscordBot()
+	return bot != nil && bot.Ready()
+}
+
+func (m *Manager) runningDiscordBot() (*discord.Bot, discord.BotConfig) {
+	m.discordBotMu.Lock()
+	defer m.discordBotMu.Unlock()
+	return m.discordBot, m.DiscordBot
+}
+
+// relayChatToDiscord posts a player's chat line to the channel bridged to the server.
+func (m *Manager) relayChatToDiscord(s *models.Server, name, message string) {
+	bot, cfg := m.runningDiscordBot()
+	if bot == nil || s == nil {
+		return
+	}
+	channel, ok := cfg.ChannelFor(s.ID)
+	if !ok {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), discordSendTimeout)
+		defer cancel()
+		if err := bot.SendMessage(ctx, channel, fmt.Sprintf("**%s**: %s", discordEscape(name), discordEscape(message))); err != nil && m.Log != nil {
+			m.Logger("discord", s.ID).Warn(fmt.Sprintf("Discord relay for server %d failed: %v", s.ID, err))
+		}
+	}()
+}
+
+// discordMessage relays a message from a bridged channel into the game as server chat. The
+// line shows up in the log as "Server" chat, which is never relayed back.
+func (m *Manager) discordMessage(msg discord.Message) {
+	if msg.Bot {
+		return
+	}
+	_, cfg := m.runningDiscordBot()
+	serverID, ok := cfg.ServerFor(msg.ChannelID)
+	if !ok {
+		return
+	}
+	s := m.ServerByID(serverID)
+	text := strings.Join(strings.Fields(msg.Content), " ")
+	if s == nil || text == "" || !s.IsRunning() {
+		return
+	}
+	line := fmt.Sprintf("[Discord] %s: %s", msg.Author, text)
+	if r := []rune(line); len(r) > discordToGameMaxLength {
+		line = string(r[:discordToGameMaxLength])
+	}
+	if err := s.SendCommand("chat", line); err != nil && m.Log != nil {
+		m.Logger("discord", s.ID).Warn(fmt.Sprintf("Discord message for server %d not delivered: %v", s.ID, err))
+	}
+}
+
+// discordInteraction answers /status, /players and /restart for Discord users linked to an
+// SDSM user holding the matching permission.
+func (m *Manager) discordInteraction(it discord.Interaction) {
+	bot, cfg := m.runningDiscordBot()
+	if bot == nil {
+		return
+	}
+	reply, ephemeral := m.discordCommandReply(cfg, it)
+	ctx, cancel := context.WithTimeout(context.Background(), discordSendTimeout)
+	defer cancel()
+	if err := bot.Respond(ctx, it, reply, ephemeral); err != nil && m.Log != nil {
+		m.Logger("discord", 0).Warn(fmt.Sprintf("Discord /%s reply failed: %v", it.Command, err))
+	}
+}
+
+func (m *Manager) discordCommandReply(cfg discord.BotConfig, it discord.Interaction) (string, bool) {
+	perm, ok := discordCommandPermissions[it.Command]
+	if !ok {
+		return "Unknown command.", true
+	}
+	s := m.discordTargetServer(cfg, it)
+	if s == nil {
+		return "No server found. Pass the server option or use the command in a bridged channel.", true
+	}
+	if m.users == nil {
+		return "Discord commands are unavailable.", true
+	}
+	username, linked := m.users.DiscordUser(it.UserID)
+	if !linked {
+		return "Your Discord account is not linked to an SDSM user.", true
+	}
+	if !m.users.DiscordIDHasPermission(it.UserID, s.ID, perm) {
+		return fmt.Sprintf("You do not have %s on %s.", perm, s.Name), true
+	}
+
+	switch it.Command {
+	case "status":
+		state := "stopped"
+		switch {
+		case s.Starting:
+			state = "starting"
+		case s.IsRunning() && s.Paused:
+			state = "running (paused)"
+		case s.IsRunning():
+			state = "running for " + s.UptimeString()
+		}
+		return fmt.Sprintf("**%s**: %s, %d/%d players, world %s.", discordEscape(s.Name), state, len(s.LiveClients()), s.MaxClients, discordEscape(s.WorldID)), false
+	case "players":
+		live := s.LiveClients()
+		if len(live) == 0 {
+			return fmt.Sprintf("Nobody is online on **%s**.", discordEscape(s.Name)), false
+		}
+		names := make([]string, 0, len(live))
+		for _, c := range live {
+			names = append(names, discordEscape(c.Name))
+		}
+		sort.Strings(names)
+		return fmt.Sprintf("Online on **%s** (%d): %s", discordEscape(s.Name), len(names), strings.Join(names, ", ")), false
+	default:
+		if !s.IsRunning() || s.Stopping {
+			return fmt.Sprintf("%s is not running.", s.Name), true
+		}
+		reason := fmt.Sprintf("Restart requested from Discord by %s (%s).", it.User, username)
+		if s.Logger != nil {
+			s.Logger.Info(reason)
+		}
+		go m.restartFromChat(s, reason)
+		return fmt.Sprintf("Restarting **%s**.", discordEscape(s.Name)), false
+	}
+}
+
+// discordTargetServer resolves the "server" option (ID or name) or the bridged channel.
+func (m *Manager) discordTargetServer(cfg discord.BotConfig, it discord.Interaction) *models.Server {
+	if want := strings.TrimSpace(it.Options["server"]); want != "" {
+		if id, err := strconv.Atoi(want); err == nil {
+			return m.ServerByID(id)
+		}
+		for _, s := range m.Servers {
+			if s != nil && strings.EqualFold(s.Name, want) {
+				return s
+			}
+		}
+		return nil
+	}
+	if id, ok := cfg.ServerFor(it.ChannelID); ok {
+		return m.ServerByID(id)
+	}
+	return nil
+}
+
+var discordMarkdown = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`)
+
+// discordEscape keeps player-controlled text from being rendered as Markdown.
+func discordEscape(s string) string {
+	return discordMarkdown.Replace(s)
+}
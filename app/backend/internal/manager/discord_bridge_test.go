@@ -0,0 +1,145 @@
+package manager
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/integrations/discord/discordtest"
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDiscordBridge(t *testing.T) {
+	gw := discordtest.NewGateway("bot-token")
+	defer gw.Close()
+
+	dir := t.TempDir()
+	users := NewUserStore(utils.NewPaths(dir))
+	if err := users.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.CreateUser("viewer", "hash", RoleOperator); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.SetAssignments("viewer", false, []int{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.SetDiscordID("viewer", "200"); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &models.Server{ID: 1, Name: "Mars", MaxClients: 8, WorldID: "Mars2"}
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Servers:    []*models.Server{srv},
+		users:      users,
+		DiscordBot: discord.BotConfig{
+			Enabled: true,
+			Token:   "bot-token",
+			APIBase: gw.URL,
+			Bridges: []discord.Bridge{{ServerID: 1, ChannelID: "300"}},
+		},
+	}
+	defer mgr.Log.Close()
+	mgr.wireServerHooks(srv)
+	mgr.StartDiscordBridge()
+	defer mgr.StopDiscordBridge()
+	if !gw.WaitReady(5 * time.Second) {
+		t.Fatal("bot did not identify")
+	}
+	waitFor(t, "slash command registration", func() bool { return len(gw.Commands()) == 3 })
+
+	// In-game chat is relayed with Markdown escaped.
+	srv.OnPlayerChat(srv, "Rook", "anyone at *base*?")
+	waitFor(t, "relayed chat", func() bool { return len(gw.Messages()) == 1 })
+	if msg := gw.Messages()[0]; msg.ChannelID != "300" || msg.Content != `**Rook**: anyone at \*base\*?` {
+		t.Fatalf("relayed message = %+v", msg)
+	}
+
+	reply := func(userID, command string, options map[string]string) discordtest.Response {
+		t.Helper()
+		id := gw.RunCommand("300", userID, "someone", command, options)
+		var resp discordtest.Response
+		waitFor(t, "/"+command+" reply", func() bool {
+			var ok bool
+			resp, ok = gw.Response(id)
+			return ok
+		})
+		return resp
+	}
+	if r := reply("200", "status", nil); r.Ephemeral || !strings.Contains(r.Content, "**Mars**: stopped, 0/8 players") {
+		t.Fatalf("/status = %+v", r)
+	}
+	if r := reply("999", "players", nil); !r.Ephemeral || !strings.Contains(r.Content, "not linked") {
+		t.Fatalf("unlinked /players = %+v", r)
+	}
+	if r := reply("200", "status", map[string]string{"server": "2"}); !r.Ephemeral || !strings.Contains(r.Content, "No server found") {
+		t.Fatalf("/status for unknown server = %+v", r)
+	}
+	if err := users.SetAssignments("viewer", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if r := reply("200", "restart", map[string]string{"server": "mars"}); !r.Ephemeral || !strings.Contains(r.Content, "server.restart") {
+		t.Fatalf("/restart without permission = %+v", r)
+	}
+}
+
+func TestSetDiscordBotKeepsToken(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Servers:    []*models.Server{{ID: 1}},
+		DiscordBot: discord.BotConfig{Token: "bot-token"},
+	}
+	defer mgr.Log.Close()
+	if err := mgr.SetDiscordBot(discord.BotConfig{Bridges: []discord.Bridge{{ServerID: 1, ChannelID: "300"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := mgr.DiscordBotConfig(); got.Token != "bot-token" || len(got.Bridges) != 1 {
+		t.Fatalf("config = %+v", got)
+	}
+	if err := mgr.SetDiscordBot(discord.BotConfig{Bridges: []discord.Bridge{{ServerID: 7, ChannelID: "300"}}}); err == nil {
+		t.Fatal("expected unknown server to be rejected")
+	}
+}
+
+func TestSetDiscordBotDoesNotRedirectToken(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		DiscordBot: discord.BotConfig{Token: "bot-token"},
+	}
+	defer mgr.Log.Close()
+
+	var body discord.BotConfig
+	if err := json.Unmarshal([]byte(`{"api_base":"https://attacker.example.net"}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.APIBase != "" {
+		t.Fatalf("api_base must not be accepted from JSON, got %q", body.APIBase)
+	}
+	if err := mgr.SetDiscordBot(discord.BotConfig{APIBase: "https://attacker.example.net"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := mgr.DiscordBotConfig().Token; got != "" {
+		t.Fatalf("stored token reused for a different API base: %q", got)
+	}
+}
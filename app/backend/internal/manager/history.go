@@ -0,0 +1,379 @@
+package manager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// Telemetry history is kept in fixed-size ring files, one per source and resolution.
+// Each slot is addressed by (bucket start / step) % slots, so the file never grows and
+// old buckets are overwritten in place once the ring wraps.
+const (
+	historyFineStep    = time.Minute
+	historyFineSlots   = 48 * 60 // 48 hours of 1-minute buckets
+	historyCoarseStep  = 15 * time.Minute
+	historyCoarseSlots = 90 * 24 * 4 // 90 days of 15-minute buckets
+	historyMaxRange    = 90 * 24 * time.Hour
+
+	historyMagic      = "SDSMTS01"
+	historyHeaderSize = 16
+	historyFields     = 5
+	historySlotSize   = 8 + historyFields*8
+)
+
+// HostHistorySource names the host-level series; servers use "server-<id>".
+const HostHistorySource = "host"
+
+// HistoryPoint is one downsampled bucket. Values are averages over the bucket except
+// PlayersPeak, which is the highest concurrent player count seen in it.
+type HistoryPoint struct {
+	Time          time.Time `json:"t"`
+	CPUPercent    float64   `json:"cpu_percent"`
+	MemoryPercent float64   `json:"memory_percent"`
+	MemoryBytes   float64   `json:"memory_bytes"`
+	Players       float64   `json:"players"`
+	PlayersPeak   float64   `json:"players_peak"`
+}
+
+// HistorySample is a single raw telemetry observation fed into the store.
+type HistorySample struct {
+	CPUPercent    float64
+	MemoryPercent float64
+	MemoryBytes   float64
+	Players       float64
+}
+
+// HistoryStore persists downsampled telemetry series under the SDSM root path.
+type HistoryStore struct {
+	mu     sync.Mutex
+	dir    string
+	series map[string]*historySeries
+}
+
+type historySeries struct {
+	fine   *historyRing
+	coarse *historyRing
+}
+
+type historyRing struct {
+	path  string
+	step  time.Duration
+	slots int
+	file  *os.File
+	acc   historyAccumulator
+}
+
+type historyAccumulator struct {
+	bucket int64
+	count  float64
+	sums   [historyFields - 1]float64
+	peak   float64
+}
+
+// NewHistoryStore returns a store rooted at dir; files are created on first write.
+func NewHistoryStore(dir string) *HistoryStore {
+	return &HistoryStore{dir: dir, series: make(map[string]*historySeries)}
+}
+
+// ServerHistorySource returns the series name used for a server.
+func ServerHistorySource(serverID int) string {
+	return "server-" + strconv.Itoa(serverID)
+}
+
+// Record folds a sample taken at the given time into both resolutions of source.
+func (s *HistoryStore) Record(source string, at time.Time, sample HistorySample) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, err := s.seriesLocked(source)
+	if err != nil {
+		return err
+	}
+	return errors.Join(series.fine.record(at, sample), series.coarse.record(at, sample))
+}
+
+// Query returns points for source between from and to, choosing the 1-minute series when
+// the range starts within its retention and the 15-minute series otherwise.
+func (s *HistoryStore) Query(source string, from, to time.Time) ([]HistoryPoint, time.Duration, error) {
+	if s == nil {
+		return nil, 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, err := s.seriesLocked(source)
+	if err != nil {
+		return nil, 0, err
+	}
+	ring := series.coarse
+	if time.Since(from) <= time.Duration(historyFineSlots)*historyFineStep {
+		ring = series.fine
+	}
+	points, err := ring.read(from, to)
+	return points, ring.step, err
+}
+
+// Forget closes and deletes every file belonging to source.
+func (s *HistoryStore) Forget(source string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if series, ok := s.series[source]; ok {
+		series.fine.close()
+		series.coarse.close()
+		delete(s.series, source)
+	}
+	var errs []error
+	for _, suffix := range []string{"1m", "15m"} {
+		if err := os.Remove(s.ringPath(source, suffix)); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close releases all open ring files.
+func (s *HistoryStore) Close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, series := range s.series {
+		series.fine.close()
+		series.coarse.close()
+	}
+	s.series = make(map[string]*historySeries)
+}
+
+func (s *HistoryStore) ringPath(source, suffix string) string {
+	return filepath.Join(s.dir, source+"."+suffix+".ring")
+}
+
+func (s *HistoryStore) seriesLocked(source string) (*historySeries, error) {
+	source = strings.TrimSpace(source)
+	if source == "" || strings.ContainsAny(source, `/\.`) {
+		return nil, fmt.Errorf("invalid history source %q", source)
+	}
+	if series, ok := s.series[source]; ok {
+		return series, nil
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, err
+	}
+	fine, err := openHistoryRing(s.ringPath(source, "1m"), historyFineStep, historyFineSlots)
+	if err != nil {
+		return nil, err
+	}
+	coarse, err := openHistoryRing(s.ringPath(source, "15m"), historyCoarseStep, historyCoarseSlots)
+	if err != nil {
+		fine.close()
+		return nil, err
+	}
+	series := &historySeries{fine: fine, coarse: coarse}
+	s.series[source] = series
+	return series, nil
+}
+
+// openHistoryRing opens (or creates) a ring file, recreating it when the header does not
+// match the expected step and capacity.
+func openHistoryRing(path string, step time.Duration, slots int) (*historyRing, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, historyHeaderSize)
+	copy(header, historyMagic)
+	binary.LittleEndian.PutUint32(header[8:], uint32(step/time.Second))
+	binary.LittleEndian.PutUint32(header[12:], uint32(slots))
+
+	existing := make([]byte, historyHeaderSize)
+	size := int64(historyHeaderSize + slots*historySlotSize)
+	info, statErr := f.Stat()
+	_, readErr := f.ReadAt(existing, 0)
+	if statErr != nil || readErr != nil || info.Size() != size || string(existing) != string(header) {
+		if err := f.Truncate(0); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if _, err := f.WriteAt(header, 0); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Truncate(size); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return &historyRing{path: path, step: step, slots: slots, file: f}, nil
+}
+
+func (r *historyRing) close() {
+	if r != nil && r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
+
+func (r *historyRing) slotOffset(bucket int64) int64 {
+	stepSec := int64(r.step / time.Second)
+	idx := (bucket / stepSec) % int64(r.slots)
+	return historyHeaderSize + idx*historySlotSize
+}
+
+// record adds the sample to the running bucket and rewrites that bucket's slot so the
+// newest partial bucket is always readable and survives restarts.
+func (r *historyRing) record(at time.Time, sample HistorySample) error {
+	bucket := at.Truncate(r.step).Unix()
+	if r.acc.bucket != bucket {
+		r.acc = historyAccumulator{bucket: bucket}
+	}
+	r.acc.count++
+	r.acc.sums[0] += sample.CPUPercent
+	r.acc.sums[1] += sample.MemoryPercent
+	r.acc.sums[2] += sample.MemoryBytes
+	r.acc.sums[3] += sample.Players
+	if sample.Players > r.acc.peak {
+		r.acc.peak = sample.Players
+	}
+
+	buf := make([]byte, historySlotSize)
+	binary.LittleEndian.PutUint64(buf, uint64(bucket))
+	for i, sum := range r.acc.sums {
+		binary.LittleEndian.PutUint64(buf[8+i*8:], math.Float64bits(sum/r.acc.count))
+	}
+	binary.LittleEndian.PutUint64(buf[8+(historyFields-1)*8:], math.Float64bits(r.acc.peak))
+	_, err := r.file.WriteAt(buf, r.slotOffset(bucket))
+	return err
+}
+
+func (r *historyRing) read(from, to time.Time) ([]HistoryPoint, error) {
+	data := make([]byte, r.slots*historySlotSize)
+	if _, err := r.file.ReadAt(data, historyHeaderSize); err != nil && err != io.EOF {
+		return nil, err
+	}
+	fromUnix := from.Truncate(r.step).Unix()
+	toUnix := to.Unix()
+	points := make([]HistoryPoint, 0, 256)
+	for i := 0; i < r.slots; i++ {
+		slot := data[i*historySlotSize : (i+1)*historySlotSize]
+		bucket := int64(binary.LittleEndian.Uint64(slot))
+		if bucket == 0 || bucket < fromUnix || bucket > toUnix {
+			continue
+		}
+		field := func(n int) float64 {
+			return math.Float64frombits(binary.LittleEndian.Uint64(slot[8+n*8:]))
+		}
+		points = append(points, HistoryPoint{
+			Time:          time.Unix(bucket, 0).UTC(),
+			CPUPercent:    field(0),
+			MemoryPercent: field(1),
+			MemoryBytes:   field(2),
+			Players:       field(3),
+			PlayersPeak:   field(4),
+		})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	return points, nil
+}
+
+// ParseHistoryRange converts values such as "6h", "48h", "7d" or "90d" into a duration,
+// defaulting to 24 hours and capping at the coarse retention window.
+func ParseHistoryRange(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return 24 * time.Hour, nil
+	}
+	var d time.Duration
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid range %q", value)
+		}
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range %q", value)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid range %q", value)
+	}
+	if d > historyMaxRange {
+		d = historyMaxRange
+	}
+	return d, nil
+}
+
+// History returns the store backing telemetry history, creating it on first use.
+func (m *Manager) History() *HistoryStore {
+	if m == nil || m.Paths == nil {
+		return nil
+	}
+	m.historyOnce.Do(func() {
+		m.history = NewHistoryStore(m.Paths.HistoryDir())
+	})
+	return m.history
+}
+
+// recordHistory appends the latest host and per-server samples to the history store.
+func (m *Manager) recordHistory(snapshot *models.SystemTelemetry) {
+	store := m.History()
+	if store == nil || snapshot == nil {
+		return
+	}
+	at := snapshot.SampledAt
+	var errs []error
+	totalPlayers := 0
+	for _, srv := range m.Servers {
+		if srv == nil || !srv.IsRunning() {
+			continue
+		}
+		players := srv.ClientCount()
+		totalPlayers += players
+		sample := HistorySample{Players: float64(players)}
+		if usage := srv.ResourceUsage(); usage != nil {
+			sample.CPUPercent = usage.CPUPercent
+			sample.MemoryPercent = usage.MemoryPercent
+			sample.MemoryBytes = float64(usage.MemoryRSSBytes)
+		}
+		errs = append(errs, store.Record(ServerHistorySource(srv.ID), at, sample))
+	}
+	errs = append(errs, store.Record(HostHistorySource, at, HistorySample{
+		CPUPercent:    snapshot.CPUPercent,
+		MemoryPercent: snapshot.MemoryPercent,
+		MemoryBytes:   float64(snapshot.MemoryUsed),
+		Players:       float64(totalPlayers),
+	}))
+	if err := errors.Join(errs...); err != nil {
+		m.historyErrOnce.Do(func() {
+			m.Logger("history", 0).Error(fmt.Sprintf("Telemetry history write failed: %v", err))
+		})
+	}
+}
+
+// ForgetServerHistory deletes the stored history of a removed server.
+func (m *Manager) ForgetServerHistory(serverID int) {
+	if store := m.History(); store != nil {
+		if err := store.Forget(ServerHistorySource(serverID)); err != nil {
+			m.Logger("history", serverID).Error(fmt.Sprintf("Failed to delete history for server %d: %v", serverID, err))
+		}
+	}
+}
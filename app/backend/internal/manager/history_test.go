@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryStoreDownsamplesAndPersists(t *testing.T) {
+	dir := t.TempDir()
+	store := NewHistoryStore(dir)
+	base := time.Now().Truncate(historyCoarseStep).Add(-historyCoarseStep)
+
+	// Two samples in the first minute, one in the second.
+	samples := []struct {
+		at time.Duration
+		s  HistorySample
+	}{
+		{0, HistorySample{CPUPercent: 10, Players: 2}},
+		{30 * time.Second, HistorySample{CPUPercent: 30, Players: 6}},
+		{90 * time.Second, HistorySample{CPUPercent: 50, Players: 1}},
+	}
+	for _, sample := range samples {
+		if err := store.Record("host", base.Add(sample.at), sample.s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	points, step, err := store.Query("host", base.Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step != historyFineStep || len(points) != 2 {
+		t.Fatalf("expected 2 one-minute points, got %d at %s", len(points), step)
+	}
+	if points[0].CPUPercent != 20 || points[0].Players != 4 || points[0].PlayersPeak != 6 {
+		t.Fatalf("unexpected first bucket: %+v", points[0])
+	}
+	if points[1].CPUPercent != 50 {
+		t.Fatalf("unexpected second bucket: %+v", points[1])
+	}
+
+	store.Close()
+	reopened := NewHistoryStore(dir)
+	defer reopened.Close()
+	coarse, step, err := reopened.Query("host", time.Now().Add(-72*time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step != historyCoarseStep || len(coarse) != 1 {
+		t.Fatalf("expected 1 fifteen-minute point after reopen, got %d at %s", len(coarse), step)
+	}
+	if coarse[0].CPUPercent != 30 || coarse[0].PlayersPeak != 6 {
+		t.Fatalf("unexpected coarse bucket: %+v", coarse[0])
+	}
+}
+
+func TestHistoryRingWrapsInPlace(t *testing.T) {
+	dir := t.TempDir()
+	store := NewHistoryStore(dir)
+	defer store.Close()
+	start := time.Unix(1_700_000_000, 0).Truncate(historyFineStep)
+	for i := 0; i < historyFineSlots+10; i++ {
+		if err := store.Record("server-1", start.Add(time.Duration(i)*historyFineStep), HistorySample{Players: float64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(filepath.Join(dir, "server-1.1m.ring"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(historyHeaderSize + historyFineSlots*historySlotSize); info.Size() != want {
+		t.Fatalf("ring grew to %d bytes, want %d", info.Size(), want)
+	}
+	points, err := store.series["server-1"].fine.read(start, start.Add(time.Duration(historyFineSlots+10)*historyFineStep))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != historyFineSlots {
+		t.Fatalf("expected %d points after wrap, got %d", historyFineSlots, len(points))
+	}
+	if points[0].Players != 10 {
+		t.Fatalf("oldest buckets should have been overwritten, first point %+v", points[0])
+	}
+	if err := store.Forget("server-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "server-1.1m.ring")); !os.IsNotExist(err) {
+		t.Fatalf("expected ring file removed, got %v", err)
+	}
+}
+
+func TestParseHistoryRange(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":     24 * time.Hour,
+		"6h":   6 * time.Hour,
+		"7d":   7 * 24 * time.Hour,
+		"365d": historyMaxRange,
+	}
+	for in, want := range cases {
+		got, err := ParseHistoryRange(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseHistoryRange(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+	if _, err := ParseHistoryRange("soon"); err == nil {
+		t.Fatal("expected error for invalid range")
+	}
+}
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"fmt"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+// LogRotationConfig holds a rotation policy per log kind.
+type LogRotationConfig struct {
+	// Manager is the SDSM log (sdsm.log).
+	Manager utils.RotationPolicy `json:"manager"`
+	// Update is the deploy/update log.
+	Update utils.RotationPolicy `json:"update"`
+	// Server covers each server's SDSM log (ServerN.log).
+	Server utils.RotationPolicy `json:"server"`
+	// Output covers the game's own output log. It rotates as the server writes it, except for
+	// detached servers, which write the file themselves and rotate only when they start.
+	Output utils.RotationPolicy `json:"output"`
+	// Web is the HTTP access log (GIN.log).
+	Web utils.RotationPolicy `json:"web"`
+}
+
+// DefaultLogRotation returns the policies used when the config has no log_rotation key.
+func DefaultLogRotation() LogRotationConfig {
+	standard := utils.RotationPolicy{MaxSizeMB: 20, MaxAgeDays: 7, MaxArchives: 10, RetentionDays: 30, Compress: true}
+	return LogRotationConfig{
+		Manager: standard,
+		Update:  standard,
+		Server:  standard,
+		Output:  utils.RotationPolicy{MaxSizeMB: 100, MaxAgeDays: 7, MaxArchives: 5, RetentionDays: 30, Compress: true},
+		Web:     standard,
+	}
+}
+
+// Validate checks every policy.
+func (c LogRotationConfig) Validate() error {
+	for kind, p := range map[string]utils.RotationPolicy{
+		"manager": c.Manager, "update": c.Update, "server": c.Server, "output": c.Output, "web": c.Web,
+	} {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// SetWebLog registers the HTTP access log so it follows the web rotation policy.
+func (m *Manager) SetWebLog(f *utils.RotatingFile) {
+	m.logRotationMu.Lock()
+	m.webLog = f
+	m.logRotationMu.Unlock()
+	m.applyLogRotation()
+}
+
+// LogRotationConfig returns the current rotation policies.
+func (m *Manager) LogRotationConfig() LogRotationConfig {
+	m.logRotationMu.Lock()
+	defer m.logRotationMu.Unlock()
+	return m.LogRotation
+}
+
+// SetLogRotation validates and stores the rotation policies and applies them to open logs.
+func (m *Manager) SetLogRotation(cfg LogRotationConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	m.logRotationMu.Lock()
+	m.LogRotation = cfg
+	m.logRotationMu.Unlock()
+	m.applyLogRotation()
+	m.Save()
+	return nil
+}
+
+// applyLogRotation pushes the policies to the manager, update, web and server logs.
+func (m *Manager) applyLogRotation() {
+	m.logRotationMu.Lock()
+	cfg, web := m.LogRotation, m.webLog
+	m.logRotationMu.Unlock()
+	m.Log.SetRotation(cfg.Manager)
+	m.UpdateLog.SetRotation(cfg.Update)
+	if web != nil {
+		web.SetPolicy(cfg.Web)
+	}
+	for _, srv := range m.Servers {
+		if srv != nil {
+			srv.SetLogRotation(cfg.Server, cfg.Output)
+		}
+	}
+}
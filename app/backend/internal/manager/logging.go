@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"io"
+	"strings"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+// discardLog swallows entries logged before startLogs has opened the manager log.
+var discardLog = utils.NewWriterLogger(io.Discard)
+
+// Logger returns the manager log tagged with component and, when non-zero, serverID.
+func (m *Manager) Logger(component string, serverID int) *utils.Logger {
+	if m == nil || m.Log == nil {
+		return discardLog
+	}
+	return m.Log.With(component, serverID)
+}
+
+// LoggingConfig is the output format and minimum level shared by every SDSM log.
+type LoggingConfig struct {
+	// Format is "text" or "json".
+	Format string `json:"format"`
+	// Level is debug, info, warn or error.
+	Level string `json:"level"`
+}
+
+// LoggingConfig returns the current format and level, filling in the defaults.
+func (m *Manager) LoggingConfig() LoggingConfig {
+	m.logRotationMu.Lock()
+	defer m.logRotationMu.Unlock()
+	cfg := LoggingConfig{Format: m.LogFormat, Level: m.LogLevel}
+	if cfg.Format == "" {
+		cfg.Format = "text"
+	}
+	if cfg.Level == "" {
+		cfg.Level = "info"
+	}
+	return cfg
+}
+
+// SetLogging validates and applies the format and level to all loggers, then saves.
+func (m *Manager) SetLogging(cfg LoggingConfig) error {
+	format := strings.ToLower(strings.TrimSpace(cfg.Format))
+	level := strings.ToLower(strings.TrimSpace(cfg.Level))
+	if err := utils.ConfigureLogging(format, level); err != nil {
+		return err
+	}
+	m.logRotationMu.Lock()
+	m.LogFormat, m.LogLevel = format, level
+	m.logRotationMu.Unlock()
+	m.Save()
+	return nil
+}
@@ -30,6 +30,10 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 
+	"sdsm/app/backend/internal/integrations/backuptarget"
+	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/integrations/notify"
+	"sdsm/app/backend/internal/integrations/oidc"
 	"sdsm/app/backend/internal/models"
 	"sdsm/app/backend/internal/utils"
 	"sdsm/app/backend/internal/version"
@@ -158,6 +162,24 @@ type Manager struct {
 	SCONRepoOverride       string `json:"scon_repo_override"`
 	SCONURLLinuxOverride   string `json:"scon_url_linux_override"`
 	SCONURLWindowsOverride string `json:"scon_url_windows_override"`
+	// BackupTargets lists off-host destinations that every world backup is copied to.
+	BackupTargets []backuptarget.Config `json:"backup_targets,omitempty"`
+	// NotifyChannels fans server and deployment notifications out beyond Discord (see notify_channels.go).
+	NotifyChannels []notify.Config `json:"notify_channels,omitempty"`
+	// AlertRules are threshold rules evaluated on every telemetry sample (see alerts.go).
+	// A missing key loads DefaultAlertRules; an empty list disables alerting.
+	AlertRules []AlertRule `json:"alert_rules"`
+	// OIDC enables single sign-on through an OpenID Connect provider (e.g. Keycloak).
+	OIDC oidc.Config `json:"oidc"`
+	// Metrics exposes Prometheus telemetry at /metrics (see metrics.go).
+	Metrics MetricsConfig `json:"metrics"`
+	// DiscordBot runs the two-way chat bridge and slash commands (see discord_bridge.go).
+	DiscordBot discord.BotConfig `json:"discord_bot"`
+	// LogRotation sets size/age rotation and archive retention per log kind (see log_rotation.go).
+	LogRotation LogRotationConfig `json:"log_rotation"`
+	// LogFormat is "text" (default) or "json"; LogLevel is the minimum level written (see logging.go).
+	LogFormat string `json:"log_format"`
+	LogLevel  string `json:"log_level"`
 	// Discord integration
 	// DiscordManagerWebhook is used for manager-level events (deployments, alerts)
 	DiscordManagerWebhook string `json:"discord_manager_webhook"`
@@ -222,19 +244,75 @@ type Manager struct {
 	// or through recovery flows. Handlers can set this to trigger realtime UI
 	// broadcasts so dashboards update immediately after attach.
 	OnServerAttached func(*models.Server) `json:"-"`
-	telemetryMu      sync.RWMutex
-	systemTelemetry  *models.SystemTelemetry
-	lastCPUTotal     float64
-	lastCPUIdle      float64
-	lastNetRecv      uint64
-	lastNetSent      uint64
-	lastNetSample    time.Time
-	serverCPUTimes   map[int]float64
-	telemetryStop    chan struct{}
-	telemetryWG      sync.WaitGroup
-	notificationsMu  sync.RWMutex
-	notifications    []models.DashboardNotification
-	notificationSeq  atomic.Uint64
+	// OnServerStatusChanged is an optional callback invoked when the manager changes a
+	// server's state on its own (crash detection, automatic restarts) so handlers can
+	// broadcast realtime updates.
+	OnServerStatusChanged func(*models.Server) `json:"-"`
+	telemetryMu           sync.RWMutex
+	systemTelemetry       *models.SystemTelemetry
+	lastCPUTotal          float64
+	lastCPUIdle           float64
+	lastNetRecv           uint64
+	lastNetSent           uint64
+	lastNetSample         time.Time
+	serverCPUTimes        map[int]float64
+	telemetryStop         chan struct{}
+	telemetryWG           sync.WaitGroup
+	notificationsMu       sync.RWMutex
+	notifications         []models.DashboardNotification
+	notificationSeq       atomic.Uint64
+	// Deploy counters and SCON probe results for the metrics exporter (see metrics.go)
+	metricsMu   sync.Mutex
+	deployStats map[DeployType]*DeployStats
+	sconHealth  map[int]models.SCONProbe
+	// On-disk telemetry history (see history.go)
+	historyOnce    sync.Once
+	history        *HistoryStore
+	historyErrOnce sync.Once
+	// Daily scheduled update loop (see update_scheduler.go)
+	updateSchedulerMu   sync.Mutex
+	updateSchedulerStop chan struct{}
+	updateSchedulerWG   sync.WaitGroup
+	// Per-server cron jobs (see task_scheduler.go)
+	scheduleMu        sync.Mutex
+	taskSchedulerMu   sync.Mutex
+	taskSchedulerStop chan struct{}
+	taskSchedulerWG   sync.WaitGroup
+	// Guards the mod library and per-server mod selections (see mod_library.go)
+	modMu sync.Mutex
+	// World backups (see backups.go)
+	backupMu            sync.Mutex
+	backupSchedulerMu   sync.Mutex
+	backupSchedulerStop chan struct{}
+	backupSchedulerWG   sync.WaitGroup
+	backupTargetsMu     sync.RWMutex
+	// Outbound notification channels (see notify_channels.go)
+	notifyChannelsMu sync.RWMutex
+	// Alert rule state (see alerts.go)
+	alertsMu                sync.Mutex
+	alerts                  map[string]*Alert
+	resolvedAlerts          []Alert
+	alertOutdated           []DeployType
+	alertUpdateCheckedAt    time.Time
+	alertUpdateCheckRunning bool
+	// Cross-server player index (see players.go)
+	playersOnce sync.Once
+	players     *PlayerIndex
+	// Ban records (see bans.go)
+	bansOnce         sync.Once
+	bans             *BanStore
+	bansReconcileMu  sync.Mutex
+	bansReconciledAt time.Time
+	// SDSM users, used to authorize in-game chat commands (see chat_commands.go)
+	users *UserStore
+	// Discord bot bridge (see discord_bridge.go)
+	discordBotMu   sync.Mutex
+	discordBot     *discord.Bot
+	discordBotStop context.CancelFunc
+	discordBotDone chan struct{}
+	// Log rotation and format (see log_rotation.go, logging.go)
+	logRotationMu sync.Mutex
+	webLog        *utils.RotatingFile
 }
 
 var processStartStamp = time.Now().UTC().Format("20060102150405")
@@ -422,20 +500,20 @@ func NewManagerWithConfig(configPath string) *Manager {
 		// Use current working directory as root for default config
 		cwd, err := os.Getwd()
 		if err != nil {
-			m.safeLog(fmt.Sprintf("Unable to determine current working directory for default config: %v", err))
+			m.Logger("config", 0).Error(fmt.Sprintf("Unable to determine current working directory for default config: %v", err))
 			return m
 		}
 		if err := m.bootstrapDefaultConfig(config, cwd); err != nil {
-			m.safeLog(fmt.Sprintf("Unable to create default configuration at %s: %v", config, err))
+			m.Logger("config", 0).Error(fmt.Sprintf("Unable to create default configuration at %s: %v", config, err))
 			return m
 		}
-		m.safeLog(fmt.Sprintf("Created default configuration at %s", config))
+		m.Logger("config", 0).Info(fmt.Sprintf("Created default configuration at %s", config))
 	}
 
 	m.ConfigFile = config
 	_, err := m.load()
 	if err != nil {
-		m.safeLog(err.Error())
+		m.Logger("config", 0).Error(err.Error())
 		return m
 	}
 
@@ -446,7 +524,7 @@ func NewManagerWithConfig(configPath string) *Manager {
 	}
 	m.initializeServers()
 
-	m.safeLog("Configuration loaded")
+	m.Logger("config", 0).Info("Configuration loaded")
 
 	// Check for missing components before attempting deploy
 	m.CheckMissingComponents()
@@ -470,7 +548,7 @@ func NewManagerWithConfig(configPath string) *Manager {
 		_ = m.BetaDeployed()
 
 		// Diagnostic matrix of deployed vs latest at startup to help trace why updates may be skipped.
-		m.safeLog(fmt.Sprintf("Startup update diagnostic: Release deployed=%s latest=%s | Beta deployed=%s latest=%s | BepInEx deployed=%s latest=%s | LaunchPad deployed=%s latest=%s | SCON deployed=%s latest=%s",
+		m.Logger("deploy", 0).Info(fmt.Sprintf("Startup update diagnostic: Release deployed=%s latest=%s | Beta deployed=%s latest=%s | BepInEx deployed=%s latest=%s | LaunchPad deployed=%s latest=%s | SCON deployed=%s latest=%s",
 			strings.TrimSpace(m.ReleaseDeployed()), strings.TrimSpace(m.ReleaseLatest()),
 			strings.TrimSpace(m.BetaDeployed()), strings.TrimSpace(m.BetaLatest()),
 			strings.TrimSpace(m.BepInExDeployed()), strings.TrimSpace(m.BepInExLatest()),
@@ -479,11 +557,11 @@ func NewManagerWithConfig(configPath string) *Manager {
 		))
 		types := m.ComponentsNeedingUpdate()
 		if len(types) == 0 {
-			m.safeLog("Startup update: all components are up-to-date; skipping deployment")
+			m.Logger("deploy", 0).Info("Startup update: all components are up-to-date; skipping deployment")
 		} else {
-			m.safeLog(fmt.Sprintf("Startup update: updating out-of-sync components: %v", types))
+			m.Logger("deploy", 0).Info(fmt.Sprintf("Startup update: updating out-of-sync components: %v", types))
 			if err := m.DeployTypes(types); err != nil {
-				m.safeLog(fmt.Sprintf("Startup selective deployment failed: %v", err))
+				m.Logger("deploy", 0).Error(fmt.Sprintf("Startup selective deployment failed: %v", err))
 			}
 		}
 	}
@@ -571,7 +649,7 @@ func (m *Manager) ComponentsNeedingUpdate() []DeployType {
 
 	logVerbose := func(format string, args ...interface{}) {
 		if verbose {
-			m.safeLog("UpdateEval: " + fmt.Sprintf(format, args...))
+			m.Logger("deploy", 0).Debug("UpdateEval: " + fmt.Sprintf(format, args...))
 		}
 	}
 
@@ -674,13 +752,13 @@ func (m *Manager) DeployTypes(types []DeployType) error {
 		return nil
 	}
 	if err := m.beginDeploy(); err != nil {
-		m.Log.Write(err.Error())
+		m.Logger("deploy", 0).Warn(err.Error())
 		return err
 	}
 	defer m.finishDeploy()
 
 	start := time.Now()
-	m.Log.Write(fmt.Sprintf("Selective deployment started (%v)", types))
+	m.Logger("deploy", 0).Info(fmt.Sprintf("Selective deployment started (%v)", types))
 
 	var aggErrs []string
 	for _, dt := range types {
@@ -702,10 +780,10 @@ func (m *Manager) DeployTypes(types []DeployType) error {
 	dur := time.Since(start)
 	if len(aggErrs) > 0 {
 		combined := errors.New(strings.Join(aggErrs, "; "))
-		m.Log.Write(fmt.Sprintf("Selective deployment completed with errors in %s", dur))
+		m.Logger("deploy", 0).Error(fmt.Sprintf("Selective deployment completed with errors in %s", dur))
 		return combined
 	}
-	m.Log.Write(fmt.Sprintf("Selective deployment completed successfully in %s", dur))
+	m.Logger("deploy", 0).Info(fmt.Sprintf("Selective deployment completed successfully in %s", dur))
 	return nil
 }
 
@@ -934,6 +1012,152 @@ func (m *Manager) IsServerUpdateRunning(serverID int) bool {
 	return ok && entry != nil && entry.Running
 }
 
+// serverDeploySnapshot records the component versions a server's files were copied from.
+type serverDeploySnapshot struct {
+	Timestamp         string `json:"timestamp"`
+	Beta              bool   `json:"beta"`
+	ReleaseDeployed   string `json:"release_deployed"`
+	BetaDeployed      string `json:"beta_deployed"`
+	BepInExDeployed   string `json:"bepinex_deployed"`
+	LaunchPadDeployed string `json:"launchpad_deployed"`
+	SCONDeployed      string `json:"scon_deployed"`
+}
+
+// WriteServerDeploySnapshot stores a per-server snapshot of component versions at the time
+// server files were last copied. This enables computing an "update needed" indicator later.
+func (m *Manager) WriteServerDeploySnapshot(s *models.Server) error {
+	if m == nil || s == nil {
+		return fmt.Errorf("invalid context")
+	}
+	// Resolve path helpers from server when available, otherwise from manager
+	paths := s.Paths
+	if paths == nil {
+		paths = m.Paths
+	}
+	if paths == nil {
+		return fmt.Errorf("paths unavailable")
+	}
+	// Ensure settings directory exists
+	if err := os.MkdirAll(paths.ServerSettingsDir(s.ID), 0o755); err != nil {
+		return err
+	}
+	// Build snapshot payload
+	snap := serverDeploySnapshot{
+		Timestamp:         time.Now().Format(time.RFC3339),
+		Beta:              s.Beta,
+		ReleaseDeployed:   strings.TrimSpace(m.ReleaseDeployed()),
+		BetaDeployed:      strings.TrimSpace(m.BetaDeployed()),
+		BepInExDeployed:   strings.TrimSpace(m.BepInExDeployed()),
+		LaunchPadDeployed: strings.TrimSpace(m.LaunchPadDeployed()),
+		SCONDeployed:      strings.TrimSpace(m.SCONDeployed()),
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write atomically: temp file then rename
+	dst := paths.ServerDeploySnapshotFile(s.ID)
+	tmp, err := os.CreateTemp(paths.ServerSettingsDir(s.ID), "deploy-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	// Best-effort permissions
+	_ = os.Chmod(dst, 0o644)
+	return nil
+}
+
+// ServerNeedsUpdate reports whether a server's copied files are out of sync with the
+// currently deployed components, based on its deploy snapshot. Servers without a snapshot
+// and components reporting a sentinel version are treated as up to date.
+func (m *Manager) ServerNeedsUpdate(s *models.Server) bool {
+	if m == nil || s == nil {
+		return false
+	}
+	paths := s.Paths
+	if paths == nil {
+		paths = m.Paths
+	}
+	if paths == nil {
+		return false
+	}
+	data, err := os.ReadFile(paths.ServerDeploySnapshotFile(s.ID))
+	if err != nil {
+		return false
+	}
+	var snap serverDeploySnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return false
+	}
+	isSentinel := func(v string) bool {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return true
+		}
+		switch v {
+		case "Missing", "Unknown", "Error", "Installed":
+			return true
+		}
+		return false
+	}
+	// Release/Beta channel source comparison
+	if s.Beta {
+		cur := strings.TrimSpace(m.BetaDeployed())
+		old := strings.TrimSpace(snap.BetaDeployed)
+		if !isSentinel(cur) && !isSentinel(old) && cur != old {
+			return true
+		}
+	} else {
+		cur := strings.TrimSpace(m.ReleaseDeployed())
+		old := strings.TrimSpace(snap.ReleaseDeployed)
+		if !isSentinel(cur) && !isSentinel(old) && cur != old {
+			return true
+		}
+	}
+	// BepInEx: direct string change indicates out-of-sync
+	if cur, old := strings.TrimSpace(m.BepInExDeployed()), strings.TrimSpace(snap.BepInExDeployed); !isSentinel(cur) && !isSentinel(old) {
+		if !strings.EqualFold(cur, old) {
+			return true
+		}
+	}
+	// LaunchPad: case-insensitive equality
+	if cur, old := strings.TrimSpace(m.LaunchPadDeployed()), strings.TrimSpace(snap.LaunchPadDeployed); !isSentinel(cur) && !isSentinel(old) {
+		if !strings.EqualFold(cur, old) {
+			return true
+		}
+	}
+	// SCON: normalize leading 'v' and case-insensitive
+	norm := func(v string) string {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return v
+		}
+		if v[0] == 'v' || v[0] == 'V' {
+			v = v[1:]
+		}
+		return strings.ToLower(v)
+	}
+	if curRaw, oldRaw := strings.TrimSpace(m.SCONDeployed()), strings.TrimSpace(snap.SCONDeployed); !isSentinel(curRaw) && !isSentinel(oldRaw) {
+		if norm(curRaw) != norm(oldRaw) {
+			return true
+		}
+	}
+	return false
+}
+
 func calculatePercent(processed, total int64) int {
 	if total <= 0 {
 		if processed > 0 {
@@ -975,15 +1199,9 @@ func (m *Manager) BuildTime() string {
 	return processStartStamp
 }
 
-func (m *Manager) safeLog(message string) {
-	if m.Log != nil {
-		m.Log.Write(message)
-	}
-}
-
 func (m *Manager) startLogs() {
 	if err := os.MkdirAll(m.Paths.LogsDir(), 0o755); err != nil {
-		m.safeLog(fmt.Sprintf("Unable to create logs directory %s: %v", m.Paths.LogsDir(), err))
+		m.Logger("manager", 0).Error(fmt.Sprintf("Unable to create logs directory %s: %v", m.Paths.LogsDir(), err))
 	}
 	if m.Log != nil {
 		m.Log.Close()
@@ -991,8 +1209,10 @@ func (m *Manager) startLogs() {
 	if m.UpdateLog != nil {
 		m.UpdateLog.Close()
 	}
-	m.Log = utils.NewLogger(m.Paths.LogFile())
-	m.UpdateLog = utils.NewLogger(m.Paths.UpdateLogFile())
+	m.Log = utils.NewLogger(m.Paths.LogFile()).With("manager", 0)
+	m.UpdateLog = utils.NewLogger(m.Paths.UpdateLogFile()).With("update", 0)
+	m.Log.SetRotation(m.LogRotation.Manager)
+	m.UpdateLog.SetRotation(m.LogRotation.Update)
 }
 
 func (m *Manager) LastUpdateLogLine() string {
@@ -1019,6 +1239,10 @@ func (m *Manager) LastUpdateLogLine() string {
 	if err := scanner.Err(); err != nil {
 		return ""
 	}
+	// Show JSON entries the way the text format would.
+	if entry, ok := utils.ParseLogLine(lastLine); ok && strings.HasPrefix(lastLine, "{") {
+		return entry.Time.Local().Format("2006-01-02 15:04:05") + ": " + entry.Message
+	}
 
 	return lastLine
 }
@@ -1027,11 +1251,11 @@ func (m *Manager) initializeServers() {
 	// Perform best-effort process discovery before per-server initialization when detached mode is enabled.
 	var discovered map[int]int
 	if m.DetachedServers {
-		discovered = discoverRunningServerPIDs(m.Paths, m.WindowsDiscoveryWMIEnabled, func(msg string) { m.safeLog(msg) })
+		discovered = discoverRunningServerPIDs(m.Paths, m.WindowsDiscoveryWMIEnabled, m.Logger("servers", 0))
 		if len(discovered) > 0 {
-			m.safeLog(fmt.Sprintf("Process discovery found running detached servers: %v", discovered))
+			m.Logger("servers", 0).Info(fmt.Sprintf("Process discovery found running detached servers: %v", discovered))
 		} else {
-			m.safeLog("Process discovery found no running detached server processes")
+			m.Logger("servers", 0).Info("Process discovery found no running detached server processes")
 		}
 	}
 	for _, srv := range m.Servers {
@@ -1040,9 +1264,10 @@ func (m *Manager) initializeServers() {
 		}
 		srv.Paths = m.Paths
 		if err := os.MkdirAll(m.Paths.ServerLogsDir(srv.ID), 0o755); err != nil {
-			m.safeLog(fmt.Sprintf("Failed to ensure logs directory for server %d: %v", srv.ID, err))
+			m.Logger("servers", srv.ID).Error(fmt.Sprintf("Failed to ensure logs directory for server %d: %v", srv.ID, err))
 		}
 		srv.EnsureLogger(m.Paths)
+		m.wireServerHooks(srv)
 
 		// Apply legacy-safe defaults for notification prefs: if fields are all zero-values,
 		// prefer manager defaults and enable notifications.
@@ -1056,7 +1281,7 @@ func (m *Manager) initializeServers() {
 		if m.DetachedServers {
 			if pid, ok := discovered[srv.ID]; ok && pid > 0 && models.IsPidAlive(pid) {
 				srv.AttachToRunning(pid)
-				m.safeLog(fmt.Sprintf("Attached detached server %s (ID:%d) PID %d", srv.Name, srv.ID, pid))
+				m.Logger("servers", srv.ID).Info(fmt.Sprintf("Attached detached server %s (ID:%d) PID %d", srv.Name, srv.ID, pid))
 				if m.OnServerAttached != nil {
 					m.OnServerAttached(srv)
 				}
@@ -1128,7 +1353,7 @@ func (m *Manager) load() (bool, error) {
 	}
 
 	// Create a temporary struct to unmarshal into, preserving existing Paths.
-	temp := &Manager{}
+	temp := &Manager{LogRotation: DefaultLogRotation()}
 	if err := json.Unmarshal(data, temp); err != nil {
 		return false, fmt.Errorf("error parsing configuration: %w", err)
 	}
@@ -1164,6 +1389,45 @@ func (m *Manager) load() (bool, error) {
 	m.SCONRepoOverride = strings.TrimSpace(temp.SCONRepoOverride)
 	m.SCONURLLinuxOverride = strings.TrimSpace(temp.SCONURLLinuxOverride)
 	m.SCONURLWindowsOverride = strings.TrimSpace(temp.SCONURLWindowsOverride)
+	m.BackupTargets = temp.BackupTargets
+	m.NotifyChannels = temp.NotifyChannels
+	m.AlertRules = temp.AlertRules
+	if m.AlertRules == nil {
+		m.AlertRules = DefaultAlertRules()
+	}
+	m.OIDC = temp.OIDC
+	if err := m.OIDC.Validate(); err != nil {
+		if m.Log != nil {
+			m.Logger("config", 0).Warn(fmt.Sprintf("Single sign-on disabled: %v", err))
+		}
+		m.OIDC.Enabled = false
+	}
+	m.DiscordBot = temp.DiscordBot
+	if err := m.DiscordBot.Validate(); err != nil {
+		if m.Log != nil {
+			m.Logger("config", 0).Warn(fmt.Sprintf("Discord bot disabled: %v", err))
+		}
+		m.DiscordBot.Enabled = false
+	}
+	m.LogRotation = temp.LogRotation
+	if err := m.LogRotation.Validate(); err != nil {
+		if m.Log != nil {
+			m.Logger("config", 0).Warn(fmt.Sprintf("Invalid log rotation settings, using defaults: %v", err))
+		}
+		m.LogRotation = DefaultLogRotation()
+	}
+	m.applyLogRotation()
+	m.LogFormat = strings.ToLower(strings.TrimSpace(temp.LogFormat))
+	m.LogLevel = strings.ToLower(strings.TrimSpace(temp.LogLevel))
+	if err := utils.ConfigureLogging(m.LogFormat, m.LogLevel); err != nil {
+		if m.Log != nil {
+			m.Logger("config", 0).Warn(fmt.Sprintf("Invalid logging settings, using text output at info level: %v", err))
+		}
+		m.LogFormat, m.LogLevel = "", ""
+		_ = utils.ConfigureLogging("", "")
+	}
+	m.Metrics = temp.Metrics
+	m.Metrics.BearerToken = strings.TrimSpace(m.Metrics.BearerToken)
 	// Discord integration fields
 	m.DiscordManagerWebhook = strings.TrimSpace(temp.DiscordManagerWebhook)
 	m.DiscordDefaultWebhook = strings.TrimSpace(temp.DiscordDefaultWebhook)
@@ -1325,7 +1589,7 @@ func (m *Manager) refreshManagerPortMapping() {
 	externalPort, err := utils.AddOrRefreshMapping(ctx, "tcp", m.Port, "SDSM Manager", 10*time.Minute)
 	if err != nil {
 		if m.Log != nil {
-			m.Log.Write("Manager port forward attempt failed: " + err.Error())
+			m.Logger("network", 0).Warn("Manager port forward attempt failed: " + err.Error())
 		}
 		m.ManagerPortForwardActive = false
 		m.ManagerPortForwardLastError = err.Error()
@@ -1335,7 +1599,7 @@ func (m *Manager) refreshManagerPortMapping() {
 	m.ManagerPortForwardExternalPort = externalPort
 	m.ManagerPortForwardLastError = ""
 	if m.Log != nil {
-		m.Log.Write(fmt.Sprintf("Manager port forward active: internal TCP %d -> external TCP %d", m.Port, externalPort))
+		m.Logger("network", 0).Info(fmt.Sprintf("Manager port forward active: internal TCP %d -> external TCP %d", m.Port, externalPort))
 	}
 }
 
@@ -1344,12 +1608,12 @@ func (m *Manager) deleteManagerPortMapping() {
 	ctx := context.Background()
 	if err := utils.DeleteMapping(ctx, "tcp", m.Port); err != nil {
 		if m.Log != nil {
-			m.Log.Write("Manager port forward removal failed: " + err.Error())
+			m.Logger("network", 0).Warn("Manager port forward removal failed: " + err.Error())
 		}
 		return
 	}
 	if m.Log != nil {
-		m.Log.Write("Manager port forward mapping removed")
+		m.Logger("network", 0).Info("Manager port forward mapping removed")
 	}
 	m.ManagerPortForwardActive = false
 }
@@ -1382,24 +1646,24 @@ func (m *Manager) StopManagerPortForwarding() {
 
 func (m *Manager) Save() {
 	if m.ConfigFile == "" {
-		m.Log.Write("No configuration file found. Please specify a configuration file path with --config.")
+		m.Logger("config", 0).Error("No configuration file found. Please specify a configuration file path with --config.")
 		return
 	}
 
 	data, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
-		m.Log.Write(fmt.Sprintf("Error marshaling configuration: %v", err))
+		m.Logger("config", 0).Error(fmt.Sprintf("Error marshaling configuration: %v", err))
 		return
 	}
 
 	err = os.WriteFile(m.ConfigFile, data, 0644)
 	if err != nil {
-		m.Log.Write(fmt.Sprintf("Error saving configuration: %v", err))
+		m.Logger("config", 0).Error(fmt.Sprintf("Error saving configuration: %v", err))
 		m.Active = false
 		return
 	}
 
-	m.Log.Write("Configuration saved successfully")
+	m.Logger("config", 0).Info("Configuration saved successfully")
 	m.Active = true
 }
 
@@ -1634,7 +1898,7 @@ func (m *Manager) UpdateConfig(steamID, rootPath string, port int, updateTime ti
 	restart := false
 
 	if m.ConfigFile == "" {
-		m.Log.Write(fmt.Sprintf("Configuration file not found. Saving to %s", m.Paths.RootPath))
+		m.Logger("config", 0).Warn(fmt.Sprintf("Configuration file not found. Saving to %s", m.Paths.RootPath))
 		m.ConfigFile = filepath.Join(m.Paths.RootPath, "sdsm.config")
 	}
 
@@ -1642,10 +1906,10 @@ func (m *Manager) UpdateConfig(steamID, rootPath string, port int, updateTime ti
 	m.StartupUpdate = startupUpdate
 
 	if m.Paths.RootPath != rootPath {
-		m.Log.Write(fmt.Sprintf("Root path changed from %s to %s. Redeploying...", m.Paths.RootPath, rootPath))
+		m.Logger("config", 0).Info(fmt.Sprintf("Root path changed from %s to %s. Redeploying...", m.Paths.RootPath, rootPath))
 		m.Paths.RootPath = rootPath
 		if !m.Paths.CheckRoot() {
-			m.Paths.DeployRoot(m.Log)
+			m.Paths.DeployRoot(m.Logger("deploy", 0))
 		}
 		m.startLogs()
 		m.initializeServers()
@@ -1653,23 +1917,23 @@ func (m *Manager) UpdateConfig(steamID, rootPath string, port int, updateTime ti
 	}
 
 	if m.SteamID != steamID {
-		m.Log.Write(fmt.Sprintf("Steam ID changed from %s to %s. Redeploying...", m.SteamID, steamID))
+		m.Logger("config", 0).Info(fmt.Sprintf("Steam ID changed from %s to %s. Redeploying...", m.SteamID, steamID))
 		m.SteamID = steamID
 		redeploy = true
 	}
 
 	if m.Port != port {
-		m.Log.Write(fmt.Sprintf("Port changed from %d to %d. Restarting servers...", m.Port, port))
+		m.Logger("config", 0).Info(fmt.Sprintf("Port changed from %d to %d. Restarting servers...", m.Port, port))
 		m.Port = port
 		restart = true
 	}
 
-	m.Log.Write(fmt.Sprintf("Configuration updated: Steam ID: %s, Root Path: %s, Port: %d, Update Time: %v, Startup Update: %v", m.SteamID, m.Paths.RootPath, m.Port, m.UpdateTime, m.StartupUpdate))
+	m.Logger("config", 0).Info(fmt.Sprintf("Configuration updated: Steam ID: %s, Root Path: %s, Port: %d, Update Time: %v, Startup Update: %v", m.SteamID, m.Paths.RootPath, m.Port, m.UpdateTime, m.StartupUpdate))
 	m.Save()
 
 	if redeploy {
 		if err := m.Deploy(DeployTypeAll); err != nil {
-			m.Log.Write(fmt.Sprintf("Redeploy failed: %v", err))
+			m.Logger("deploy", 0).Error(fmt.Sprintf("Redeploy failed: %v", err))
 		}
 	}
 	if restart {
@@ -1710,7 +1974,7 @@ func (m *Manager) StartDeployAsync(deployType DeployType) error {
 
 func (m *Manager) Deploy(deployType DeployType) error {
 	if err := m.beginDeploy(); err != nil {
-		m.Log.Write(err.Error())
+		m.Logger("deploy", 0).Warn(err.Error())
 		return err
 	}
 	defer m.finishDeploy()
@@ -1719,13 +1983,13 @@ func (m *Manager) Deploy(deployType DeployType) error {
 
 func (m *Manager) runDeploy(deployType DeployType) error {
 	startTime := time.Now()
-	m.Log.Write(fmt.Sprintf("Deployment (%s) started", deployType))
+	m.Logger("deploy", 0).Info(fmt.Sprintf("Deployment (%s) started", deployType))
 	if m.UpdateLog != nil {
-		m.UpdateLog.Write(fmt.Sprintf("Deployment (%s) started", deployType))
+		m.UpdateLog.Info(fmt.Sprintf("Deployment (%s) started", deployType))
 	}
 	m.notifyDeployStart(deployType)
 
-	m.Paths.DeployRoot(m.Log)
+	m.Paths.DeployRoot(m.Logger("deploy", 0))
 
 	s := steam.NewSteam(m.SteamID, m.UpdateLog, m.Paths)
 	s.SetSCONOverrides(m.SCONRepoOverride, m.SCONURLLinuxOverride, m.SCONURLWindowsOverride)
@@ -1733,19 +1997,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	var errs []string
 
 	if deployType == DeployTypeSteamCMD || deployType == DeployTypeAll {
-		m.Log.Write("Beginning SteamCMD deployment")
+		m.Logger("deploy", 0).Info("Beginning SteamCMD deployment")
 		m.progressBegin(DeployTypeSteamCMD, "Queued")
 		s.SetProgressReporter(string(DeployTypeSteamCMD), m.progressReporter(DeployTypeSteamCMD))
 		if err := s.UpdateSteamCMD(); err != nil {
 			msg := fmt.Sprintf("SteamCMD deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeSteamCMD, "Failed", err)
 		} else {
-			m.Log.Write("SteamCMD deployment completed successfully")
+			m.Logger("deploy", 0).Info("SteamCMD deployment completed successfully")
 			m.progressComplete(DeployTypeSteamCMD, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1753,19 +2017,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	}
 
 	if deployType == DeployTypeRelease || deployType == DeployTypeAll {
-		m.Log.Write("Beginning Release server deployment")
+		m.Logger("deploy", 0).Info("Beginning Release server deployment")
 		m.progressBegin(DeployTypeRelease, "Queued")
 		s.SetProgressReporter(string(DeployTypeRelease), m.progressReporter(DeployTypeRelease))
 		if err := s.UpdateGame(false); err != nil {
 			msg := fmt.Sprintf("Release deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeRelease, "Failed", err)
 		} else {
-			m.Log.Write("Release server deployment completed successfully")
+			m.Logger("deploy", 0).Info("Release server deployment completed successfully")
 			m.progressComplete(DeployTypeRelease, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1774,19 +2038,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	}
 
 	if deployType == DeployTypeBeta || deployType == DeployTypeAll {
-		m.Log.Write("Beginning Beta server deployment")
+		m.Logger("deploy", 0).Info("Beginning Beta server deployment")
 		m.progressBegin(DeployTypeBeta, "Queued")
 		s.SetProgressReporter(string(DeployTypeBeta), m.progressReporter(DeployTypeBeta))
 		if err := s.UpdateGame(true); err != nil {
 			msg := fmt.Sprintf("Beta deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeBeta, "Failed", err)
 		} else {
-			m.Log.Write("Beta server deployment completed successfully")
+			m.Logger("deploy", 0).Info("Beta server deployment completed successfully")
 			m.progressComplete(DeployTypeBeta, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1795,19 +2059,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	}
 
 	if deployType == DeployTypeBepInEx || deployType == DeployTypeAll {
-		m.Log.Write("Beginning BepInEx deployment")
+		m.Logger("deploy", 0).Info("Beginning BepInEx deployment")
 		m.progressBegin(DeployTypeBepInEx, "Queued")
 		s.SetProgressReporter(string(DeployTypeBepInEx), m.progressReporter(DeployTypeBepInEx))
 		if err := s.UpdateBepInEx(); err != nil {
 			msg := fmt.Sprintf("BepInEx deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeBepInEx, "Failed", err)
 		} else {
-			m.Log.Write("BepInEx deployment completed successfully")
+			m.Logger("deploy", 0).Info("BepInEx deployment completed successfully")
 			m.progressComplete(DeployTypeBepInEx, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1815,19 +2079,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	}
 
 	if deployType == DeployTypeLaunchPad || deployType == DeployTypeAll {
-		m.Log.Write("Beginning LaunchPad deployment")
+		m.Logger("deploy", 0).Info("Beginning LaunchPad deployment")
 		m.progressBegin(DeployTypeLaunchPad, "Queued")
 		s.SetProgressReporter(string(DeployTypeLaunchPad), m.progressReporter(DeployTypeLaunchPad))
 		if err := s.UpdateLaunchPad(); err != nil {
 			msg := fmt.Sprintf("Stationeers LaunchPad deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeLaunchPad, "Failed", err)
 		} else {
-			m.Log.Write("LaunchPad deployment completed successfully")
+			m.Logger("deploy", 0).Info("LaunchPad deployment completed successfully")
 			m.progressComplete(DeployTypeLaunchPad, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1835,19 +2099,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	}
 
 	if deployType == DeployTypeSCON || deployType == DeployTypeAll {
-		m.Log.Write("Beginning SCON deployment")
+		m.Logger("deploy", 0).Info("Beginning SCON deployment")
 		m.progressBegin(DeployTypeSCON, "Queued")
 		s.SetProgressReporter(string(DeployTypeSCON), m.progressReporter(DeployTypeSCON))
 		if err := s.UpdateSCON(); err != nil {
 			msg := fmt.Sprintf("SCON deployment failed: %v", err)
 			errs = append(errs, msg)
-			m.Log.Write(msg)
+			m.Logger("deploy", 0).Error(msg)
 			if m.UpdateLog != nil {
-				m.UpdateLog.Write(msg)
+				m.UpdateLog.Error(msg)
 			}
 			m.progressComplete(DeployTypeSCON, "Failed", err)
 		} else {
-			m.Log.Write("SCON deployment completed successfully")
+			m.Logger("deploy", 0).Info("SCON deployment completed successfully")
 			m.progressComplete(DeployTypeSCON, "Completed", nil)
 		}
 		s.SetProgressReporter("", nil)
@@ -1857,19 +2121,19 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	if deployType == DeployTypeServers || deployType == DeployTypeAll {
 		serversFailed := false
 		for _, srv := range m.Servers {
-			m.Log.Write(fmt.Sprintf("Deploying server: %s", srv.Name))
+			m.Logger("deploy", srv.ID).Info(fmt.Sprintf("Deploying server: %s", srv.Name))
 			if err := srv.Deploy(); err != nil {
 				serversFailed = true
 				msg := fmt.Sprintf("Server %s deploy failed: %v", srv.Name, err)
 				errs = append(errs, msg)
-				m.Log.Write(msg)
+				m.Logger("deploy", srv.ID).Error(msg)
 				if m.UpdateLog != nil {
-					m.UpdateLog.Write(msg)
+					m.UpdateLog.Error(msg)
 				}
 			}
 		}
 		if !serversFailed {
-			m.Log.Write("All servers deployed successfully.")
+			m.Logger("deploy", 0).Info("All servers deployed successfully.")
 		}
 	}
 
@@ -1886,18 +2150,20 @@ func (m *Manager) runDeploy(deployType DeployType) error {
 	duration := time.Since(startTime)
 	if len(errs) > 0 {
 		combined := errors.New(strings.Join(errs, "; "))
-		m.Log.Write(fmt.Sprintf("Deployment (%s) completed with errors in %s", deployType, duration))
+		m.Logger("deploy", 0).Error(fmt.Sprintf("Deployment (%s) completed with errors in %s", deployType, duration))
 		if m.UpdateLog != nil {
-			m.UpdateLog.Write(fmt.Sprintf("Deployment (%s) completed with errors in %s", deployType, duration))
+			m.UpdateLog.Error(fmt.Sprintf("Deployment (%s) completed with errors in %s", deployType, duration))
 		}
+		m.recordDeploy(deployType, duration, true)
 		m.notifyDeployComplete(deployType, duration, errs)
 		return combined
 	}
 
-	m.Log.Write(fmt.Sprintf("Deployment (%s) completed successfully in %s", deployType, duration))
+	m.Logger("deploy", 0).Info(fmt.Sprintf("Deployment (%s) completed successfully in %s", deployType, duration))
 	if m.UpdateLog != nil {
-		m.UpdateLog.Write(fmt.Sprintf("Deployment (%s) completed successfully in %s", deployType, duration))
+		m.UpdateLog.Info(fmt.Sprintf("Deployment (%s) completed successfully in %s", deployType, duration))
 	}
+	m.recordDeploy(deployType, duration, false)
 	m.notifyDeployComplete(deployType, duration, nil)
 	m.Active = true
 	return nil
@@ -2027,20 +2293,20 @@ func (m *Manager) Shutdown() {
 	// Allow HTTP response to complete before shutting down
 	time.Sleep(1000 * time.Millisecond)
 
-	m.Log.Write("Shutting down all servers.")
+	m.Logger("manager", 0).Info("Shutting down all servers.")
 	if m.DetachedServers {
-		m.Log.Write("Shutdown requested (detached mode disabled for this operation). Stopping all servers.")
+		m.Logger("manager", 0).Info("Shutdown requested (detached mode disabled for this operation). Stopping all servers.")
 	}
 	for _, srv := range m.Servers {
 		if srv.IsRunning() {
-			m.Log.Write(fmt.Sprintf("Stopping server: %s", srv.Name))
+			m.Logger("manager", srv.ID).Info(fmt.Sprintf("Stopping server: %s", srv.Name))
 			srv.Stop()
 		}
 	}
-	m.Log.Write("All servers stop sequence completed.")
+	m.Logger("manager", 0).Info("All servers stop sequence completed.")
 	m.Save()
 	m.Active = false
-	m.Log.Write("SDSM is shutting down.")
+	m.Logger("manager", 0).Info("SDSM is shutting down.")
 	// Exit the application
 	time.Sleep(1000 * time.Millisecond) // Give logs time to flush
 	os.Exit(0)
@@ -2052,20 +2318,20 @@ func (m *Manager) ExitDetached(stopServers bool) {
 	// Allow HTTP response to complete before exiting
 	time.Sleep(1000 * time.Millisecond)
 	if stopServers {
-		m.Log.Write("Detached shutdown: stopping all servers before exit.")
+		m.Logger("manager", 0).Info("Detached shutdown: stopping all servers before exit.")
 		for _, srv := range m.Servers {
 			if srv.IsRunning() {
-				m.Log.Write(fmt.Sprintf("Stopping server: %s", srv.Name))
+				m.Logger("manager", srv.ID).Info(fmt.Sprintf("Stopping server: %s", srv.Name))
 				srv.Stop()
 			}
 		}
-		m.Log.Write("All servers stopped.")
+		m.Logger("manager", 0).Info("All servers stopped.")
 	} else {
-		m.Log.Write("Detached shutdown: leaving servers running.")
+		m.Logger("manager", 0).Info("Detached shutdown: leaving servers running.")
 	}
 	m.Save()
 	m.Active = false
-	m.Log.Write("SDSM exiting now.")
+	m.Logger("manager", 0).Info("SDSM exiting now.")
 	time.Sleep(1000 * time.Millisecond)
 	os.Exit(0)
 }
@@ -2074,15 +2340,15 @@ func (m *Manager) Restart() {
 	// Allow HTTP response to complete before restarting
 	time.Sleep(1000 * time.Millisecond)
 
-	m.Log.Write("Restarting SDSM...")
-	m.Log.Write("Shutting down all servers.")
+	m.Logger("manager", 0).Info("Restarting SDSM...")
+	m.Logger("manager", 0).Info("Shutting down all servers.")
 	for _, srv := range m.Servers {
-		m.Log.Write(fmt.Sprintf("Stopping server: %s", srv.Name))
+		m.Logger("manager", srv.ID).Info(fmt.Sprintf("Stopping server: %s", srv.Name))
 		srv.Stop()
 	}
-	m.Log.Write("All servers stopped successfully.")
+	m.Logger("manager", 0).Info("All servers stopped successfully.")
 	m.Save()
-	m.Log.Write("SDSM will now restart.")
+	m.Logger("manager", 0).Info("SDSM will now restart.")
 
 	truncateLogFile := func(path string) {
 		if path == "" {
@@ -2090,7 +2356,7 @@ func (m *Manager) Restart() {
 		}
 		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o666)
 		if err != nil {
-			m.safeLog(fmt.Sprintf("Unable to truncate log file %s: %v", path, err))
+			m.Logger("manager", 0).Warn(fmt.Sprintf("Unable to truncate log file %s: %v", path, err))
 			return
 		}
 		file.Close()
@@ -2104,14 +2370,14 @@ func (m *Manager) Restart() {
 	// Get the executable path and arguments
 	executable, err := os.Executable()
 	if err != nil {
-		m.Log.Write(fmt.Sprintf("Failed to get executable path: %v", err))
+		m.Logger("manager", 0).Error(fmt.Sprintf("Failed to get executable path: %v", err))
 		os.Exit(1)
 	}
 
 	// Start new process
 	args := os.Args[1:] // Get original arguments
 	if err := utils.RestartProcess(executable, args); err != nil {
-		m.Log.Write(fmt.Sprintf("Failed to restart: %v", err))
+		m.Logger("manager", 0).Error(fmt.Sprintf("Failed to restart: %v", err))
 		os.Exit(1)
 	}
 
@@ -2808,20 +3074,41 @@ func (m *Manager) AddServer(cfg *models.ServerConfig) (*models.Server, error) {
 
 	id := m.NextID()
 	srv := models.NewServerFromConfig(id, m.Paths, cfg)
+	m.registerServer(srv)
+	return srv, nil
+}
+
+// registerServer wires a newly built server's hooks, opens its log, adds it and saves the config.
+func (m *Manager) registerServer(srv *models.Server) {
 	// Apply current manager-level detached behavior to new server instances.
 	srv.Detached = m.DetachedServers
+	m.wireServerHooks(srv)
 
 	if srv.Paths != nil {
 		if err := os.MkdirAll(srv.Paths.ServerLogsDir(srv.ID), 0o755); err != nil {
-			m.safeLog(fmt.Sprintf("Unable to create server logs directory for %s (ID: %d): %v", srv.Name, srv.ID, err))
+			m.Logger("servers", srv.ID).Error(fmt.Sprintf("Unable to create server logs directory for %s (ID: %d): %v", srv.Name, srv.ID, err))
 		}
 		srv.EnsureLogger(srv.Paths)
 	}
 
 	m.Servers = append(m.Servers, srv)
-	m.Log.Write(fmt.Sprintf("Server %s added successfully with ID %d.", srv.Name, srv.ID))
+	m.Logger("servers", srv.ID).Info(fmt.Sprintf("Server %s added successfully with ID %d.", srv.Name, srv.ID))
 	m.Save()
-	return srv, nil
+}
+
+// wireServerHooks connects a server's callbacks to the manager: crash handling, settings
+// persistence, chat moderation and chat commands, the Discord chat relay and log rotation.
+func (m *Manager) wireServerHooks(srv *models.Server) {
+	if m == nil || srv == nil {
+		return
+	}
+	m.superviseServer(srv)
+	srv.OnSettingsChanged = func(*models.Server) { m.Save() }
+	srv.OnChatBan = m.chatModerationBan
+	srv.ChatCommandAllowed = m.chatCommandAllowed
+	srv.OnRestartRequest = m.restartFromChat
+	srv.OnPlayerChat = m.relayChatToDiscord
+	srv.SetLogRotation(m.LogRotation.Server, m.LogRotation.Output)
 }
 
 func (m *Manager) ReleaseLatest() string {
@@ -2977,20 +3264,20 @@ func (m *Manager) SteamCmdVersion() string {
 
 	shouldLogCheck := cached == ""
 	if shouldLogCheck {
-		m.safeLog("Checking SteamCMD version...")
+		m.Logger("versions", 0).Debug("Checking SteamCMD version...")
 	}
 	version, err := m.fetchSteamCmdVersion()
 	if err != nil {
 		result := "Error"
 		switch {
 		case errors.Is(err, os.ErrNotExist):
-			m.safeLog(fmt.Sprintf("SteamCMD version check failed: %v", err))
+			m.Logger("versions", 0).Warn(fmt.Sprintf("SteamCMD version check failed: %v", err))
 			result = "Missing"
 		case errors.Is(err, context.DeadlineExceeded):
-			m.safeLog("SteamCMD version check timed out after 10s")
+			m.Logger("versions", 0).Warn("SteamCMD version check timed out after 10s")
 			result = "Timeout"
 		default:
-			m.safeLog(fmt.Sprintf("Failed to read SteamCMD version: %v", err))
+			m.Logger("versions", 0).Error(fmt.Sprintf("Failed to read SteamCMD version: %v", err))
 		}
 
 		m.steamCmdMu.Lock()
@@ -3007,7 +3294,7 @@ func (m *Manager) SteamCmdVersion() string {
 	m.steamCmdMu.Unlock()
 
 	if prev != version {
-		m.safeLog(fmt.Sprintf("SteamCMD version reported: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("SteamCMD version reported: %s", version))
 	}
 	return version
 }
@@ -3048,7 +3335,7 @@ func (m *Manager) BepInExLatest() string {
 
 	version, err := m.fetchBepInExLatestVersion()
 	if err != nil {
-		m.safeLog(fmt.Sprintf("Failed to fetch latest BepInEx version: %v", err))
+		m.Logger("versions", 0).Warn(fmt.Sprintf("Failed to fetch latest BepInEx version: %v", err))
 		if cached != "" {
 			return cached
 		}
@@ -3107,9 +3394,9 @@ func (m *Manager) rocketStationVersion(beta bool) string {
 			result = "Missing"
 		case errors.Is(err, errRocketStationVersionNotFound):
 			result = "Unknown"
-			m.safeLog(fmt.Sprintf("Stationeers %s installation found but build ID missing", label))
+			m.Logger("versions", 0).Warn(fmt.Sprintf("Stationeers %s installation found but build ID missing", label))
 		default:
-			m.safeLog(fmt.Sprintf("Failed to determine Stationeers %s build ID: %v", label, err))
+			m.Logger("versions", 0).Error(fmt.Sprintf("Failed to determine Stationeers %s build ID: %v", label, err))
 		}
 
 		mu.Lock()
@@ -3138,7 +3425,7 @@ func (m *Manager) rocketStationVersion(beta bool) string {
 	mu.Unlock()
 
 	if prev != version {
-		m.safeLog(fmt.Sprintf("Stationeers %s build ID: %s", label, version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("Stationeers %s build ID: %s", label, version))
 	}
 
 	return version
@@ -3163,10 +3450,10 @@ func (m *Manager) BepInExDeployed() string {
 		result := "Error"
 		switch {
 		case errors.Is(err, os.ErrNotExist):
-			m.safeLog("BepInEx version check failed: installation not found")
+			m.Logger("versions", 0).Warn("BepInEx version check failed: installation not found")
 			result = "Missing"
 		default:
-			m.safeLog(fmt.Sprintf("Failed to determine BepInEx version: %v", err))
+			m.Logger("versions", 0).Error(fmt.Sprintf("Failed to determine BepInEx version: %v", err))
 		}
 		m.bepInExMu.Lock()
 		m.bepInExVersion = result
@@ -3182,7 +3469,7 @@ func (m *Manager) BepInExDeployed() string {
 	m.bepInExMu.Unlock()
 
 	if prev != version {
-		m.safeLog(fmt.Sprintf("BepInEx version reported: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("BepInEx version reported: %s", version))
 	}
 	return version
 }
@@ -3238,7 +3525,7 @@ func (m *Manager) LaunchPadLatest() string {
 
 	version, err := m.fetchLaunchPadLatestVersion()
 	if err != nil {
-		m.safeLog(fmt.Sprintf("Failed to fetch latest Stationeers LaunchPad version: %v", err))
+		m.Logger("versions", 0).Warn(fmt.Sprintf("Failed to fetch latest Stationeers LaunchPad version: %v", err))
 		if cached != "" {
 			return cached
 		}
@@ -3252,7 +3539,7 @@ func (m *Manager) LaunchPadLatest() string {
 	m.launchPadLatestMu.Unlock()
 
 	if err == nil && prev != version {
-		m.safeLog(fmt.Sprintf("Stationeers LaunchPad latest version reported: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("Stationeers LaunchPad latest version reported: %s", version))
 	}
 
 	return version
@@ -3316,9 +3603,9 @@ func (m *Manager) LaunchPadDeployed() string {
 			result = "Missing"
 		case errors.Is(err, errLaunchPadVersionNotFound):
 			result = "Installed"
-			m.safeLog("Stationeers LaunchPad installation found but version metadata missing")
+			m.Logger("versions", 0).Warn("Stationeers LaunchPad installation found but version metadata missing")
 		default:
-			m.safeLog(fmt.Sprintf("Failed to determine Stationeers LaunchPad deployed version: %v", err))
+			m.Logger("versions", 0).Error(fmt.Sprintf("Failed to determine Stationeers LaunchPad deployed version: %v", err))
 		}
 
 		m.launchPadMu.Lock()
@@ -3335,7 +3622,7 @@ func (m *Manager) LaunchPadDeployed() string {
 	m.launchPadMu.Unlock()
 
 	if prev != version {
-		m.safeLog(fmt.Sprintf("Stationeers LaunchPad deployed version: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("Stationeers LaunchPad deployed version: %s", version))
 	}
 
 	return version
@@ -3408,7 +3695,7 @@ func (m *Manager) SCONLatest() string {
 
 	version, err := m.fetchSCONLatestVersion()
 	if err != nil {
-		m.safeLog(fmt.Sprintf("Failed to fetch latest SCON version: %v", err))
+		m.Logger("versions", 0).Warn(fmt.Sprintf("Failed to fetch latest SCON version: %v", err))
 		if cached != "" {
 			return cached
 		}
@@ -3422,7 +3709,7 @@ func (m *Manager) SCONLatest() string {
 	m.sconLatestMu.Unlock()
 
 	if err == nil && prevLatest != version {
-		m.safeLog(fmt.Sprintf("SCON latest version reported: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("SCON latest version reported: %s", version))
 	}
 
 	return version
@@ -3444,7 +3731,7 @@ func (m *Manager) SCONDeployed() string {
 		if errors.Is(err, os.ErrNotExist) {
 			result = "Missing"
 		} else {
-			m.safeLog(fmt.Sprintf("Failed to determine SCON deployed version: %v", err))
+			m.Logger("versions", 0).Error(fmt.Sprintf("Failed to determine SCON deployed version: %v", err))
 		}
 
 		m.sconMu.Lock()
@@ -3461,7 +3748,7 @@ func (m *Manager) SCONDeployed() string {
 	m.sconMu.Unlock()
 
 	if prev != version {
-		m.safeLog(fmt.Sprintf("SCON deployed version: %s", version))
+		m.Logger("versions", 0).Info(fmt.Sprintf("SCON deployed version: %s", version))
 	}
 
 	return version
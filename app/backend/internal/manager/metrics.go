@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// sconProbeInterval throttles SCON reachability checks made by the telemetry sampler.
+const sconProbeInterval = 30 * time.Second
+
+// MetricsConfig controls the Prometheus /metrics exporter.
+type MetricsConfig struct {
+	Enabled bool `json:"enabled"`
+	// BearerToken, when set, must be sent as "Authorization: Bearer <token>" by scrapers.
+	// Leave empty to allow unauthenticated scraping.
+	BearerToken string `json:"bearer_token,omitempty"`
+}
+
+// DeployStats aggregates completed deployments of one type for the metrics exporter.
+type DeployStats struct {
+	Type              DeployType
+	Runs              uint64
+	Failures          uint64
+	TotalDuration     time.Duration
+	LastDuration      time.Duration
+	LastCompletedAt   time.Time
+	LastCompletedFail bool
+}
+
+func (m *Manager) recordDeploy(deployType DeployType, duration time.Duration, failed bool) {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	if m.deployStats == nil {
+		m.deployStats = make(map[DeployType]*DeployStats)
+	}
+	stats := m.deployStats[deployType]
+	if stats == nil {
+		stats = &DeployStats{Type: deployType}
+		m.deployStats[deployType] = stats
+	}
+	stats.Runs++
+	if failed {
+		stats.Failures++
+	}
+	stats.TotalDuration += duration
+	stats.LastDuration = duration
+	stats.LastCompletedAt = time.Now()
+	stats.LastCompletedFail = failed
+}
+
+// DeployStatsSnapshot returns per-type deployment counters ordered by deploy type.
+func (m *Manager) DeployStatsSnapshot() []DeployStats {
+	if m == nil {
+		return nil
+	}
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	out := make([]DeployStats, 0, len(m.deployStats))
+	for _, dt := range []DeployType{DeployTypeAll, DeployTypeSteamCMD, DeployTypeRelease, DeployTypeBeta, DeployTypeBepInEx, DeployTypeLaunchPad, DeployTypeSCON, DeployTypeServers} {
+		if stats := m.deployStats[dt]; stats != nil {
+			out = append(out, *stats)
+		}
+	}
+	return out
+}
+
+// refreshSCONHealth probes SCON on running servers whose last probe is older than
+// sconProbeInterval and forgets results for stopped servers.
+func (m *Manager) refreshSCONHealth(srv *models.Server) {
+	if srv == nil {
+		return
+	}
+	if !srv.IsRunning() {
+		m.metricsMu.Lock()
+		delete(m.sconHealth, srv.ID)
+		m.metricsMu.Unlock()
+		return
+	}
+	m.metricsMu.Lock()
+	last, ok := m.sconHealth[srv.ID]
+	m.metricsMu.Unlock()
+	if ok && time.Since(last.CheckedAt) < sconProbeInterval {
+		return
+	}
+	probe := srv.ProbeSCON(1500 * time.Millisecond)
+	m.metricsMu.Lock()
+	if m.sconHealth == nil {
+		m.sconHealth = make(map[int]models.SCONProbe)
+	}
+	m.sconHealth[srv.ID] = probe
+	m.metricsMu.Unlock()
+}
+
+// SCONHealth returns the most recent SCON probe for a running server.
+func (m *Manager) SCONHealth(serverID int) (models.SCONProbe, bool) {
+	if m == nil {
+		return models.SCONProbe{}, false
+	}
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	probe, ok := m.sconHealth[serverID]
+	return probe, ok
+}
+
+// Authorized reports whether the Authorization header satisfies the exporter's bearer token.
+func (c MetricsConfig) Authorized(header string) bool {
+	token := strings.TrimSpace(c.BearerToken)
+	if token == "" {
+		return true
+	}
+	const prefix = "bearer "
+	header = strings.TrimSpace(header)
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(header[len(prefix):])), []byte(token)) == 1
+}
@@ -0,0 +1,235 @@
+package manager
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+const maxModArchiveEntryBytes = 512 << 20
+
+var ErrModNotFound = errors.New("mod not found")
+
+// LibraryMods returns the mods installed in the shared mod library.
+func (m *Manager) LibraryMods() ([]models.Mod, error) {
+	if m.Paths == nil {
+		return nil, errors.New("paths are not configured")
+	}
+	return models.LoadModLibrary(m.Paths.ModsLibraryDir())
+}
+
+// ImportMod installs a mod from a zip archive into the library. The archive must contain
+// About/About.xml, optionally nested under a single top-level folder. A mod whose name
+// matches an existing library entry replaces it in place and keeps its ID.
+func (m *Manager) ImportMod(archivePath string) (models.Mod, error) {
+	if m.Paths == nil {
+		return models.Mod{}, errors.New("paths are not configured")
+	}
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return models.Mod{}, fmt.Errorf("invalid mod archive: %w", err)
+	}
+	defer zr.Close()
+
+	prefix, about, err := findModAbout(&zr.Reader)
+	if err != nil {
+		return models.Mod{}, err
+	}
+	mod, err := models.ParseModAbout(about)
+	if err != nil {
+		return models.Mod{}, err
+	}
+
+	m.modMu.Lock()
+	defer m.modMu.Unlock()
+
+	library := m.Paths.ModsLibraryDir()
+	if err := os.MkdirAll(library, os.ModePerm); err != nil {
+		return models.Mod{}, err
+	}
+	existing, err := models.LoadModLibrary(library)
+	if err != nil {
+		return models.Mod{}, err
+	}
+	for _, e := range existing {
+		if mod.ID == 0 && strings.EqualFold(e.Name, mod.Name) {
+			mod.ID = e.ID
+		}
+	}
+	if mod.ID == 0 {
+		mod.ID = nextModID(library)
+	}
+
+	staging, err := os.MkdirTemp(library, ".import-")
+	if err != nil {
+		return models.Mod{}, err
+	}
+	defer os.RemoveAll(staging)
+	if err := extractModArchive(&zr.Reader, prefix, staging); err != nil {
+		return models.Mod{}, err
+	}
+
+	target := filepath.Join(library, strconv.Itoa(mod.ID))
+	if err := os.RemoveAll(target); err != nil {
+		return models.Mod{}, err
+	}
+	if err := os.Rename(staging, target); err != nil {
+		return models.Mod{}, err
+	}
+	installed, err := models.ReadModDir(target)
+	if err != nil {
+		return models.Mod{}, err
+	}
+	installed.ID = mod.ID
+	m.Logger("mods", 0).Info(fmt.Sprintf("Installed mod %d: %s %s", installed.ID, installed.Name, installed.Version))
+	return installed, nil
+}
+
+// SetServerModEnabled adds or removes a library mod from the server's selection and persists
+// the config. Stopped servers are synced immediately; running servers pick the change up on
+// their next deploy.
+func (m *Manager) SetServerModEnabled(serverID, modID int, enabled bool) error {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return ErrServerNotFound
+	}
+	if m.Paths == nil {
+		return errors.New("paths are not configured")
+	}
+	m.modMu.Lock()
+	if enabled {
+		info, err := os.Stat(filepath.Join(m.Paths.ModsLibraryDir(), strconv.Itoa(modID)))
+		if err != nil || !info.IsDir() {
+			m.modMu.Unlock()
+			return ErrModNotFound
+		}
+	}
+	ids := make([]string, 0, len(s.Mods)+1)
+	for _, id := range s.ModIDs() {
+		if id != modID {
+			ids = append(ids, strconv.Itoa(id))
+		}
+	}
+	if enabled {
+		ids = append(ids, strconv.Itoa(modID))
+	}
+	s.Mods = ids
+	var syncErr error
+	if !s.IsRunning() && !m.IsServerUpdateRunning(serverID) {
+		syncErr = s.SyncMods()
+	}
+	m.modMu.Unlock()
+	m.Save()
+	if syncErr != nil {
+		m.Logger("mods", s.ID).Error(fmt.Sprintf("Mod sync for %s failed: %v", s.Name, syncErr))
+	}
+	return syncErr
+}
+
+// findModAbout locates About/About.xml in the archive and returns the directory prefix
+// that holds the mod root along with the file contents.
+func findModAbout(zr *zip.Reader) (string, []byte, error) {
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(path.Clean(strings.ReplaceAll(f.Name, "\\", "/")), "/")
+		if !strings.EqualFold(path.Base(name), "About.xml") || !strings.EqualFold(path.Base(path.Dir(name)), "About") {
+			continue
+		}
+		prefix := path.Dir(path.Dir(name))
+		if prefix == "." {
+			prefix = ""
+		}
+		if strings.Contains(prefix, "/") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, 1<<20))
+		rc.Close()
+		if err != nil {
+			return "", nil, err
+		}
+		return prefix, data, nil
+	}
+	return "", nil, fmt.Errorf("mod archive does not contain %s", models.ModAboutPath)
+}
+
+func extractModArchive(zr *zip.Reader, prefix, dst string) error {
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(path.Clean(strings.ReplaceAll(f.Name, "\\", "/")), "/")
+		if prefix != "" {
+			if !strings.HasPrefix(name, prefix+"/") {
+				continue
+			}
+			name = strings.TrimPrefix(name, prefix+"/")
+		}
+		if name == "" || name == "." {
+			continue
+		}
+		target, err := utils.SecureJoin(dst, filepath.FromSlash(name))
+		if err != nil {
+			return fmt.Errorf("invalid path in mod archive: %s", f.Name)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		if f.UncompressedSize64 > maxModArchiveEntryBytes {
+			return fmt.Errorf("mod archive entry too large: %s", f.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	_, copyErr := io.Copy(out, io.LimitReader(rc, maxModArchiveEntryBytes))
+	if err := out.Close(); copyErr == nil {
+		copyErr = err
+	}
+	return copyErr
+}
+
+// nextModID returns one more than the highest ID-named directory in the library.
+func nextModID(library string) int {
+	next := 1
+	entries, _ := os.ReadDir(library)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if id, err := strconv.Atoi(entry.Name()); err == nil && id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
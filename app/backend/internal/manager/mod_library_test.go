@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func writeModZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+}
+
+func aboutXML(name, version string) string {
+	return "<?xml version=\"1.0\"?><ModMetadata><Name>" + name + "</Name><Author>Tester</Author><Version>" + version + "</Version><Description>Test mod</Description></ModMetadata>"
+}
+
+func TestImportModAndSyncServerMods(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Paths:      paths,
+		Servers:    []*models.Server{{ID: 1, Name: "Alpha", Paths: paths}},
+	}
+	defer mgr.Log.Close()
+
+	archive := filepath.Join(dir, "better-power.zip")
+	writeModZip(t, archive, map[string]string{
+		"BetterPower/About/About.xml":  aboutXML("Better Power", "1.0"),
+		"BetterPower/GameData/a.xml":   "a",
+		"BetterPower/GameData/old.xml": "old",
+	})
+	mod, err := mgr.ImportMod(archive)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if mod.ID != 1 || mod.Name != "Better Power" || mod.Version != "1.0" || mod.Author != "Tester" {
+		t.Fatalf("unexpected mod metadata: %+v", mod)
+	}
+
+	if err := mgr.SetServerModEnabled(1, mod.ID, true); err != nil {
+		t.Fatalf("enable failed: %v", err)
+	}
+	deployed := filepath.Join(paths.ServerModsDir(1), "1")
+	if _, err := os.Stat(filepath.Join(deployed, "GameData", "old.xml")); err != nil {
+		t.Fatalf("expected mod to be synced: %v", err)
+	}
+
+	// Re-importing a mod with the same name replaces it in place and keeps the ID.
+	writeModZip(t, archive, map[string]string{
+		"About/About.xml": aboutXML("Better Power", "1.1"),
+		"GameData/a.xml":  "a2",
+	})
+	updated, err := mgr.ImportMod(archive)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if updated.ID != mod.ID || updated.Version != "1.1" {
+		t.Fatalf("expected in-place update, got %+v", updated)
+	}
+	if err := mgr.ServerByID(1).SyncMods(); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(deployed, "GameData", "old.xml")); !os.IsNotExist(err) {
+		t.Fatalf("expected stale mod file to be removed, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(deployed, "GameData", "a.xml")); string(data) != "a2" {
+		t.Fatalf("expected updated mod file, got %q", data)
+	}
+
+	if err := mgr.SetServerModEnabled(1, mod.ID, false); err != nil {
+		t.Fatalf("disable failed: %v", err)
+	}
+	if _, err := os.Stat(deployed); !os.IsNotExist(err) {
+		t.Fatalf("expected deselected mod to be removed, got %v", err)
+	}
+	if err := mgr.SetServerModEnabled(1, 42, true); !errors.Is(err, ErrModNotFound) {
+		t.Fatalf("expected ErrModNotFound, got %v", err)
+	}
+}
+
+func TestImportModRejectsArchivesWithoutAbout(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{Paths: utils.NewPaths(dir)}
+	archive := filepath.Join(dir, "bad.zip")
+	writeModZip(t, archive, map[string]string{"readme.txt": "hi"})
+	if _, err := mgr.ImportMod(archive); err == nil {
+		t.Fatalf("expected error for archive without About.xml")
+	}
+}
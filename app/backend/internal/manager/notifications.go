@@ -8,6 +8,7 @@ import (
 	"unicode"
 
 	"sdsm/app/backend/internal/integrations/discord"
+	"sdsm/app/backend/internal/integrations/notify"
 	"sdsm/app/backend/internal/models"
 )
 
@@ -41,7 +42,7 @@ func (m *Manager) DiscordNotifyTo(webhook, content string, embeds ...discord.Emb
 	}
 	status, err := discord.Post(wh, payload)
 	if err != nil || status < 200 || status >= 300 {
-		m.Log.Write(fmt.Sprintf("Discord notify failed (status=%d): %v", status, err))
+		m.Logger("notify", 0).Warn(fmt.Sprintf("Discord notify failed (status=%d): %v", status, err))
 	}
 }
 
@@ -70,10 +71,7 @@ func (m *Manager) deployNotificationsEnabled(dt DeployType) bool {
 }
 
 func (m *Manager) notifyDeployStart(dt DeployType) {
-	if m == nil || !m.NotifyEnableDeploy || !m.deployNotificationsEnabled(dt) {
-		return
-	}
-	if !m.NotifyDeployOnStarted {
+	if m == nil {
 		return
 	}
 	// Tokens: component, status, timestamp
@@ -88,24 +86,27 @@ func (m *Manager) notifyDeployStart(dt DeployType) {
 	if strings.TrimSpace(msg) == "" {
 		msg = fmt.Sprintf("Deployment started: %s", dt)
 	}
-	color := parseHexColor(m.NotifyColorDeployStarted, 0x2563EB)
 	title := fmt.Sprintf("Deployment: %s", dt)
+	m.fanOutNotification(notify.Message{
+		Event:     "deploy-started",
+		Kind:      models.NotificationKindInfo,
+		Title:     title,
+		Text:      msg,
+		Timestamp: time.Now().UTC(),
+	}, tokens)
+	if !m.NotifyEnableDeploy || !m.deployNotificationsEnabled(dt) || !m.NotifyDeployOnStarted {
+		return
+	}
+	color := parseHexColor(m.NotifyColorDeployStarted, 0x2563EB)
 	embed := discord.NewEmbed(title, msg, color, "SDSM")
 	m.DiscordNotify("", embed)
 }
 
 func (m *Manager) notifyDeployComplete(dt DeployType, duration time.Duration, errs []string) {
-	if m == nil || !m.NotifyEnableDeploy || !m.deployNotificationsEnabled(dt) {
+	if m == nil {
 		return
 	}
 	isError := len(errs) > 0
-	if isError {
-		if !m.NotifyDeployOnCompletedError {
-			return
-		}
-	} else if !m.NotifyDeployOnCompleted {
-		return
-	}
 	durStr := duration.Truncate(time.Millisecond).String()
 	tokens := map[string]string{
 		"component": string(dt),
@@ -114,7 +115,9 @@ func (m *Manager) notifyDeployComplete(dt DeployType, duration time.Duration, er
 	}
 	var msg string
 	var colorHex string
+	event, kind := "deploy-completed", models.NotificationKindSuccess
 	if isError {
+		event, kind = "deploy-failed", models.NotificationKindDanger
 		tokens["status"] = "completed-error"
 		tokens["errors"] = strings.Join(errs, "; ")
 		msg = renderTemplate(m.NotifyMsgDeployCompletedError, tokens)
@@ -131,8 +134,27 @@ func (m *Manager) notifyDeployComplete(dt DeployType, duration time.Duration, er
 			msg = fmt.Sprintf("Deployment completed: %s in %s", dt, durStr)
 		}
 	}
-	color := parseHexColor(colorHex, defaultColorForEvent("update-completed"))
 	title := fmt.Sprintf("Deployment: %s", dt)
+	m.fanOutNotification(notify.Message{
+		Event:     event,
+		Kind:      kind,
+		Title:     title,
+		Text:      msg,
+		Timestamp: time.Now().UTC(),
+	}, tokens)
+
+	// Discord keeps its own per-component and per-outcome toggles.
+	if !m.NotifyEnableDeploy || !m.deployNotificationsEnabled(dt) {
+		return
+	}
+	if isError {
+		if !m.NotifyDeployOnCompletedError {
+			return
+		}
+	} else if !m.NotifyDeployOnCompleted {
+		return
+	}
+	color := parseHexColor(colorHex, defaultColorForEvent("update-completed"))
 	embed := discord.NewEmbed(title, msg, color, "SDSM")
 	m.DiscordNotify("", embed)
 }
@@ -144,6 +166,7 @@ func (m *Manager) NotifyServerEvent(s *models.Server, event, detail string) {
 	}
 	msgTemplate, colorHex := m.effectiveTemplateAndColor(s, event)
 	ts := map[string]string{
+		"server_id":   strconv.Itoa(s.ID),
 		"server_name": s.Name,
 		"event":       event,
 		"detail":      detail,
@@ -163,10 +186,19 @@ func (m *Manager) NotifyServerEvent(s *models.Server, event, detail string) {
 	}
 	kind := notificationKindForEvent(event)
 	m.enqueueDashboardNotification(kind, event, fmt.Sprintf("%s %s", s.Name, label), rendered, s.ID, "server")
+	title := fmt.Sprintf("Server %s: %s", s.Name, event)
+	m.fanOutNotification(notify.Message{
+		Event:      event,
+		Kind:       kind,
+		Title:      title,
+		Text:       rendered,
+		ServerID:   s.ID,
+		ServerName: s.Name,
+		Timestamp:  time.Now().UTC(),
+	}, ts)
 	if !m.shouldNotifyServerEvent(s, event) {
 		return
 	}
-	title := fmt.Sprintf("Server %s: %s", s.Name, event)
 	color := parseHexColor(colorHex, defaultColorForEvent(event))
 	embed := discord.NewEmbed(title, rendered, color, "SDSM")
 	target := strings.TrimSpace(s.DiscordWebhook)
@@ -314,11 +346,11 @@ func (m *Manager) enqueueDashboardNotification(kind, event, title, message strin
 
 func notificationKindForEvent(event string) string {
 	switch event {
-	case "started", "update-completed":
+	case "started", "update-completed", "restore-completed", "backup-offsite":
 		return models.NotificationKindSuccess
 	case "stopping", "restart-scheduled", "restart-pending", "update-started":
 		return models.NotificationKindWarning
-	case "stopped", "update-failed":
+	case "stopped", "crashed", "update-failed", "restore-failed", "backup-offsite-failed":
 		return models.NotificationKindDanger
 	default:
 		return models.NotificationKindInfo
@@ -397,15 +429,15 @@ func defaultColorForEvent(event string) int {
 		return 0x16A34A
 	case "stopping":
 		return 0xF59E0B
-	case "stopped":
+	case "stopped", "crashed":
 		return 0xDC2626
 	case "restart-scheduled", "restart-pending", "restarting":
 		return 0xF59E0B
 	case "update-started":
 		return 0x2563EB
-	case "update-completed":
+	case "update-completed", "restore-completed", "backup-offsite":
 		return 0x16A34A
-	case "update-failed":
+	case "update-failed", "restore-failed", "backup-offsite-failed":
 		return 0xDC2626
 	default:
 		return 0x2563EB
@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/notify"
+)
+
+const notifyChannelTimeout = 15 * time.Second
+
+// NotifyChannelConfigs returns a copy of the configured notification channels.
+func (m *Manager) NotifyChannelConfigs() []notify.Config {
+	m.notifyChannelsMu.RLock()
+	defer m.notifyChannelsMu.RUnlock()
+	return append([]notify.Config(nil), m.NotifyChannels...)
+}
+
+// SetNotifyChannels validates and replaces the channel list. A channel submitted without
+// credentials keeps the stored credentials of the existing channel with the same name,
+// so redacted configs can be round-tripped through the API, but only while its
+// connection fields are unchanged; a moved channel must be given its secret again.
+func (m *Manager) SetNotifyChannels(cfgs []notify.Config) error {
+	m.notifyChannelsMu.Lock()
+	existing := make(map[string]notify.Config, len(m.NotifyChannels))
+	for _, cfg := range m.NotifyChannels {
+		existing[strings.ToLower(cfg.Name)] = cfg
+	}
+	seen := make(map[string]bool, len(cfgs))
+	out := make([]notify.Config, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		cfg.Name = strings.TrimSpace(cfg.Name)
+		cfg.Type = strings.ToLower(strings.TrimSpace(cfg.Type))
+		key := strings.ToLower(cfg.Name)
+		if seen[key] {
+			m.notifyChannelsMu.Unlock()
+			return fmt.Errorf("duplicate channel name %q", cfg.Name)
+		}
+		seen[key] = true
+		if prev, ok := existing[key]; ok && !cfg.HasSecret() && cfg.SameConnection(prev) {
+			cfg.Secret = prev.Secret
+			cfg.Token = prev.Token
+			cfg.Password = prev.Password
+		}
+		if err := cfg.Validate(); err != nil {
+			m.notifyChannelsMu.Unlock()
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		out = append(out, cfg)
+	}
+	m.NotifyChannels = out
+	m.notifyChannelsMu.Unlock()
+	m.Save()
+	return nil
+}
+
+// TestNotifyChannel sends a test message to the named channel regardless of its event filter.
+func (m *Manager) TestNotifyChannel(ctx context.Context, name string) error {
+	for _, cfg := range m.NotifyChannelConfigs() {
+		if !strings.EqualFold(cfg.Name, strings.TrimSpace(name)) {
+			continue
+		}
+		n, err := notify.New(cfg)
+		if err != nil {
+			return err
+		}
+		tokens := map[string]string{
+			"event":     "test",
+			"detail":    "This is a test notification from SDSM.",
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		}
+		msg := channelMessage(cfg, notify.Message{
+			Event:     "test",
+			Kind:      "info",
+			Title:     "SDSM test notification",
+			Text:      tokens["detail"],
+			Timestamp: time.Now().UTC(),
+		}, tokens)
+		return n.Send(ctx, msg)
+	}
+	return errors.New("notification channel not found")
+}
+
+// fanOutNotification delivers msg to every enabled channel subscribed to its event.
+// Delivery runs in the background; failures are logged per channel.
+func (m *Manager) fanOutNotification(msg notify.Message, tokens map[string]string) {
+	if m == nil {
+		return
+	}
+	var targets []notify.Config
+	for _, cfg := range m.NotifyChannelConfigs() {
+		if cfg.Wants(msg.Event, msg.ServerID) {
+			targets = append(targets, cfg)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	go m.deliverNotification(targets, msg, tokens)
+}
+
+func (m *Manager) deliverNotification(targets []notify.Config, msg notify.Message, tokens map[string]string) {
+	for _, cfg := range targets {
+		n, err := notify.New(cfg)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyChannelTimeout)
+			err = n.Send(ctx, channelMessage(cfg, msg, tokens))
+			cancel()
+		}
+		if err != nil {
+			m.Logger("notify", 0).Warn(fmt.Sprintf("Notification %q to %s channel %s failed: %v", msg.Event, cfg.Type, cfg.Name, err))
+		}
+	}
+}
+
+// channelMessage applies the channel's template, if any, using the renderTemplate tokens.
+// Webhook templates produce the raw JSON body, so token values are JSON-escaped first.
+func channelMessage(cfg notify.Config, msg notify.Message, tokens map[string]string) notify.Message {
+	if strings.TrimSpace(cfg.Template) == "" {
+		return msg
+	}
+	all := make(map[string]string, len(tokens)+4)
+	for k, v := range tokens {
+		all[k] = v
+	}
+	all["title"] = msg.Title
+	all["message"] = msg.Text
+	all["kind"] = msg.Kind
+	all["server_id"] = strconv.Itoa(msg.ServerID)
+	if cfg.Type == notify.TypeWebhook {
+		for k, v := range all {
+			all[k] = jsonEscape(v)
+		}
+		msg.Raw = true
+	}
+	msg.Text = renderTemplate(cfg.Template, all)
+	return msg
+}
+
+func jsonEscape(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b[1 : len(b)-1])
+}
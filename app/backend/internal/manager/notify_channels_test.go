@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/integrations/notify"
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestNotifyServerEventFansOutWithFilters(t *testing.T) {
+	bodies := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies <- r.URL.Path + " " + string(b)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	mgr := &Manager{
+		Log: utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		NotifyChannels: []notify.Config{
+			{Name: "crashes", Type: notify.TypeWebhook, Enabled: true, URL: srv.URL + "/crashes", Events: []string{"crashed"},
+				Template: `{"text":"{{server_name}}: {{detail}}"}`},
+			{Name: "deploys", Type: notify.TypeSlack, Enabled: true, URL: srv.URL + "/deploys", Events: []string{"deploy-*"}},
+		},
+	}
+	defer mgr.Log.Close()
+	s := &models.Server{ID: 1, Name: `Mars "Base"`}
+
+	mgr.NotifyServerEvent(s, "started", "")
+	mgr.NotifyServerEvent(s, "crashed", "exit code 1")
+	select {
+	case got := <-bodies:
+		var payload map[string]string
+		if err := json.Unmarshal([]byte(got[len("/crashes "):]), &payload); err != nil || got[:9] != "/crashes " {
+			t.Fatalf("unexpected delivery %q (%v)", got, err)
+		}
+		if payload["text"] != `Mars "Base": exit code 1` {
+			t.Fatalf("template not rendered with escaped tokens: %q", payload["text"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash notification not delivered")
+	}
+
+	mgr.notifyDeployComplete(DeployTypeSCON, time.Second, nil)
+	select {
+	case got := <-bodies:
+		if got[:9] != "/deploys " {
+			t.Fatalf("deploy event delivered to wrong channel: %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("deploy notification not delivered")
+	}
+	select {
+	case got := <-bodies:
+		t.Fatalf("unexpected extra delivery %q", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSetNotifyChannelsKeepsStoredSecrets(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile:     filepath.Join(dir, "sdsm.config"),
+		Log:            utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		NotifyChannels: []notify.Config{{Name: "push", Type: notify.TypeGotify, URL: "https://push.example.org", Token: "app-token"}},
+	}
+	defer mgr.Log.Close()
+	if err := mgr.SetNotifyChannels([]notify.Config{mgr.NotifyChannels[0].Redacted()}); err != nil {
+		t.Fatal(err)
+	}
+	if got := mgr.NotifyChannelConfigs()[0].Token; got != "app-token" {
+		t.Fatalf("expected stored token to be kept, got %q", got)
+	}
+	moved := mgr.NotifyChannels[0].Redacted()
+	moved.URL = "https://attacker.example.net"
+	if err := mgr.SetNotifyChannels([]notify.Config{moved}); err == nil {
+		t.Fatal("expected a moved channel without a token to be rejected")
+	}
+	if got := mgr.NotifyChannelConfigs()[0]; got.URL != "https://push.example.org" || got.Token != "app-token" {
+		t.Fatalf("rejected update must leave the stored channel untouched, got %+v", got)
+	}
+	if err := mgr.SetNotifyChannels([]notify.Config{{Name: "mail", Type: notify.TypeSMTP}}); err == nil {
+		t.Fatal("expected validation error for incomplete smtp channel")
+	}
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OIDCPasswordHash marks accounts that can only sign in through single sign-on; it is not
+// a valid bcrypt hash, so password logins always fail for them.
+const OIDCPasswordHash = "!oidc"
+
+var usernameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9._@-]+`)
+
+// UpsertOIDCUser finds the user linked to issuer/subject or creates one just in time, and
+// sets its role from the identity provider. New users take the preferred username when it
+// is free; local accounts with the same name are never linked, a suffix is added instead.
+func (s *UserStore) UpsertOIDCUser(issuer, subject, preferred string, role Role) (*User, error) {
+	if issuer == "" || subject == "" {
+		return nil, errors.New("issuer and subject required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.roleLocked(role); !ok {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	for _, u := range s.users {
+		if u.OIDCIssuer == issuer && u.OIDCSubject == subject {
+			if u.Role != role {
+				u.Role = role
+				if err := s.saveLocked(); err != nil {
+					return nil, err
+				}
+			}
+			cp := *u
+			return &cp, nil
+		}
+	}
+	base := strings.Trim(usernameUnsafe.ReplaceAllString(strings.TrimSpace(preferred), "-"), "-")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if len(base) < 3 {
+		base = "sso-" + base
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := s.users[name]; !taken {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	u := &User{Username: name, PasswordHash: OIDCPasswordHash, Role: role, CreatedAt: time.Now(), OIDCIssuer: issuer, OIDCSubject: subject}
+	s.users[name] = u
+	if err := s.saveLocked(); err != nil {
+		delete(s.users, name)
+		return nil, err
+	}
+	cp := *u
+	return &cp, nil
+}
@@ -0,0 +1,336 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Permission names a single action a user may perform. Permissions prefixed with
+// "server." or "saves." apply per server; the rest are manager-wide.
+type Permission string
+
+const (
+	PermServerView     Permission = "server.view"
+	PermServerStart    Permission = "server.start"
+	PermServerStop     Permission = "server.stop"
+	PermServerRestart  Permission = "server.restart"
+	PermServerConsole  Permission = "server.console"
+	PermServerKick     Permission = "server.kick"
+	PermServerBan      Permission = "server.ban"
+	PermServerSave     Permission = "server.save"
+	PermServerBackup   Permission = "server.backup"
+	PermServerSettings Permission = "server.settings"
+	PermServerUpdate   Permission = "server.update"
+	PermServerDelete   Permission = "server.delete"
+	PermSavesDelete    Permission = "saves.delete"
+	PermSavesDownload  Permission = "saves.download"
+	PermServersCreate  Permission = "servers.create"
+	PermServersBulk    Permission = "servers.bulk"
+	PermManagerUpdate  Permission = "manager.update"
+	PermManagerConfig  Permission = "manager.config"
+	PermUsersManage    Permission = "users.manage"
+	PermAuditView      Permission = "audit.view"
+)
+
+// AllPermissions lists every permission in display order.
+var AllPermissions = []Permission{
+	PermServerView, PermServerStart, PermServerStop, PermServerRestart, PermServerConsole,
+	PermServerKick, PermServerBan, PermServerSave, PermServerBackup, PermServerSettings,
+	PermServerUpdate, PermServerDelete, PermSavesDelete, PermSavesDownload,
+	PermServersCreate, PermServersBulk, PermManagerUpdate, PermManagerConfig, PermUsersManage, PermAuditView,
+}
+
+// ServerScoped reports whether the permission is granted per server.
+func (p Permission) ServerScoped() bool {
+	return strings.HasPrefix(string(p), "server.") || strings.HasPrefix(string(p), "saves.")
+}
+
+// ValidPermission reports whether p is a known permission.
+func ValidPermission(p Permission) bool {
+	for _, known := range AllPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet is a lookup of granted permissions.
+type PermissionSet map[Permission]bool
+
+// Has reports whether perm is granted.
+func (ps PermissionSet) Has(perm Permission) bool { return ps[perm] }
+
+// HasAny reports whether any of perms is granted.
+func (ps PermissionSet) HasAny(perms ...Permission) bool {
+	for _, p := range perms {
+		if ps[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// FullPermissionSet grants every permission.
+func FullPermissionSet() PermissionSet {
+	ps := make(PermissionSet, len(AllPermissions))
+	for _, p := range AllPermissions {
+		ps[p] = true
+	}
+	return ps
+}
+
+// RoleDefinition bundles permissions under a role name.
+type RoleDefinition struct {
+	Name        Role         `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	BuiltIn     bool         `json:"built_in,omitempty"`
+}
+
+var operatorPermissions = []Permission{
+	PermServerView, PermServerStart, PermServerStop, PermServerRestart, PermServerConsole,
+	PermServerKick, PermServerBan, PermServerSave, PermServerBackup, PermSavesDelete, PermSavesDownload,
+}
+
+// builtInRoles keeps the historical behaviour of the fixed roles.
+var builtInRoles = map[Role]RoleDefinition{
+	RoleAdmin:    {Name: RoleAdmin, Description: "Full access to every server and manager setting.", Permissions: AllPermissions, BuiltIn: true},
+	RoleOperator: {Name: RoleOperator, Description: "Runs and moderates assigned servers.", Permissions: operatorPermissions, BuiltIn: true},
+	RoleViewer:   {Name: RoleViewer, Description: "Read-only access to assigned servers.", Permissions: []Permission{PermServerView}, BuiltIn: true},
+	RoleUser:     {Name: RoleUser, Description: "Read-only access to assigned servers.", Permissions: []Permission{PermServerView}, BuiltIn: true},
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// loadRolesLocked reads custom role definitions. Caller MUST hold s.mu.
+func (s *UserStore) loadRolesLocked() error {
+	s.roles = make(map[Role]*RoleDefinition)
+	if s.rolesPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.rolesPath)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(data) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var list []*RoleDefinition
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, r := range list {
+		if r == nil || r.Name == "" {
+			continue
+		}
+		if _, builtIn := builtInRoles[r.Name]; builtIn {
+			continue
+		}
+		r.BuiltIn = false
+		s.roles[r.Name] = r
+	}
+	return nil
+}
+
+// saveRolesLocked writes custom roles atomically. Caller MUST hold s.mu.
+func (s *UserStore) saveRolesLocked() error {
+	list := make([]*RoleDefinition, 0, len(s.roles))
+	for _, r := range s.roles {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.rolesPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.rolesPath)
+}
+
+func (s *UserStore) roleLocked(name Role) (RoleDefinition, bool) {
+	if r, ok := builtInRoles[name]; ok {
+		return r, true
+	}
+	if r, ok := s.roles[name]; ok && r != nil {
+		return *r, true
+	}
+	return RoleDefinition{}, false
+}
+
+func (s *UserStore) roleHasLocked(name Role, perm Permission) bool {
+	r, ok := s.roleLocked(name)
+	if !ok {
+		return false
+	}
+	for _, p := range r.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles returns the built-in and custom role definitions sorted by name.
+func (s *UserStore) Roles() []RoleDefinition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]RoleDefinition, 0, len(builtInRoles)+len(s.roles))
+	for _, r := range builtInRoles {
+		out = append(out, r)
+	}
+	for _, r := range s.roles {
+		cp := *r
+		cp.Permissions = append([]Permission(nil), r.Permissions...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// RoleExists reports whether name is a built-in or custom role.
+func (s *UserStore) RoleExists(name Role) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.roleLocked(name)
+	return ok
+}
+
+// SaveRole creates or replaces a custom role. Built-in roles cannot be changed.
+func (s *UserStore) SaveRole(def RoleDefinition) error {
+	def.Name = Role(strings.ToLower(strings.TrimSpace(string(def.Name))))
+	if !roleNamePattern.MatchString(string(def.Name)) {
+		return errors.New("role name must be 2-32 lowercase letters, digits, '-' or '_'")
+	}
+	if _, builtIn := builtInRoles[def.Name]; builtIn {
+		return fmt.Errorf("%s is a built-in role", def.Name)
+	}
+	seen := make(map[Permission]bool, len(def.Permissions))
+	perms := make([]Permission, 0, len(def.Permissions))
+	for _, p := range def.Permissions {
+		if !ValidPermission(p) {
+			return fmt.Errorf("unknown permission %q", p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			perms = append(perms, p)
+		}
+	}
+	def.Permissions = perms
+	def.Description = strings.TrimSpace(def.Description)
+	def.BuiltIn = false
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[def.Name] = &def
+	return s.saveRolesLocked()
+}
+
+// DeleteRole removes a custom role that is not assigned to any user.
+func (s *UserStore) DeleteRole(name Role) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, builtIn := builtInRoles[name]; builtIn {
+		return fmt.Errorf("%s is a built-in role", name)
+	}
+	if _, ok := s.roles[name]; !ok {
+		return errors.New("role not found")
+	}
+	for _, u := range s.users {
+		if u.Role == name {
+			return fmt.Errorf("role is assigned to %s", u.Username)
+		}
+		for _, r := range u.ServerRoles {
+			if r == name {
+				return fmt.Errorf("role is assigned to %s", u.Username)
+			}
+		}
+	}
+	delete(s.roles, name)
+	return s.saveRolesLocked()
+}
+
+// SetServerRole assigns a role to the user on one server; an empty role removes the override.
+func (s *UserStore) SetServerRole(username string, serverID int, role Role) error {
+	if serverID <= 0 {
+		return errors.New("invalid server id")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return errors.New("user not found")
+	}
+	if role == "" {
+		delete(u.ServerRoles, serverID)
+		if len(u.ServerRoles) == 0 {
+			u.ServerRoles = nil
+		}
+		return s.saveLocked()
+	}
+	if role == RoleAdmin {
+		return errors.New("admin cannot be assigned per server")
+	}
+	if _, ok := s.roleLocked(role); !ok {
+		return errors.New("unknown role")
+	}
+	if u.ServerRoles == nil {
+		u.ServerRoles = make(map[int]Role)
+	}
+	u.ServerRoles[serverID] = role
+	return s.saveLocked()
+}
+
+// HasPermission reports whether username holds perm. For server-scoped permissions
+// serverID selects the server: a per-server role replaces the global role there, and
+// otherwise the global role applies to servers the user is assigned to. A serverID of
+// 0 asks about manager-wide permissions.
+func (s *UserStore) HasPermission(username string, serverID int, perm Permission) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.users[username]
+	if !ok {
+		return false
+	}
+	if u.Role == RoleAdmin {
+		return true
+	}
+	if !perm.ServerScoped() || serverID <= 0 {
+		return s.roleHasLocked(u.Role, perm)
+	}
+	if role, ok := u.ServerRoles[serverID]; ok {
+		return s.roleHasLocked(role, perm)
+	}
+	return canAccessLocked(u, serverID) && s.roleHasLocked(u.Role, perm)
+}
+
+// Permissions returns the permissions username holds on serverID (see HasPermission).
+func (s *UserStore) Permissions(username string, serverID int) PermissionSet {
+	ps := make(PermissionSet)
+	for _, p := range AllPermissions {
+		if s.HasPermission(username, serverID, p) {
+			ps[p] = true
+		}
+	}
+	return ps
+}
+
+func canAccessLocked(u *User, serverID int) bool {
+	if u.AssignedAllServers {
+		return true
+	}
+	for _, id := range u.AssignedServers {
+		if id == serverID {
+			return true
+		}
+	}
+	return false
+}
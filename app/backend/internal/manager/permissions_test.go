@@ -0,0 +1,123 @@
+package manager
+
+import (
+	"testing"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestCustomRolePermissions(t *testing.T) {
+	paths := utils.NewPaths(t.TempDir())
+	store := NewUserStore(paths)
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	moderator := RoleDefinition{Name: "Moderator", Permissions: []Permission{PermServerView, PermServerKick, PermServerBan, PermServerKick}}
+	if err := store.SaveRole(moderator); err != nil {
+		t.Fatalf("SaveRole: %v", err)
+	}
+	if err := store.SaveRole(RoleDefinition{Name: RoleOperator}); err == nil {
+		t.Fatalf("expected built-in role to be refused")
+	}
+	if err := store.SaveRole(RoleDefinition{Name: "bogus", Permissions: []Permission{"server.explode"}}); err == nil {
+		t.Fatalf("expected unknown permission to be refused")
+	}
+
+	if _, err := store.CreateUser("mod", "hash", "moderator"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetAssignments("mod", false, []int{1}); err != nil {
+		t.Fatal(err)
+	}
+	if !store.HasPermission("mod", 1, PermServerBan) || !store.HasPermission("mod", 1, PermServerKick) {
+		t.Fatalf("moderator should kick and ban on assigned server")
+	}
+	if store.HasPermission("mod", 1, PermSavesDelete) || store.HasPermission("mod", 1, PermServerStop) {
+		t.Fatalf("moderator must not delete saves or stop the server")
+	}
+	if store.HasPermission("mod", 2, PermServerKick) {
+		t.Fatalf("moderator must not act on unassigned servers")
+	}
+	if store.HasPermission("mod", 0, PermUsersManage) {
+		t.Fatalf("moderator must not hold manager-wide permissions")
+	}
+
+	// Roles survive a reload.
+	reloaded := NewUserStore(paths)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.RoleExists("moderator") || !reloaded.HasPermission("mod", 1, PermServerBan) {
+		t.Fatalf("custom role not persisted")
+	}
+
+	if err := store.DeleteRole("moderator"); err == nil {
+		t.Fatalf("expected assigned role to be kept")
+	}
+	if err := store.SetRole("mod", RoleViewer); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteRole("moderator"); err != nil {
+		t.Fatalf("DeleteRole: %v", err)
+	}
+}
+
+func TestServerRoleOverride(t *testing.T) {
+	store := NewUserStore(utils.NewPaths(t.TempDir()))
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateUser("viewer", "hash", RoleViewer); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetServerRole("viewer", 4, RoleAdmin); err == nil {
+		t.Fatalf("expected admin to be refused per server")
+	}
+	if err := store.SetServerRole("viewer", 4, RoleOperator); err != nil {
+		t.Fatalf("SetServerRole: %v", err)
+	}
+	if !store.CanAccess("viewer", 4) || !store.HasPermission("viewer", 4, PermServerStart) {
+		t.Fatalf("per-server operator role should grant start on server 4")
+	}
+	if store.CanAccess("viewer", 5) || store.HasPermission("viewer", 5, PermServerView) {
+		t.Fatalf("override must not leak to other servers")
+	}
+	perms := store.Permissions("viewer", 4)
+	if !perms.HasAny(PermServerBan) || perms.Has(PermServerDelete) {
+		t.Fatalf("unexpected permission set %v", perms)
+	}
+	if err := store.SetServerRole("viewer", 4, ""); err != nil {
+		t.Fatal(err)
+	}
+	if store.HasPermission("viewer", 4, PermServerStart) {
+		t.Fatalf("override should be removed")
+	}
+}
+
+func TestSteamIDHasPermission(t *testing.T) {
+	store := NewUserStore(utils.NewPaths(t.TempDir()))
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"op", "other"} {
+		if _, err := store.CreateUser(name, "hash", RoleOperator); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SetAssignments("op", false, []int{1}); err != nil {
+		t.Fatal(err)
+	}
+	const steamID = "76561198000000001"
+	if err := store.SetSteamID("op", steamID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetSteamID("other", steamID); err == nil {
+		t.Fatal("expected a SteamID to link to one user only")
+	}
+	if !store.SteamIDHasPermission(steamID, 1, PermServerRestart) {
+		t.Fatal("linked operator should restart an assigned server")
+	}
+	if store.SteamIDHasPermission(steamID, 2, PermServerRestart) || store.SteamIDHasPermission("76561198000000009", 1, PermServerRestart) {
+		t.Fatal("unassigned server or unlinked SteamID must not pass")
+	}
+}
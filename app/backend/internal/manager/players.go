@@ -0,0 +1,441 @@
+package manager
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+const (
+	maxPlayerSessions   = 200
+	maxPlayerNoteLength = 2000
+	// On-demand refreshes (API reads) reuse an index younger than this.
+	playerIndexRefreshTTL = 30 * time.Second
+	// Background refresh interval driven by the telemetry loop.
+	playerIndexBackgroundInterval = 5 * time.Minute
+)
+
+// PlayerAlias is a display name a SteamID has used.
+type PlayerAlias struct {
+	Name      string    `json:"name"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// PlayerServerStats aggregates a player's sessions on one server.
+type PlayerServerStats struct {
+	ServerID        int       `json:"server_id"`
+	ServerName      string    `json:"server_name"`
+	FirstSeen       time.Time `json:"first_seen"`
+	LastSeen        time.Time `json:"last_seen"`
+	Sessions        int       `json:"sessions"`
+	PlaytimeSeconds int64     `json:"playtime_seconds"`
+}
+
+// PlayerSession is one connection of a player to a server.
+type PlayerSession struct {
+	ServerID     int        `json:"server_id"`
+	Name         string     `json:"name"`
+	Connected    time.Time  `json:"connected"`
+	Disconnected *time.Time `json:"disconnected,omitempty"`
+}
+
+// PlayerNote is a free-form staff note attached to a player.
+type PlayerNote struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PlayerProfile is the persisted, cross-server record for one SteamID. Stats for servers
+// that have since been deleted are kept.
+type PlayerProfile struct {
+	SteamID   string              `json:"steam_id"`
+	Name      string              `json:"name"`
+	Names     []PlayerAlias       `json:"names"`
+	FirstSeen time.Time           `json:"first_seen"`
+	LastSeen  time.Time           `json:"last_seen"`
+	Servers   []PlayerServerStats `json:"servers"`
+	Sessions  []PlayerSession     `json:"sessions"`
+	Notes     []PlayerNote        `json:"notes,omitempty"`
+}
+
+// PlayerView is a profile plus live status read from the servers at request time.
+type PlayerView struct {
+	PlayerProfile
+	PlaytimeSeconds int64 `json:"playtime_seconds"`
+	OnlineServerIDs []int `json:"online_server_ids"`
+	AdminServerIDs  []int `json:"admin_server_ids"`
+	BannedServerIDs []int `json:"banned_server_ids"`
+}
+
+// PlayerIndex merges every server's players log into per-SteamID profiles stored in
+// config/players.json.
+type PlayerIndex struct {
+	mu          sync.Mutex
+	path        string
+	players     map[string]*PlayerProfile
+	refreshedAt time.Time
+	written     []byte
+}
+
+// NewPlayerIndex loads the index from path; a missing or unreadable file starts empty.
+func NewPlayerIndex(path string) *PlayerIndex {
+	idx := &PlayerIndex{path: path, players: make(map[string]*PlayerProfile)}
+	if data, err := os.ReadFile(path); err == nil {
+		var list []*PlayerProfile
+		if json.Unmarshal(data, &list) == nil {
+			for _, p := range list {
+				if p != nil && p.SteamID != "" {
+					idx.players[p.SteamID] = p
+				}
+			}
+			idx.written = data
+		}
+	}
+	return idx
+}
+
+// Refresh rebuilds the stats and sessions of every listed server from its players log.
+// Data for servers not in the list is left untouched.
+func (idx *PlayerIndex) Refresh(servers []*models.Server, now time.Time) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, s := range servers {
+		if s == nil {
+			continue
+		}
+		bySteamID := make(map[string][]models.Client)
+		for _, c := range s.PlayerSessions() {
+			bySteamID[c.SteamID] = append(bySteamID[c.SteamID], c)
+		}
+		// Players who no longer appear in the log keep nothing for this server.
+		for id, p := range idx.players {
+			if _, ok := bySteamID[id]; !ok {
+				p.dropServer(s.ID)
+			}
+		}
+		for id, sessions := range bySteamID {
+			p := idx.players[id]
+			if p == nil {
+				p = &PlayerProfile{SteamID: id}
+				idx.players[id] = p
+			}
+			p.dropServer(s.ID)
+			p.addServerSessions(s, sessions, now)
+		}
+	}
+	for id, p := range idx.players {
+		if len(p.Servers) == 0 && len(p.Notes) == 0 {
+			delete(idx.players, id)
+			continue
+		}
+		p.recomputeTotals()
+	}
+	idx.refreshedAt = now
+	return idx.saveLocked()
+}
+
+// Stale reports whether the last refresh is older than maxAge.
+func (idx *PlayerIndex) Stale(now time.Time, maxAge time.Duration) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return now.Sub(idx.refreshedAt) >= maxAge
+}
+
+// Search returns profiles whose SteamID starts with query or whose current or past
+// names contain it (case-insensitive), most recently seen first. Empty query lists all.
+func (idx *PlayerIndex) Search(query string, limit int) []PlayerProfile {
+	q := strings.ToLower(strings.TrimSpace(query))
+	idx.mu.Lock()
+	out := make([]PlayerProfile, 0)
+	for _, p := range idx.players {
+		if q == "" || strings.HasPrefix(p.SteamID, q) || p.matchesName(q) {
+			out = append(out, p.clone())
+		}
+	}
+	idx.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].LastSeen.After(out[j].LastSeen) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// Get returns a copy of the profile for steamID.
+func (idx *PlayerIndex) Get(steamID string) (PlayerProfile, bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	p, ok := idx.players[strings.TrimSpace(steamID)]
+	if !ok {
+		return PlayerProfile{}, false
+	}
+	return p.clone(), true
+}
+
+// AddNote attaches a staff note to a known player.
+func (idx *PlayerIndex) AddNote(steamID, author, text string, now time.Time) (PlayerNote, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return PlayerNote{}, errors.New("note text is required")
+	}
+	if len(text) > maxPlayerNoteLength {
+		return PlayerNote{}, fmt.Errorf("note too long (max %d characters)", maxPlayerNoteLength)
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	p, ok := idx.players[strings.TrimSpace(steamID)]
+	if !ok {
+		return PlayerNote{}, errors.New("player not found")
+	}
+	note := PlayerNote{ID: newPlayerNoteID(), Author: author, Text: text, CreatedAt: now}
+	p.Notes = append(p.Notes, note)
+	return note, idx.saveLocked()
+}
+
+// DeleteNote removes a staff note by ID.
+func (idx *PlayerIndex) DeleteNote(steamID, noteID string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	p, ok := idx.players[strings.TrimSpace(steamID)]
+	if !ok {
+		return errors.New("player not found")
+	}
+	for i, n := range p.Notes {
+		if n.ID == noteID {
+			p.Notes = append(p.Notes[:i], p.Notes[i+1:]...)
+			return idx.saveLocked()
+		}
+	}
+	return errors.New("note not found")
+}
+
+// saveLocked writes the index atomically when its content changed. Caller MUST hold idx.mu.
+func (idx *PlayerIndex) saveLocked() error {
+	if idx.path == "" {
+		return nil
+	}
+	list := make([]*PlayerProfile, 0, len(idx.players))
+	for _, p := range idx.players {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SteamID < list[j].SteamID })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if string(data) == string(idx.written) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(idx.path), 0o755); err != nil {
+		return err
+	}
+	tmp := idx.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, idx.path); err != nil {
+		return err
+	}
+	idx.written = data
+	return nil
+}
+
+func (p *PlayerProfile) dropServer(serverID int) {
+	stats := p.Servers[:0]
+	for _, st := range p.Servers {
+		if st.ServerID != serverID {
+			stats = append(stats, st)
+		}
+	}
+	p.Servers = stats
+	sessions := p.Sessions[:0]
+	for _, sess := range p.Sessions {
+		if sess.ServerID != serverID {
+			sessions = append(sessions, sess)
+		}
+	}
+	p.Sessions = sessions
+}
+
+func (p *PlayerProfile) addServerSessions(s *models.Server, sessions []models.Client, now time.Time) {
+	stats := PlayerServerStats{ServerID: s.ID, ServerName: s.Name}
+	for i := range sessions {
+		c := &sessions[i]
+		end := now
+		if c.DisconnectDatetime != nil {
+			end = *c.DisconnectDatetime
+		}
+		if stats.FirstSeen.IsZero() || c.ConnectDatetime.Before(stats.FirstSeen) {
+			stats.FirstSeen = c.ConnectDatetime
+		}
+		if end.After(stats.LastSeen) {
+			stats.LastSeen = end
+		}
+		stats.Sessions++
+		if d := end.Sub(c.ConnectDatetime); d > 0 {
+			stats.PlaytimeSeconds += int64(d / time.Second)
+		}
+		p.Sessions = append(p.Sessions, PlayerSession{
+			ServerID:     s.ID,
+			Name:         c.Name,
+			Connected:    c.ConnectDatetime,
+			Disconnected: c.DisconnectDatetime,
+		})
+		p.noteAlias(c.Name, c.ConnectDatetime, end)
+	}
+	p.Servers = append(p.Servers, stats)
+}
+
+// noteAlias records a name use. Aliases are never removed so renames stay visible.
+func (p *PlayerProfile) noteAlias(name string, first, last time.Time) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	for i := range p.Names {
+		a := &p.Names[i]
+		if a.Name != name {
+			continue
+		}
+		if first.Before(a.FirstSeen) {
+			a.FirstSeen = first
+		}
+		if last.After(a.LastSeen) {
+			a.LastSeen = last
+		}
+		return
+	}
+	p.Names = append(p.Names, PlayerAlias{Name: name, FirstSeen: first, LastSeen: last})
+}
+
+func (p *PlayerProfile) recomputeTotals() {
+	sort.Slice(p.Servers, func(i, j int) bool { return p.Servers[i].ServerID < p.Servers[j].ServerID })
+	sort.Slice(p.Sessions, func(i, j int) bool { return p.Sessions[i].Connected.After(p.Sessions[j].Connected) })
+	if len(p.Sessions) > maxPlayerSessions {
+		p.Sessions = p.Sessions[:maxPlayerSessions]
+	}
+	sort.Slice(p.Names, func(i, j int) bool { return p.Names[i].LastSeen.After(p.Names[j].LastSeen) })
+	if len(p.Names) > 0 {
+		p.Name = p.Names[0].Name
+	}
+	p.FirstSeen, p.LastSeen = time.Time{}, time.Time{}
+	for _, st := range p.Servers {
+		if p.FirstSeen.IsZero() || st.FirstSeen.Before(p.FirstSeen) {
+			p.FirstSeen = st.FirstSeen
+		}
+		if st.LastSeen.After(p.LastSeen) {
+			p.LastSeen = st.LastSeen
+		}
+	}
+}
+
+func (p *PlayerProfile) matchesName(q string) bool {
+	for _, a := range p.Names {
+		if strings.Contains(strings.ToLower(a.Name), q) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PlayerProfile) clone() PlayerProfile {
+	c := *p
+	c.Names = append([]PlayerAlias(nil), p.Names...)
+	c.Servers = append([]PlayerServerStats(nil), p.Servers...)
+	c.Sessions = append([]PlayerSession(nil), p.Sessions...)
+	c.Notes = append([]PlayerNote(nil), p.Notes...)
+	return c
+}
+
+func newPlayerNoteID() string {
+	var b [6]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Players returns the manager's player index, loading it on first use.
+func (m *Manager) Players() *PlayerIndex {
+	if m == nil {
+		return nil
+	}
+	m.playersOnce.Do(func() {
+		path := ""
+		if m.Paths != nil {
+			path = m.Paths.PlayersFile()
+		}
+		m.players = NewPlayerIndex(path)
+	})
+	return m.players
+}
+
+// RefreshPlayerIndex merges every server's players log into the index.
+func (m *Manager) RefreshPlayerIndex() {
+	idx := m.Players()
+	if idx == nil {
+		return
+	}
+	if err := idx.Refresh(m.Servers, time.Now()); err != nil {
+		m.Logger("players", 0).Error(fmt.Sprintf("Failed to save player index: %v", err))
+	}
+}
+
+// refreshPlayerIndexIfStale refreshes the index when it is older than maxAge.
+func (m *Manager) refreshPlayerIndexIfStale(maxAge time.Duration) {
+	if idx := m.Players(); idx != nil && idx.Stale(time.Now(), maxAge) {
+		m.RefreshPlayerIndex()
+	}
+}
+
+// SearchPlayers refreshes a stale index and returns matching profiles.
+func (m *Manager) SearchPlayers(query string, limit int) []PlayerProfile {
+	m.refreshPlayerIndexIfStale(playerIndexRefreshTTL)
+	return m.Players().Search(query, limit)
+}
+
+// PlayerDetail returns a player's profile with live online/admin/ban status across servers.
+func (m *Manager) PlayerDetail(steamID string) (PlayerView, bool) {
+	m.refreshPlayerIndexIfStale(playerIndexRefreshTTL)
+	p, ok := m.Players().Get(steamID)
+	if !ok {
+		return PlayerView{}, false
+	}
+	view := PlayerView{PlayerProfile: p, OnlineServerIDs: []int{}, AdminServerIDs: []int{}, BannedServerIDs: []int{}}
+	for _, st := range p.Servers {
+		view.PlaytimeSeconds += st.PlaytimeSeconds
+	}
+	for _, s := range m.Servers {
+		if s == nil {
+			continue
+		}
+		for _, c := range s.LiveClients() {
+			if c.SteamID == p.SteamID {
+				view.OnlineServerIDs = append(view.OnlineServerIDs, s.ID)
+				break
+			}
+		}
+		if s.IsSteamIDAdmin(p.SteamID) {
+			view.AdminServerIDs = append(view.AdminServerIDs, s.ID)
+		}
+		for _, id := range s.ReadBlacklistIDs() {
+			if id == p.SteamID {
+				view.BannedServerIDs = append(view.BannedServerIDs, s.ID)
+				break
+			}
+		}
+	}
+	return view, true
+}
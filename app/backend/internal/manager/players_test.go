@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+func TestPlayerIndexMergesServersAndKeepsDeleted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "players.json")
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(min int) *time.Time {
+		v := base.Add(time.Duration(min) * time.Minute)
+		return &v
+	}
+	alpha := &models.Server{ID: 1, Name: "Alpha", Clients: []*models.Client{
+		{SteamID: "76561198000000001", Name: "Rook", ConnectDatetime: base, DisconnectDatetime: at(30)},
+		{SteamID: "76561198000000001", Name: "Rookie", ConnectDatetime: *at(60), DisconnectDatetime: at(90)},
+	}}
+	beta := &models.Server{ID: 2, Name: "Beta", Clients: []*models.Client{
+		{SteamID: "76561198000000001", Name: "Rookie", ConnectDatetime: *at(120), DisconnectDatetime: at(180)},
+		{SteamID: "76561198000000002", Name: "Bishop", ConnectDatetime: *at(10), DisconnectDatetime: at(20)},
+	}}
+
+	idx := NewPlayerIndex(path)
+	if err := idx.Refresh([]*models.Server{alpha, beta}, base.Add(4*time.Hour)); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	p, ok := idx.Get("76561198000000001")
+	if !ok {
+		t.Fatalf("player not indexed")
+	}
+	if p.Name != "Rookie" || len(p.Names) != 2 {
+		t.Fatalf("name history = %q %+v", p.Name, p.Names)
+	}
+	if len(p.Servers) != 2 || p.Servers[0].Sessions != 2 || p.Servers[0].PlaytimeSeconds != 3600 || p.Servers[1].PlaytimeSeconds != 3600 {
+		t.Fatalf("server stats = %+v", p.Servers)
+	}
+	if !p.FirstSeen.Equal(base) || !p.LastSeen.Equal(*at(180)) {
+		t.Fatalf("first/last seen = %v / %v", p.FirstSeen, p.LastSeen)
+	}
+	if _, err := idx.AddNote(p.SteamID, "mod", "griefed spawn", base); err != nil {
+		t.Fatalf("add note: %v", err)
+	}
+
+	if got := idx.Search("rook", 0); len(got) != 1 {
+		t.Fatalf("name search = %d results", len(got))
+	}
+	if got := idx.Search("7656119800000000", 0); len(got) != 2 || got[0].SteamID != "76561198000000001" {
+		t.Fatalf("steamid search = %+v", got)
+	}
+
+	// Beta was deleted: its stats survive, Alpha is rebuilt from its log.
+	reloaded := NewPlayerIndex(path)
+	if err := reloaded.Refresh([]*models.Server{alpha}, base.Add(5*time.Hour)); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	p, _ = reloaded.Get("76561198000000001")
+	if len(p.Servers) != 2 || len(p.Notes) != 1 || len(p.Sessions) != 3 {
+		t.Fatalf("after reload: servers=%d notes=%d sessions=%d", len(p.Servers), len(p.Notes), len(p.Sessions))
+	}
+	if err := reloaded.DeleteNote(p.SteamID, p.Notes[0].ID); err != nil {
+		t.Fatalf("delete note: %v", err)
+	}
+}
@@ -23,13 +23,13 @@ import (
 //  3. Validate the parent directory matches Paths.ServerLogsDir(N) to avoid false positives.
 //
 // If any step fails, the process is skipped. Best-effort only; errors are silently ignored.
-func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) map[int]int {
+func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logger *utils.Logger) map[int]int {
 	result := make(map[int]int)
 	if paths == nil {
 		return result
 	}
-	if logf != nil {
-		logf("Process discovery (unix): scanning /proc for Stationeers server processes")
+	if logger != nil {
+		logger.Debug("Process discovery (unix): scanning /proc for Stationeers server processes")
 	}
 	procDir := "/proc"
 	entries, err := os.ReadDir(procDir)
@@ -66,8 +66,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) ma
 		if !hasExe {
 			continue
 		}
-		if logf != nil {
-			logf("Process discovery: candidate PID " + name + " contains rocketstation_DedicatedServer")
+		if logger != nil {
+			logger.Debug("Process discovery: candidate PID " + name + " contains rocketstation_DedicatedServer")
 		}
 		// Find -logFile argument and its value
 		var logFile string
@@ -80,8 +80,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) ma
 		if logFile == "" {
 			continue
 		}
-		if logf != nil {
-			logf("Process discovery: PID " + name + " uses -logFile " + logFile)
+		if logger != nil {
+			logger.Debug("Process discovery: PID " + name + " uses -logFile " + logFile)
 		}
 		base := filepath.Base(logFile)
 		m := filePattern.FindStringSubmatch(base)
@@ -92,8 +92,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) ma
 		if sid <= 0 {
 			continue
 		}
-		if logf != nil {
-			logf("Process discovery: PID " + name + " appears to be Server" + m[1])
+		if logger != nil {
+			logger.Debug("Process discovery: PID " + name + " appears to be Server" + m[1])
 		}
 		// Verify directory consistency
 		expectedDir := paths.ServerLogsDir(sid)
@@ -108,23 +108,23 @@ func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) ma
 			realEval = realDir
 		}
 		if !samePathInsensitive(expEval, realEval) {
-			if logf != nil {
-				logf("Process discovery: PID " + name + " log dir mismatch; expected " + expEval + ", got " + realEval + ". Skipping")
+			if logger != nil {
+				logger.Warn("Process discovery: PID " + name + " log dir mismatch; expected " + expEval + ", got " + realEval + ". Skipping")
 			}
 			continue
 		}
 		// Record mapping if not already set; prefer first seen (should be unique)
 		if _, exists := result[sid]; !exists {
 			result[sid] = pid
-			if logf != nil {
-				logf("Process discovery: mapped Server" + m[1] + " -> PID " + name)
+			if logger != nil {
+				logger.Info("Process discovery: mapped Server" + m[1] + " -> PID " + name)
 			}
 		}
 	}
-	if logf != nil {
+	if logger != nil {
 		// create summary list
 		if len(result) == 0 {
-			logf("Process discovery (unix): no running server processes found")
+			logger.Info("Process discovery (unix): no running server processes found")
 		} else {
 			// Build a compact summary like "1:1234, 2:5678"
 			var parts []string
@@ -133,7 +133,7 @@ func discoverRunningServerPIDs(paths *utils.Paths, _ bool, logf func(string)) ma
 			}
 			// sort for determinism
 			sort.Strings(parts)
-			logf("Process discovery (unix): found " + strconv.Itoa(len(result)) + " servers: " + strings.Join(parts, ", "))
+			logger.Info("Process discovery (unix): found " + strconv.Itoa(len(result)) + " servers: " + strings.Join(parts, ", "))
 		}
 	}
 	return result
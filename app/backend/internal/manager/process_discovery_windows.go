@@ -23,21 +23,21 @@ type win32Process struct {
 	CommandLine *string // may be nil
 }
 
-func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(string)) map[int]int {
+func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logger *utils.Logger) map[int]int {
 	result := make(map[int]int)
 	if paths == nil {
 		return result
 	}
 	// Allow disabling WMI-based discovery via configuration for environments where WMI is blocked/disabled.
 	if !wmiEnabled {
-		if logf != nil {
-			logf("Process discovery (windows): WMI disabled by configuration; relying on PID files")
+		if logger != nil {
+			logger.Info("Process discovery (windows): WMI disabled by configuration; relying on PID files")
 		}
 		return result
 	}
 
-	if logf != nil {
-		logf("Process discovery (windows): querying WMI for rocketstation_DedicatedServer.exe")
+	if logger != nil {
+		logger.Debug("Process discovery (windows): querying WMI for rocketstation_DedicatedServer.exe")
 	}
 	// Perform WMI query with timeout and simple retries to harden against transient issues.
 	var procs []win32Process
@@ -54,8 +54,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(st
 			break
 		}
 		lastErr = err
-		if logf != nil {
-			logf("Process discovery (windows): WMI query attempt " + strconv.Itoa(attempt) + " failed: " + err.Error())
+		if logger != nil {
+			logger.Warn("Process discovery (windows): WMI query attempt " + strconv.Itoa(attempt) + " failed: " + err.Error())
 		}
 		// Backoff: 250ms, 500ms (skip sleep after last attempt)
 		if attempt < 3 {
@@ -63,8 +63,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(st
 		}
 	}
 	if lastErr != nil {
-		if logf != nil {
-			logf("Process discovery (windows): WMI unavailable after retries; falling back to PID files: " + lastErr.Error())
+		if logger != nil {
+			logger.Warn("Process discovery (windows): WMI unavailable after retries; falling back to PID files: " + lastErr.Error())
 		}
 		return result
 	}
@@ -76,8 +76,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(st
 			continue
 		}
 		if p.CommandLine == nil {
-			if logf != nil {
-				logf("Process discovery (windows): PID " + strconv.Itoa(pid) + " CommandLine unavailable (insufficient permissions?). Try running SDSM elevated or rely on PID files.")
+			if logger != nil {
+				logger.Warn("Process discovery (windows): PID " + strconv.Itoa(pid) + " CommandLine unavailable (insufficient permissions?). Try running SDSM elevated or rely on PID files.")
 			}
 			continue
 		}
@@ -95,8 +95,8 @@ func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(st
 		if logFile == "" {
 			continue
 		}
-		if logf != nil {
-			logf("Process discovery (windows): PID " + strconv.Itoa(pid) + " uses -logFile " + logFile)
+		if logger != nil {
+			logger.Debug("Process discovery (windows): PID " + strconv.Itoa(pid) + " uses -logFile " + logFile)
 		}
 		base := filepath.Base(logFile)
 		m := filePattern.FindStringSubmatch(base)
@@ -118,28 +118,28 @@ func discoverRunningServerPIDs(paths *utils.Paths, wmiEnabled bool, logf func(st
 			realEval = realDir
 		}
 		if !samePathInsensitive(expEval, realEval) {
-			if logf != nil {
-				logf("Process discovery (windows): PID " + strconv.Itoa(pid) + " log dir mismatch; expected " + expEval + ", got " + realEval + ". Skipping")
+			if logger != nil {
+				logger.Warn("Process discovery (windows): PID " + strconv.Itoa(pid) + " log dir mismatch; expected " + expEval + ", got " + realEval + ". Skipping")
 			}
 			continue
 		}
 		if _, exists := result[sid]; !exists {
 			result[sid] = pid
-			if logf != nil {
-				logf("Process discovery (windows): mapped Server" + m[1] + " -> PID " + strconv.Itoa(pid))
+			if logger != nil {
+				logger.Info("Process discovery (windows): mapped Server" + m[1] + " -> PID " + strconv.Itoa(pid))
 			}
 		}
 	}
-	if logf != nil {
+	if logger != nil {
 		if len(result) == 0 {
-			logf("Process discovery (windows): no running server processes found via WMI")
+			logger.Info("Process discovery (windows): no running server processes found via WMI")
 		} else {
 			var parts []string
 			for sid, pid := range result {
 				parts = append(parts, strconv.Itoa(sid)+":"+strconv.Itoa(pid))
 			}
 			// No need to sort strictly, but keep consistency
-			logf("Process discovery (windows): found " + strconv.Itoa(len(result)) + " servers: " + strings.Join(parts, ", "))
+			logger.Info("Process discovery (windows): found " + strconv.Itoa(len(result)) + " servers: " + strings.Join(parts, ", "))
 		}
 	}
 	return result
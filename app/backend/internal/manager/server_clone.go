@@ -0,0 +1,241 @@
+package manager
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// Clone save sources.
+const (
+	CloneSaveNone   = "none"
+	CloneSaveHead   = "head"
+	CloneSaveBackup = "backup"
+)
+
+// CloneOptions describes a server clone.
+type CloneOptions struct {
+	// Name of the new server; empty uses "<source> Copy", made unique.
+	Name string `json:"name"`
+	// Save is none (fresh world), head (the source's current save) or backup.
+	Save string `json:"save"`
+	// Backup names the source backup archive when Save is backup.
+	Backup string `json:"backup"`
+}
+
+// cloneSource is the save file the clone starts from: a plain file or an entry in a backup.
+type cloneSource struct {
+	file    string
+	archive string
+	entry   string
+}
+
+// CloneServer copies the source's configuration, admin list and blacklist to a new server on
+// the next free port. Auto-start, auto-update, schedules, scheduled backups and the Discord
+// webhook are not copied, so the clone does not run or notify until configured. Deploying
+// the game files and copying the chosen save run in the background and report through the
+// new server's copy progress.
+func (m *Manager) CloneServer(sourceID int, opts CloneOptions) (*models.Server, error) {
+	src := m.ServerByID(sourceID)
+	if src == nil {
+		return nil, ErrServerNotFound
+	}
+	save, err := m.cloneSaveSource(src, opts)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := src.ExportTemplate(false)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = m.AvailableServerName(src.Name + " Copy")
+	}
+	dst, err := m.ImportServerTemplate(tmpl, name)
+	if err != nil {
+		return nil, err
+	}
+	m.Logger("servers", dst.ID).Info(fmt.Sprintf("Cloning %s (ID: %d) to %s (ID: %d)", src.Name, src.ID, dst.Name, dst.ID))
+	m.ServerProgressBegin(dst.ID, "Queued")
+	go m.runClone(dst, save)
+	return dst, nil
+}
+
+// cloneSaveSource validates the save option up front so a bad request creates nothing.
+func (m *Manager) cloneSaveSource(src *models.Server, opts CloneOptions) (*cloneSource, error) {
+	paths := m.serverPaths(src)
+	switch strings.ToLower(strings.TrimSpace(opts.Save)) {
+	case "", CloneSaveNone:
+		return nil, nil
+	case CloneSaveHead:
+		if paths == nil {
+			return nil, errors.New("paths are not configured")
+		}
+		head := filepath.Join(paths.ServerSavesDir(src.ID), src.Name, src.Name+".save")
+		if !fileExistsRegular(head) {
+			return nil, fmt.Errorf("%s has no current save", src.Name)
+		}
+		return &cloneSource{file: head}, nil
+	case CloneSaveBackup:
+		archive, err := m.serverBackupPath(src, opts.Backup)
+		if err != nil {
+			return nil, err
+		}
+		zr, err := zip.OpenReader(archive)
+		if err != nil {
+			return nil, fmt.Errorf("backup archive is unreadable: %w", err)
+		}
+		defer zr.Close()
+		entry := backupHeadSave(&zr.Reader, src.Name)
+		if entry == "" {
+			return nil, fmt.Errorf("backup %s has no world save", opts.Backup)
+		}
+		return &cloneSource{archive: archive, entry: entry}, nil
+	}
+	return nil, fmt.Errorf("unknown save option %q", opts.Save)
+}
+
+// backupHeadSave finds the head save ("<world>/<world>.save") in a saves archive, preferring
+// the server's current name in case it was renamed since.
+func backupHeadSave(zr *zip.Reader, name string) string {
+	found := ""
+	for _, f := range zr.File {
+		dir, file := path.Split(f.Name)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" || strings.Contains(dir, "/") || file != dir+".save" {
+			continue
+		}
+		if dir == name {
+			return f.Name
+		}
+		if found == "" {
+			found = f.Name
+		}
+	}
+	return found
+}
+
+func (m *Manager) runClone(dst *models.Server, save *cloneSource) {
+	fail := func(stage string, err error) {
+		if dst.Logger != nil {
+			dst.Logger.Error(fmt.Sprintf("Clone failed while %s: %v", strings.ToLower(stage), err))
+		}
+		m.ServerProgressComplete(dst.ID, "Failed", err)
+	}
+	if save != nil {
+		m.ServerProgressUpdate(dst.ID, "Copying save", 0, 0)
+		if err := m.copyCloneSave(dst, save); err != nil {
+			fail("Copying save", err)
+			return
+		}
+	}
+
+	dst.SetProgressReporter(func(stage string, processed, total int64) {
+		m.ServerProgressUpdate(dst.ID, stage, processed, total)
+	})
+	err := dst.Deploy()
+	dst.SetProgressReporter(nil)
+	if err != nil {
+		fail("Deploying", err)
+		return
+	}
+	if err := m.WriteServerDeploySnapshot(dst); err != nil && dst.Logger != nil {
+		dst.Logger.Warn("Failed to write deploy snapshot: " + err.Error())
+	}
+	m.ServerProgressComplete(dst.ID, "Completed", nil)
+	m.notifyServerStatusChanged(dst)
+}
+
+// copyCloneSave writes the chosen save as the new server's head save, renamed for its name.
+func (m *Manager) copyCloneSave(dst *models.Server, save *cloneSource) error {
+	paths := m.serverPaths(dst)
+	if paths == nil {
+		return errors.New("paths are not configured")
+	}
+	var (
+		r     io.ReadCloser
+		total int64
+	)
+	if save.archive != "" {
+		zr, err := zip.OpenReader(save.archive)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if f.Name == save.entry {
+				if r, err = f.Open(); err != nil {
+					return err
+				}
+				total = int64(f.UncompressedSize64)
+				break
+			}
+		}
+		if r == nil {
+			return fmt.Errorf("%s is missing from the backup", save.entry)
+		}
+	} else {
+		f, err := os.Open(save.file)
+		if err != nil {
+			return err
+		}
+		if info, err := f.Stat(); err == nil {
+			total = info.Size()
+		}
+		r = f
+	}
+	defer r.Close()
+
+	worldDir := filepath.Join(paths.ServerSavesDir(dst.ID), dst.Name)
+	if err := os.MkdirAll(worldDir, 0o755); err != nil {
+		return err
+	}
+	head := filepath.Join(worldDir, dst.Name+".save")
+	tmp := head + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	pw := &progressWriter{w: out, report: func(n int64) { m.ServerProgressUpdate(dst.ID, "Copying save", n, total) }}
+	_, err = io.Copy(pw, r)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, head)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if dst.Logger != nil {
+		dst.Logger.Info("Copied world save for clone")
+	}
+	return nil
+}
+
+// progressWriter reports the bytes written about every megabyte.
+type progressWriter struct {
+	w        io.Writer
+	n        int64
+	reported int64
+	report   func(int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	if p.n-p.reported >= 1<<20 {
+		p.reported = p.n
+		p.report(p.n)
+	}
+	return n, err
+}
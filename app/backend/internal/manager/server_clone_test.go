@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestCloneServerCopiesHeadSaveAndBackup(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	src := &models.Server{ID: 1, Name: "Alpha", Paths: paths, Port: 27016, Password: "hunter2",
+		World: "Lunar", StartLocation: "LunarSpawnCraterVesper", StartCondition: "DefaultStart", Difficulty: "Normal",
+		AutoStart: true, AutoUpdate: true, BackupEnabled: true, DiscordWebhook: "https://discord.example/hook",
+		Schedules: []models.ScheduledJob{{ID: "nightly"}}}
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Paths:      paths,
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Servers:    []*models.Server{src},
+	}
+	defer mgr.Log.Close()
+
+	head := filepath.Join(paths.ServerSavesDir(1), "Alpha", "Alpha.save")
+	if err := os.MkdirAll(filepath.Dir(head), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(head, []byte("backed up"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := mgr.CreateServerBackup(1, BackupReasonManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(head, []byte("current"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mgr.CloneServer(1, CloneOptions{Save: CloneSaveBackup, Backup: "missing.zip"}); err == nil {
+		t.Fatal("missing backup accepted")
+	}
+	if _, err := mgr.CloneServer(1, CloneOptions{Name: "alpha"}); err == nil {
+		t.Fatal("taken name accepted")
+	}
+	if len(mgr.Servers) != 1 {
+		t.Fatalf("rejected clones created servers: %d", len(mgr.Servers))
+	}
+
+	cases := []struct {
+		opts CloneOptions
+		name string
+		want string
+	}{
+		{CloneOptions{Save: CloneSaveHead}, "Alpha Copy", "current"},
+		{CloneOptions{Name: "Upgrade Test", Save: CloneSaveBackup, Backup: backup.Name}, "Upgrade Test", "backed up"},
+	}
+	for _, tc := range cases {
+		clone, err := mgr.CloneServer(1, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer clone.Logger.Close()
+		if clone.Name != tc.name || clone.ID == src.ID || clone.Port == src.Port || clone.Password != "hunter2" {
+			t.Fatalf("clone identity = id %d name %q port %d", clone.ID, clone.Name, clone.Port)
+		}
+		if clone.AutoStart || clone.AutoUpdate || clone.BackupEnabled || clone.DiscordWebhook != "" || len(clone.Schedules) != 0 {
+			t.Fatalf("clone kept automation: start %v update %v backup %v webhook %q schedules %d",
+				clone.AutoStart, clone.AutoUpdate, clone.BackupEnabled, clone.DiscordWebhook, len(clone.Schedules))
+		}
+		waitServerProgress(t, mgr, clone.ID)
+		got, err := os.ReadFile(filepath.Join(paths.ServerSavesDir(clone.ID), tc.name, tc.name+".save"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Fatalf("%s save = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// waitServerProgress waits for the server's copy progress to finish; the deploy that follows
+// the save copy fails here without release files, which these tests ignore.
+func waitServerProgress(t *testing.T, mgr *Manager, serverID int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for mgr.ServerProgressSnapshot(serverID).Running {
+		if time.Now().After(deadline) {
+			t.Fatalf("server %d progress still running", serverID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
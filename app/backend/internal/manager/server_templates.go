@@ -0,0 +1,112 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sdsm/app/backend/internal/models"
+)
+
+// AvailableServerName returns base when no server uses it, otherwise base with the first free
+// numeric suffix ("Event 2", "Event 3", ...).
+func (m *Manager) AvailableServerName(base string) string {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = "Stationeers Server"
+	}
+	if m.IsServerNameAvailable(base, -1) {
+		return base
+	}
+	for i := 2; ; i++ {
+		if name := fmt.Sprintf("%s %d", base, i); m.IsServerNameAvailable(name, -1) {
+			return name
+		}
+	}
+}
+
+// ImportServerTemplate creates a server from t on the next free port. An empty name uses the
+// template's name, made unique; an explicit name must be free. The template's automation is
+// cleared so the new server stays idle and silent until configured, and its world, start
+// location, start condition and difficulty must exist in the installed game data.
+func (m *Manager) ImportServerTemplate(t *models.ServerTemplate, name string) (*models.Server, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	if err := t.ClearAutomation(); err != nil {
+		return nil, fmt.Errorf("invalid template settings: %w", err)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = m.AvailableServerName(t.Name)
+	} else if !m.IsServerNameAvailable(name, -1) {
+		return nil, fmt.Errorf("server name %q already exists", name)
+	}
+	srv, err := t.NewServer(m.NextID(), m.Paths, m.GetNextAvailablePort(0), name)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.validateGameSelection(srv); err != nil {
+		return nil, err
+	}
+	m.registerServer(srv)
+	if err := srv.ApplyTemplateLists(t); err != nil {
+		m.Logger("servers", srv.ID).Warn(fmt.Sprintf("Imported server %s (ID: %d) without its admin list or blacklist: %v", srv.Name, srv.ID, err))
+	}
+	if t.SecretsStripped {
+		m.Logger("servers", srv.ID).Warn(fmt.Sprintf("Imported server %s (ID: %d) from a template without secrets; set its password and auth secret.", srv.Name, srv.ID))
+	}
+	return srv, nil
+}
+
+// validateGameSelection checks the server's world, start location, start condition and
+// difficulty against the game data installed for its channel, the same options the create form
+// offers. Values are only required to be present when no game data is installed yet.
+func (m *Manager) validateGameSelection(s *models.Server) error {
+	switch {
+	case strings.TrimSpace(s.World) == "":
+		return errors.New("world selection is required")
+	case strings.TrimSpace(s.StartLocation) == "":
+		return errors.New("start location is required")
+	case strings.TrimSpace(s.StartCondition) == "":
+		return errors.New("start condition is required")
+	case strings.TrimSpace(s.Difficulty) == "":
+		return errors.New("difficulty selection is required")
+	}
+	if m.Paths == nil {
+		return nil
+	}
+	if cache := m.worldDefinitionsCache(s.Beta); cache != nil && len(cache.definitions) > 0 {
+		def, ok := cache.byCanonical[canonicalWorldIdentifier(s.World)]
+		if !ok {
+			return fmt.Errorf("world %q is not installed", s.World)
+		}
+		locations := make([]string, 0, len(def.StartLocations))
+		for _, l := range def.StartLocations {
+			locations = append(locations, l.ID)
+		}
+		if len(locations) > 0 && !containsFold(locations, strings.TrimSpace(s.StartLocation)) {
+			return fmt.Errorf("start location %q is not available in %s", s.StartLocation, def.DisplayName)
+		}
+		conditions := make([]string, 0, len(def.StartConditions))
+		for _, c := range def.StartConditions {
+			conditions = append(conditions, c.ID)
+		}
+		if len(conditions) > 0 && !containsFold(conditions, strings.TrimSpace(s.StartCondition)) {
+			return fmt.Errorf("start condition %q is not available in %s", s.StartCondition, def.DisplayName)
+		}
+	}
+	if diffs := m.GetDifficultiesForVersion(s.Beta); len(diffs) > 0 && !containsFold(diffs, strings.TrimSpace(s.Difficulty)) {
+		return fmt.Errorf("difficulty %q is not installed", s.Difficulty)
+	}
+	return nil
+}
+
+// ExportServerTemplate exports the server with the given ID.
+func (m *Manager) ExportServerTemplate(serverID int, stripSecrets bool) (*models.ServerTemplate, error) {
+	srv := m.ServerByID(serverID)
+	if srv == nil {
+		return nil, errors.New("server not found")
+	}
+	return srv.ExportTemplate(stripSecrets)
+}
@@ -0,0 +1,173 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestServerTemplateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	src := &models.Server{
+		ID:                  1,
+		Paths:               paths,
+		Name:                "Event",
+		Port:                27016,
+		World:               "Lunar",
+		StartLocation:       "LunarSpawnCraterVesper",
+		StartCondition:      "DefaultStart",
+		Difficulty:          "Normal",
+		AutoStart:           true,
+		AutoUpdate:          true,
+		BackupEnabled:       true,
+		Schedules:           []models.ScheduledJob{{ID: "nightly"}},
+		Password:            "hunter2",
+		AuthSecret:          "secret",
+		DiscordWebhook:      "https://discord.example/hook",
+		WelcomeMessage:      "Welcome {player}",
+		NotifyOnRestart:     true,
+		NotifyMsgRestart:    "Back soon",
+		CardToggles:         map[string]bool{"chat": false},
+		Mods:                []string{"better-power"},
+		ChatCommandsEnabled: true,
+	}
+	if err := src.WriteAdminListIDs([]string{"76561198000000001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.WriteBlacklistIDs([]string{"76561198000000002"}); err != nil {
+		t.Fatal(err)
+	}
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Paths:      paths,
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Servers:    []*models.Server{src},
+	}
+	defer mgr.Log.Close()
+
+	tmpl, err := mgr.ExportServerTemplate(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Templates travel as files, so import from the encoded form.
+	raw, err := json.Marshal(tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded models.ServerTemplate
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := mgr.ImportServerTemplate(&decoded, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Logger.Close()
+	if srv.ID != 2 || srv.Name != "Event 2" || srv.Port != 27019 || srv.SCONPort != 27020 {
+		t.Fatalf("imported identity = id %d name %q port %d scon %d", srv.ID, srv.Name, srv.Port, srv.SCONPort)
+	}
+	if srv.Password != "" || srv.AuthSecret != "" || srv.DiscordWebhook != "" {
+		t.Fatal("secrets survived a stripped export")
+	}
+	if srv.WelcomeMessage != "Welcome {player}" || !srv.NotifyOnRestart || srv.NotifyMsgRestart != "Back soon" ||
+		srv.CardToggles["chat"] || len(srv.Mods) != 1 || !srv.ChatCommandsEnabled {
+		t.Fatalf("settings not carried over: %+v", srv)
+	}
+	if ids := srv.ReadAdminListIDs(); len(ids) != 1 || ids[0] != "76561198000000001" {
+		t.Fatalf("admin list = %v", ids)
+	}
+	if ids := srv.ReadBlacklistIDs(); len(ids) != 1 || ids[0] != "76561198000000002" {
+		t.Fatalf("blacklist = %v", ids)
+	}
+
+	withSecrets, err := mgr.ExportServerTemplate(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.ImportServerTemplate(withSecrets, "event"); err == nil {
+		t.Fatal("taken name accepted")
+	}
+	dup, err := mgr.ImportServerTemplate(withSecrets, "Event Copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dup.Logger.Close()
+	if dup.Password != "hunter2" || dup.Port != 27022 {
+		t.Fatalf("unstripped import = password %q port %d", dup.Password, dup.Port)
+	}
+	for _, s := range []*models.Server{srv, dup} {
+		if s.AutoStart || s.AutoUpdate || s.BackupEnabled || s.DiscordWebhook != "" || len(s.Schedules) != 0 {
+			t.Fatalf("%s kept automation: start %v update %v backup %v webhook %q schedules %d",
+				s.Name, s.AutoStart, s.AutoUpdate, s.BackupEnabled, s.DiscordWebhook, len(s.Schedules))
+		}
+	}
+}
+
+func TestImportServerTemplateChecksGameData(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	dataDir := filepath.Join(paths.ReleaseDir(), "rocketstation_DedicatedServer_Data", "StreamingAssets", "Data")
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	difficulties := `<GameData><DifficultySettings><DifficultySetting Id="Normal"/><DifficultySetting Id="Stationeer"/></DifficultySettings></GameData>`
+	if err := os.WriteFile(filepath.Join(dataDir, "difficultySettings.xml"), []byte(difficulties), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Paths:      paths,
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+	}
+	defer mgr.Log.Close()
+	lunar := worldDefinition{
+		ID:              "Lunar",
+		DisplayName:     "The Moon",
+		StartLocations:  []RSStartLocation{{ID: "LunarSpawnCraterVesper"}},
+		StartConditions: []RSStartCondition{{ID: "DefaultStart"}},
+	}
+	mgr.worldIndex = map[bool]*worldDefinitionCache{false: {
+		definitions: []worldDefinition{lunar},
+		byCanonical: map[string]worldDefinition{"lunar": lunar, "themoon": lunar},
+		generatedAt: time.Now(),
+	}}
+
+	cases := []struct {
+		name   string
+		change func(*models.Server)
+		ok     bool
+	}{
+		{"valid", func(*models.Server) {}, true},
+		{"display name", func(s *models.Server) { s.World = "The Moon"; s.Difficulty = "stationeer" }, true},
+		{"missing world", func(s *models.Server) { s.World = "" }, false},
+		{"unknown world", func(s *models.Server) { s.World = "Europa" }, false},
+		{"unknown start location", func(s *models.Server) { s.StartLocation = "MarsSpawnCanyon" }, false},
+		{"unknown start condition", func(s *models.Server) { s.StartCondition = "Brutal" }, false},
+		{"unknown difficulty", func(s *models.Server) { s.Difficulty = "Creative" }, false},
+	}
+	for _, tc := range cases {
+		s := &models.Server{Name: tc.name, World: "Lunar", StartLocation: "LunarSpawnCraterVesper", StartCondition: "DefaultStart", Difficulty: "Normal"}
+		tc.change(s)
+		tmpl, err := s.ExportTemplate(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv, err := mgr.ImportServerTemplate(tmpl, "")
+		if (err == nil) != tc.ok {
+			t.Fatalf("%s: import error %v, want ok %v", tc.name, err, tc.ok)
+		}
+		if srv != nil {
+			srv.Logger.Close()
+		}
+	}
+	if len(mgr.Servers) != 2 {
+		t.Fatalf("rejected imports registered servers: %d", len(mgr.Servers))
+	}
+}
@@ -0,0 +1,354 @@
+package manager
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+const taskSchedulerInterval = 20 * time.Second
+
+var (
+	ErrServerNotFound   = errors.New("server not found")
+	ErrScheduleNotFound = errors.New("schedule not found")
+)
+
+var unsafeSaveNameChars = regexp.MustCompile(`[^A-Za-z0-9_\-.]+`)
+
+// StartTaskScheduler launches the per-server cron job loop.
+func (m *Manager) StartTaskScheduler() {
+	if m == nil {
+		return
+	}
+	m.taskSchedulerMu.Lock()
+	if m.taskSchedulerStop != nil {
+		m.taskSchedulerMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	m.taskSchedulerStop = stop
+	m.taskSchedulerMu.Unlock()
+
+	m.taskSchedulerWG.Add(1)
+	go func() {
+		defer m.taskSchedulerWG.Done()
+		ticker := time.NewTicker(taskSchedulerInterval)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case now := <-ticker.C:
+				m.runDueScheduledJobs(last, now)
+				last = now
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopTaskScheduler stops the cron job loop. Jobs already running are left to finish.
+func (m *Manager) StopTaskScheduler() {
+	if m == nil {
+		return
+	}
+	m.taskSchedulerMu.Lock()
+	stop := m.taskSchedulerStop
+	m.taskSchedulerStop = nil
+	m.taskSchedulerMu.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+	m.taskSchedulerWG.Wait()
+}
+
+// runDueScheduledJobs starts every enabled job whose next run falls within (prev, now].
+func (m *Manager) runDueScheduledJobs(prev, now time.Time) {
+	type dueJob struct {
+		srv *models.Server
+		job models.ScheduledJob
+	}
+	var due []dueJob
+	m.scheduleMu.Lock()
+	for _, srv := range m.Servers {
+		if srv == nil {
+			continue
+		}
+		for _, job := range srv.Schedules {
+			if !job.Enabled {
+				continue
+			}
+			sched, err := parseCron(job.Cron)
+			if err != nil {
+				continue
+			}
+			if next := sched.Next(prev); !next.IsZero() && !next.After(now) {
+				due = append(due, dueJob{srv: srv, job: job})
+			}
+		}
+	}
+	m.scheduleMu.Unlock()
+
+	for _, d := range due {
+		go m.runScheduledJob(d.srv, d.job)
+	}
+}
+
+func (m *Manager) runScheduledJob(s *models.Server, job models.ScheduledJob) {
+	label := job.Name
+	if strings.TrimSpace(label) == "" {
+		label = job.Action
+	}
+	err := m.executeScheduledJob(s, job)
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+		m.Logger("scheduler", s.ID).Error(fmt.Sprintf("Scheduled job '%s' on %s failed: %v", label, s.Name, err))
+	} else {
+		m.Logger("scheduler", s.ID).Info(fmt.Sprintf("Scheduled job '%s' on %s completed", label, s.Name))
+	}
+
+	now := time.Now()
+	m.scheduleMu.Lock()
+	for i := range s.Schedules {
+		if s.Schedules[i].ID == job.ID {
+			s.Schedules[i].LastRun = &now
+			s.Schedules[i].LastResult = result
+			break
+		}
+	}
+	m.scheduleMu.Unlock()
+	m.Save()
+}
+
+func (m *Manager) executeScheduledJob(s *models.Server, job models.ScheduledJob) error {
+	if s == nil {
+		return ErrServerNotFound
+	}
+	if !s.IsRunning() {
+		return errors.New("skipped: server not running")
+	}
+	switch job.Action {
+	case models.ScheduleActionRestart:
+		if s.Stopping {
+			return errors.New("skipped: shutdown already in progress")
+		}
+		m.NotifyServerEvent(s, "restarting", "Scheduled restart initiated.")
+		s.Restart()
+		m.notifyServerStatusChanged(s)
+		m.NotifyServerEvent(s, "started", "Scheduled restart complete.")
+		return nil
+	case models.ScheduleActionSave:
+		return s.SendCommand("console", "FILE save")
+	case models.ScheduleActionQuickSave:
+		return s.SendCommand("console", "FILE quicksave")
+	case models.ScheduleActionSaveAs:
+		name := scheduledSaveName(s, job.Payload, time.Now())
+		if name == "" {
+			return errors.New("save name is empty")
+		}
+		return s.SendCommand("console", "FILE saveas "+name)
+	case models.ScheduleActionAnnounce:
+		return s.SendCommand("chat", s.RenderChatMessage(job.Payload, nil))
+	case models.ScheduleActionCommand:
+		return s.SendRaw(strings.TrimSpace(job.Payload))
+	default:
+		return fmt.Errorf("unsupported action %q", job.Action)
+	}
+}
+
+// scheduledSaveName renders a save-as template. Besides the chat tokens ({server}, {date}, ...),
+// {timestamp} expands to YYMMDD_HHMMSS; characters the game cannot use in filenames are dropped.
+func scheduledSaveName(s *models.Server, tmpl string, now time.Time) string {
+	tmpl = strings.TrimSpace(tmpl)
+	if tmpl == "" {
+		tmpl = "scheduled_{timestamp}"
+	}
+	tmpl = strings.ReplaceAll(tmpl, "{timestamp}", now.Format("060102_150405"))
+	name := s.RenderChatMessage(tmpl, nil)
+	name = strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
+	name = unsafeSaveNameChars.ReplaceAllString(name, "")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".save"), ".SAVE")
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	return name
+}
+
+// ServerSchedules returns a copy of the server's scheduled jobs.
+func (m *Manager) ServerSchedules(serverID int) ([]models.ScheduledJob, error) {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return nil, ErrServerNotFound
+	}
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+	out := make([]models.ScheduledJob, len(s.Schedules))
+	copy(out, s.Schedules)
+	return out, nil
+}
+
+// AddServerSchedule validates and appends a job to the server, persisting the config.
+func (m *Manager) AddServerSchedule(serverID int, job models.ScheduledJob) (models.ScheduledJob, error) {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return job, ErrServerNotFound
+	}
+	if err := normalizeScheduledJob(&job); err != nil {
+		return job, err
+	}
+	job.ID = newScheduleID()
+	job.LastRun = nil
+	job.LastResult = ""
+	m.scheduleMu.Lock()
+	s.Schedules = append(s.Schedules, job)
+	m.scheduleMu.Unlock()
+	m.Save()
+	return job, nil
+}
+
+// UpdateServerSchedule replaces the editable fields of an existing job.
+func (m *Manager) UpdateServerSchedule(serverID int, jobID string, job models.ScheduledJob) (models.ScheduledJob, error) {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return job, ErrServerNotFound
+	}
+	if err := normalizeScheduledJob(&job); err != nil {
+		return job, err
+	}
+	m.scheduleMu.Lock()
+	var updated *models.ScheduledJob
+	for i := range s.Schedules {
+		if s.Schedules[i].ID == jobID {
+			existing := &s.Schedules[i]
+			existing.Name = job.Name
+			existing.Cron = job.Cron
+			existing.Action = job.Action
+			existing.Payload = job.Payload
+			existing.Enabled = job.Enabled
+			updated = existing
+			break
+		}
+	}
+	if updated == nil {
+		m.scheduleMu.Unlock()
+		return job, ErrScheduleNotFound
+	}
+	result := *updated
+	m.scheduleMu.Unlock()
+	m.Save()
+	return result, nil
+}
+
+// DeleteServerSchedule removes a job from the server.
+func (m *Manager) DeleteServerSchedule(serverID int, jobID string) error {
+	s := m.ServerByID(serverID)
+	if s == nil {
+		return ErrServerNotFound
+	}
+	m.scheduleMu.Lock()
+	idx := -1
+	for i := range s.Schedules {
+		if s.Schedules[i].ID == jobID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		m.scheduleMu.Unlock()
+		return ErrScheduleNotFound
+	}
+	s.Schedules = append(s.Schedules[:idx], s.Schedules[idx+1:]...)
+	m.scheduleMu.Unlock()
+	m.Save()
+	return nil
+}
+
+// PreviewSchedule returns the next count run times for a cron expression starting from now.
+func (m *Manager) PreviewSchedule(expr string, count int) ([]time.Time, error) {
+	sched, err := parseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		count = 5
+	}
+	if count > 50 {
+		count = 50
+	}
+	out := make([]time.Time, 0, count)
+	t := time.Now()
+	for len(out) < count {
+		t = sched.Next(t)
+		if t.IsZero() {
+			break
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// NextScheduleRun returns the next run time for a job, or zero when disabled or invalid.
+func NextScheduleRun(job models.ScheduledJob, after time.Time) time.Time {
+	if !job.Enabled {
+		return time.Time{}
+	}
+	sched, err := parseCron(job.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.Next(after)
+}
+
+func normalizeScheduledJob(job *models.ScheduledJob) error {
+	job.Name = strings.TrimSpace(job.Name)
+	job.Cron = strings.Join(strings.Fields(job.Cron), " ")
+	job.Action = strings.ToLower(strings.TrimSpace(job.Action))
+	job.Payload = strings.TrimSpace(job.Payload)
+	if len(job.Name) > 100 {
+		return errors.New("name too long (max 100)")
+	}
+	if _, err := parseCron(job.Cron); err != nil {
+		return err
+	}
+	switch job.Action {
+	case "saveas", "save_as":
+		job.Action = models.ScheduleActionSaveAs
+	case "quick-save", "quick_save":
+		job.Action = models.ScheduleActionQuickSave
+	case "chat":
+		job.Action = models.ScheduleActionAnnounce
+	case "console":
+		job.Action = models.ScheduleActionCommand
+	}
+	switch job.Action {
+	case models.ScheduleActionRestart, models.ScheduleActionSave, models.ScheduleActionQuickSave, models.ScheduleActionSaveAs:
+	case models.ScheduleActionAnnounce, models.ScheduleActionCommand:
+		if job.Payload == "" {
+			return fmt.Errorf("%s requires a payload", job.Action)
+		}
+	default:
+		return fmt.Errorf("unsupported action %q", job.Action)
+	}
+	if strings.ContainsAny(job.Payload, "\r\n") {
+		return errors.New("payload must be a single line")
+	}
+	if len(job.Payload) > 500 {
+		return errors.New("payload too long (max 500)")
+	}
+	return nil
+}
+
+func newScheduleID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
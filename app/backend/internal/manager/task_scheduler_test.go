@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestServerScheduleCRUD(t *testing.T) {
+	dir := t.TempDir()
+	mgr := &Manager{
+		ConfigFile: filepath.Join(dir, "sdsm.config"),
+		Log:        utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		Servers:    []*models.Server{{ID: 1, Name: "Alpha"}},
+	}
+	defer mgr.Log.Close()
+
+	job, err := mgr.AddServerSchedule(1, models.ScheduledJob{Cron: "0  4 * * *", Action: "Restart", Enabled: true})
+	if err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if job.ID == "" || job.Cron != "0 4 * * *" || job.Action != models.ScheduleActionRestart {
+		t.Fatalf("unexpected normalized job: %+v", job)
+	}
+
+	if _, err := mgr.AddServerSchedule(1, models.ScheduledJob{Cron: "0 * * * *", Action: "announce"}); err == nil {
+		t.Fatalf("expected announce without payload to be rejected")
+	}
+	if _, err := mgr.AddServerSchedule(2, models.ScheduledJob{Cron: "0 * * * *", Action: "save"}); !errors.Is(err, ErrServerNotFound) {
+		t.Fatalf("expected ErrServerNotFound, got %v", err)
+	}
+
+	updated, err := mgr.UpdateServerSchedule(1, job.ID, models.ScheduledJob{Cron: "@daily", Action: "saveas", Payload: "nightly_{timestamp}"})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updated.Action != models.ScheduleActionSaveAs || updated.Enabled {
+		t.Fatalf("unexpected updated job: %+v", updated)
+	}
+
+	if err := mgr.DeleteServerSchedule(1, job.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := mgr.DeleteServerSchedule(1, job.ID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Fatalf("expected ErrScheduleNotFound, got %v", err)
+	}
+}
+
+func TestScheduledSaveName(t *testing.T) {
+	srv := &models.Server{Name: "Mars Base"}
+	now := time.Date(2024, 5, 15, 3, 4, 5, 0, time.Local)
+
+	if got := scheduledSaveName(srv, "", now); got != "scheduled_240515_030405" {
+		t.Fatalf("unexpected default name %q", got)
+	}
+	if got := scheduledSaveName(srv, "{server} {timestamp}.save", now); got != "Mars_Base_240515_030405" {
+		t.Fatalf("unexpected rendered name %q", got)
+	}
+}
@@ -65,6 +65,9 @@ func (m *Manager) StopTelemetryMonitor() {
 		close(stop)
 	}
 	m.telemetryWG.Wait()
+	if m.history != nil {
+		m.history.Close()
+	}
 }
 
 func (m *Manager) refreshTelemetry(ctx context.Context) {
@@ -78,6 +81,10 @@ func (m *Manager) refreshTelemetry(ctx context.Context) {
 		m.telemetryMu.Unlock()
 	}
 	m.refreshServerTelemetry(ctx, hostDelta, memTotal, diskUsage)
+	m.recordHistory(snapshot)
+	m.evaluateAlerts(snapshot, time.Now())
+	m.refreshPlayerIndexIfStale(playerIndexBackgroundInterval)
+	m.sweepBans(time.Now())
 }
 
 func (m *Manager) collectSystemTelemetry(ctx context.Context) (*models.SystemTelemetry, float64, uint64, *disk.UsageStat) {
@@ -182,6 +189,7 @@ func (m *Manager) refreshServerTelemetry(ctx context.Context, hostDelta float64,
 		}
 		usage := m.buildServerUsage(ctx, srv, hostDelta, memTotal, diskStats)
 		srv.UpdateResourceUsage(usage)
+		m.refreshSCONHealth(srv)
 	}
 }
 
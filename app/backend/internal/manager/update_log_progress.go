@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"sdsm/app/backend/internal/utils"
 )
 
 // SetupStageProgress represents parsed progress for a single deployment stage
@@ -30,7 +32,6 @@ type SetupLogProgress struct {
 }
 
 var (
-	tsPrefix       = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}):\s+`)
 	startedRe      = regexp.MustCompile(`^Deployment \(([^)]+)\) started$`)
 	completedOKRe  = regexp.MustCompile(`^Deployment \(([^)]+)\) completed successfully in ([0-9a-zA-Z\.:]+)$`)
 	completedErrRe = regexp.MustCompile(`^Deployment \(([^)]+)\) completed with errors in ([0-9a-zA-Z\.:]+)$`)
@@ -75,15 +76,12 @@ func (m *Manager) ParseSetupProgressFromUpdateLog() SetupLogProgress {
 		if line == "" {
 			continue
 		}
-		// Extract timestamp
+		// Extract timestamp and strip the entry header for content parsing
 		ts := lastTS
-		if m := tsPrefix.FindStringSubmatch(line); len(m) == 2 {
-			if t, err := time.Parse("2006-01-02 15:04:05", m[1]); err == nil {
-				ts = t
-				lastTS = t
-			}
-			// Remove timestamp prefix for content parsing
-			line = tsPrefix.ReplaceAllString(line, "")
+		if entry, ok := utils.ParseLogLine(line); ok {
+			ts = entry.Time
+			lastTS = entry.Time
+			line = entry.Message
 		}
 
 		if mm := startedRe.FindStringSubmatch(line); len(mm) == 2 {
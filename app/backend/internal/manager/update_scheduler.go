@@ -0,0 +1,199 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+)
+
+const updateSchedulerInterval = 30 * time.Second
+
+// StartUpdateScheduler launches a background loop that runs the daily update at UpdateTime.
+// A zero UpdateTime disables the scheduled update; changes made through UpdateConfig are
+// picked up on the next tick.
+func (m *Manager) StartUpdateScheduler() {
+	if m == nil {
+		return
+	}
+	m.updateSchedulerMu.Lock()
+	if m.updateSchedulerStop != nil {
+		m.updateSchedulerMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	m.updateSchedulerStop = stop
+	m.updateSchedulerMu.Unlock()
+
+	m.updateSchedulerWG.Add(1)
+	go func() {
+		defer m.updateSchedulerWG.Done()
+		ticker := time.NewTicker(updateSchedulerInterval)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case now := <-ticker.C:
+				if scheduledUpdateDue(last, now, m.UpdateTime) {
+					m.runScheduledUpdate()
+				}
+				last = now
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopUpdateScheduler stops the daily update loop and waits for any in-flight run to finish.
+func (m *Manager) StopUpdateScheduler() {
+	if m == nil {
+		return
+	}
+	m.updateSchedulerMu.Lock()
+	stop := m.updateSchedulerStop
+	m.updateSchedulerStop = nil
+	m.updateSchedulerMu.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+	m.updateSchedulerWG.Wait()
+}
+
+// NextScheduledUpdate returns the next local time the daily update will run, or the zero
+// time when no update time is configured.
+func (m *Manager) NextScheduledUpdate() time.Time {
+	if m == nil || m.UpdateTime.IsZero() {
+		return time.Time{}
+	}
+	return nextScheduledUpdate(time.Now(), m.UpdateTime)
+}
+
+// nextScheduledUpdate returns the first occurrence of at's clock time (in after's location)
+// strictly after the given instant.
+func nextScheduledUpdate(after, at time.Time) time.Time {
+	y, mo, d := after.Date()
+	next := time.Date(y, mo, d, at.Hour(), at.Minute(), at.Second(), 0, after.Location())
+	if !next.After(after) {
+		next = time.Date(y, mo, d+1, at.Hour(), at.Minute(), at.Second(), 0, after.Location())
+	}
+	return next
+}
+
+// scheduledUpdateDue reports whether a daily occurrence of at falls within (prev, now].
+func scheduledUpdateDue(prev, now, at time.Time) bool {
+	if at.IsZero() || !now.After(prev) {
+		return false
+	}
+	return !nextScheduledUpdate(prev, at).After(now)
+}
+
+// runScheduledUpdate deploys out-of-date components and then refreshes the AutoUpdate servers
+// that are behind: running servers are warned and stopped, redeployed, and started again.
+func (m *Manager) runScheduledUpdate() {
+	if m.IsUpdating() {
+		m.Logger("scheduler", 0).Warn("Scheduled update: deployment already in progress; skipping")
+		return
+	}
+
+	// Refresh cached versions so the evaluation reflects the current Steam/GitHub state.
+	m.invalidateRocketStationVersionCache(false)
+	m.invalidateRocketStationVersionCache(true)
+
+	var types []DeployType
+	for _, dt := range m.ComponentsNeedingUpdate() {
+		// Server copies are handled per server below so running instances can be stopped first.
+		if dt != DeployTypeServers {
+			types = append(types, dt)
+		}
+	}
+	if len(types) > 0 {
+		m.Logger("scheduler", 0).Info(fmt.Sprintf("Scheduled update: updating out-of-sync components: %v", types))
+		if err := m.DeployTypes(types); err != nil {
+			m.Logger("scheduler", 0).Error(fmt.Sprintf("Scheduled update: component deployment failed; servers left untouched: %v", err))
+			return
+		}
+	}
+
+	targets := m.scheduledUpdateTargets(len(types) > 0)
+	if len(types) == 0 && len(targets) == 0 {
+		m.Logger("scheduler", 0).Info("Scheduled update: all components and AutoUpdate servers are up-to-date; skipping deployment")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, srv := range targets {
+		wg.Add(1)
+		go func(s *models.Server) {
+			defer wg.Done()
+			m.scheduledServerUpdate(s)
+		}(srv)
+	}
+	wg.Wait()
+	m.Logger("scheduler", 0).Info("Scheduled update: finished")
+}
+
+// scheduledUpdateTargets returns the AutoUpdate servers to refresh. After a component
+// deployment every one of them is behind; otherwise only servers whose deploy snapshot is
+// older than the deployed components, such as after a failed or skipped copy.
+func (m *Manager) scheduledUpdateTargets(componentsDeployed bool) []*models.Server {
+	var out []*models.Server
+	for _, srv := range m.Servers {
+		if srv == nil || !srv.AutoUpdate {
+			continue
+		}
+		if componentsDeployed || m.ServerNeedsUpdate(srv) {
+			out = append(out, srv)
+		}
+	}
+	return out
+}
+
+func (m *Manager) scheduledServerUpdate(s *models.Server) {
+	if m.IsServerUpdateRunning(s.ID) {
+		m.Logger("scheduler", s.ID).Warn(fmt.Sprintf("Scheduled update: %s update already running; skipping", s.Name))
+		return
+	}
+
+	wasRunning := s.IsRunning()
+	m.NotifyServerEvent(s, "update-started", "Scheduled server update started.")
+	m.ServerProgressBegin(s.ID, "Queued")
+	if wasRunning {
+		if s.Logger != nil {
+			s.Logger.Info("Scheduled update: stopping server")
+		}
+		s.StopForUpdate()
+		m.notifyServerStatusChanged(s)
+	}
+
+	m.ServerProgressUpdate(s.ID, "Backing up saves", 0, 0)
+	m.BackupBeforeDeploy(s, BackupReasonPreUpdate)
+
+	s.SetProgressReporter(func(stage string, processed, total int64) {
+		m.ServerProgressUpdate(s.ID, stage, processed, total)
+	})
+	err := s.Deploy()
+	s.SetProgressReporter(nil)
+
+	if err != nil {
+		if s.Logger != nil {
+			s.Logger.Error(fmt.Sprintf("Scheduled update failed: %v", err))
+		}
+		m.ServerProgressComplete(s.ID, "Failed", err)
+		m.NotifyServerEvent(s, "update-failed", fmt.Sprintf("Scheduled server update failed: %v", err))
+	} else {
+		if err := m.WriteServerDeploySnapshot(s); err != nil && s.Logger != nil {
+			s.Logger.Warn("Failed to write deploy snapshot: " + err.Error())
+		}
+		m.ServerProgressComplete(s.ID, "Completed", nil)
+		m.NotifyServerEvent(s, "update-completed", "Scheduled server update completed successfully.")
+	}
+
+	// Bring the server back even after a failed copy; the previous files are usually intact.
+	if wasRunning {
+		s.Start()
+		m.NotifyServerEvent(s, "started", "Server restarted after scheduled update.")
+		m.notifyServerStatusChanged(s)
+	}
+}
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/models"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestNextScheduledUpdate_LaterToday(t *testing.T) {
+	at, _ := time.Parse("15:04", "04:00")
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.Local)
+
+	next := nextScheduledUpdate(now, at)
+	want := time.Date(2024, 3, 10, 4, 0, 0, 0, time.Local)
+	if !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+}
+
+func TestNextScheduledUpdate_RollsToTomorrow(t *testing.T) {
+	at, _ := time.Parse("15:04", "04:00")
+	now := time.Date(2024, 3, 10, 4, 0, 0, 0, time.Local)
+
+	next := nextScheduledUpdate(now, at)
+	want := time.Date(2024, 3, 11, 4, 0, 0, 0, time.Local)
+	if !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+}
+
+func TestScheduledUpdateDue(t *testing.T) {
+	at, _ := time.Parse("15:04", "04:00")
+	base := time.Date(2024, 3, 10, 3, 59, 45, 0, time.Local)
+
+	if !scheduledUpdateDue(base, base.Add(30*time.Second), at) {
+		t.Fatalf("expected update to be due when the window crosses 04:00")
+	}
+	if scheduledUpdateDue(base.Add(30*time.Second), base.Add(60*time.Second), at) {
+		t.Fatalf("expected update not to fire twice")
+	}
+	if scheduledUpdateDue(base, base.Add(30*time.Second), time.Time{}) {
+		t.Fatalf("expected zero update time to disable the schedule")
+	}
+}
+
+func TestScheduledUpdateTargetsIncludeStaleServers(t *testing.T) {
+	dir := t.TempDir()
+	paths := utils.NewPaths(dir)
+	now := time.Now()
+	mgr := &Manager{
+		ConfigFile:       filepath.Join(dir, "sdsm.config"),
+		Paths:            paths,
+		Log:              utils.NewLogger(filepath.Join(dir, "sdsm.log")),
+		bepInExVersion:   "Missing",
+		bepInExCheckedAt: now,
+		launchPadVersion: "Missing",
+		launchPadChecked: now,
+		sconVersion:      "Missing",
+		sconChecked:      now,
+	}
+	defer mgr.Log.Close()
+	setRelease := func(v string) {
+		mgr.releaseVersion, mgr.releaseCheckedAt = v, time.Now()
+	}
+	stale := &models.Server{ID: 1, Paths: paths, AutoUpdate: true}
+	current := &models.Server{ID: 2, Paths: paths, AutoUpdate: true}
+	manual := &models.Server{ID: 3, Paths: paths}
+	unknown := &models.Server{ID: 4, Paths: paths, AutoUpdate: true}
+	mgr.Servers = []*models.Server{stale, current, manual, unknown}
+
+	setRelease("100")
+	for _, s := range []*models.Server{stale, manual} {
+		if err := mgr.WriteServerDeploySnapshot(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setRelease("101")
+	if err := mgr.WriteServerDeploySnapshot(current); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := mgr.scheduledUpdateTargets(false); len(got) != 1 || got[0] != stale {
+		t.Fatalf("targets without component changes = %v, want only the stale server", serverIDs(got))
+	}
+	if got := serverIDs(mgr.scheduledUpdateTargets(true)); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 4 {
+		t.Fatalf("targets after a component deploy = %v, want every AutoUpdate server", got)
+	}
+}
+
+func serverIDs(servers []*models.Server) []int {
+	ids := make([]int, 0, len(servers))
+	for _, s := range servers {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
@@ -3,8 +3,10 @@ package manager
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,19 +32,32 @@ type User struct {
 	// Operator access control
 	AssignedAllServers bool  `json:"assigned_all_servers,omitempty"`
 	AssignedServers    []int `json:"assigned_servers,omitempty"`
+	// Per-server role overrides (server ID -> role); grants access to that server
+	ServerRoles map[int]Role `json:"server_roles,omitempty"`
+	// Personal access tokens for automation (hashes only)
+	APITokens []APIToken `json:"api_tokens,omitempty"`
+	// Single sign-on link; set for users provisioned by an OIDC login
+	OIDCIssuer  string `json:"oidc_issuer,omitempty"`
+	OIDCSubject string `json:"oidc_subject,omitempty"`
+	// SteamID links the user to an in-game player so chat commands can check permissions
+	SteamID string `json:"steam_id,omitempty"`
+	// DiscordID links the user to a Discord account so bot slash commands can check permissions
+	DiscordID string `json:"discord_id,omitempty"`
 }
 
 // UserStore manages persistent users with a JSON file backend.
 type UserStore struct {
-	path  string
-	mu    sync.RWMutex
-	users map[string]*User
+	path      string
+	rolesPath string
+	mu        sync.RWMutex
+	users     map[string]*User
+	roles     map[Role]*RoleDefinition
 }
 
 // NewUserStore initializes a user store at the configured path.
 func NewUserStore(paths *utils.Paths) *UserStore {
 	p := paths.UsersFile()
-	return &UserStore{path: p, users: make(map[string]*User)}
+	return &UserStore{path: p, rolesPath: paths.RolesFile(), users: make(map[string]*User), roles: make(map[Role]*RoleDefinition)}
 }
 
 // Path returns the absolute path to the users.json backing file used by this store.
@@ -60,6 +75,9 @@ func (s *UserStore) Load() error {
 	if s.path == "" {
 		return errors.New("user store path not set")
 	}
+	if err := s.loadRolesLocked(); err != nil {
+		return err
+	}
 	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
 		// Ensure parent directory exists
 		_ = os.MkdirAll(filepath.Dir(s.path), 0o755)
@@ -226,15 +244,10 @@ func (s *UserStore) CanAccess(username string, serverID int) bool {
 	if !ok {
 		return false
 	}
-	if u.AssignedAllServers {
+	if _, ok := u.ServerRoles[serverID]; ok {
 		return true
 	}
-	for _, id := range u.AssignedServers {
-		if id == serverID {
-			return true
-		}
-	}
-	return false
+	return canAccessLocked(u, serverID)
 }
 
 // Users returns a snapshot list of users.
@@ -259,6 +272,73 @@ func (s *UserStore) Delete(username string) error {
 	return s.saveLocked()
 }
 
+// SetSteamID links a user to a Steam account; an empty ID removes the link. A SteamID
+// can only be linked to one user.
+func (s *UserStore) SetSteamID(username, steamID string) error {
+	return s.setLink(username, "steam id", steamID, func(u *User) *string { return &u.SteamID })
+}
+
+// SetDiscordID links a user to a Discord account; an empty ID removes the link. A Discord
+// ID can only be linked to one user.
+func (s *UserStore) SetDiscordID(username, discordID string) error {
+	return s.setLink(username, "discord id", discordID, func(u *User) *string { return &u.DiscordID })
+}
+
+func (s *UserStore) setLink(username, kind, id string, field func(*User) *string) error {
+	id = strings.TrimSpace(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return errors.New("user not found")
+	}
+	if id != "" {
+		for name, other := range s.users {
+			if name != username && *field(other) == id {
+				return fmt.Errorf("%s already linked to %s", kind, name)
+			}
+		}
+	}
+	*field(u) = id
+	return s.saveLocked()
+}
+
+// SteamIDHasPermission reports whether the user linked to steamID holds perm on serverID.
+func (s *UserStore) SteamIDHasPermission(steamID string, serverID int, perm Permission) bool {
+	return s.linkHasPermission(steamID, serverID, perm, func(u *User) string { return u.SteamID })
+}
+
+// DiscordIDHasPermission reports whether the user linked to discordID holds perm on serverID.
+func (s *UserStore) DiscordIDHasPermission(discordID string, serverID int, perm Permission) bool {
+	return s.linkHasPermission(discordID, serverID, perm, func(u *User) string { return u.DiscordID })
+}
+
+// DiscordUser returns the username linked to discordID.
+func (s *UserStore) DiscordUser(discordID string) (string, bool) {
+	username := s.linkedUser(discordID, func(u *User) string { return u.DiscordID })
+	return username, username != ""
+}
+
+func (s *UserStore) linkHasPermission(id string, serverID int, perm Permission, field func(*User) string) bool {
+	username := s.linkedUser(id, field)
+	return username != "" && s.HasPermission(username, serverID, perm)
+}
+
+func (s *UserStore) linkedUser(id string, field func(*User) string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for name, u := range s.users {
+		if field(u) == id {
+			return name
+		}
+	}
+	return ""
+}
+
 // AdminCount returns the number of users with admin role.
 func (s *UserStore) AdminCount() int {
 	s.mu.RLock()
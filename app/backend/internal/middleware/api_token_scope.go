@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"sdsm/app/backend/internal/manager"
+)
+
+const apiTokenContextKey = "api_token"
+
+// adminTokenRoutes are API route prefixes that need the admin scope regardless of method.
+var adminTokenRoutes = []string{
+	"/api/users",
+	"/api/roles",
+	"/api/audit",
+	"/api/backup-targets",
+	"/api/notify-channels",
+	"/api/alert-rules",
+	"/api/manager/log",
+	"/api/manager/update",
+	"/api/paths/",
+	"/api/profile",
+	"/api/bug-report",
+}
+
+// APITokenFromContext returns the personal access token that authenticated the request, if any.
+func APITokenFromContext(c *gin.Context) (manager.APIToken, bool) {
+	v, ok := c.Get(apiTokenContextKey)
+	if !ok {
+		return manager.APIToken{}, false
+	}
+	t, ok := v.(manager.APIToken)
+	return t, ok
+}
+
+// TokenAllowsServer reports whether the request may touch serverID. Session (JWT)
+// requests are always allowed here; role and assignment checks still apply downstream.
+func TokenAllowsServer(c *gin.Context, serverID int) bool {
+	t, ok := APITokenFromContext(c)
+	return !ok || t.AllowsServer(serverID)
+}
+
+// RequiredTokenScope classifies an API route (gin FullPath) into the scope a token needs to call it.
+func RequiredTokenScope(method, fullPath string) manager.TokenScope {
+	for _, prefix := range adminTokenRoutes {
+		if strings.HasPrefix(fullPath, prefix) {
+			return manager.ScopeAdmin
+		}
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return manager.ScopeRead
+	}
+	if strings.HasPrefix(fullPath, "/api/servers/:server_id/") {
+		if strings.HasSuffix(fullPath, "/console") || strings.HasSuffix(fullPath, "/chat") {
+			return manager.ScopeConsole
+		}
+		return manager.ScopeControl
+	}
+	return manager.ScopeAdmin
+}
+
+// EnforceAPITokenScope rejects token-authenticated requests outside the token's scopes or
+// server list. Requests authenticated by a session JWT pass through untouched. It must run
+// after RequireAPIAuth.
+func EnforceAPITokenScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, ok := APITokenFromContext(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		scope := RequiredTokenScope(c.Request.Method, c.FullPath())
+		if !t.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("token lacks %s scope", scope)})
+			return
+		}
+		if raw := c.Param("server_id"); raw != "" {
+			id, err := strconv.Atoi(raw)
+			if err != nil || !t.AllowsServer(id) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token not valid for this server"})
+				return
+			}
+		}
+		c.Next()
+	}
+}
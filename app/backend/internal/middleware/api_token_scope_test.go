@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestAPITokenAuthAndScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := manager.NewUserStore(utils.NewPaths(t.TempDir()))
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateUser("bot", "hash", manager.RoleOperator); err != nil {
+		t.Fatal(err)
+	}
+	raw, _, err := store.CreateAPIToken("bot", "ci", []manager.TokenScope{manager.ScopeControl}, false, []int{1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := NewAuthService()
+	auth.SetAPITokenStore(store)
+	r := gin.New()
+	api := r.Group("/api")
+	api.Use(auth.RequireAPIAuth(), EnforceAPITokenScope())
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"user": c.GetString("username")}) }
+	api.GET("/servers/:server_id/status", ok)
+	api.POST("/servers/:server_id/start", ok)
+	api.POST("/servers/:server_id/console", ok)
+	api.GET("/users", ok)
+
+	cases := []struct {
+		method, path, token string
+		want                int
+	}{
+		{http.MethodGet, "/api/servers/1/status", raw, http.StatusOK},
+		{http.MethodPost, "/api/servers/1/start", raw, http.StatusOK},
+		{http.MethodPost, "/api/servers/2/start", raw, http.StatusForbidden},
+		{http.MethodPost, "/api/servers/1/console", raw, http.StatusForbidden},
+		{http.MethodGet, "/api/users", raw, http.StatusForbidden},
+		{http.MethodGet, "/api/servers/1/status", raw + "x", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s %s: expected %d, got %d (%s)", tc.method, tc.path, tc.want, w.Code, w.Body.String())
+		}
+	}
+
+	// Session JWTs are unaffected by token scoping.
+	jwt, err := auth.GenerateToken("bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/servers/2/console", nil)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected JWT request to pass, got %d", w.Code)
+	}
+}
+
+func TestRequiredTokenScope(t *testing.T) {
+	cases := map[string]manager.TokenScope{
+		"GET /api/servers":                     manager.ScopeRead,
+		"GET /api/manager/log/tail":            manager.ScopeAdmin,
+		"POST /api/servers/:server_id/chat":    manager.ScopeConsole,
+		"POST /api/servers/:server_id/restart": manager.ScopeControl,
+		"POST /api/servers/start-all":          manager.ScopeAdmin,
+		"DELETE /api/profile/tokens/:token_id": manager.ScopeAdmin,
+	}
+	for route, want := range cases {
+		method, path, _ := strings.Cut(route, " ")
+		if got := RequiredTokenScope(method, path); got != want {
+			t.Fatalf("%s: expected %s, got %s", route, want, got)
+		}
+	}
+}
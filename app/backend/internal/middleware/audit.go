@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"sdsm/app/backend/internal/manager"
+)
+
+// auditBodyLimit caps how much of a request body is copied into the audit log.
+const auditBodyLimit = 64 << 10
+
+// AuditTrail records every state-changing request (anything but GET/HEAD/OPTIONS) to the
+// audit log once the handler has run. It must run after EnsureRoleContext so the user and
+// role are known, and before EnforceAPITokenScope so refused token calls are recorded too.
+func AuditTrail(audit *manager.AuditLog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if audit == nil {
+			c.Next()
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		params := auditRequestParams(c)
+
+		c.Next()
+
+		if form := c.Request.MultipartForm; form != nil {
+			for k, v := range form.Value {
+				params[k] = auditFlatten(v)
+			}
+			for k, files := range form.File {
+				names := make([]string, 0, len(files))
+				for _, fh := range files {
+					names = append(names, fh.Filename)
+				}
+				params[k] = strings.Join(names, ",")
+			}
+		}
+		entry := manager.AuditEntry{
+			User:   c.GetString("username"),
+			Role:   c.GetString("role"),
+			IP:     c.ClientIP(),
+			Method: c.Request.Method,
+			Route:  c.FullPath(),
+			Path:   c.Request.URL.Path,
+			Params: manager.RedactAuditParams(params),
+			Status: c.Writer.Status(),
+		}
+		if t, ok := APITokenFromContext(c); ok {
+			entry.TokenID = t.ID
+		}
+		if id, err := strconv.Atoi(c.Param("server_id")); err == nil {
+			entry.ServerID = id
+		} else if id, ok := params["server_id"].(float64); ok {
+			entry.ServerID = int(id)
+		}
+		switch {
+		case entry.Status == http.StatusUnauthorized || entry.Status == http.StatusForbidden:
+			entry.Result = "denied"
+		case entry.Status >= 400:
+			entry.Result = "error"
+		default:
+			entry.Result = "ok"
+		}
+		if len(c.Errors) > 0 {
+			entry.Error = c.Errors.String()
+		}
+		_ = audit.Record(entry)
+	}
+}
+
+// auditRequestParams collects path, query and body parameters without consuming the body.
+func auditRequestParams(c *gin.Context) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	for k, v := range c.Request.URL.Query() {
+		params[k] = auditFlatten(v)
+	}
+	ct := strings.ToLower(c.GetHeader("Content-Type"))
+	if c.Request.Body == nil || strings.Contains(ct, "multipart/") {
+		// Multipart uploads are summarised from the parsed form after the handler runs.
+		return params
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, auditBodyLimit+1))
+	rest := c.Request.Body
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if err != nil || len(body) == 0 {
+		return params
+	}
+	if len(body) > auditBodyLimit {
+		params["_body"] = "[truncated]"
+		return params
+	}
+	switch {
+	case strings.Contains(ct, "application/json"):
+		var obj map[string]interface{}
+		if json.Unmarshal(body, &obj) == nil {
+			for k, v := range obj {
+				params[k] = v
+			}
+		}
+	case strings.Contains(ct, "application/x-www-form-urlencoded"):
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for k, v := range values {
+				params[k] = auditFlatten(v)
+			}
+		}
+	}
+	return params
+}
+
+func auditFlatten(v []string) interface{} {
+	if len(v) == 1 {
+		return v[0]
+	}
+	out := make([]interface{}, len(v))
+	for i, s := range v {
+		out[i] = s
+	}
+	return out
+}
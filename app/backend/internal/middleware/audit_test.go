@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"sdsm/app/backend/internal/manager"
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestAuditTrailRecordsPrivilegedCalls(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	audit := manager.NewAuditLog(utils.NewPaths(t.TempDir()))
+	r := gin.New()
+	api := r.Group("/api")
+	api.Use(func(c *gin.Context) {
+		c.Set("username", "alice")
+		c.Set("role", "operator")
+		c.Next()
+	}, AuditTrail(audit))
+	api.GET("/servers/:server_id/status", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.POST("/servers/:server_id/console", func(c *gin.Context) {
+		var req struct {
+			Command string `json:"command"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil || req.Command == "" {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	api.POST("/servers/:server_id/delete", func(c *gin.Context) { c.Status(http.StatusForbidden) })
+
+	do := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := do(http.MethodGet, "/api/servers/3/status", ""); code != http.StatusOK {
+		t.Fatalf("status: %d", code)
+	}
+	if code := do(http.MethodPost, "/api/servers/3/console", `{"command":"CLEANUPPLAYERS all","password":"hunter2"}`); code != http.StatusOK {
+		t.Fatalf("handler should still see the request body, got %d", code)
+	}
+	do(http.MethodPost, "/api/servers/3/delete", "")
+
+	entries, err := audit.Query(manager.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected GET to be skipped and 2 entries recorded, got %d", len(entries))
+	}
+	if entries[0].Route != "/api/servers/:server_id/delete" || entries[0].Result != "denied" {
+		t.Fatalf("unexpected newest entry %+v", entries[0])
+	}
+	console := entries[1]
+	if console.User != "alice" || console.Role != "operator" || console.ServerID != 3 || console.Result != "ok" {
+		t.Fatalf("unexpected console entry %+v", console)
+	}
+	if console.Params["command"] != "CLEANUPPLAYERS all" || console.Params["password"] != "[redacted]" {
+		t.Fatalf("unexpected params %+v", console.Params)
+	}
+	found, _ := audit.Query(manager.AuditFilter{Query: "cleanupplayers"})
+	if len(found) != 1 {
+		t.Fatalf("expected free-text search to find the console command, got %d", len(found))
+	}
+}
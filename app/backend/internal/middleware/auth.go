@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"sdsm/app/backend/internal/manager"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -34,6 +36,7 @@ type AuthService struct {
 	secret      []byte
 	mu          sync.Mutex
 	apiFailures map[string]*apiFailure
+	tokens      *manager.UserStore
 }
 
 type apiFailure struct {
@@ -56,6 +59,9 @@ func NewAuthServiceWithSecret(secret string) *AuthService {
 	}
 }
 
+// SetAPITokenStore enables personal access tokens (see manager.APIToken) on API routes.
+func (a *AuthService) SetAPITokenStore(store *manager.UserStore) { a.tokens = store }
+
 // HashPassword returns a bcrypt hash for the provided password.
 func (a *AuthService) HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -268,6 +274,28 @@ func (a *AuthService) RequireAPIAuth() gin.HandlerFunc {
 			return
 		}
 
+		if a.tokens != nil && strings.HasPrefix(tokenString, manager.APITokenPrefix) {
+			username, token, err := a.tokens.AuthenticateAPIToken(tokenString)
+			if err != nil {
+				retryAfter, locked := a.recordAPIFailure(key)
+				if locked {
+					c.Header("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+					c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+						"error":       "Too many unauthorized attempts",
+						"retry_after": int(retryAfter.Seconds()),
+					})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				return
+			}
+			a.clearAPIFailures(key)
+			c.Set("username", username)
+			c.Set(apiTokenContextKey, token)
+			c.Next()
+			return
+		}
+
 		claims, err := a.ValidateToken(tokenString)
 		if err != nil {
 			retryAfter, locked := a.recordAPIFailure(key)
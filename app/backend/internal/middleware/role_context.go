@@ -31,9 +31,9 @@ func EnsureRoleContext(users *manager.UserStore, logger *utils.Logger, contextLa
 					if logger != nil {
 						// Context label helps identify source (API/UI) in logs
 						if strings.TrimSpace(contextLabel) == "" {
-							logger.Write("No admin found; auto-promoted 'admin' to admin role.")
+							logger.With("auth", 0).Warn("No admin found; auto-promoted 'admin' to admin role.")
 						} else {
-							logger.Write("[" + contextLabel + "] No admin found; auto-promoted 'admin' to admin role.")
+							logger.With("auth", 0).Warn("No admin found; auto-promoted 'admin' to admin role.", "source", contextLabel)
 						}
 					}
 				}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 	"sync"
@@ -9,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
+	"sdsm/app/backend/internal/utils"
 )
 
 // Rate limiter middleware
@@ -175,6 +175,9 @@ func RequestLoggerWithOptions(noisy bool) gin.HandlerFunc {
 		"/favicon.ico": {},
 		"/sdsm.png":    {},
 	}
+	// One logger for every request so its lock serializes writes. gin.DefaultWriter is
+	// redirected to GIN.log (or the manager log) before the router is built.
+	logger := utils.NewWriterLogger(gin.DefaultWriter).With("http", 0)
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -204,13 +207,25 @@ func RequestLoggerWithOptions(noisy bool) gin.HandlerFunc {
 			}
 		}
 
-		// Log line format (IP - time method path proto status latency ua err)
-		clientIP := c.ClientIP()
-		ua := c.Request.UserAgent()
-		errMsg := c.Errors.ByType(gin.ErrorTypePrivate).String()
-		line := fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\n", clientIP, time.Now().Format(time.RFC1123), method, path, c.Request.Proto, status, latency, ua, strings.TrimSpace(errMsg))
-		// Use gin DefaultWriter (already redirected to GIN.log) – fallback safe.
-		_, _ = gin.DefaultWriter.Write([]byte(line))
+		kv := []interface{}{
+			"status", status,
+			"latency", latency.String(),
+			"ip", c.ClientIP(),
+			"proto", c.Request.Proto,
+			"ua", c.Request.UserAgent(),
+		}
+		if errMsg := strings.TrimSpace(c.Errors.ByType(gin.ErrorTypePrivate).String()); errMsg != "" {
+			kv = append(kv, "error", errMsg)
+		}
+		msg := method + " " + path
+		switch {
+		case status >= 500:
+			logger.Error(msg, kv...)
+		case status >= 400:
+			logger.Warn(msg, kv...)
+		default:
+			logger.Info(msg, kv...)
+		}
 	}
 }
 
@@ -39,7 +39,7 @@ func NewHub(logger *utils.Logger) *Hub {
 		broadcast:  make(chan []byte),
 		register:   make(chan *websocket.Conn),
 		unregister: make(chan *websocket.Conn),
-		logger:     logger,
+		logger:     logger.With("websocket", 0),
 	}
 }
 
@@ -53,7 +53,7 @@ func (h *Hub) Run() {
 			h.mutex.Lock()
 			h.clients[conn] = true
 			h.mutex.Unlock()
-			h.logf("WebSocket client connected")
+			h.log().Debug("WebSocket client connected")
 
 		case conn := <-h.unregister:
 			h.mutex.Lock()
@@ -62,7 +62,7 @@ func (h *Hub) Run() {
 				conn.Close()
 			}
 			h.mutex.Unlock()
-			h.logf("WebSocket client disconnected")
+			h.log().Debug("WebSocket client disconnected")
 
 		case message := <-h.broadcast:
 			h.writeToClients(websocket.TextMessage, message)
@@ -78,10 +78,10 @@ func (h *Hub) writeToClients(messageType int, payload []byte) {
 	defer h.mutex.Unlock()
 	for conn := range h.clients {
 		if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
-			h.logf("WebSocket set write deadline error: %v", err)
+			h.log().Warn(fmt.Sprintf("WebSocket set write deadline error: %v", err))
 		}
 		if err := conn.WriteMessage(messageType, payload); err != nil {
-			h.logf("WebSocket write error: %v", err)
+			h.log().Warn(fmt.Sprintf("WebSocket write error: %v", err))
 			conn.Close()
 			delete(h.clients, conn)
 		}
@@ -94,7 +94,7 @@ func (h *Hub) writePingToClients() {
 	for conn := range h.clients {
 		deadline := time.Now().Add(writeWait)
 		if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
-			h.logf("WebSocket ping error: %v", err)
+			h.log().Warn(fmt.Sprintf("WebSocket ping error: %v", err))
 			conn.Close()
 			delete(h.clients, conn)
 		}
@@ -115,7 +115,7 @@ func (h *Hub) HandleWebSocket() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 		if err != nil {
-			h.logf("WebSocket upgrade error: %v", err)
+			h.log().Warn(fmt.Sprintf("WebSocket upgrade error: %v", err))
 			return
 		}
 
@@ -134,7 +134,7 @@ func (h *Hub) HandleWebSocket() gin.HandlerFunc {
 		for {
 			if _, _, err := conn.ReadMessage(); err != nil {
 				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNoStatusReceived) {
-					h.logf("WebSocket error: %v", err)
+					h.log().Warn(fmt.Sprintf("WebSocket error: %v", err))
 				}
 				break
 			}
@@ -142,12 +142,10 @@ func (h *Hub) HandleWebSocket() gin.HandlerFunc {
 	}
 }
 
-func (h *Hub) logf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
+func (h *Hub) log() *utils.Logger {
 	if h.logger != nil {
-		h.logger.Write(msg)
-		return
+		return h.logger
 	}
 	// Fallback: write to default SDSM log instead of stdout
-	utils.NewLogger("").Write(msg)
+	return utils.NewLogger("").With("websocket", 0)
 }
@@ -1,5 +1,16 @@
 package models
 
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 type Mod struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
@@ -8,3 +19,201 @@ type Mod struct {
 	Description string `json:"description"`
 	Image       string `json:"image"`
 }
+
+// ModAboutPath is the location of a mod's metadata file relative to the mod root.
+const ModAboutPath = "About/About.xml"
+
+// modAbout mirrors the ModMetadata document shipped in About/About.xml.
+type modAbout struct {
+	XMLName     xml.Name `xml:"ModMetadata"`
+	Name        string   `xml:"Name"`
+	Author      string   `xml:"Author"`
+	Version     string   `xml:"Version"`
+	Description string   `xml:"Description"`
+}
+
+// ParseModAbout decodes About.xml content into a Mod (ID is left unset).
+func ParseModAbout(data []byte) (Mod, error) {
+	var about modAbout
+	if err := xml.Unmarshal(data, &about); err != nil {
+		return Mod{}, fmt.Errorf("invalid About.xml: %w", err)
+	}
+	mod := Mod{
+		Name:        strings.TrimSpace(about.Name),
+		Author:      strings.TrimSpace(about.Author),
+		Version:     strings.TrimSpace(about.Version),
+		Description: strings.TrimSpace(about.Description),
+	}
+	if mod.Name == "" {
+		return Mod{}, errors.New("About.xml is missing a mod name")
+	}
+	return mod, nil
+}
+
+// ReadModDir loads the metadata for the mod installed at dir.
+func ReadModDir(dir string) (Mod, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ModAboutPath)))
+	if err != nil {
+		return Mod{}, err
+	}
+	mod, err := ParseModAbout(data)
+	if err != nil {
+		return Mod{}, err
+	}
+	for _, img := range []string{"Preview.png", "thumb.png"} {
+		if fileExists(filepath.Join(dir, "About", img)) {
+			mod.Image = "About/" + img
+			break
+		}
+	}
+	return mod, nil
+}
+
+// LoadModLibrary lists the mods in the library directory, ordered by ID. Only
+// subdirectories with numeric names are considered; unreadable mods are skipped.
+func LoadModLibrary(libraryDir string) ([]Mod, error) {
+	entries, err := os.ReadDir(libraryDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Mod{}, nil
+		}
+		return nil, err
+	}
+	mods := make([]Mod, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := modIDFromDirName(entry)
+		if !ok {
+			continue
+		}
+		mod, err := ReadModDir(filepath.Join(libraryDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		mod.ID = id
+		mods = append(mods, mod)
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].ID < mods[j].ID })
+	return mods, nil
+}
+
+func modIDFromDirName(entry os.DirEntry) (int, bool) {
+	if !entry.IsDir() {
+		return 0, false
+	}
+	id, err := strconv.Atoi(entry.Name())
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// ModIDs returns the server's selected mod IDs, ignoring malformed entries.
+func (s *Server) ModIDs() []int {
+	ids := make([]int, 0, len(s.Mods))
+	seen := make(map[int]bool, len(s.Mods))
+	for _, raw := range s.Mods {
+		id, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SyncMods copies the selected library mods into the server's mods directory and removes
+// mods that are no longer selected. Only ID-named directories are managed; anything else
+// placed in the mods directory is left alone.
+func (s *Server) SyncMods() error {
+	return s.syncMods(nil)
+}
+
+func (s *Server) countSelectedModFiles() int64 {
+	if s.Paths == nil {
+		return 0
+	}
+	var total int64
+	for _, id := range s.ModIDs() {
+		total += s.countFilesIfExists(filepath.Join(s.Paths.ModsLibraryDir(), strconv.Itoa(id)))
+	}
+	return total
+}
+
+func (s *Server) syncMods(tracker *copyTracker) error {
+	if s.Paths == nil {
+		return errors.New("server paths are not configured")
+	}
+	library := s.Paths.ModsLibraryDir()
+	dst := s.Paths.ServerModsDir(s.ID)
+	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+		return err
+	}
+
+	selected := make(map[string]bool)
+	var errs []error
+	for _, id := range s.ModIDs() {
+		name := strconv.Itoa(id)
+		src := filepath.Join(library, name)
+		if info, err := os.Stat(src); err != nil || !info.IsDir() {
+			if s.Logger != nil {
+				s.Logger.Warn(fmt.Sprintf("Mod %d is not in the mod library; skipping", id))
+			}
+			continue
+		}
+		selected[name] = true
+		target := filepath.Join(dst, name)
+		if err := s.copyDir(src, target, tracker); err != nil {
+			errs = append(errs, fmt.Errorf("mod %d: %w", id, err))
+			continue
+		}
+		if err := removeExtraneous(src, target); err != nil {
+			errs = append(errs, fmt.Errorf("mod %d: %w", id, err))
+		}
+	}
+
+	entries, err := os.ReadDir(dst)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, entry := range entries {
+		if _, ok := modIDFromDirName(entry); !ok || selected[entry.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dst, entry.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if s.Logger != nil {
+			s.Logger.Info(fmt.Sprintf("Removed deselected mod %s", entry.Name()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeExtraneous deletes entries under dst that no longer exist under src so that
+// files dropped by a mod update do not linger in the deployed copy.
+func removeExtraneous(src, dst string) error {
+	entries, err := os.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		info, statErr := os.Stat(srcPath)
+		if statErr != nil || info.IsDir() != entry.IsDir() {
+			if err := os.RemoveAll(dstPath); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if entry.IsDir() {
+			if err := removeExtraneous(srcPath, dstPath); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
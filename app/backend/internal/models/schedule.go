@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Scheduled job actions.
+const (
+	ScheduleActionRestart   = "restart"
+	ScheduleActionSave      = "save"
+	ScheduleActionQuickSave = "quicksave"
+	ScheduleActionSaveAs    = "save-as"
+	ScheduleActionAnnounce  = "announce"
+	ScheduleActionCommand   = "command"
+)
+
+// ScheduledJob is a cron-driven task attached to a server and persisted in sdsm.config.
+type ScheduledJob struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Cron   string `json:"cron"`
+	Action string `json:"action"`
+	// Payload carries the save-as name, announcement text, or console command depending on Action.
+	Payload    string     `json:"payload,omitempty"`
+	Enabled    bool       `json:"enabled"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	LastResult string     `json:"last_result,omitempty"`
+}
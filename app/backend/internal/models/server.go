@@ -100,6 +100,8 @@ type Chat struct {
 	Datetime time.Time `json:"datetime"`
 	Name     string    `json:"name"`
 	Message  string    `json:"message"`
+	// Flag names the moderation rule the message broke, if any.
+	Flag string `json:"flag,omitempty"`
 }
 
 // ServerConfig is a lightweight input model used to create a new server.
@@ -264,6 +266,9 @@ type Server struct {
 	clientsScanActive bool
 	clientsScanSeen   map[string]struct{}
 	clientsScanStart  time.Time
+	// whitelistPending holds clients (lowercased name to name) that joined without a SteamID
+	// while whitelist mode is on; the next CLIENTS scan resolves their SteamID for the check.
+	whitelistPending map[string]string
 	// --- Discord notifications ---
 	// DiscordWebhook overrides the manager default for server-specific notifications when non-empty.
 	DiscordWebhook string `json:"discord_webhook"`
@@ -297,6 +302,68 @@ type Server struct {
 	NotifyColorUpdateFailed    string `json:"notify_color_update_failed"`
 	resourceMu                 sync.RWMutex
 	resourceUsage              *ServerResourceUsage
+	// Schedules lists cron-driven jobs (restart, saves, announcements, console commands).
+	Schedules []ScheduledJob `json:"schedules,omitempty"`
+	// --- Crash supervision ---
+	// CrashAutoRestart restarts the server after an unexpected exit, up to CrashMaxRestarts
+	// times within CrashWindowMinutes, waiting CrashBackoffSeconds (doubled per crash) first.
+	CrashAutoRestart    bool `json:"crash_auto_restart"`
+	CrashMaxRestarts    int  `json:"crash_max_restarts"`
+	CrashWindowMinutes  int  `json:"crash_window_minutes"`
+	CrashBackoffSeconds int  `json:"crash_backoff_seconds"`
+	// CrashRestoreAutosave restores the newest valid autosave before an automatic restart.
+	CrashRestoreAutosave bool `json:"crash_restore_autosave"`
+	// --- World backups ---
+	// BackupEnabled takes a scheduled archive of the saves directory every BackupIntervalMinutes
+	// while the server runs. Archives are pruned GFS-style, keeping the newest archive in each of
+	// the last BackupKeepHourly hours, BackupKeepDaily days and BackupKeepWeekly weeks.
+	BackupEnabled         bool `json:"backup_enabled"`
+	BackupIntervalMinutes int  `json:"backup_interval_minutes"`
+	BackupKeepHourly      int  `json:"backup_keep_hourly"`
+	BackupKeepDaily       int  `json:"backup_keep_daily"`
+	BackupKeepWeekly      int  `json:"backup_keep_weekly"`
+	// --- Whitelist ---
+	// WhitelistEnabled kicks clients whose SteamID is neither whitelisted nor an admin once
+	// they are ready. WhitelistMessage is sent as chat first when non-empty ({player} token).
+	WhitelistEnabled bool     `json:"whitelist_enabled"`
+	Whitelist        []string `json:"whitelist,omitempty"`
+	WhitelistMessage string   `json:"whitelist_message,omitempty"`
+	// WhitelistAutoAdd enrolls unknown players instead of kicking them while the server has a
+	// password, so a group can join once with the password before the list is locked.
+	WhitelistAutoAdd bool `json:"whitelist_auto_add"`
+	// OnUnexpectedExit is invoked after the process exits without a Stop/StopAsync/QUIT request.
+	OnUnexpectedExit func(*Server) `json:"-"`
+	// ChatModeration holds the automatic chat moderation rules; see server_chat_moderation.go.
+	ChatModeration ChatModeration `json:"chat_moderation"`
+	// OnSettingsChanged is invoked when log handling changes persisted settings (whitelist auto-add).
+	OnSettingsChanged func(*Server) `json:"-"`
+	// ChatCommandsEnabled turns on in-game chat commands such as "!online".
+	ChatCommandsEnabled bool `json:"chat_commands_enabled"`
+	// ChatCommandPrefix starts a chat command; empty means "!".
+	ChatCommandPrefix string `json:"chat_command_prefix,omitempty"`
+	// ChatCommandAllowed reports whether the SDSM user linked to steamID holds permission.
+	ChatCommandAllowed func(s *Server, steamID, permission string) bool `json:"-"`
+	// OnRestartRequest performs restarts requested from chat (votes and admin commands).
+	OnRestartRequest func(s *Server, reason string) `json:"-"`
+	// OnPlayerChat receives player chat that passed moderation (used by the Discord bridge).
+	OnPlayerChat func(s *Server, name, message string) `json:"-"`
+	// Rotation policies for the server log and the game output log, set by the manager.
+	logRotation       utils.RotationPolicy
+	outputLogRotation utils.RotationPolicy
+	// outputMu guards outputLog, the output log of a managed process while it runs.
+	outputMu     sync.Mutex
+	outputLog    *utils.RotatingFile
+	commandsOnce sync.Once
+	commands     *chatCommandState
+	// OnChatBan issues a temporary ban for chat moderation (duration like "1h").
+	OnChatBan      func(s *Server, steamID, name, reason, duration string) error `json:"-"`
+	moderationOnce sync.Once
+	moderation     *chatModerator
+	// exitMu guards run and crashTimes, which the exit monitors and the crash supervisor
+	// use from their own goroutines.
+	exitMu     sync.Mutex
+	run        *processRun
+	crashTimes []time.Time
 }
 
 // PID returns the best-known operating system process ID for the running server.
@@ -476,6 +543,9 @@ func (s *Server) noteClientsScan(steamID, name string, when time.Time) {
 		return
 	}
 	s.clientsScanSeen[key] = struct{}{}
+	if id != "" && nm != "" {
+		s.resolveWhitelistPending(nm, id)
+	}
 	// Ensure client exists and is online
 	// Try by SteamID first
 	for _, existing := range s.Clients {
@@ -538,6 +608,7 @@ func (s *Server) endClientsScan() {
 	}
 	s.rewritePlayersLog()
 	s.clientsScanActive = false
+	s.dropWhitelistPending()
 }
 
 // queuePendingPlayerSave enqueues a manual save filename to be moved to playersave once completed.
@@ -560,7 +631,7 @@ func (s *Server) queuePendingPlayerSave(filename string) {
 	s.pendingPlayerSave = append(s.pendingPlayerSave, filename)
 	s.pendingPlayerSaveMu.Unlock()
 	if s.Logger != nil {
-		s.Logger.Write("Queued player save for move: " + filename)
+		s.Logger.Info("Queued player save for move: " + filename)
 	}
 }
 
@@ -614,12 +685,12 @@ func (s *Server) tryMoveNextPendingPlayerSave() {
 			// Move and normalize to single-extension at destination
 			if errMove := os.Rename(legacySrc, dstPath); errMove != nil {
 				if s.Logger != nil {
-					s.Logger.Write(fmt.Sprintf("Failed to normalize and move player save %s -> %s: %v", legacySrc, dstPath, errMove))
+					s.Logger.Error(fmt.Sprintf("Failed to normalize and move player save %s -> %s: %v", legacySrc, dstPath, errMove))
 				}
 				return
 			}
 			if s.Logger != nil {
-				s.Logger.Write("Normalized double-extension and moved player save to playersave: " + dstPath)
+				s.Logger.Info("Normalized double-extension and moved player save to playersave: " + dstPath)
 			}
 			// Pop the moved filename
 			s.pendingPlayerSave = s.pendingPlayerSave[1:]
@@ -627,18 +698,18 @@ func (s *Server) tryMoveNextPendingPlayerSave() {
 		}
 		// Not yet on disk; keep queued and try again on next save completion
 		if s.Logger != nil {
-			s.Logger.Write("Pending player save not found yet: " + srcPath)
+			s.Logger.Debug("Pending player save not found yet: " + srcPath)
 		}
 		return
 	}
 	if err := os.Rename(srcPath, dstPath); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to move player save %s -> %s: %v", srcPath, dstPath, err))
+			s.Logger.Error(fmt.Sprintf("Failed to move player save %s -> %s: %v", srcPath, dstPath, err))
 		}
 		return
 	}
 	if s.Logger != nil {
-		s.Logger.Write("Moved player save to playersave: " + dstPath)
+		s.Logger.Info("Moved player save to playersave: " + dstPath)
 	}
 	// Pop the moved filename
 	s.pendingPlayerSave = s.pendingPlayerSave[1:]
@@ -682,10 +753,38 @@ func (s *Server) EnsureLogger(paths *utils.Paths) {
 		s.Logger.Close()
 	}
 
-	s.Logger = utils.NewLogger(paths.ServerLogFile(s.ID))
+	s.Logger = utils.NewLogger(paths.ServerLogFile(s.ID)).With("server", s.ID)
+	s.Logger.SetRotation(s.logRotation)
 	s.loadPlayerHistory()
 }
 
+// SetLogRotation sets the rotation policies for the server log and the game output log. The
+// output of a managed server rotates as it is written; a detached server writes its own log
+// file, which is only rotated when it starts.
+func (s *Server) SetLogRotation(serverLog, outputLog utils.RotationPolicy) {
+	s.logRotation = serverLog
+	s.outputLogRotation = outputLog
+	s.Logger.SetRotation(serverLog)
+	s.outputMu.Lock()
+	if s.outputLog != nil {
+		s.outputLog.SetPolicy(outputLog)
+	}
+	s.outputMu.Unlock()
+}
+
+func (s *Server) rotateOutputLog() {
+	if s.Paths == nil {
+		return
+	}
+	archive, err := utils.RotateFileIfDue(s.Paths.ServerOutputFile(s.ID), s.outputLogRotation, time.Now())
+	switch {
+	case err != nil:
+		s.Logger.Error(fmt.Sprintf("Output log rotation failed: %v", err))
+	case archive != "":
+		s.Logger.Info(fmt.Sprintf("Output log rotated to %s", archive))
+	}
+}
+
 func (s *Server) playersLogPath() string {
 	if s.Paths == nil {
 		return ""
@@ -815,6 +914,19 @@ func (s *Server) WriteBlacklistIDs(ids []string) error {
 	if path == "" {
 		return fmt.Errorf("blacklist path unavailable")
 	}
+	return writeSteamIDList(path, ids)
+}
+
+// WriteAdminListIDs writes the provided IDs to AdminList.txt in the same format as Blacklist.txt.
+func (s *Server) WriteAdminListIDs(ids []string) error {
+	path := s.adminListPath()
+	if path == "" {
+		return fmt.Errorf("admin list path unavailable")
+	}
+	return writeSteamIDList(path, ids)
+}
+
+func writeSteamIDList(path string, ids []string) error {
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
@@ -895,9 +1007,30 @@ func (s *Server) ResolveNameForSteamID(id string) string {
 	return ""
 }
 
+// PlayerSessions returns copies of every known session (history and live), loading the
+// players log on first use.
+func (s *Server) PlayerSessions() []Client {
+	if s == nil {
+		return nil
+	}
+	s.loadPlayerHistory()
+	out := make([]Client, 0, len(s.Clients))
+	for _, c := range s.Clients {
+		if c != nil && strings.TrimSpace(c.SteamID) != "" {
+			out = append(out, *c)
+		}
+	}
+	return out
+}
+
 type BannedEntry struct {
 	SteamID string `json:"steam_id"`
 	Name    string `json:"name"`
+	// Filled from the manager's ban records when the ban was issued through SDSM.
+	Reason    string     `json:"reason,omitempty"`
+	Author    string     `json:"author,omitempty"`
+	Scope     string     `json:"scope,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 // BannedEntries returns the list of banned Steam IDs with best-effort names from player history.
@@ -926,7 +1059,7 @@ func (s *Server) loadPlayerHistory() {
 	file, err := os.Open(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to open players log: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to open players log: %v", err))
 		}
 		return
 	}
@@ -969,7 +1102,7 @@ func (s *Server) loadPlayerHistory() {
 		}
 	}
 	if err := scanner.Err(); err != nil && s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("Error reading players log: %v", err))
+		s.Logger.Error(fmt.Sprintf("Error reading players log: %v", err))
 	}
 	if deduped {
 		s.markPlayersLogDirty()
@@ -986,14 +1119,14 @@ func (s *Server) appendPlayerLog(c *Client) {
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to create players log dir: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to create players log dir: %v", err))
 		}
 		return
 	}
 	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to open players log for append: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to open players log for append: %v", err))
 		}
 		return
 	}
@@ -1016,7 +1149,7 @@ func (s *Server) appendPlayerLog(c *Client) {
 		adminFlag,
 	)
 	if _, err := file.WriteString(entry); err != nil && s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("Failed to write players log entry: %v", err))
+		s.Logger.Error(fmt.Sprintf("Failed to write players log entry: %v", err))
 	}
 }
 
@@ -1077,7 +1210,7 @@ func (s *Server) rewritePlayersLog() {
 
 	if err := os.MkdirAll(baseDir, 0o755); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to create players log dir during update: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to create players log dir during update: %v", err))
 		}
 		return
 	}
@@ -1085,7 +1218,7 @@ func (s *Server) rewritePlayersLog() {
 	file, err := os.OpenFile(safeTempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to open temp players log: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to open temp players log: %v", err))
 		}
 		return
 	}
@@ -1094,7 +1227,7 @@ func (s *Server) rewritePlayersLog() {
 			file.Close()
 			os.Remove(safeTempPath)
 			if s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Failed to write temp players log: %v", err))
+				s.Logger.Error(fmt.Sprintf("Failed to write temp players log: %v", err))
 			}
 			return
 		}
@@ -1102,7 +1235,7 @@ func (s *Server) rewritePlayersLog() {
 	if err := file.Close(); err != nil {
 		os.Remove(safeTempPath)
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed closing temp players log: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed closing temp players log: %v", err))
 		}
 		return
 	}
@@ -1110,14 +1243,14 @@ func (s *Server) rewritePlayersLog() {
 	if !isPathWithin(baseDir, safeTempPath) || !isPathWithin(baseDir, safeLogPath) {
 		os.Remove(safeTempPath)
 		if s.Logger != nil {
-			s.Logger.Write("Aborting players log replace due to failed path containment check")
+			s.Logger.Error("Aborting players log replace due to failed path containment check")
 		}
 		return
 	}
 	if err := os.Rename(safeTempPath, safeLogPath); err != nil {
 		os.Remove(safeTempPath)
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to replace players log: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to replace players log: %v", err))
 		}
 		return
 	}
@@ -1139,6 +1272,39 @@ func (s *Server) LiveClients() []*Client {
 	return live
 }
 
+// chatAuthor resolves a chat line's author among the live clients. An exact name match is
+// preferred over a case-insensitive one. unique is false unless exactly one client matches
+// the name exactly and no other player's name differs from it only by case, so callers must
+// not act on the author's SteamID otherwise.
+func (s *Server) chatAuthor(name string) (client *Client, unique bool) {
+	var exact, folded []*Client
+	for _, c := range s.LiveClients() {
+		switch {
+		case c.Name == name:
+			exact = append(exact, c)
+		case strings.EqualFold(c.Name, name):
+			folded = append(folded, c)
+		}
+	}
+	switch {
+	case len(exact) > 0:
+		client = exact[0]
+	case len(folded) > 0:
+		return folded[0], false
+	default:
+		return nil, false
+	}
+	if len(exact) != 1 {
+		return client, false
+	}
+	for _, c := range folded {
+		if c.SteamID == "" || c.SteamID != client.SteamID {
+			return client, false
+		}
+	}
+	return client, true
+}
+
 func (s *Server) addChatMessage(name string, when time.Time, message string) {
 	name = strings.TrimSpace(name)
 	message = strings.TrimSpace(message)
@@ -1628,11 +1794,11 @@ func NewServer(serverID int, paths *utils.Paths, data string) *Server {
 
 	s.CardToggles = copyCardToggleMap(s.CardToggles)
 
-	s.Logger.Write("Server initialized.")
+	s.Logger.Info("Server initialized.")
 
 	if s.AutoUpdate {
 		if err := s.Deploy(); err != nil && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("AutoUpdate deploy failed: %v", err))
+			s.Logger.Error(fmt.Sprintf("AutoUpdate deploy failed: %v", err))
 		}
 	}
 
@@ -1756,12 +1922,12 @@ func (s *Server) reportProgress(stage string, processed, total int64) {
 }
 
 // Deploy copies the release/beta game files (and BepInEx/LaunchPad assets)
-// into this server's game directory. Progress is reported via the
-// progressReporter callback when set.
+// into this server's game directory, then syncs the selected library mods.
+// Progress is reported via the progressReporter callback when set.
 func (s *Server) Deploy() error {
 	if s.Paths == nil {
 		if s.Logger != nil {
-			s.Logger.Write("Skipping deploy because server paths are not configured")
+			s.Logger.Warn("Skipping deploy because server paths are not configured")
 		}
 		return errors.New("server paths are not configured")
 	}
@@ -1775,14 +1941,14 @@ func (s *Server) Deploy() error {
 	}
 	dst := s.Paths.ServerGameDir(s.ID)
 
-	s.Logger.Write(fmt.Sprintf("Deploying server files from %s to %s", src, dst))
+	s.Logger.Info(fmt.Sprintf("Deploying server files from %s to %s", src, dst))
 	totalFiles, err := countFiles(src)
 	if err != nil {
-		s.Logger.Write(fmt.Sprintf("Failed to enumerate source files: %v", err))
+		s.Logger.Error(fmt.Sprintf("Failed to enumerate source files: %v", err))
 		return err
 	}
 
-	additionalFiles := s.countFilesIfExists(s.Paths.BepInExDir()) + s.countFilesIfExists(s.Paths.LaunchPadDir()) + s.countFilesIfExists(s.Paths.SCONDir())
+	additionalFiles := s.countFilesIfExists(s.Paths.BepInExDir()) + s.countFilesIfExists(s.Paths.LaunchPadDir()) + s.countFilesIfExists(s.Paths.SCONDir()) + s.countSelectedModFiles()
 	if additionalFiles > 0 {
 		totalFiles += additionalFiles
 	}
@@ -1791,13 +1957,19 @@ func (s *Server) Deploy() error {
 
 	tracker := newCopyTracker(s, totalFiles)
 	if err := s.copyDir(src, dst, tracker); err != nil {
-		s.Logger.Write(fmt.Sprintf("Deploy encountered errors: %v", err))
+		s.Logger.Error(fmt.Sprintf("Deploy encountered errors: %v", err))
 		s.reportProgress("Failed", tracker.processed, tracker.total)
 		return err
 	}
 
 	if err := s.deployBepInExAssets(dst, tracker); err != nil {
-		s.Logger.Write(fmt.Sprintf("Failed to deploy BepInEx assets: %v", err))
+		s.Logger.Error(fmt.Sprintf("Failed to deploy BepInEx assets: %v", err))
+		s.reportProgress("Failed", tracker.processed, tracker.total)
+		return err
+	}
+
+	if err := s.syncMods(tracker); err != nil {
+		s.Logger.Error(fmt.Sprintf("Failed to deploy mods: %v", err))
 		s.reportProgress("Failed", tracker.processed, tracker.total)
 		return err
 	}
@@ -1805,8 +1977,6 @@ func (s *Server) Deploy() error {
 	s.reportProgress("Completed", tracker.processed, tracker.total)
 
 	return nil
-
-	// TODO: add mods
 }
 
 func (s *Server) countFilesIfExists(path string) int64 {
@@ -1816,7 +1986,7 @@ func (s *Server) countFilesIfExists(path string) int64 {
 	info, err := os.Stat(path)
 	if err != nil {
 		if !os.IsNotExist(err) && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to access %s: %v", path, err))
+			s.Logger.Error(fmt.Sprintf("Failed to access %s: %v", path, err))
 		}
 		return 0
 	}
@@ -1826,7 +1996,7 @@ func (s *Server) countFilesIfExists(path string) int64 {
 	count, err := countFiles(path)
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to count files in %s: %v", path, err))
+			s.Logger.Error(fmt.Sprintf("Failed to count files in %s: %v", path, err))
 		}
 		return 0
 	}
@@ -1847,7 +2017,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 	}
 
 	if s.Logger != nil {
-		s.Logger.Write("Copying BepInEx files into server game directory")
+		s.Logger.Info("Copying BepInEx files into server game directory")
 	}
 	if err := s.copyDir(bepDir, dst, tracker); err != nil {
 		return fmt.Errorf("copying BepInEx content: %w", err)
@@ -1858,7 +2028,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 	if err != nil {
 		if os.IsNotExist(err) {
 			if s.Logger != nil {
-				s.Logger.Write("LaunchPad directory not found; skipping plugin copy")
+				s.Logger.Warn("LaunchPad directory not found; skipping plugin copy")
 			}
 			return nil
 		}
@@ -1866,7 +2036,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 	}
 	if !launchInfo.IsDir() {
 		if s.Logger != nil {
-			s.Logger.Write("LaunchPad path is not a directory; skipping plugin copy")
+			s.Logger.Warn("LaunchPad path is not a directory; skipping plugin copy")
 		}
 		return nil
 	}
@@ -1876,7 +2046,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 		return fmt.Errorf("creating plugins directory: %w", err)
 	}
 	if s.Logger != nil {
-		s.Logger.Write("Copying LaunchPad files into BepInEx/plugins/StationeersLaunchPad")
+		s.Logger.Info("Copying LaunchPad files into BepInEx/plugins/StationeersLaunchPad")
 	}
 	launchpadDst := filepath.Join(pluginsDst, "StationeersLaunchPad")
 	if err := os.MkdirAll(launchpadDst, os.ModePerm); err != nil {
@@ -1890,7 +2060,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 	sconDir := s.Paths.SCONDir()
 	if sconInfo, err := os.Stat(sconDir); err == nil && sconInfo.IsDir() {
 		if s.Logger != nil {
-			s.Logger.Write("Copying SCON files into BepInEx/plugins")
+			s.Logger.Info("Copying SCON files into BepInEx/plugins")
 		}
 		if err := s.copyDir(sconDir, pluginsDst, tracker); err != nil {
 			return fmt.Errorf("copying SCON content: %w", err)
@@ -1902,7 +2072,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 	// After copying BepInEx files, run the installer to finalize setup.
 	if err := s.installBepInEx(dst); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("BepInEx installation step failed: %v", err))
+			s.Logger.Error(fmt.Sprintf("BepInEx installation step failed: %v", err))
 		}
 		// Non-fatal; BepInEx may still work on first server start
 	}
@@ -1916,7 +2086,7 @@ func (s *Server) deployBepInExAssets(dst string, tracker *copyTracker) error {
 func (s *Server) installBepInEx(gameDir string) error {
 	if runtime.GOOS == "windows" {
 		if s.Logger != nil {
-			s.Logger.Write("Installing BepInEx via initial server run (Windows)")
+			s.Logger.Info("Installing BepInEx via initial server run (Windows)")
 		}
 
 		// Determine the executable name
@@ -1933,7 +2103,7 @@ func (s *Server) installBepInEx(gameDir string) error {
 		cmd.Dir = gameDir
 
 		if s.Logger != nil {
-			s.Logger.Write("Starting server briefly to initialize BepInEx config")
+			s.Logger.Info("Starting server briefly to initialize BepInEx config")
 		}
 
 		// Run with a timeout in case it doesn't quit cleanly (configurable)
@@ -1955,7 +2125,7 @@ func (s *Server) installBepInEx(gameDir string) error {
 		case err := <-done:
 			// Process exited on its own
 			if err != nil && s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("BepInEx init run exited with status: %v", err))
+				s.Logger.Warn(fmt.Sprintf("BepInEx init run exited with status: %v", err))
 			}
 		case <-time.After(timeout * time.Second):
 			// Timeout: kill the process
@@ -1969,11 +2139,11 @@ func (s *Server) installBepInEx(gameDir string) error {
 		bepinexConfigDir := filepath.Join(gameDir, "BepInEx", "config")
 		if _, err := os.Stat(bepinexConfigDir); err == nil {
 			if s.Logger != nil {
-				s.Logger.Write("BepInEx initialization run completed successfully (config detected)")
+				s.Logger.Info("BepInEx initialization run completed successfully (config detected)")
 			}
 		} else {
 			if s.Logger != nil {
-				s.Logger.Write("Warning: BepInEx config directory not found after init run; plugins may not load until first full start")
+				s.Logger.Warn("BepInEx config directory not found after init run; plugins may not load until first full start")
 			}
 		}
 		return nil
@@ -1981,7 +2151,7 @@ func (s *Server) installBepInEx(gameDir string) error {
 
 	// Other platforms (macOS, etc.) not yet implemented
 	if s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("BepInEx installation not implemented for platform: %s", runtime.GOOS))
+		s.Logger.Error(fmt.Sprintf("BepInEx installation not implemented for platform: %s", runtime.GOOS))
 	}
 	return nil
 }
@@ -2072,7 +2242,7 @@ func (s *Server) StopAsync(broadcast func(*Server)) {
 			// If all players have disconnected during the countdown, accelerate shutdown immediately
 			if len(srv.LiveClients()) == 0 && time.Until(srv.StoppingEnds) > 0 {
 				if srv.Logger != nil {
-					srv.Logger.Write("No players connected; skipping remaining shutdown delay")
+					srv.Logger.Info("No players connected; skipping remaining shutdown delay")
 				}
 				srv.StoppingEnds = time.Now()
 			}
@@ -2091,7 +2261,7 @@ func (s *Server) StopAsync(broadcast func(*Server)) {
 			case <-cancel:
 				// Cancellation requested; finalize without stopping process
 				if srv.Logger != nil {
-					srv.Logger.Write("Shutdown canceled before completion")
+					srv.Logger.Info("Shutdown canceled before completion")
 				}
 				srv.sendCancellationNotice()
 				srv.Stopping = false
@@ -2151,7 +2321,7 @@ func (s *Server) sendChat(msg string) {
 	expanded := s.RenderChatMessage(msg, nil)
 	if err := s.SendCommand("chat", expanded); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to send chat notice '%s': %v", expanded, err))
+			s.Logger.Warn(fmt.Sprintf("Failed to send chat notice '%s': %v", expanded, err))
 		}
 	}
 }
@@ -2182,10 +2352,11 @@ func (s *Server) performFinalShutdown() {
 		}
 		return
 	}
+	s.markStopRequested()
 	// Best-effort graceful shutdown via SCON QUIT
 	if err := s.SendCommand("console", "QUIT"); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to send QUIT command: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to send QUIT command: %v", err))
 		}
 	}
 	time.Sleep(3 * time.Second)
@@ -2325,7 +2496,7 @@ func (s *Server) Restart() {
 	defer s.restartMu.Unlock()
 
 	if s.Logger != nil {
-		s.Logger.Write("Restart requested; stopping server")
+		s.Logger.Info("Restart requested; stopping server")
 	}
 
 	s.Stop()
@@ -2334,18 +2505,30 @@ func (s *Server) Restart() {
 	delay := s.restartDelayDuration()
 	if delay > 0 {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Waiting %s before restarting server", delay))
+			s.Logger.Info(fmt.Sprintf("Waiting %s before restarting server", delay))
 		}
 		time.Sleep(delay)
 	}
 
 	if s.Logger != nil {
-		s.Logger.Write("Restart delay elapsed; starting server")
+		s.Logger.Info("Restart delay elapsed; starting server")
 	}
 
 	s.Start()
 }
 
+// StopForUpdate performs the blocking Stop (warning connected players with the staged
+// shutdown notices) and waits for the process to exit so server files can be replaced.
+func (s *Server) StopForUpdate() {
+	if s == nil {
+		return
+	}
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+	s.Stop()
+	s.waitForShutdown(15 * time.Second)
+}
+
 func (s *Server) restartDelayDuration() time.Duration {
 	if s.RestartDelaySeconds > 0 {
 		return time.Duration(s.RestartDelaySeconds) * time.Second
@@ -2390,7 +2573,7 @@ func (s *Server) waitForShutdown(timeout time.Duration) {
 		case <-ticker.C:
 		case <-deadline.C:
 			if s.Logger != nil {
-				s.Logger.Write("Timed out waiting for server to shut down before restart")
+				s.Logger.Warn("Timed out waiting for server to shut down before restart")
 			}
 			return
 		}
@@ -2463,7 +2646,7 @@ func (s *Server) adaptivePortForward() {
 		s.PortForwardLastError = ""
 		s.PortForwardSource = "game"
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Port forward detected/available (assuming game mapping). External UDP %d", external))
+			s.Logger.Info(fmt.Sprintf("Port forward detected/available (assuming game mapping). External UDP %d", external))
 		}
 		// Do not start the SDSM refresh loop; rely on game's mapping
 		return
@@ -2471,7 +2654,7 @@ func (s *Server) adaptivePortForward() {
 
 	// Probe failed; fall back to SDSM-managed loop (UPnP/NAT-PMP + refresh)
 	if s.Logger != nil {
-		s.Logger.Write("UPnP probe failed; falling back to SDSM-managed port forwarding")
+		s.Logger.Warn("UPnP probe failed; falling back to SDSM-managed port forwarding")
 	}
 	s.managePortForwarding()
 }
@@ -2482,7 +2665,7 @@ func (s *Server) refreshPortMapping() {
 	externalPort, err := utils.AddOrRefreshMapping(ctx, "udp", s.Port, "SDSM Stationeers Server", 10*time.Minute)
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write("Port forward attempt failed: " + err.Error())
+			s.Logger.Warn("Port forward attempt failed: " + err.Error())
 		}
 		s.PortForwardActive = false
 		s.PortForwardLastError = err.Error()
@@ -2494,7 +2677,7 @@ func (s *Server) refreshPortMapping() {
 	s.PortForwardLastError = ""
 	s.PortForwardSource = "sdsm"
 	if s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("Port forward active: internal UDP %d -> external UDP %d", s.Port, externalPort))
+		s.Logger.Info(fmt.Sprintf("Port forward active: internal UDP %d -> external UDP %d", s.Port, externalPort))
 	}
 }
 
@@ -2503,12 +2686,12 @@ func (s *Server) deletePortMapping() {
 	ctx := context.Background()
 	if err := utils.DeleteMapping(ctx, "udp", s.Port); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write("Port forward removal failed: " + err.Error())
+			s.Logger.Warn("Port forward removal failed: " + err.Error())
 		}
 		return
 	}
 	if s.Logger != nil {
-		s.Logger.Write("Port forward mapping removed")
+		s.Logger.Info("Port forward mapping removed")
 	}
 	s.PortForwardActive = false
 	s.PortForwardSource = ""
@@ -2516,19 +2699,19 @@ func (s *Server) deletePortMapping() {
 
 func (s *Server) Start() {
 	if s.Logger != nil {
-		s.Logger.Write("Start requested")
+		s.Logger.Info("Start requested")
 	}
 
 	if s.Paths == nil {
 		if s.Logger != nil {
-			s.Logger.Write("Cannot start: server paths are not configured")
+			s.Logger.Error("Cannot start: server paths are not configured")
 		}
 		return
 	}
 
 	if s.Running {
 		if s.Logger != nil {
-			s.Logger.Write("Start skipped: process is already running")
+			s.Logger.Warn("Start skipped: process is already running")
 		}
 		return
 	}
@@ -2536,7 +2719,7 @@ func (s *Server) Start() {
 	if s.Proc != nil {
 		if s.Proc.ProcessState == nil {
 			if s.Logger != nil {
-				s.Logger.Write("Start skipped: process is already running")
+				s.Logger.Warn("Start skipped: process is already running")
 			}
 			return
 		}
@@ -2547,11 +2730,12 @@ func (s *Server) Start() {
 	// Clear previous error state on a new start attempt
 	s.LastError = ""
 	s.LastErrorAt = nil
+	run := s.beginProcessRun()
 
 	// Stubbed save purge hook: if core parameters changed previously, we would purge saves here.
 	// For now, just log intent and proceed without deleting anything.
 	if s.PendingSavePurge && s.Logger != nil {
-		s.Logger.Write("Pending save purge flagged due to core parameter change (stub: no deletion performed)")
+		s.Logger.Info("Pending save purge flagged due to core parameter change (stub: no deletion performed)")
 	}
 
 	var executableName string
@@ -2562,15 +2746,15 @@ func (s *Server) Start() {
 	}
 	executablePath := filepath.Join(s.Paths.ServerGameDir(s.ID), executableName)
 	if s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("Resolved executable path: %s", executablePath))
+		s.Logger.Debug(fmt.Sprintf("Resolved executable path: %s", executablePath))
 	}
 
 	if s.AutoUpdate || !fileExists(executablePath) {
 		if s.Logger != nil {
-			s.Logger.Write("AutoUpdate triggered deploy before start")
+			s.Logger.Info("AutoUpdate triggered deploy before start")
 		}
 		if err := s.Deploy(); err != nil && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Deploy before start failed: %v", err))
+			s.Logger.Error(fmt.Sprintf("Deploy before start failed: %v", err))
 		}
 	}
 
@@ -2579,7 +2763,24 @@ func (s *Server) Start() {
 		worldIdentifier = s.World
 	}
 
-	s.Logger.Write(fmt.Sprintf("World: %s  -  WorldId: %s", s.World, s.WorldID))
+	s.Logger.Info(fmt.Sprintf("World: %s  -  WorldId: %s", s.World, s.WorldID))
+
+	// A managed server logs to stdout, which is written to the output log by a RotatingFile.
+	// A detached server outlives the manager and must write its own log file instead.
+	var output *outputPump
+	logFileArg := s.Paths.ServerOutputFile(s.ID)
+	if s.Detached {
+		s.rotateOutputLog()
+	} else {
+		f, err := utils.OpenRotatingFile(logFileArg)
+		if err != nil {
+			s.Logger.Error(fmt.Sprintf("Failed to open output log: %v", err))
+			return
+		}
+		f.SetPolicy(s.outputLogRotation)
+		output = newOutputPump(s, f)
+		logFileArg = "-"
+	}
 
 	// Sanitize key launch parameters to a restricted character set to avoid
 	// injecting unexpected control characters or shell metacharacters into
@@ -2608,7 +2809,7 @@ func (s *Server) Start() {
 	serverAuthSecret := secure(s.AuthSecret)
 	args := []string{
 		"-FILE", "start", launchName, launchWorld, launchDifficulty, launchStartCond, launchStartLoc,
-		"-logFile", s.Paths.ServerOutputFile(s.ID),
+		"-logFile", logFileArg,
 		"-SETTINGSPATH", s.Paths.ServerSettingsFile(s.ID),
 		"-SETTINGS",
 		"ServerVisible", strconv.FormatBool(s.Visible),
@@ -2640,7 +2841,7 @@ func (s *Server) Start() {
 	if s.UseGameUPnP {
 		args = append(args, "UPNPEnabled", "true")
 	}
-	s.Logger.Write(fmt.Sprintf("Starting server %d with command line: %v %v", s.ID, executablePath, args))
+	s.Logger.Info(fmt.Sprintf("Starting server %d with command line: %v %v", s.ID, executablePath, args))
 
 	var cmd *exec.Cmd
 	if runtime.GOOS == "linux" {
@@ -2650,50 +2851,60 @@ func (s *Server) Start() {
 		if _, err := os.Stat(scriptPath); err != nil {
 			bepDir := filepath.Join(s.Paths.ServerGameDir(s.ID), "BepInEx")
 			if _, berr := os.Stat(bepDir); berr == nil && s.Logger != nil {
-				s.Logger.Write("Warning: BepInEx directory present but run_bepinex.sh wrapper missing; plugins may not load")
+				s.Logger.Warn("BepInEx directory present but run_bepinex.sh wrapper missing; plugins may not load")
 			}
 		}
 		// Make the script executable
 		if err := os.Chmod(scriptPath, 0o755); err != nil && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Warning: failed to make run_bepinex.sh executable: %v", err))
+			s.Logger.Warn(fmt.Sprintf("Failed to make run_bepinex.sh executable: %v", err))
 		}
 		// run_bepinex.sh expects: ./run_bepinex.sh <executable> [args...]
 		scriptArgs := append([]string{executablePath}, args...)
 		cmd = exec.Command(scriptPath, scriptArgs...)
 		if s.Logger != nil {
-			s.Logger.Write("Using run_bepinex.sh wrapper for BepInEx support")
+			s.Logger.Info("Using run_bepinex.sh wrapper for BepInEx support")
 		}
 	} else if runtime.GOOS == "windows" {
 		// On Windows, run executable directly
 		cmd = exec.Command(executablePath, args...)
 	} else {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Unsupported platform: %s. Server start aborted.", runtime.GOOS))
+			s.Logger.Error(fmt.Sprintf("Unsupported platform: %s. Server start aborted.", runtime.GOOS))
+		}
+		if output != nil {
+			output.Close()
 		}
 		return
 	}
 	cmd.Dir = s.Paths.ServerGameDir(s.ID)
+	if output != nil {
+		cmd.Stdout = output
+		cmd.Stderr = output
+	}
 
 	// Optionally detach process group (Unix uses Setpgid; Windows uses CREATE_NEW_PROCESS_GROUP).
 	if s.Detached {
 		if s.Logger != nil {
-			s.Logger.Write("Applying detached process group for server process")
+			s.Logger.Debug("Applying detached process group for server process")
 		}
 		setDetachedProcessGroup(cmd)
 	}
 
 	if s.Logger != nil {
-		s.Logger.Write("Starting server process")
+		s.Logger.Info("Starting server process")
 	}
 	// stdin fallback removed: Stationeers does not accept stdin commands reliably
 	if err := cmd.Start(); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to start server process: %v", err))
+			s.Logger.Error(fmt.Sprintf("Failed to start server process: %v", err))
+		}
+		if output != nil {
+			output.Close()
 		}
 		return
 	}
 	if s.Logger != nil {
-		s.Logger.Write("Server process started successfully")
+		s.Logger.Info("Server process started successfully")
 	}
 
 	s.Proc = cmd
@@ -2712,15 +2923,22 @@ func (s *Server) Start() {
 	}
 
 	var tailWG sync.WaitGroup
-	tailWG.Add(1)
-	go func(stop <-chan bool) {
-		defer tailWG.Done()
-		s.tailServerLog(s.Paths.ServerOutputFile(s.ID), stop)
-	}(stopChan)
+	if output != nil {
+		s.outputMu.Lock()
+		s.outputLog = output.file
+		s.outputMu.Unlock()
+	} else {
+		tailWG.Add(1)
+		go func(stop <-chan bool) {
+			defer tailWG.Done()
+			s.tailServerLog(s.Paths.ServerOutputFile(s.ID), stop)
+		}(stopChan)
+	}
 
 	go func(stop chan bool) {
-		if err := cmd.Wait(); err != nil && s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Server process exited with error: %v", err))
+		waitErr := cmd.Wait()
+		if waitErr != nil && s.Logger != nil {
+			s.Logger.Error(fmt.Sprintf("Server process exited with error: %v", waitErr))
 		}
 		s.Running = false
 		s.Starting = false
@@ -2735,16 +2953,23 @@ func (s *Server) Start() {
 			s.Thrd = nil
 		}
 		tailWG.Wait()
+		if output != nil {
+			s.outputMu.Lock()
+			s.outputLog = nil
+			s.outputMu.Unlock()
+			output.Close()
+		}
 		s.markAllClientsDisconnected(time.Now())
 		s.rewritePlayersLog()
 		s.resetChat()
 		if s.Logger != nil {
-			s.Logger.Write("Server process ended")
+			s.Logger.Info("Server process ended")
 		}
 		// Best-effort cleanup of PID file when process ends
 		if p := s.safePIDFilePath(); p != "" {
 			_ = os.Remove(p)
 		}
+		s.handleProcessExit(run, waitErr)
 	}(stopChan)
 	now := time.Now()
 	s.ServerStarted = &now
@@ -2768,13 +2993,14 @@ func (s *Server) AttachToRunning(pid int) {
 		return
 	}
 	if s.Logger != nil {
-		s.Logger.Write(fmt.Sprintf("Attaching to running server (PID %d)", pid))
+		s.Logger.Info(fmt.Sprintf("Attaching to running server (PID %d)", pid))
 	}
 	s.Proc = nil
 	s.pid = pid
 	s.Starting = false
 	s.Stopping = false
 	s.Running = true
+	run := s.beginProcessRun()
 
 	stopChan := make(chan bool)
 	s.Thrd = stopChan
@@ -2819,12 +3045,13 @@ func (s *Server) AttachToRunning(pid int) {
 			if pf != "" && isPathWithin(base, pf) {
 				_ = os.Remove(pf)
 			} else if srv.Logger != nil && pf != "" {
-				srv.Logger.Write("Skipped PID file cleanup due to failed path containment check")
+				srv.Logger.Warn("Skipped PID file cleanup due to failed path containment check")
 			}
 		}
 		if srv.Logger != nil {
-			srv.Logger.Write("Detached server process ended (attach monitor)")
+			srv.Logger.Info("Detached server process ended (attach monitor)")
 		}
+		srv.handleProcessExit(run, nil)
 	}(s, pid, stopChan)
 
 	// Best-effort: issue CLIENTS query via SCON to repopulate live client list, with limited retries
@@ -2900,7 +3127,7 @@ func (s *Server) tailServerLog(path string, stop <-chan bool) {
 			file, err = os.Open(path)
 			if err != nil {
 				if !errors.Is(err, os.ErrNotExist) && s.Logger != nil {
-					s.Logger.Write(fmt.Sprintf("Failed to open server log: %v", err))
+					s.Logger.Error(fmt.Sprintf("Failed to open server log: %v", err))
 				}
 				if waitForStop(pollInterval) {
 					return
@@ -2942,7 +3169,7 @@ func (s *Server) tailServerLog(path string, stop <-chan bool) {
 			}
 
 			if s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Error reading server log: %v", err))
+				s.Logger.Error(fmt.Sprintf("Error reading server log: %v", err))
 			}
 			file.Close()
 			file = nil
@@ -3007,7 +3234,7 @@ func (s *Server) tailServerLogFromOffset(path string, stop <-chan bool, window i
 		f, err := os.Open(path)
 		if err != nil {
 			if !errors.Is(err, os.ErrNotExist) && s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Failed to open server log: %v", err))
+				s.Logger.Error(fmt.Sprintf("Failed to open server log: %v", err))
 			}
 			return false
 		}
@@ -3071,7 +3298,7 @@ func (s *Server) tailServerLogFromOffset(path string, stop <-chan bool, window i
 			}
 
 			if s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Error reading server log: %v", err))
+				s.Logger.Error(fmt.Sprintf("Error reading server log: %v", err))
 			}
 			file.Close()
 			file = nil
@@ -3230,6 +3457,37 @@ func (s *Server) CurrentSCONPort() int {
 	return 8081
 }
 
+// SCONProbe is the outcome of a reachability check against a server's SCON HTTP endpoint.
+type SCONProbe struct {
+	Reachable bool      `json:"reachable"`
+	Status    int       `json:"status"`
+	URL       string    `json:"url"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ProbeSCON performs a lightweight GET against the SCON /command path. Any HTTP
+// response (2xx-5xx) counts as reachable; only connection errors mark it down.
+func (s *Server) ProbeSCON(timeout time.Duration) SCONProbe {
+	url := fmt.Sprintf("http://localhost:%d/command", s.CurrentSCONPort())
+	probe := SCONProbe{URL: url, CheckedAt: time.Now()}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	resp.Body.Close()
+	probe.Reachable = true
+	probe.Status = resp.StatusCode
+	return probe
+}
+
 // SendRaw sends a single console command via SCON HTTP API.
 // Returns an error if the server is not running or SCON API is unavailable.
 func (s *Server) SendRaw(line string) error {
@@ -3239,7 +3497,7 @@ func (s *Server) SendRaw(line string) error {
 	trimmed := strings.TrimSpace(line)
 	if trimmed == "" {
 		if s.Logger != nil {
-			s.Logger.Write("Command send aborted: empty command")
+			s.Logger.Warn("Command send aborted: empty command")
 		}
 		return fmt.Errorf("empty command")
 	}
@@ -3247,7 +3505,7 @@ func (s *Server) SendRaw(line string) error {
 	// (attached-to-running case using SCON HTTP API).
 	if !s.IsRunning() {
 		if s.Logger != nil {
-			s.Logger.Write("Command send failed: server is not running")
+			s.Logger.Warn("Command send failed: server is not running")
 		}
 		return fmt.Errorf("server is not running")
 	}
@@ -3259,7 +3517,7 @@ func (s *Server) SendRaw(line string) error {
 	// Ensure single line only
 	trimmed = strings.ReplaceAll(trimmed, "\n", " ")
 	trimmed = strings.ReplaceAll(trimmed, "\r", " ")
-	s.Logger.Write(fmt.Sprintf("Sending: %s", trimmed))
+	s.Logger.Info(fmt.Sprintf("Sending: %s", trimmed))
 
 	// Create HTTP request to SCON API
 	type CommandRequest struct {
@@ -3268,7 +3526,7 @@ func (s *Server) SendRaw(line string) error {
 	reqBody, err := json.Marshal(CommandRequest{Command: trimmed})
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Command marshal failed: %v", err))
+			s.Logger.Error(fmt.Sprintf("Command marshal failed: %v", err))
 		}
 		return fmt.Errorf("failed to marshal command: %w", err)
 	}
@@ -3277,7 +3535,7 @@ func (s *Server) SendRaw(line string) error {
 	resp, err := http.Post(url, "application/json", bytes.NewReader(reqBody))
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("SCON HTTP POST failed: %v", err))
+			s.Logger.Error(fmt.Sprintf("SCON HTTP POST failed: %v", err))
 		}
 		return fmt.Errorf("failed to send command to SCON API: %w", err)
 	}
@@ -3291,7 +3549,7 @@ func (s *Server) SendRaw(line string) error {
 			bodyStr = bodyStr[:1024] + "…"
 		}
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("SCON API error %d: %s", resp.StatusCode, bodyStr))
+			s.Logger.Error(fmt.Sprintf("SCON API error %d: %s", resp.StatusCode, bodyStr))
 		}
 		return fmt.Errorf("SCON API returned status %d: %s", resp.StatusCode, bodyStr)
 	}
@@ -3310,6 +3568,9 @@ func (s *Server) SendCommand(kind, payload string) error {
 	}
 	switch k {
 	case "", "console":
+		if strings.EqualFold(msg, "QUIT") {
+			s.markStopRequested()
+		}
 		return s.SendRaw(msg)
 	case "chat":
 		// Stationeers console command is 'SAY' (case-sensitive in some contexts)
@@ -3333,7 +3594,7 @@ func (s *Server) parseTime(line string) time.Time {
 func (s *Server) copyDir(src, dst string, tracker *copyTracker) error {
 	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to create directory %s: %v", dst, err))
+			s.Logger.Error(fmt.Sprintf("Failed to create directory %s: %v", dst, err))
 		}
 		return err
 	}
@@ -3341,7 +3602,7 @@ func (s *Server) copyDir(src, dst string, tracker *copyTracker) error {
 	entries, err := os.ReadDir(src)
 	if err != nil {
 		if s.Logger != nil {
-			s.Logger.Write(fmt.Sprintf("Failed to read directory %s: %v", src, err))
+			s.Logger.Error(fmt.Sprintf("Failed to read directory %s: %v", src, err))
 		}
 		return err
 	}
@@ -3360,14 +3621,14 @@ func (s *Server) copyDir(src, dst string, tracker *copyTracker) error {
 		copyNeeded, diffErr := filesDiffer(srcPath, dstPath)
 		if diffErr != nil {
 			if s.Logger != nil {
-				s.Logger.Write(fmt.Sprintf("Failed to compare %s and %s: %v", srcPath, dstPath, diffErr))
+				s.Logger.Error(fmt.Sprintf("Failed to compare %s and %s: %v", srcPath, dstPath, diffErr))
 			}
 			copyNeeded = true
 		}
 		if copyNeeded {
 			if err := copyFile(srcPath, dstPath); err != nil {
 				if s.Logger != nil {
-					s.Logger.Write(fmt.Sprintf("Failed to copy %s to %s: %v", srcPath, dstPath, err))
+					s.Logger.Error(fmt.Sprintf("Failed to copy %s to %s: %v", srcPath, dstPath, err))
 				}
 				errs = append(errs, err)
 			}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Backup policy defaults applied when the per-server values are zero.
+const (
+	DefaultBackupIntervalMinutes = 60
+	DefaultBackupKeepHourly      = 24
+	DefaultBackupKeepDaily       = 7
+	DefaultBackupKeepWeekly      = 4
+)
+
+// BackupInterval returns the effective time between scheduled backups.
+func (s *Server) BackupInterval() time.Duration {
+	if s == nil || s.BackupIntervalMinutes <= 0 {
+		return time.Duration(DefaultBackupIntervalMinutes) * time.Minute
+	}
+	return time.Duration(s.BackupIntervalMinutes) * time.Minute
+}
+
+// BackupRetention returns the effective hourly, daily and weekly keep counts.
+func (s *Server) BackupRetention() (hourly, daily, weekly int) {
+	hourly, daily, weekly = DefaultBackupKeepHourly, DefaultBackupKeepDaily, DefaultBackupKeepWeekly
+	if s == nil {
+		return
+	}
+	if s.BackupKeepHourly > 0 {
+		hourly = s.BackupKeepHourly
+	}
+	if s.BackupKeepDaily > 0 {
+		daily = s.BackupKeepDaily
+	}
+	if s.BackupKeepWeekly > 0 {
+		weekly = s.BackupKeepWeekly
+	}
+	return
+}
@@ -0,0 +1,342 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultChatCommandPrefix = "!"
+	chatCommandCooldown      = 3 * time.Second
+	chatVoteDuration         = 2 * time.Minute
+
+	chatVoteRestart   = "restart"
+	chatVoteSkipStorm = "skipstorm"
+)
+
+// ChatCommandContext describes the player and arguments of one chat command invocation.
+type ChatCommandContext struct {
+	Player *Client
+	// Args holds the words after the command name.
+	Args []string
+	// Prefix is the server's command prefix, for usage hints in replies.
+	Prefix string
+	// Ambiguous is set when another online player shares the author's name, so the
+	// author's SteamID cannot be trusted and only open commands are allowed.
+	Ambiguous bool
+}
+
+// ChatCommand is an in-game command such as "!online". Run returns the chat reply;
+// an empty reply sends nothing.
+type ChatCommand struct {
+	Name  string
+	Usage string
+	Help  string
+	// Permission, when set, limits the command to in-game admins and players whose
+	// linked SDSM user holds this permission on the server (for example "server.kick").
+	Permission string
+	Run        func(s *Server, ctx *ChatCommandContext) string
+}
+
+// chatCommands is the dispatch table for chat commands; RegisterChatCommand adds to it.
+var (
+	chatCommandsMu sync.RWMutex
+	chatCommands   []ChatCommand
+)
+
+// The built-in table is filled in init because the handlers reach back into the log
+// pipeline (restart -> Start -> logLineHandlers), which a variable initializer cannot.
+func init() {
+	chatCommands = []ChatCommand{
+		{Name: "help", Usage: "help", Help: "List the commands you can use", Run: chatCommandHelp},
+		{Name: "playtime", Usage: "playtime", Help: "Your total time on this server", Run: chatCommandPlaytime},
+		{Name: "online", Usage: "online", Help: "Who is online", Run: chatCommandOnline},
+		{Name: "vote", Usage: "vote restart|skipstorm", Help: "Start or join a vote", Run: chatCommandVote},
+		{Name: "restart", Usage: "restart", Help: "Restart the server", Permission: "server.restart", Run: chatCommandRestart},
+		{Name: "kick", Usage: "kick <player>", Help: "Kick a player", Permission: "server.kick", Run: chatCommandKick},
+	}
+}
+
+// RegisterChatCommand adds a chat command, replacing any existing command with the same name.
+func RegisterChatCommand(cmd ChatCommand) {
+	cmd.Name = strings.ToLower(strings.TrimSpace(cmd.Name))
+	if cmd.Name == "" || cmd.Run == nil {
+		return
+	}
+	chatCommandsMu.Lock()
+	defer chatCommandsMu.Unlock()
+	for i := range chatCommands {
+		if chatCommands[i].Name == cmd.Name {
+			chatCommands[i] = cmd
+			return
+		}
+	}
+	chatCommands = append(chatCommands, cmd)
+}
+
+// ChatCommands returns the registered chat commands sorted by name.
+func ChatCommands() []ChatCommand {
+	chatCommandsMu.RLock()
+	out := append([]ChatCommand(nil), chatCommands...)
+	chatCommandsMu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func lookupChatCommand(name string) (ChatCommand, bool) {
+	chatCommandsMu.RLock()
+	defer chatCommandsMu.RUnlock()
+	for _, cmd := range chatCommands {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return ChatCommand{}, false
+}
+
+// chatCommandState holds per-player cooldowns and the running vote.
+type chatCommandState struct {
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+	vote     *chatVote
+}
+
+type chatVote struct {
+	kind    string
+	started time.Time
+	voters  map[string]bool
+}
+
+func (s *Server) commandState() *chatCommandState {
+	s.commandsOnce.Do(func() {
+		s.commands = &chatCommandState{lastUsed: make(map[string]time.Time)}
+	})
+	return s.commands
+}
+
+// ChatCommandPrefixOrDefault returns the configured command prefix or "!".
+func (s *Server) ChatCommandPrefixOrDefault() string {
+	if p := strings.TrimSpace(s.ChatCommandPrefix); p != "" {
+		return p
+	}
+	return defaultChatCommandPrefix
+}
+
+func playerKey(c *Client) string {
+	if id := strings.TrimSpace(c.SteamID); id != "" {
+		return id
+	}
+	return "name:" + strings.ToLower(c.Name)
+}
+
+// canUseChatCommand reports whether the player may run cmd: open commands are available
+// to everyone, others need the in-game admin list or a linked SDSM user with the permission.
+// An ambiguous author never gets permissioned commands.
+func (s *Server) canUseChatCommand(client *Client, ambiguous bool, cmd ChatCommand) bool {
+	if cmd.Permission == "" {
+		return true
+	}
+	if ambiguous {
+		return false
+	}
+	if client.IsAdmin || s.IsSteamIDAdmin(client.SteamID) {
+		return true
+	}
+	return client.SteamID != "" && s.ChatCommandAllowed != nil && s.ChatCommandAllowed(s, client.SteamID, cmd.Permission)
+}
+
+// handleChatCommand dispatches a player's chat line to the matching command. ambiguous
+// marks an author that could not be resolved to a single player. It returns false when
+// the line is not a command.
+func (s *Server) handleChatCommand(client *Client, ambiguous bool, message string) bool {
+	if s == nil || client == nil || !s.ChatCommandsEnabled {
+		return false
+	}
+	prefix := s.ChatCommandPrefixOrDefault()
+	if !strings.HasPrefix(message, prefix) {
+		return false
+	}
+	fields := strings.Fields(strings.TrimPrefix(message, prefix))
+	if len(fields) == 0 {
+		return false
+	}
+	name := strings.ToLower(fields[0])
+
+	state := s.commandState()
+	state.mu.Lock()
+	key := playerKey(client)
+	if last, ok := state.lastUsed[key]; ok && time.Since(last) < chatCommandCooldown {
+		state.mu.Unlock()
+		return true
+	}
+	state.lastUsed[key] = time.Now()
+	state.mu.Unlock()
+
+	cmd, ok := lookupChatCommand(name)
+	var reply string
+	switch {
+	case !ok:
+		reply = fmt.Sprintf("Unknown command %s%s. Try %shelp.", prefix, name, prefix)
+	case !s.canUseChatCommand(client, ambiguous, cmd):
+		reply = fmt.Sprintf("%s, you are not allowed to use %s%s.", client.Name, prefix, cmd.Name)
+	default:
+		if s.Logger != nil {
+			s.Logger.Info(fmt.Sprintf("Chat command: %s (%s) ran %s", client.Name, client.SteamID, strings.Join(fields, " ")))
+		}
+		reply = cmd.Run(s, &ChatCommandContext{Player: client, Args: fields[1:], Prefix: prefix, Ambiguous: ambiguous})
+	}
+	if reply != "" {
+		go func() {
+			if err := s.SendCommand("chat", reply); err != nil && s.Logger != nil {
+				s.Logger.Warn(fmt.Sprintf("Chat command reply failed: %v", err))
+			}
+		}()
+	}
+	return true
+}
+
+func chatCommandHelp(s *Server, ctx *ChatCommandContext) string {
+	var names []string
+	for _, cmd := range ChatCommands() {
+		if s.canUseChatCommand(ctx.Player, ctx.Ambiguous, cmd) {
+			names = append(names, ctx.Prefix+cmd.Usage)
+		}
+	}
+	return "Commands: " + strings.Join(names, ", ")
+}
+
+func chatCommandPlaytime(s *Server, ctx *ChatCommandContext) string {
+	key := playerKey(ctx.Player)
+	var total time.Duration
+	for _, session := range s.PlayerSessions() {
+		session := session
+		if playerKey(&session) == key {
+			total += session.SessionDuration()
+		}
+	}
+	return fmt.Sprintf("%s has played %s on this server.", ctx.Player.Name, formatPlaytime(total))
+}
+
+func chatCommandOnline(s *Server, _ *ChatCommandContext) string {
+	live := s.LiveClients()
+	names := make([]string, 0, len(live))
+	for _, c := range live {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("Online (%d): %s", len(names), strings.Join(names, ", "))
+}
+
+func chatCommandVote(s *Server, ctx *ChatCommandContext) string {
+	if len(ctx.Args) == 0 {
+		return "Usage: " + ctx.Prefix + "vote restart|skipstorm"
+	}
+	kind := strings.ToLower(ctx.Args[0])
+	if kind != chatVoteRestart && kind != chatVoteSkipStorm {
+		return "You can vote on: restart, skipstorm"
+	}
+	if kind == chatVoteSkipStorm && !s.Storming {
+		return "There is no storm to skip."
+	}
+
+	state := s.commandState()
+	state.mu.Lock()
+	now := time.Now()
+	if v := state.vote; v != nil && now.Sub(v.started) > chatVoteDuration {
+		state.vote = nil
+	}
+	if state.vote != nil && state.vote.kind != kind {
+		other := state.vote.kind
+		state.mu.Unlock()
+		return fmt.Sprintf("A vote to %s is already running.", other)
+	}
+	if state.vote == nil {
+		state.vote = &chatVote{kind: kind, started: now, voters: make(map[string]bool)}
+	}
+	state.vote.voters[playerKey(ctx.Player)] = true
+	votes := len(state.vote.voters)
+	needed := len(s.LiveClients())/2 + 1
+	passed := votes >= needed
+	if passed {
+		state.vote = nil
+	}
+	state.mu.Unlock()
+
+	if !passed {
+		return fmt.Sprintf("Vote %s: %d/%d. Type %svote %s to agree.", kind, votes, needed, ctx.Prefix, kind)
+	}
+	if s.Logger != nil {
+		s.Logger.Info(fmt.Sprintf("Chat vote %s passed with %d votes", kind, votes))
+	}
+	switch kind {
+	case chatVoteRestart:
+		s.requestRestart("Players voted to restart the server.")
+		return "Vote passed: restarting the server."
+	default:
+		go func() { _ = s.SendCommand("console", "STORM stop") }()
+		return "Vote passed: ending the storm."
+	}
+}
+
+func chatCommandRestart(s *Server, ctx *ChatCommandContext) string {
+	s.requestRestart(fmt.Sprintf("Restart requested in chat by %s.", ctx.Player.Name))
+	return "Restarting the server."
+}
+
+func chatCommandKick(s *Server, ctx *ChatCommandContext) string {
+	if len(ctx.Args) == 0 {
+		return "Usage: " + ctx.Prefix + "kick <player>"
+	}
+	target := strings.ToLower(strings.Join(ctx.Args, " "))
+	var match *Client
+	live := s.LiveClients()
+	for _, c := range live {
+		if strings.ToLower(c.Name) == target {
+			match = c
+			break
+		}
+	}
+	if match == nil {
+		for _, c := range live {
+			if !strings.HasPrefix(strings.ToLower(c.Name), target) {
+				continue
+			}
+			if match != nil {
+				return "More than one player matches " + strings.Join(ctx.Args, " ") + "."
+			}
+			match = c
+		}
+	}
+	if match == nil || match.SteamID == "" {
+		return "No online player matches " + strings.Join(ctx.Args, " ") + "."
+	}
+	steamID, name := match.SteamID, match.Name
+	go func() { _ = s.SendCommand("console", "KICK "+steamID) }()
+	return fmt.Sprintf("Kicked %s.", name)
+}
+
+// requestRestart hands the restart to OnRestartRequest (which adds notifications) or
+// restarts directly when no handler is wired.
+func (s *Server) requestRestart(reason string) {
+	if s.Logger != nil {
+		s.Logger.Info(reason)
+	}
+	if s.OnRestartRequest != nil {
+		go s.OnRestartRequest(s, reason)
+		return
+	}
+	go s.Restart()
+}
+
+func formatPlaytime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %dm", h, m)
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChatCommandPermissions(t *testing.T) {
+	s := &Server{ID: 1, ChatCommandsEnabled: true}
+	player := &Client{Name: "Rook", SteamID: "76561198000000001"}
+	operator := &Client{Name: "Op", SteamID: "76561198000000002"}
+	s.ChatCommandAllowed = func(_ *Server, steamID, permission string) bool {
+		return steamID == operator.SteamID && permission == "server.kick"
+	}
+	help := func(c *Client) string {
+		return chatCommandHelp(s, &ChatCommandContext{Player: c, Prefix: "!"})
+	}
+	if got := help(player); strings.Contains(got, "!kick") || !strings.Contains(got, "!online") {
+		t.Fatalf("player help = %q", got)
+	}
+	if got := help(operator); !strings.Contains(got, "!kick") || strings.Contains(got, "!restart") {
+		t.Fatalf("operator help = %q", got)
+	}
+	if got := chatCommandHelp(s, &ChatCommandContext{Player: operator, Prefix: "!", Ambiguous: true}); strings.Contains(got, "!kick") {
+		t.Fatalf("ambiguous operator help = %q", got)
+	}
+
+	RegisterChatCommand(ChatCommand{Name: "Ping", Usage: "ping", Run: func(*Server, *ChatCommandContext) string { return "pong" }})
+	if cmd, ok := lookupChatCommand("ping"); !ok || cmd.Run(s, nil) != "pong" {
+		t.Fatal("registered command not found")
+	}
+	if s.handleChatCommand(player, false, "hello !ping") {
+		t.Fatal("non-prefixed line handled as command")
+	}
+	if !s.handleChatCommand(player, false, "!ping") {
+		t.Fatal("command not handled")
+	}
+}
+
+func TestChatVoteRestart(t *testing.T) {
+	now := time.Now()
+	a := &Client{Name: "A", SteamID: "1", ConnectDatetime: now}
+	b := &Client{Name: "B", SteamID: "2", ConnectDatetime: now}
+	c := &Client{Name: "C", SteamID: "3", ConnectDatetime: now}
+	s := &Server{ID: 1, ChatCommandsEnabled: true, Clients: []*Client{a, b, c}}
+	restarted := make(chan string, 1)
+	s.OnRestartRequest = func(_ *Server, reason string) { restarted <- reason }
+
+	vote := func(p *Client, args ...string) string {
+		return chatCommandVote(s, &ChatCommandContext{Player: p, Args: args, Prefix: "!"})
+	}
+	if got := vote(a, "skipstorm"); got != "There is no storm to skip." {
+		t.Fatalf("skipstorm without storm = %q", got)
+	}
+	if got := vote(a, "restart"); !strings.Contains(got, "1/2") {
+		t.Fatalf("first vote = %q", got)
+	}
+	if got := vote(a, "restart"); !strings.Contains(got, "1/2") {
+		t.Fatalf("repeat vote counted twice: %q", got)
+	}
+	if got := vote(b, "restart"); !strings.Contains(got, "passed") {
+		t.Fatalf("second vote = %q", got)
+	}
+	select {
+	case <-restarted:
+	case <-time.After(time.Second):
+		t.Fatal("restart not requested")
+	}
+	if got := vote(c, "restart"); !strings.Contains(got, "1/2") {
+		t.Fatalf("vote after pass should start a new vote: %q", got)
+	}
+}
+
+func TestChatAuthorRequiresUniqueName(t *testing.T) {
+	admin := &Client{Name: "Rook", SteamID: "1"}
+	s := &Server{Clients: []*Client{admin}}
+	if c, unique := s.chatAuthor("Rook"); c != admin || !unique {
+		t.Fatalf("single player: got %v unique=%v", c, unique)
+	}
+	if c, unique := s.chatAuthor("rook"); c != admin || unique {
+		t.Fatalf("case-only match must not be unique: got %v unique=%v", c, unique)
+	}
+
+	impostor := &Client{Name: "rook", SteamID: "2"}
+	s.Clients = append(s.Clients, impostor)
+	if c, unique := s.chatAuthor("rook"); c != impostor || unique {
+		t.Fatalf("case-colliding names: got %v unique=%v", c, unique)
+	}
+	if _, unique := s.chatAuthor("Rook"); unique {
+		t.Fatal("admin name shared with another player must be ambiguous")
+	}
+	if c, _ := s.chatAuthor("Nobody"); c != nil {
+		t.Fatalf("unknown author resolved to %v", c)
+	}
+}
@@ -0,0 +1,323 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	maxModerationHits = 200
+
+	defaultSpamWindowSeconds  = 10
+	defaultStrikeResetMinutes = 30
+	defaultModerationWarnMsg  = "{player}, please keep chat civil ({rule})."
+	moderationKickNoticeDelay = 2 * time.Second
+	moderationActionWarn      = "warn"
+	moderationActionKick      = "kick"
+	moderationActionBan       = "ban"
+	moderationRuleWord        = "word"
+	moderationRulePattern     = "pattern"
+	moderationRuleCaps        = "caps"
+	moderationRuleSpam        = "spam"
+)
+
+// ChatModeration configures automatic chat moderation for a server. Each rule hit is a
+// strike; strikes escalate from a SAY warning to a kick and then a temporary ban.
+// Zero values disable the matching rule or escalation step.
+type ChatModeration struct {
+	Enabled bool `json:"enabled"`
+	// BlockedWords match whole words case-insensitively; entries with spaces match as phrases.
+	BlockedWords []string `json:"blocked_words,omitempty"`
+	// BlockedPatterns are Go regular expressions matched against the message.
+	BlockedPatterns []string `json:"blocked_patterns,omitempty"`
+	// SpamMaxMessages flags a player sending more than this many messages in SpamWindowSeconds.
+	SpamMaxMessages   int `json:"spam_max_messages"`
+	SpamWindowSeconds int `json:"spam_window_seconds"`
+	// CapsMaxPercent flags messages with at least CapsMinLength letters that are more
+	// than this percentage upper-case.
+	CapsMinLength  int `json:"caps_min_length"`
+	CapsMaxPercent int `json:"caps_max_percent"`
+	// WarnMessage is sent on a warning strike; {player} and {rule} are replaced.
+	WarnMessage string `json:"warn_message,omitempty"`
+	KickAfter   int    `json:"kick_after"`
+	BanAfter    int    `json:"ban_after"`
+	// BanDuration is a ban length such as "1h" or "3d"; bans are always temporary.
+	BanDuration string `json:"ban_duration,omitempty"`
+	// StrikeResetMinutes forgets a player's strikes after this long without a new hit.
+	StrikeResetMinutes int `json:"strike_reset_minutes"`
+}
+
+// Validate checks that every blocked pattern compiles.
+func (cm ChatModeration) Validate() error {
+	for _, p := range cm.BlockedPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", p, err)
+		}
+	}
+	if cm.SpamMaxMessages < 0 || cm.SpamWindowSeconds < 0 || cm.CapsMinLength < 0 || cm.KickAfter < 0 || cm.BanAfter < 0 || cm.StrikeResetMinutes < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	if cm.CapsMaxPercent < 0 || cm.CapsMaxPercent > 100 {
+		return fmt.Errorf("caps_max_percent must be between 0 and 100")
+	}
+	if cm.BanAfter > 0 && strings.TrimSpace(cm.BanDuration) == "" {
+		return fmt.Errorf("ban_duration is required when ban_after is set")
+	}
+	return nil
+}
+
+// ModerationHit records one chat message that broke a moderation rule.
+type ModerationHit struct {
+	Datetime time.Time `json:"datetime"`
+	Name     string    `json:"name"`
+	SteamID  string    `json:"steam_id,omitempty"`
+	Rule     string    `json:"rule"`
+	Detail   string    `json:"detail,omitempty"`
+	Message  string    `json:"message"`
+	Strike   int       `json:"strike"`
+	Action   string    `json:"action"`
+}
+
+// chatModerator holds runtime moderation state: compiled rules, per-player message
+// times and strikes, and the recent hit log.
+type chatModerator struct {
+	mu       sync.Mutex
+	compiled bool
+	words    map[string]bool
+	phrases  []string
+	patterns []*regexp.Regexp
+	recent   map[string][]time.Time
+	strikes  map[string]*chatStrikes
+	hits     []ModerationHit
+}
+
+type chatStrikes struct {
+	count int
+	last  time.Time
+}
+
+func (s *Server) moderator() *chatModerator {
+	s.moderationOnce.Do(func() {
+		s.moderation = &chatModerator{
+			recent:  make(map[string][]time.Time),
+			strikes: make(map[string]*chatStrikes),
+		}
+	})
+	return s.moderation
+}
+
+// SetChatModeration replaces the moderation rules. Strikes and recorded hits are kept.
+func (s *Server) SetChatModeration(cm ChatModeration) error {
+	if err := cm.Validate(); err != nil {
+		return err
+	}
+	mod := s.moderator()
+	mod.mu.Lock()
+	defer mod.mu.Unlock()
+	s.ChatModeration = cm
+	mod.compiled = false
+	return nil
+}
+
+// ModerationHits returns recorded moderation hits, newest first.
+func (s *Server) ModerationHits() []ModerationHit {
+	mod := s.moderator()
+	mod.mu.Lock()
+	defer mod.mu.Unlock()
+	out := make([]ModerationHit, len(mod.hits))
+	for i, h := range mod.hits {
+		out[len(mod.hits)-1-i] = h
+	}
+	return out
+}
+
+func (mod *chatModerator) compile(cm ChatModeration) {
+	if mod.compiled {
+		return
+	}
+	mod.words = make(map[string]bool)
+	mod.phrases = nil
+	mod.patterns = nil
+	for _, w := range cm.BlockedWords {
+		w = strings.ToLower(strings.TrimSpace(w))
+		switch {
+		case w == "":
+		case strings.ContainsAny(w, " \t"):
+			mod.phrases = append(mod.phrases, w)
+		default:
+			mod.words[w] = true
+		}
+	}
+	for _, p := range cm.BlockedPatterns {
+		if re, err := regexp.Compile(p); err == nil {
+			mod.patterns = append(mod.patterns, re)
+		}
+	}
+	mod.compiled = true
+}
+
+// check returns the first rule the message breaks. It must be called with mod.mu held.
+func (mod *chatModerator) check(cm ChatModeration, key string, when time.Time, message string) (rule, detail string) {
+	if cm.SpamMaxMessages > 0 {
+		window := time.Duration(cm.SpamWindowSeconds) * time.Second
+		if window <= 0 {
+			window = defaultSpamWindowSeconds * time.Second
+		}
+		times := mod.recent[key][:0]
+		for _, t := range mod.recent[key] {
+			if when.Sub(t) < window {
+				times = append(times, t)
+			}
+		}
+		times = append(times, when)
+		mod.recent[key] = times
+		if len(times) > cm.SpamMaxMessages {
+			// Start a fresh window so a burst counts as one strike rather than one per line.
+			delete(mod.recent, key)
+			return moderationRuleSpam, fmt.Sprintf("%d messages in %s", len(times), window)
+		}
+	}
+
+	lower := strings.ToLower(message)
+	for _, field := range strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if mod.words[field] {
+			return moderationRuleWord, field
+		}
+	}
+	for _, phrase := range mod.phrases {
+		if strings.Contains(lower, phrase) {
+			return moderationRuleWord, phrase
+		}
+	}
+	for _, re := range mod.patterns {
+		if re.MatchString(message) {
+			return moderationRulePattern, re.String()
+		}
+	}
+
+	if cm.CapsMinLength > 0 && cm.CapsMaxPercent > 0 {
+		letters, upper := 0, 0
+		for _, r := range message {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters >= cm.CapsMinLength && upper*100 > cm.CapsMaxPercent*letters {
+			return moderationRuleCaps, fmt.Sprintf("%d%% caps", upper*100/letters)
+		}
+	}
+	return "", ""
+}
+
+// moderateChat applies the moderation rules to a player's chat line and returns the
+// recorded hit, or nil when the message is allowed. Admins are never moderated. An
+// ambiguous author (see chatAuthor) is tracked by name and only ever warned, so strikes,
+// kicks and bans cannot land on another player's SteamID.
+func (s *Server) moderateChat(client *Client, ambiguous bool, when time.Time, message string) *ModerationHit {
+	if s == nil || client == nil || !s.ChatModeration.Enabled {
+		return nil
+	}
+	steamID := strings.TrimSpace(client.SteamID)
+	if ambiguous {
+		steamID = ""
+	} else if client.IsAdmin || s.IsSteamIDAdmin(steamID) {
+		return nil
+	}
+	key := steamID
+	if key == "" {
+		key = "name:" + strings.ToLower(client.Name)
+	}
+
+	mod := s.moderator()
+	mod.mu.Lock()
+	cm := s.ChatModeration
+	mod.compile(cm)
+	rule, detail := mod.check(cm, key, when, message)
+	if rule == "" {
+		mod.mu.Unlock()
+		return nil
+	}
+
+	reset := time.Duration(cm.StrikeResetMinutes) * time.Minute
+	if reset <= 0 {
+		reset = defaultStrikeResetMinutes * time.Minute
+	}
+	st := mod.strikes[key]
+	if st == nil || when.Sub(st.last) > reset {
+		st = &chatStrikes{}
+		mod.strikes[key] = st
+	}
+	st.count++
+	st.last = when
+
+	hit := ModerationHit{
+		Datetime: when,
+		Name:     client.Name,
+		SteamID:  steamID,
+		Rule:     rule,
+		Detail:   detail,
+		Message:  message,
+		Strike:   st.count,
+		Action:   moderationActionWarn,
+	}
+	// Kicks and bans need a SteamID; without one the player can only be warned.
+	if steamID != "" {
+		switch {
+		case cm.BanAfter > 0 && st.count >= cm.BanAfter:
+			hit.Action = moderationActionBan
+			delete(mod.strikes, key)
+		case cm.KickAfter > 0 && st.count >= cm.KickAfter:
+			hit.Action = moderationActionKick
+		}
+	}
+	mod.hits = append(mod.hits, hit)
+	if excess := len(mod.hits) - maxModerationHits; excess > 0 {
+		mod.hits = append([]ModerationHit(nil), mod.hits[excess:]...)
+	}
+	mod.mu.Unlock()
+
+	if s.Logger != nil {
+		s.Logger.Warn(fmt.Sprintf("Moderation: %s (%s) broke %s rule [%s], strike %d -> %s", hit.Name, hit.SteamID, hit.Rule, hit.Detail, hit.Strike, hit.Action))
+	}
+	go s.applyModerationAction(hit, cm)
+	return &hit
+}
+
+func (s *Server) applyModerationAction(hit ModerationHit, cm ChatModeration) {
+	warn := strings.TrimSpace(cm.WarnMessage)
+	if warn == "" {
+		warn = defaultModerationWarnMsg
+	}
+	warn = s.RenderChatMessage(warn, map[string]string{"player": hit.Name, "rule": hit.Rule})
+	logErr := func(what string, err error) {
+		if err != nil && s.Logger != nil {
+			s.Logger.Error(fmt.Sprintf("Moderation: failed to %s %s: %v", what, hit.Name, err))
+		}
+	}
+	switch hit.Action {
+	case moderationActionWarn:
+		logErr("warn", s.SendCommand("chat", warn))
+	case moderationActionKick:
+		_ = s.SendCommand("chat", warn)
+		time.Sleep(moderationKickNoticeDelay)
+		logErr("kick", s.SendCommand("console", "KICK "+hit.SteamID))
+	case moderationActionBan:
+		reason := fmt.Sprintf("Chat moderation: %s (%s)", hit.Rule, hit.Detail)
+		if s.OnChatBan == nil {
+			logErr("ban", fmt.Errorf("no ban handler; kicking instead"))
+			logErr("kick", s.SendCommand("console", "KICK "+hit.SteamID))
+			return
+		}
+		if err := s.OnChatBan(s, hit.SteamID, hit.Name, reason, cm.BanDuration); err != nil {
+			logErr("ban", err)
+			logErr("kick", s.SendCommand("console", "KICK "+hit.SteamID))
+		}
+	}
+}
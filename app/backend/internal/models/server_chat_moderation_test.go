@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModerateChatEscalates(t *testing.T) {
+	s := &Server{ID: 1}
+	if err := s.SetChatModeration(ChatModeration{
+		Enabled:         true,
+		BlockedWords:    []string{"grief", "free stuff"},
+		BlockedPatterns: []string{`(?i)discord\.gg/\w+`},
+		CapsMinLength:   8,
+		CapsMaxPercent:  70,
+		KickAfter:       2,
+		BanAfter:        3,
+		BanDuration:     "1h",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bans := make(chan string, 1)
+	s.OnChatBan = func(_ *Server, steamID, _, _, duration string) error {
+		bans <- steamID + " " + duration
+		return nil
+	}
+	player := &Client{Name: "Rook", SteamID: "76561198000000001"}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if hit := s.moderateChat(player, false, base, "hello there, anyone building?"); hit != nil {
+		t.Fatalf("clean message flagged: %+v", hit)
+	}
+	cases := []struct {
+		msg, rule, action string
+	}{
+		{"lets GRIEF the base", moderationRuleWord, moderationActionWarn},
+		{"join discord.gg/abc", moderationRulePattern, moderationActionKick},
+		{"WHY IS EVERYONE SO QUIET", moderationRuleCaps, moderationActionBan},
+		{"any free stuff at spawn?", moderationRuleWord, moderationActionWarn},
+	}
+	for i, tc := range cases {
+		hit := s.moderateChat(player, false, base.Add(time.Duration(i+1)*time.Minute), tc.msg)
+		if hit == nil || hit.Rule != tc.rule || hit.Action != tc.action {
+			t.Fatalf("%q: hit = %+v, want %s/%s", tc.msg, hit, tc.rule, tc.action)
+		}
+	}
+	select {
+	case got := <-bans:
+		if got != "76561198000000001 1h" {
+			t.Fatalf("ban = %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ban callback not called")
+	}
+	if hits := s.ModerationHits(); len(hits) != 4 || hits[0].Strike != 1 || hits[1].Rule != moderationRuleCaps {
+		t.Fatalf("hits = %+v", hits)
+	}
+
+	admin := &Client{Name: "Op", SteamID: "76561198000000002", IsAdmin: true}
+	if hit := s.moderateChat(admin, false, base, "GRIEF GRIEF GRIEF"); hit != nil {
+		t.Fatalf("admin moderated: %+v", hit)
+	}
+}
+
+func TestModerateChatSpamWindow(t *testing.T) {
+	s := &Server{ID: 1}
+	if err := s.SetChatModeration(ChatModeration{Enabled: true, SpamMaxMessages: 3, SpamWindowSeconds: 5}); err != nil {
+		t.Fatal(err)
+	}
+	player := &Client{Name: "Rook"}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if hit := s.moderateChat(player, false, base.Add(time.Duration(i)*time.Second), "hi"); hit != nil {
+			t.Fatalf("message %d flagged", i)
+		}
+	}
+	hit := s.moderateChat(player, false, base.Add(3*time.Second), "hi")
+	if hit == nil || hit.Rule != moderationRuleSpam || hit.Action != moderationActionWarn {
+		t.Fatalf("spam hit = %+v", hit)
+	}
+	if hit := s.moderateChat(player, false, base.Add(20*time.Second), "hi"); hit != nil {
+		t.Fatalf("message after window flagged: %+v", hit)
+	}
+
+	if err := s.SetChatModeration(ChatModeration{BlockedPatterns: []string{"("}}); err == nil {
+		t.Fatal("invalid pattern accepted")
+	}
+}
+
+func TestModerateChatAmbiguousAuthorOnlyWarned(t *testing.T) {
+	s := &Server{ID: 1}
+	if err := s.SetChatModeration(ChatModeration{Enabled: true, BlockedWords: []string{"grief"}, KickAfter: 1}); err != nil {
+		t.Fatal(err)
+	}
+	player := &Client{Name: "Rook", SteamID: "76561198000000001"}
+	hit := s.moderateChat(player, true, time.Now(), "grief")
+	if hit == nil || hit.Action != moderationActionWarn || hit.SteamID != "" {
+		t.Fatalf("ambiguous author hit = %+v, want warn without SteamID", hit)
+	}
+}
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Crash policy defaults applied when the per-server values are zero.
+const (
+	DefaultCrashMaxRestarts    = 3
+	DefaultCrashWindowMinutes  = 10
+	DefaultCrashBackoffSeconds = 10
+	maxCrashBackoff            = 10 * time.Minute
+	crashLogTailLines          = 12
+	crashLogTailBytes          = 16 * 1024
+)
+
+// processRun tracks one server process. Its exit monitor keeps a reference, so a stop
+// requested for it is still seen after the next Start has begun a new run.
+type processRun struct {
+	stopRequested bool
+}
+
+// beginProcessRun starts tracking a new process and makes it the target of markStopRequested.
+func (s *Server) beginProcessRun() *processRun {
+	run := &processRun{}
+	s.exitMu.Lock()
+	s.run = run
+	s.exitMu.Unlock()
+	return run
+}
+
+// markStopRequested records that the current process exit was asked for (Stop/StopAsync/QUIT)
+// so the exit monitor does not report it as a crash.
+func (s *Server) markStopRequested() {
+	if s == nil {
+		return
+	}
+	s.exitMu.Lock()
+	if s.run != nil {
+		s.run.stopRequested = true
+	}
+	s.exitMu.Unlock()
+}
+
+// handleProcessExit is invoked by the monitor of run once cleanup has finished. Exits that
+// were not requested are recorded in LastError and reported via OnUnexpectedExit.
+func (s *Server) handleProcessExit(run *processRun, exitErr error) {
+	if s == nil {
+		return
+	}
+	s.exitMu.Lock()
+	requested := run.stopRequested
+	s.exitMu.Unlock()
+	if requested {
+		return
+	}
+	reason := "process exited"
+	if exitErr != nil {
+		reason = exitErr.Error()
+	}
+	msg := "Server exited unexpectedly (" + reason + ")"
+	if tail := s.OutputLogTail(crashLogTailLines); len(tail) > 0 {
+		msg += ":\n" + strings.Join(tail, "\n")
+	}
+	now := time.Now()
+	s.LastError = msg
+	s.LastErrorAt = &now
+	if s.Logger != nil {
+		s.Logger.Error("Crash detected: " + reason)
+	}
+	if s.OnUnexpectedExit != nil {
+		s.OnUnexpectedExit(s)
+	}
+}
+
+// OutputLogTail returns up to maxLines trailing non-empty lines of the server output log.
+func (s *Server) OutputLogTail(maxLines int) []string {
+	if s == nil || s.Paths == nil || maxLines <= 0 {
+		return nil
+	}
+	f, err := os.Open(s.Paths.ServerOutputFile(s.ID))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+	offset := info.Size() - crashLogTailBytes
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	// Drop a partial first line when we started mid-file.
+	if offset > 0 && len(lines) > 0 {
+		lines = lines[1:]
+	}
+	out := make([]string, 0, maxLines)
+	for i := len(lines) - 1; i >= 0 && len(out) < maxLines; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			out = append(out, line)
+		}
+	}
+	// Restore chronological order
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// RecordCrash appends a crash timestamp and returns how many crashes fall within the
+// configured window (including this one).
+func (s *Server) RecordCrash(at time.Time) int {
+	if s == nil {
+		return 0
+	}
+	cutoff := at.Add(-s.CrashWindow())
+	s.exitMu.Lock()
+	defer s.exitMu.Unlock()
+	kept := s.crashTimes[:0]
+	for _, t := range s.crashTimes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	s.crashTimes = append(kept, at)
+	return len(s.crashTimes)
+}
+
+// ResetCrashHistory clears recorded crashes (e.g. after a manual start).
+func (s *Server) ResetCrashHistory() {
+	if s != nil {
+		s.exitMu.Lock()
+		s.crashTimes = nil
+		s.exitMu.Unlock()
+	}
+}
+
+// CrashRestartLimit returns the effective maximum automatic restarts per window.
+func (s *Server) CrashRestartLimit() int {
+	if s == nil || s.CrashMaxRestarts <= 0 {
+		return DefaultCrashMaxRestarts
+	}
+	return s.CrashMaxRestarts
+}
+
+// CrashWindow returns the effective sliding window used to count crashes.
+func (s *Server) CrashWindow() time.Duration {
+	if s == nil || s.CrashWindowMinutes <= 0 {
+		return time.Duration(DefaultCrashWindowMinutes) * time.Minute
+	}
+	return time.Duration(s.CrashWindowMinutes) * time.Minute
+}
+
+// CrashBackoff returns the delay before the given restart attempt (1-based); the base
+// backoff doubles with each consecutive crash and is capped at ten minutes.
+func (s *Server) CrashBackoff(attempt int) time.Duration {
+	base := time.Duration(DefaultCrashBackoffSeconds) * time.Second
+	if s != nil && s.CrashBackoffSeconds > 0 {
+		base = time.Duration(s.CrashBackoffSeconds) * time.Second
+	}
+	d := base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxCrashBackoff {
+			return maxCrashBackoff
+		}
+	}
+	return d
+}
+
+// RestoreLatestAutosave replaces the head save with the newest autosave that opens as a
+// valid save archive. The previous head save is preserved in manualsave so nothing is lost.
+// It returns the restored autosave filename.
+func (s *Server) RestoreLatestAutosave() (string, error) {
+	if s == nil || s.Paths == nil {
+		return "", fmt.Errorf("server paths unavailable")
+	}
+	worldDir := filepath.Join(s.Paths.ServerSavesDir(s.ID), s.Name)
+	autoDir := filepath.Join(worldDir, "autosave")
+	if !isPathWithin(s.Paths.ServerSavesDir(s.ID), autoDir) {
+		return "", fmt.Errorf("invalid save directory")
+	}
+	entries, err := os.ReadDir(autoDir)
+	if err != nil {
+		return "", fmt.Errorf("no autosaves available: %w", err)
+	}
+	type candidate struct {
+		path string
+		mod  time.Time
+	}
+	var candidates []candidate
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(strings.ToLower(e.Name()), ".save") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{path: filepath.Join(autoDir, e.Name()), mod: info.ModTime()})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].mod.After(candidates[j].mod) })
+
+	var chosen string
+	for _, c := range candidates {
+		// A save interrupted by the crash is usually a truncated archive; skip those.
+		if zr, err := zip.OpenReader(c.path); err == nil {
+			zr.Close()
+			chosen = c.path
+			break
+		}
+	}
+	if chosen == "" {
+		return "", fmt.Errorf("no valid autosave found")
+	}
+
+	head := filepath.Join(worldDir, s.Name+".save")
+	if fileExists(head) {
+		keepDir := filepath.Join(worldDir, "manualsave")
+		if err := os.MkdirAll(keepDir, 0o755); err != nil {
+			return "", err
+		}
+		keep := filepath.Join(keepDir, "crash_"+time.Now().Format("020106_150405")+".save")
+		if err := os.Rename(head, keep); err != nil {
+			return "", fmt.Errorf("failed to preserve head save: %w", err)
+		}
+	}
+	tmp := head + ".tmp"
+	if err := copyFile(chosen, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, head); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	name := filepath.Base(chosen)
+	if s.Logger != nil {
+		s.Logger.Info("Restored autosave " + name + " after crash")
+	}
+	return name, nil
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestRecordCrashCountsWithinWindow(t *testing.T) {
+	s := &Server{CrashWindowMinutes: 10}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, want := range []int{1, 2, 3} {
+		if got := s.RecordCrash(base.Add(time.Duration(i) * time.Minute)); got != want {
+			t.Fatalf("crash %d counted %d, want %d", i+1, got, want)
+		}
+	}
+	if got := s.RecordCrash(base.Add(10*time.Minute + 30*time.Second)); got != 3 {
+		t.Fatalf("crash after the first left the window counted %d, want 3", got)
+	}
+	if got := s.RecordCrash(base.Add(30 * time.Minute)); got != 1 {
+		t.Fatalf("crash after a quiet window counted %d, want 1", got)
+	}
+	s.ResetCrashHistory()
+	if got := s.RecordCrash(base.Add(31 * time.Minute)); got != 1 {
+		t.Fatalf("crash after reset counted %d, want 1", got)
+	}
+
+	if got := (&Server{}).CrashRestartLimit(); got != DefaultCrashMaxRestarts {
+		t.Fatalf("default limit = %d", got)
+	}
+	if got := (&Server{CrashMaxRestarts: 5}).CrashRestartLimit(); got != 5 {
+		t.Fatalf("configured limit = %d", got)
+	}
+	if got := (&Server{}).CrashWindow(); got != DefaultCrashWindowMinutes*time.Minute {
+		t.Fatalf("default window = %v", got)
+	}
+}
+
+func TestCrashBackoffDoublesUpToCap(t *testing.T) {
+	s := &Server{CrashBackoffSeconds: 30}
+	for attempt, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 5: 8 * time.Minute, 6: maxCrashBackoff, 40: maxCrashBackoff} {
+		if got := s.CrashBackoff(attempt); got != want {
+			t.Fatalf("attempt %d backoff = %v, want %v", attempt, got, want)
+		}
+	}
+	if got := (&Server{}).CrashBackoff(1); got != DefaultCrashBackoffSeconds*time.Second {
+		t.Fatalf("default backoff = %v", got)
+	}
+}
+
+func TestRestoreLatestAutosaveSkipsBrokenSaves(t *testing.T) {
+	paths := utils.NewPaths(t.TempDir())
+	s := &Server{ID: 1, Name: "Alpha", Paths: paths}
+	worldDir := filepath.Join(paths.ServerSavesDir(1), "Alpha")
+	autoDir := filepath.Join(worldDir, "autosave")
+	if err := os.MkdirAll(autoDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeSave := func(name string, age time.Duration, valid bool) {
+		t.Helper()
+		p := filepath.Join(autoDir, name)
+		f, err := os.Create(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid {
+			zw := zip.NewWriter(f)
+			w, _ := zw.Create("world.xml")
+			w.Write([]byte(name))
+			zw.Close()
+		} else {
+			f.Write([]byte("PK\x03\x04 truncated"))
+		}
+		f.Close()
+		mod := time.Now().Add(-age)
+		if err := os.Chtimes(p, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSave("auto_old.save", 3*time.Hour, true)
+	writeSave("auto_good.save", 2*time.Hour, true)
+	writeSave("auto_broken.save", time.Hour, false)
+	head := filepath.Join(worldDir, "Alpha.save")
+	if err := os.WriteFile(head, []byte("crashed head"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := s.RestoreLatestAutosave()
+	if err != nil || name != "auto_good.save" {
+		t.Fatalf("restored %q %v", name, err)
+	}
+	want, _ := os.ReadFile(filepath.Join(autoDir, "auto_good.save"))
+	if got, err := os.ReadFile(head); err != nil || string(got) != string(want) {
+		t.Fatalf("head save not replaced by the autosave: %v", err)
+	}
+	kept, _ := filepath.Glob(filepath.Join(worldDir, "manualsave", "crash_*.save"))
+	if len(kept) != 1 {
+		t.Fatalf("previous head save not preserved: %v", kept)
+	}
+	if got, _ := os.ReadFile(kept[0]); string(got) != "crashed head" {
+		t.Fatalf("preserved head = %q", got)
+	}
+
+	if _, err := (&Server{ID: 2, Name: "Empty", Paths: paths}).RestoreLatestAutosave(); err == nil {
+		t.Fatal("restore without autosaves succeeded")
+	}
+}
+
+func TestRequestedStopSurvivesNextStart(t *testing.T) {
+	s := &Server{ID: 1}
+	crashes := 0
+	s.OnUnexpectedExit = func(*Server) { crashes++ }
+
+	first := s.beginProcessRun()
+	s.markStopRequested()
+	// A restart with no delay begins the next run before the first monitor reports the exit.
+	second := s.beginProcessRun()
+	s.handleProcessExit(first, nil)
+	if crashes != 0 {
+		t.Fatal("requested stop reported as a crash")
+	}
+	s.handleProcessExit(second, nil)
+	if crashes != 1 || s.LastError == "" {
+		t.Fatalf("unrequested exit not reported: crashes %d, last error %q", crashes, s.LastError)
+	}
+}
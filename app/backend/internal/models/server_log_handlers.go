@@ -81,7 +81,7 @@ var (
 		},
 		{
 			match: func(line string) []string {
-				if strings.Contains(line, "World Saved") || strings.Contains(line, "Saving - file created") {
+				if isWorldSavedLine(line) {
 					return []string{}
 				}
 				return nil
@@ -94,7 +94,7 @@ var (
 		// Recognize completion line like: "17:13:57: Saved <ServerName>"
 		{
 			match: func(line string) []string {
-				if strings.Contains(line, ": Saved ") {
+				if isSaveCompletedLine(line) {
 					return []string{}
 				}
 				return nil
@@ -156,6 +156,11 @@ var (
 				}
 				// If admin flag added to a pre-existing session via backfill, flush handled above.
 
+				// Whitelist mode: players who are not allowed are kicked; skip saves and welcomes.
+				if s.enforceWhitelist(name, steamID) {
+					return
+				}
+
 				// Player Saves automation: on player connect, if enabled and not excluded,
 				// issue a FILE saveas <ddmmyy_hhmmss_steamid> (the game appends .save in manualsave),
 				// then move the resulting file to playersave when we see the 'Saved' log line.
@@ -325,15 +330,21 @@ var (
 					return
 				}
 
-				for _, client := range s.LiveClients() {
-					if client == nil {
-						continue
-					}
-					if strings.EqualFold(client.Name, name) {
-						s.addChatMessage(client.Name, t, message)
-						return
+				client, unique := s.chatAuthor(name)
+				if client == nil {
+					return
+				}
+				s.addChatMessage(client.Name, t, message)
+				if hit := s.moderateChat(client, !unique, t, message); hit != nil {
+					if len(s.Chat) > 0 {
+						s.Chat[len(s.Chat)-1].Flag = hit.Rule
 					}
+					return
+				}
+				if s.OnPlayerChat != nil {
+					s.OnPlayerChat(s, client.Name, message)
 				}
+				s.handleChatCommand(client, !unique, message)
 			},
 		},
 		{
@@ -402,7 +413,7 @@ var (
 		{
 			match: func(line string) []string {
 				// Quick pre-filter to avoid regex on every line
-				if !strings.Contains(strings.ToLower(line), "no such world name") {
+				if !isNoSuchWorldLine(line) {
 					return nil
 				}
 				if m := noSuchWorldRegex.FindStringSubmatch(line); m != nil {
@@ -435,7 +446,7 @@ var (
 				s.LastError = msg
 				s.LastErrorAt = &now
 				if s.Logger != nil {
-					s.Logger.Write("Startup error: " + msg)
+					s.Logger.Error("Startup error: " + msg)
 				}
 				// Stop the server if it's starting/running
 				if s.Running || s.Starting {
@@ -445,3 +456,18 @@ var (
 		},
 	}
 )
+
+// isWorldSavedLine reports a world save starting or being written.
+func isWorldSavedLine(line string) bool {
+	return strings.Contains(line, "World Saved") || strings.Contains(line, "Saving - file created")
+}
+
+// isSaveCompletedLine reports a completed save, e.g. "17:13:57: Saved <ServerName>".
+func isSaveCompletedLine(line string) bool {
+	return strings.Contains(line, ": Saved ")
+}
+
+// isNoSuchWorldLine reports the fatal invalid-world startup error.
+func isNoSuchWorldLine(line string) bool {
+	return strings.Contains(strings.ToLower(line), "no such world name")
+}
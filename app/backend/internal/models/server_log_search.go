@@ -0,0 +1,386 @@
+package models
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+// LogClass is a category of log line, recognized with the same patterns the live log
+// handlers use.
+type LogClass string
+
+const (
+	LogClassChat       LogClass = "chat"
+	LogClassConnect    LogClass = "connect"
+	LogClassDisconnect LogClass = "disconnect"
+	LogClassSave       LogClass = "save"
+	LogClassError      LogClass = "error"
+)
+
+// ParseLogClass accepts the class names above in any case.
+func ParseLogClass(s string) (LogClass, bool) {
+	switch c := LogClass(strings.ToLower(strings.TrimSpace(s))); c {
+	case LogClassChat, LogClassConnect, LogClassDisconnect, LogClassSave, LogClassError:
+		return c, true
+	}
+	return "", false
+}
+
+const (
+	// MaxLogSearchContext caps the context lines around each match.
+	MaxLogSearchContext = 10
+	// MaxLogSearchResults caps the matches returned by one search.
+	MaxLogSearchResults = 5000
+	defaultSearchLimit  = 500
+)
+
+var (
+	outputErrorRegex = regexp.MustCompile(`(?i)\b(error|exception|fatal)\b`)
+	logClockRegex    = regexp.MustCompile(`^(\d{2}):(\d{2}):(\d{2}):?\s`)
+)
+
+// ClassifyLogLine returns the class of a game output or SDSM server log line, or "" when the
+// line is none of them. Connects, disconnects and saves win over chat, whose pattern is loose.
+func ClassifyLogLine(line string) LogClass {
+	if e, ok := utils.ParseLogLine(line); ok {
+		if e.Level >= utils.LevelError {
+			return LogClassError
+		}
+		return ""
+	}
+	switch {
+	case clientReadyRegex.MatchString(line):
+		return LogClassConnect
+	case clientDisconnectRegex.MatchString(line):
+		return LogClassDisconnect
+	case isWorldSavedLine(line) || isSaveCompletedLine(line):
+		return LogClassSave
+	case isNoSuchWorldLine(line) || outputErrorRegex.MatchString(line):
+		return LogClassError
+	case chatMessageRegex.MatchString(line):
+		return LogClassChat
+	}
+	return ""
+}
+
+// LogSearchQuery selects lines across a server's logs and their rotated archives.
+type LogSearchQuery struct {
+	// Text is a substring (case-insensitive) or, with Regex, a regular expression. Empty matches
+	// every line, so classes or a time range alone can drive a search.
+	Text  string
+	Regex bool
+	// Since and Until bound the line time; zero leaves that side open.
+	Since, Until time.Time
+	// Classes keeps only lines of these classes; empty keeps all.
+	Classes []LogClass
+	// Context is the number of lines included before and after each match.
+	Context int
+	// Limit stops the search after this many matches.
+	Limit int
+}
+
+// LogSearchMatch is one matching line with its surrounding context.
+type LogSearchMatch struct {
+	File string `json:"file"`
+	// Line is 1-based within File.
+	Line int `json:"line"`
+	// Time is the line time. Game output lines only carry a clock, so their date is inferred
+	// from when the file was rotated and where the clock wraps past midnight.
+	Time   *time.Time `json:"time,omitempty"`
+	Class  LogClass   `json:"class,omitempty"`
+	Text   string     `json:"text"`
+	Before []string   `json:"before,omitempty"`
+	After  []string   `json:"after,omitempty"`
+}
+
+// LogSearchSummary reports what a search covered.
+type LogSearchSummary struct {
+	Files     int  `json:"files"`
+	Matches   int  `json:"matches"`
+	Truncated bool `json:"truncated"`
+}
+
+// errSearchLimit stops the scan once the limit is reached.
+var errSearchLimit = errors.New("search limit reached")
+
+type searchFile struct {
+	name string
+	path string
+	// end is when the file stopped growing: its rotation time, or its modification time.
+	end time.Time
+	// output files carry clock-only timestamps.
+	output bool
+}
+
+// SearchLogs scans the server log and game output log, with all their archives, oldest file
+// first, and calls emit for every match. It stops early when ctx is done or emit fails.
+func (s *Server) SearchLogs(ctx context.Context, q LogSearchQuery, emit func(LogSearchMatch) error) (LogSearchSummary, error) {
+	var sum LogSearchSummary
+	if s == nil || s.Paths == nil {
+		return sum, errors.New("server logs are not available")
+	}
+	match, err := q.matcher()
+	if err != nil {
+		return sum, err
+	}
+	if q.Context < 0 {
+		q.Context = 0
+	} else if q.Context > MaxLogSearchContext {
+		q.Context = MaxLogSearchContext
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultSearchLimit
+	} else if q.Limit > MaxLogSearchResults {
+		q.Limit = MaxLogSearchResults
+	}
+
+	for _, f := range s.searchFiles() {
+		if !q.Since.IsZero() && f.end.Before(q.Since) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return sum, err
+		}
+		sum.Files++
+		err := searchLogFile(ctx, f, q, match, func(m LogSearchMatch) error {
+			if err := emit(m); err != nil {
+				return err
+			}
+			sum.Matches++
+			if sum.Matches >= q.Limit {
+				return errSearchLimit
+			}
+			return nil
+		})
+		if errors.Is(err, errSearchLimit) {
+			sum.Truncated = true
+			return sum, nil
+		}
+		if err != nil {
+			return sum, err
+		}
+	}
+	return sum, nil
+}
+
+// Validate reports an invalid regular expression.
+func (q LogSearchQuery) Validate() error {
+	_, err := q.matcher()
+	return err
+}
+
+func (q LogSearchQuery) matcher() (func(string) bool, error) {
+	text := strings.TrimSpace(q.Text)
+	switch {
+	case text == "":
+		return func(string) bool { return true }, nil
+	case q.Regex:
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		return re.MatchString, nil
+	default:
+		needle := strings.ToLower(text)
+		return func(line string) bool { return strings.Contains(strings.ToLower(line), needle) }, nil
+	}
+}
+
+func (q LogSearchQuery) wantClass(c LogClass) bool {
+	if len(q.Classes) == 0 {
+		return true
+	}
+	for _, want := range q.Classes {
+		if want == c {
+			return true
+		}
+	}
+	return false
+}
+
+// searchFiles lists the active and archived admin and output logs in chronological order.
+func (s *Server) searchFiles() []searchFile {
+	dir := s.Paths.ServerLogsDir(s.ID)
+	admin := filepath.Base(s.Paths.ServerLogFile(s.ID))
+	output := filepath.Base(s.Paths.ServerOutputFile(s.ID))
+	var files []searchFile
+	for _, name := range []string{admin, output} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			files = append(files, searchFile{name: name, path: filepath.Join(dir, name), end: info.ModTime(), output: name == output})
+		}
+	}
+	for _, a := range utils.ListLogArchives(dir) {
+		if a.Source == admin || a.Source == output {
+			files = append(files, searchFile{name: a.Name, path: filepath.Join(dir, a.Name), end: a.Rotated, output: a.Source == output})
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].end.Before(files[j].end) })
+	return files
+}
+
+func scanLogLines(path string, fn func(string) error) error {
+	r, err := utils.OpenLogReader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		if err := fn(strings.TrimRight(sc.Text(), "\r")); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func lineClock(line string) (time.Duration, bool) {
+	m := logClockRegex.FindStringSubmatch(line)
+	if m == nil {
+		return 0, false
+	}
+	h, _ := strconv.Atoi(m[1])
+	min, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.Atoi(m[3])
+	if h > 23 || min > 59 || sec > 59 {
+		return 0, false
+	}
+	return time.Duration(h)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second, true
+}
+
+// firstOutputDay works out the date of the first clock in an output log. Every time the clock
+// goes backwards a day has passed, so it counts those wraps back from the day the file ended.
+func firstOutputDay(f searchFile) (time.Time, error) {
+	wraps := 0
+	var last time.Duration
+	seen := false
+	err := scanLogLines(f.path, func(line string) error {
+		if clock, ok := lineClock(line); ok {
+			if seen && clock < last {
+				wraps++
+			}
+			last, seen = clock, true
+		}
+		return nil
+	})
+	end := f.end.Local()
+	day := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.Local)
+	if seen && last > end.Sub(day) {
+		// The last line was written before midnight of the day the file ended.
+		wraps++
+	}
+	return day.AddDate(0, 0, -wraps), err
+}
+
+type pendingMatch struct {
+	LogSearchMatch
+	need int
+}
+
+func searchLogFile(ctx context.Context, f searchFile, q LogSearchQuery, match func(string) bool, emit func(LogSearchMatch) error) error {
+	var day time.Time
+	if f.output {
+		var err error
+		if day, err = firstOutputDay(f); err != nil {
+			return err
+		}
+	}
+	var (
+		lineNo  int
+		current time.Time
+		last    time.Duration
+		seen    bool
+		before  []string
+		pending []*pendingMatch
+	)
+	flush := func() error {
+		for len(pending) > 0 && pending[0].need == 0 {
+			if err := emit(pending[0].LogSearchMatch); err != nil {
+				return err
+			}
+			pending = pending[1:]
+		}
+		return nil
+	}
+	err := scanLogLines(f.path, func(line string) error {
+		lineNo++
+		if lineNo%4096 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if f.output {
+			if clock, ok := lineClock(line); ok {
+				if seen && clock < last {
+					day = day.AddDate(0, 0, 1)
+				}
+				last, seen = clock, true
+				current = day.Add(clock)
+			}
+		} else if e, ok := utils.ParseLogLine(line); ok {
+			current = e.Time
+		}
+		for _, p := range pending {
+			if p.need > 0 {
+				p.After = append(p.After, line)
+				p.need--
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if q.matchLine(line, current, match) {
+			m := &pendingMatch{LogSearchMatch: LogSearchMatch{File: f.name, Line: lineNo, Text: line, Class: ClassifyLogLine(line)}, need: q.Context}
+			if !current.IsZero() {
+				t := current
+				m.Time = &t
+			}
+			if len(before) > 0 {
+				m.Before = append([]string(nil), before...)
+			}
+			pending = append(pending, m)
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if q.Context > 0 {
+			before = append(before, line)
+			if len(before) > q.Context {
+				before = before[1:]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Matches near the end of the file get what context there is.
+	for _, p := range pending {
+		p.need = 0
+	}
+	return flush()
+}
+
+func (q LogSearchQuery) matchLine(line string, t time.Time, match func(string) bool) bool {
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		if t.IsZero() || (!q.Since.IsZero() && t.Before(q.Since)) || (!q.Until.IsZero() && t.After(q.Until)) {
+			return false
+		}
+	}
+	if len(q.Classes) > 0 && !q.wantClass(ClassifyLogLine(line)) {
+		return false
+	}
+	return match(line)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestSearchLogsAcrossArchives(t *testing.T) {
+	paths := utils.NewPaths(t.TempDir())
+	s := &Server{ID: 1, Paths: paths}
+	dir := paths.ServerLogsDir(1)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.Local) }
+	write := func(name, data string, mod time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Rotated at 02:00 on the 10th, so the clock wrap puts the first lines on the 9th.
+	write("Server1_output-20260310-020000.log", ""+
+		"22:00:00: Client Rook (76561198000000001) is ready\n"+
+		"23:30:00: Rook: where did my base go\n"+
+		"01:15:00: World Saved\n", at(10, 2, 0))
+	write("Server1_output.log", "12:00:00: Saved Mars\n", at(11, 13, 0))
+	write("Server1_admin.log", "2026-03-10 00:10:00: ERROR [server 1] Backup failed\n", at(10, 0, 10))
+
+	search := func(q LogSearchQuery) ([]LogSearchMatch, LogSearchSummary) {
+		t.Helper()
+		var got []LogSearchMatch
+		sum, err := s.SearchLogs(context.Background(), q, func(m LogSearchMatch) error {
+			got = append(got, m)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got, sum
+	}
+
+	got, sum := search(LogSearchQuery{Text: "BASE", Classes: []LogClass{LogClassChat}, Context: 1})
+	if len(got) != 1 || sum.Files != 3 {
+		t.Fatalf("chat search = %+v %+v", got, sum)
+	}
+	m := got[0]
+	if m.Line != 2 || m.Time == nil || !m.Time.Equal(at(9, 23, 30)) {
+		t.Fatalf("chat match position = line %d time %v", m.Line, m.Time)
+	}
+	if len(m.Before) != 1 || len(m.After) != 1 || m.After[0] != "01:15:00: World Saved" {
+		t.Fatalf("chat match context = %q / %q", m.Before, m.After)
+	}
+
+	got, _ = search(LogSearchQuery{Since: at(10, 0, 0), Until: at(10, 23, 59)})
+	if len(got) != 2 || got[0].Class != LogClassError || got[1].Class != LogClassSave {
+		t.Fatalf("time range search = %+v", got)
+	}
+
+	got, _ = search(LogSearchQuery{Text: `Saved\s+Mars`, Regex: true})
+	if len(got) != 1 || got[0].File != "Server1_output.log" || !got[0].Time.Equal(at(11, 12, 0)) {
+		t.Fatalf("regex search = %+v", got)
+	}
+
+	if _, sum = search(LogSearchQuery{Limit: 2}); !sum.Truncated || sum.Matches != 2 {
+		t.Fatalf("limited search summary = %+v", sum)
+	}
+	if err := (LogSearchQuery{Text: "(", Regex: true}).Validate(); err == nil {
+		t.Fatal("invalid pattern accepted")
+	}
+}
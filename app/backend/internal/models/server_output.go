@@ -0,0 +1,70 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+// outputPumpBacklog is how many unparsed lines may queue before the game's writes block.
+const outputPumpBacklog = 4096
+
+// outputPump receives the game's stdout and stderr. It appends everything to the rotating
+// output log and hands complete lines to the server's line handlers on its own goroutine.
+// exec.Cmd serializes writes when Stdout and Stderr are the same writer.
+type outputPump struct {
+	s       *Server
+	file    *utils.RotatingFile
+	lines   chan string
+	done    chan struct{}
+	partial []byte
+	failed  bool
+}
+
+func newOutputPump(s *Server, file *utils.RotatingFile) *outputPump {
+	p := &outputPump{s: s, file: file, lines: make(chan string, outputPumpBacklog), done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		for line := range p.lines {
+			s.processLine(line)
+		}
+	}()
+	return p
+}
+
+// Write never fails: an error would close the pipe and break the game's output.
+func (p *outputPump) Write(b []byte) (int, error) {
+	if _, err := p.file.Write(b); err != nil && !p.failed {
+		p.failed = true
+		if p.s.Logger != nil {
+			p.s.Logger.Error(fmt.Sprintf("Failed to write output log: %v", err))
+		}
+	}
+	p.partial = append(p.partial, b...)
+	for {
+		i := bytes.IndexByte(p.partial, '\n')
+		if i < 0 {
+			break
+		}
+		p.send(string(p.partial[:i]))
+		p.partial = p.partial[i+1:]
+	}
+	return len(b), nil
+}
+
+func (p *outputPump) send(line string) {
+	if line = strings.TrimRight(line, "\r"); line != "" {
+		p.lines <- line
+	}
+}
+
+// Close handles a trailing unterminated line, waits for the queued lines and closes the log.
+func (p *outputPump) Close() error {
+	p.send(string(p.partial))
+	p.partial = nil
+	close(p.lines)
+	<-p.done
+	return p.file.Close()
+}
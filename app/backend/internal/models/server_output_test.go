@@ -0,0 +1,60 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestOutputPumpLogsAndParsesLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Server1_output.log")
+	f, err := utils.OpenRotatingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{ID: 1}
+	p := newOutputPump(s, f)
+	for _, chunk := range []string{"booting\r\nhalf ", "a line\n\n", "unterminated"} {
+		if n, err := p.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("write %q = %d %v", chunk, n, err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s.LastLogLine != "unterminated" {
+		t.Fatalf("last parsed line = %q", s.LastLogLine)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "booting\r\nhalf a line\n\nunterminated" {
+		t.Fatalf("output log = %q %v", data, err)
+	}
+}
+
+func TestOutputPumpRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Server1_output.log")
+	f, err := utils.OpenRotatingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetPolicy(utils.RotationPolicy{MaxSizeMB: 1})
+	p := newOutputPump(&Server{ID: 1}, f)
+	line := strings.Repeat("x", 1023) + "\n"
+	for i := 0; i < 1100; i++ {
+		p.Write([]byte(line))
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Size() >= 1<<20 {
+		t.Fatalf("active log not rotated: %v %v", info, err)
+	}
+	if archives := utils.ListLogArchives(dir); len(archives) != 1 {
+		t.Fatalf("archives = %v", archives)
+	}
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+// ServerTemplateVersion is the template format written by ExportTemplate.
+const ServerTemplateVersion = 1
+
+// ServerTemplate is a portable server definition: every persisted setting (including
+// notification prefs, card toggles, welcome messages, schedules and mods) plus the admin list
+// and blacklist. Instance details such as the ID, ports and run history are left out.
+type ServerTemplate struct {
+	Version    int       `json:"version"`
+	Name       string    `json:"name"`
+	ExportedAt time.Time `json:"exported_at"`
+	// SecretsStripped is set when the password, auth secret and webhook were removed.
+	SecretsStripped bool `json:"secrets_stripped"`
+	// Settings holds the server's sdsm.config entry without instance fields.
+	Settings  json.RawMessage `json:"settings"`
+	AdminList []string        `json:"admin_list,omitempty"`
+	Blacklist []string        `json:"blacklist,omitempty"`
+}
+
+// templateInstanceKeys belong to one installation and are never exported.
+var templateInstanceKeys = []string{
+	"id", "port", "scon_port", "server_started", "last_stopped_at", "server_saved",
+	"last_error", "last_error_at", "pending_save_purge",
+}
+
+// templateSecretKeys are removed when exporting with secrets stripped.
+var templateSecretKeys = []string{"password", "auth_secret", "discord_webhook"}
+
+// templateAutomationKeys make a server act on its own: start with the manager, update,
+// run schedules and backups, and post to Discord. ClearAutomation removes them.
+var templateAutomationKeys = []string{"auto_start", "auto_update", "schedules", "backup_enabled", "discord_webhook"}
+
+// ExportTemplate captures the server's definition. With stripSecrets the password, auth
+// secret and Discord webhook are left empty.
+func (s *Server) ExportTemplate(stripSecrets bool) (*ServerTemplate, error) {
+	if s == nil {
+		return nil, errors.New("server is nil")
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, err
+	}
+	for _, k := range templateInstanceKeys {
+		delete(settings, k)
+	}
+	if stripSecrets {
+		for _, k := range templateSecretKeys {
+			delete(settings, k)
+		}
+	}
+	out, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerTemplate{
+		Version:         ServerTemplateVersion,
+		Name:            s.Name,
+		ExportedAt:      time.Now().UTC(),
+		SecretsStripped: stripSecrets,
+		Settings:        out,
+		AdminList:       s.ReadAdminListIDs(),
+		Blacklist:       s.ReadBlacklistIDs(),
+	}, nil
+}
+
+// ClearAutomation drops the settings that make a server act on its own, so a copy stays idle
+// and silent until it is configured.
+func (t *ServerTemplate) ClearAutomation() error {
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(t.Settings, &settings); err != nil {
+		return err
+	}
+	for _, k := range templateAutomationKeys {
+		delete(settings, k)
+	}
+	out, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	t.Settings = out
+	return nil
+}
+
+// Validate checks the format version and that settings are present.
+func (t *ServerTemplate) Validate() error {
+	if t == nil {
+		return errors.New("template is empty")
+	}
+	if t.Version < 1 || t.Version > ServerTemplateVersion {
+		return fmt.Errorf("unsupported template version %d", t.Version)
+	}
+	if len(t.Settings) == 0 || strings.TrimSpace(string(t.Settings)) == "null" {
+		return errors.New("template has no settings")
+	}
+	return nil
+}
+
+// NewServer builds a server from the template with the given ID, port and name. The admin
+// list and blacklist are written separately with ApplyTemplateLists once the server exists.
+func (t *ServerTemplate) NewServer(id int, paths *utils.Paths, port int, name string) (*Server, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Server{}
+	if err := json.Unmarshal(t.Settings, s); err != nil {
+		return nil, fmt.Errorf("invalid template settings: %w", err)
+	}
+	s.ID = id
+	s.Paths = paths
+	s.Port = port
+	s.SCONPort = port + 1
+	if strings.TrimSpace(name) != "" {
+		s.Name = strings.TrimSpace(name)
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		s.Name = fmt.Sprintf("Stationeers Server %d", id)
+	}
+	s.Clients = []*Client{}
+	s.Chat = []*Chat{}
+	if s.Mods == nil {
+		s.Mods = []string{}
+	}
+	return s, nil
+}
+
+// ApplyTemplateLists writes the template's admin list and blacklist into the server's game
+// directory.
+func (s *Server) ApplyTemplateLists(t *ServerTemplate) error {
+	var errs []error
+	if len(t.AdminList) > 0 {
+		if err := s.WriteAdminListIDs(t.AdminList); err != nil {
+			errs = append(errs, fmt.Errorf("admin list: %w", err))
+		}
+	}
+	if len(t.Blacklist) > 0 {
+		if err := s.WriteBlacklistIDs(t.Blacklist); err != nil {
+			errs = append(errs, fmt.Errorf("blacklist: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
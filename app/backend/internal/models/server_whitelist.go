@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// whitelistKickDelay gives the optional chat message time to reach the player before KICK.
+const whitelistKickDelay = 2 * time.Second
+
+// WhitelistEntry is an allowed SteamID with its best-known player name.
+type WhitelistEntry struct {
+	SteamID string `json:"steam_id"`
+	Name    string `json:"name"`
+}
+
+// IsWhitelisted returns true if the Steam ID is present in the whitelist.
+func (s *Server) IsWhitelisted(id string) bool {
+	id = strings.TrimSpace(id)
+	if id == "" || s == nil {
+		return false
+	}
+	for _, v := range s.Whitelist {
+		if strings.EqualFold(v, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddWhitelistID appends a Steam ID to the whitelist if missing.
+func (s *Server) AddWhitelistID(id string) bool {
+	id = strings.TrimSpace(id)
+	if id == "" || s == nil || s.IsWhitelisted(id) {
+		return false
+	}
+	s.Whitelist = append(s.Whitelist, id)
+	return true
+}
+
+// RemoveWhitelistID removes a Steam ID from the whitelist.
+func (s *Server) RemoveWhitelistID(id string) bool {
+	id = strings.TrimSpace(id)
+	if id == "" || s == nil {
+		return false
+	}
+	for i, v := range s.Whitelist {
+		if strings.EqualFold(v, id) {
+			s.Whitelist = append(s.Whitelist[:i], s.Whitelist[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// WhitelistEntries returns the whitelist with best-effort player names.
+func (s *Server) WhitelistEntries() []WhitelistEntry {
+	out := make([]WhitelistEntry, 0, len(s.Whitelist))
+	for _, id := range s.Whitelist {
+		out = append(out, WhitelistEntry{SteamID: id, Name: s.ResolveNameForSteamID(id)})
+	}
+	return out
+}
+
+// enforceWhitelist runs when a client reaches "is ready". Admins and listed SteamIDs pass.
+// With WhitelistAutoAdd on a password-protected server, newcomers are added (they knew the
+// password); otherwise they are sent WhitelistMessage and kicked through SCON. A client
+// without a SteamID cannot be verified, so it is held back and checked again once a CLIENTS
+// scan reports its SteamID. It returns true when the client is being kicked or held back.
+func (s *Server) enforceWhitelist(name, steamID string) bool {
+	if s == nil || !s.WhitelistEnabled {
+		return false
+	}
+	steamID = strings.TrimSpace(steamID)
+	if steamID == "" {
+		if s.Logger != nil {
+			s.Logger.Warn(fmt.Sprintf("Whitelist: %s has no SteamID; checking the client list", name))
+		}
+		if s.whitelistPending == nil {
+			s.whitelistPending = make(map[string]string)
+		}
+		s.whitelistPending[strings.ToLower(strings.TrimSpace(name))] = name
+		go func(srv *Server) {
+			if err := srv.SendCommand("console", "CLIENTS"); err != nil && srv.Logger != nil {
+				srv.Logger.Error(fmt.Sprintf("Whitelist: cannot verify %s without the client list: %v", name, err))
+			}
+		}(s)
+		return true
+	}
+	if s.IsWhitelisted(steamID) || s.IsSteamIDAdmin(steamID) {
+		return false
+	}
+	if s.WhitelistAutoAdd && strings.TrimSpace(s.Password) != "" {
+		s.AddWhitelistID(steamID)
+		if s.Logger != nil {
+			s.Logger.Info(fmt.Sprintf("Whitelist: added %s (%s) after joining with the server password", name, steamID))
+		}
+		if s.OnSettingsChanged != nil {
+			s.OnSettingsChanged(s)
+		}
+		return false
+	}
+	if s.Logger != nil {
+		s.Logger.Info(fmt.Sprintf("Whitelist: kicking %s (%s)", name, steamID))
+	}
+	msg := strings.TrimSpace(s.WhitelistMessage)
+	go func(srv *Server, text string) {
+		if text != "" {
+			_ = srv.SendCommand("chat", srv.RenderChatMessage(text, map[string]string{"player": name}))
+			time.Sleep(whitelistKickDelay)
+		}
+		if err := srv.SendCommand("console", "KICK "+steamID); err != nil && srv.Logger != nil {
+			srv.Logger.Error(fmt.Sprintf("Whitelist: failed to kick %s: %v", steamID, err))
+		}
+	}(s, msg)
+	return true
+}
+
+// resolveWhitelistPending runs the whitelist check for a held-back client once a CLIENTS
+// scan lists it with a SteamID.
+func (s *Server) resolveWhitelistPending(name, steamID string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if _, ok := s.whitelistPending[key]; !ok {
+		return
+	}
+	delete(s.whitelistPending, key)
+	s.enforceWhitelist(name, steamID)
+}
+
+// dropWhitelistPending reports held-back clients a finished CLIENTS scan did not resolve.
+// They cannot be kicked without a SteamID.
+func (s *Server) dropWhitelistPending() {
+	for key, name := range s.whitelistPending {
+		if s.Logger != nil {
+			s.Logger.Error(fmt.Sprintf("Whitelist: %s has no SteamID in the client list and cannot be kicked; remove them manually", name))
+		}
+		delete(s.whitelistPending, key)
+	}
+}
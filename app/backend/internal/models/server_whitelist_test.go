@@ -0,0 +1,54 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sdsm/app/backend/internal/utils"
+)
+
+func TestWhitelistHoldsClientsWithoutSteamID(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "server.log")
+	s := &Server{ID: 1, Name: "Alpha", Paths: utils.NewPaths(dir), WhitelistEnabled: true, Logger: utils.NewLogger(logPath)}
+	defer s.Logger.Close()
+
+	if !s.enforceWhitelist("Bob", " ") {
+		t.Fatal("client without a SteamID was let in")
+	}
+	if _, ok := s.whitelistPending["bob"]; !ok {
+		t.Fatal("client without a SteamID was not held for the CLIENTS check")
+	}
+	for _, line := range []string{
+		"12:00:01: CLIENTS",
+		"12:00:01: 76561198000000009 | Bob connectTime: 5s ClientId: 76561198000000009",
+		"12:00:01: Host Client: Alpha",
+	} {
+		s.processLine(line)
+	}
+	if len(s.whitelistPending) != 0 {
+		t.Fatalf("pending clients left after the scan: %v", s.whitelistPending)
+	}
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), "Whitelist: kicking Bob (76561198000000009)") {
+		t.Fatalf("resolved client not kicked; log:\n%s", log)
+	}
+
+	s.Whitelist = []string{"76561198000000010"}
+	s.enforceWhitelist("Ann", "")
+	for _, line := range []string{
+		"12:00:02: CLIENTS",
+		"12:00:02: 76561198000000010 | Ann connectTime: 5s ClientId: 76561198000000010",
+		"12:00:02: Host Client: Alpha",
+	} {
+		s.processLine(line)
+	}
+	if log, _ = os.ReadFile(logPath); strings.Contains(string(log), "kicking Ann") {
+		t.Fatal("whitelisted client kicked after the CLIENTS check")
+	}
+}
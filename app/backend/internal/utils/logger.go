@@ -1,16 +1,99 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Logger writes timestamped lines to a log file (and allows reading recent data).
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return "INFO"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel accepts debug, info, warn/warning and error in any case.
+func ParseLevel(s string) (Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, true
+	case "info", "":
+		return LevelInfo, true
+	case "warn", "warning":
+		return LevelWarn, true
+	case "error":
+		return LevelError, true
+	}
+	return LevelInfo, false
+}
+
+// Log output options shared by every Logger in the process.
+var (
+	logJSON     atomic.Bool
+	logMinLevel atomic.Int32
+)
+
+func init() { logMinLevel.Store(int32(LevelInfo)) }
+
+// ConfigureLogging sets the output format ("text" or "json") and the minimum level written.
+func ConfigureLogging(format, level string) error {
+	lvl, ok := ParseLevel(level)
+	if !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		logJSON.Store(false)
+	case "json":
+		logJSON.Store(true)
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+	logMinLevel.Store(int32(lvl))
+	return nil
+}
+
+// Logger writes leveled entries to a log file (and allows reading recent data). Each entry
+// carries the logger's component and, for server logs, the server ID. Text lines look like
+//
+//	2026-01-02 15:04:05: WARN [server 3] Failed to open players log key=value
+//
+// and JSON lines like {"time":...,"level":"warn","component":"server","server_id":3,"msg":...}.
+// The file rotates according to the policy set with SetRotation.
 type Logger struct {
-	writeFile *os.File
+	core      *logCore
+	component string
+	serverID  int
+}
+
+// logCore is the file shared by a logger and the children created with With.
+type logCore struct {
+	mu        sync.Mutex
+	writeFile *RotatingFile
 	readFile  *os.File
+	writer    io.Writer
 }
 
 // defaultLogPath returns the path to the default SDSM log file using the
@@ -29,26 +112,25 @@ func defaultLogPath() string {
 	return NewPaths(filepath.Join(os.TempDir(), "sdsm")).LogFile()
 }
 
-// writeToDefaultLog attempts to write a single timestamped line to the default
+// writeToDefaultLog attempts to write a single formatted entry to the default
 // SDSM log. If it fails, it falls back to stderr.
-func writeToDefaultLog(message string) {
+func writeToDefaultLog(line string) {
 	path := defaultLogPath()
 	_ = os.MkdirAll(filepath.Dir(path), 0o755)
 	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
 		// Last resort: stderr
-		fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+		_, _ = os.Stderr.WriteString(line)
 		return
 	}
 	defer f.Close()
-	ts := time.Now().Format("2006-01-02 15:04:05")
-	_, _ = f.WriteString(fmt.Sprintf("%s: %s\n", ts, message))
+	_, _ = f.WriteString(line)
 }
 
 // NewLogger opens the given log file for appending and a parallel read handle.
 // If the file cannot be opened, logs will be written to stdout.
 func NewLogger(logFile string) *Logger {
-	logger := &Logger{}
+	logger := &Logger{core: &logCore{}}
 	// Ensure we always have a target path; prefer provided path, else default.
 	if logFile == "" {
 		logFile = defaultLogPath()
@@ -58,56 +140,270 @@ func NewLogger(logFile string) *Logger {
 	_ = os.MkdirAll(filepath.Dir(logFile), 0o755)
 
 	var err error
-	logger.writeFile, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	logger.core.writeFile, err = OpenRotatingFile(logFile)
 	if err != nil {
-		writeToDefaultLog(fmt.Sprintf("Error opening log file (%s): %v", logFile, err))
+		writeToDefaultLog(formatEntry(time.Now(), LevelError, "", 0, fmt.Sprintf("Error opening log file (%s): %v", logFile, err), nil))
 		// Return a logger that will fall back to stdout on Write()
+		logger.core.writeFile = nil
 		return logger
 	}
-	logger.readFile, err = os.Open(logFile)
+	logger.core.readFile, err = os.Open(logFile)
 	if err != nil {
-		writeToDefaultLog(fmt.Sprintf("Error opening log file for reading (%s): %v", logFile, err))
+		writeToDefaultLog(formatEntry(time.Now(), LevelError, "", 0, fmt.Sprintf("Error opening log file for reading (%s): %v", logFile, err), nil))
 	}
 	return logger
 }
 
-// Write appends a timestamped message to the log (or stdout when no file).
-func (l *Logger) Write(message string) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	logMessage := fmt.Sprintf("%s: %s\n", timestamp, message)
-	if l.writeFile != nil {
-		l.writeFile.WriteString(logMessage)
-		l.writeFile.Sync()
-	} else {
+// NewWriterLogger returns a logger that writes entries to w, such as the HTTP access log.
+func NewWriterLogger(w io.Writer) *Logger {
+	return &Logger{core: &logCore{writer: w}}
+}
+
+// With returns a logger for component (and serverID when non-zero) that shares this
+// logger's file. Closing either closes the file.
+func (l *Logger) With(component string, serverID int) *Logger {
+	if l == nil {
+		return nil
+	}
+	return &Logger{core: l.core, component: component, serverID: serverID}
+}
+
+// Component returns the component name attached to entries.
+func (l *Logger) Component() string {
+	if l == nil {
+		return ""
+	}
+	return l.component
+}
+
+// Debug logs at debug level; kv are alternating key/value pairs appended as fields.
+func (l *Logger) Debug(msg string, kv ...interface{}) { l.log(LevelDebug, msg, kv) }
+
+// Info logs at info level; kv are alternating key/value pairs appended as fields.
+func (l *Logger) Info(msg string, kv ...interface{}) { l.log(LevelInfo, msg, kv) }
+
+// Warn logs at warn level; kv are alternating key/value pairs appended as fields.
+func (l *Logger) Warn(msg string, kv ...interface{}) { l.log(LevelWarn, msg, kv) }
+
+// Error logs at error level; kv are alternating key/value pairs appended as fields.
+func (l *Logger) Error(msg string, kv ...interface{}) { l.log(LevelError, msg, kv) }
+
+func (l *Logger) log(level Level, msg string, kv []interface{}) {
+	if level < Level(logMinLevel.Load()) {
+		return
+	}
+	if l == nil || l.core == nil {
+		writeToDefaultLog(formatEntry(time.Now(), level, "", 0, msg, kv))
+		return
+	}
+	l.core.write(formatEntry(time.Now(), level, l.component, l.serverID, msg, kv))
+}
+
+// Write appends p, which must already be formatted as log lines (for example entries
+// from the HTTP access logger), without altering it. It lets a Logger serve as an io.Writer.
+func (l *Logger) Write(p []byte) (int, error) {
+	if l == nil || l.core == nil {
+		writeToDefaultLog(string(p))
+		return len(p), nil
+	}
+	l.core.write(string(p))
+	return len(p), nil
+}
+
+func (c *logCore) write(line string) {
+	switch {
+	case c.writeFile != nil:
+		_, _ = c.writeFile.Write([]byte(line))
+		_ = c.writeFile.Sync()
+	case c.writer != nil:
+		c.mu.Lock()
+		_, _ = io.WriteString(c.writer, line)
+		c.mu.Unlock()
+	default:
 		// No open file: write to default SDSM log instead of stdout
-		writeToDefaultLog(message)
+		writeToDefaultLog(line)
 	}
 }
 
 // Read reads up to 1 KiB from the current read handle for quick previews.
 func (l *Logger) Read() string {
-	if l.readFile == nil {
+	if l == nil || l.core == nil || l.core.readFile == nil {
 		return ""
 	}
 	buf := make([]byte, 1024)
-	n, _ := l.readFile.Read(buf)
+	n, _ := l.core.readFile.Read(buf)
 	return string(buf[:n])
 }
 
 // Close flushes and closes underlying file handles.
 func (l *Logger) Close() {
-	if l.writeFile != nil {
-		l.writeFile.Close()
+	if l == nil || l.core == nil {
+		return
 	}
-	if l.readFile != nil {
-		l.readFile.Close()
+	if l.core.writeFile != nil {
+		l.core.writeFile.Close()
+	}
+	if l.core.readFile != nil {
+		l.core.readFile.Close()
 	}
 }
 
 // File returns the underlying write file handle when available.
 func (l *Logger) File() *os.File {
-	if l == nil {
+	if l == nil || l.core == nil || l.core.writeFile == nil {
 		return nil
 	}
-	return l.writeFile
+	return l.core.writeFile.File()
+}
+
+// SetRotation sets the size/age rotation and archive retention policy for the log file.
+func (l *Logger) SetRotation(p RotationPolicy) {
+	if l != nil && l.core != nil && l.core.writeFile != nil {
+		l.core.writeFile.SetPolicy(p)
+	}
+}
+
+const logTimeLayout = "2006-01-02 15:04:05"
+
+func formatEntry(t time.Time, level Level, component string, serverID int, msg string, kv []interface{}) string {
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields[fmt.Sprint(kv[i])] = kv[i+1]
+	}
+	if logJSON.Load() {
+		entry := map[string]interface{}{
+			"time":  t.Format(time.RFC3339Nano),
+			"level": strings.ToLower(level.String()),
+			"msg":   msg,
+		}
+		if component != "" {
+			entry["component"] = component
+		}
+		if serverID != 0 {
+			entry["server_id"] = serverID
+		}
+		for k, v := range fields {
+			if _, taken := entry[k]; !taken {
+				if err, ok := v.(error); ok {
+					v = err.Error()
+				}
+				entry[k] = v
+			}
+		}
+		raw, err := json.Marshal(entry)
+		if err == nil {
+			return string(raw) + "\n"
+		}
+	}
+	var b strings.Builder
+	b.WriteString(t.Format(logTimeLayout))
+	b.WriteString(": ")
+	b.WriteString(level.String())
+	if component != "" {
+		b.WriteString(" [")
+		b.WriteString(component)
+		if serverID != 0 {
+			b.WriteString(" ")
+			b.WriteString(strconv.Itoa(serverID))
+		}
+		b.WriteString("]")
+	}
+	b.WriteString(" ")
+	b.WriteString(msg)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := fmt.Sprint(fields[k])
+		if v == "" || strings.ContainsAny(v, " \t\"=") {
+			v = strconv.Quote(v)
+		}
+		b.WriteString(" ")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(v)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// LogEntry is one parsed log line.
+type LogEntry struct {
+	Time      time.Time
+	Level     Level
+	Component string
+	ServerID  int
+	Message   string
+}
+
+var textEntryRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}):\s+(?:(DEBUG|INFO|WARN|ERROR)\s+)?(?:\[([A-Za-z0-9_.-]+)(?: (\d+))?\]\s+)?(.*)$`)
+
+// ParseLogLine parses a text or JSON entry written by Logger. Lines written before levels
+// existed parse as info; lines that are not entries (raw tool output, stack traces) do not parse.
+func ParseLogLine(line string) (LogEntry, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "{") {
+		var raw struct {
+			Time      time.Time `json:"time"`
+			Level     string    `json:"level"`
+			Component string    `json:"component"`
+			ServerID  int       `json:"server_id"`
+			Msg       string    `json:"msg"`
+		}
+		if err := json.Unmarshal([]byte(line), &raw); err != nil || raw.Time.IsZero() {
+			return LogEntry{}, false
+		}
+		lvl, _ := ParseLevel(raw.Level)
+		return LogEntry{Time: raw.Time, Level: lvl, Component: raw.Component, ServerID: raw.ServerID, Message: raw.Msg}, true
+	}
+	m := textEntryRegex.FindStringSubmatch(line)
+	if m == nil {
+		return LogEntry{}, false
+	}
+	t, err := time.ParseInLocation(logTimeLayout, m[1], time.Local)
+	if err != nil {
+		return LogEntry{}, false
+	}
+	lvl, _ := ParseLevel(m[2])
+	id, _ := strconv.Atoi(m[4])
+	return LogEntry{Time: t, Level: lvl, Component: m[3], ServerID: id, Message: m[5]}, true
+}
+
+// LogFilter selects entries by minimum level and component.
+type LogFilter struct {
+	MinLevel  Level
+	Component string
+}
+
+// Active reports whether the filter drops anything.
+func (f LogFilter) Active() bool {
+	return f.MinLevel > LevelDebug || f.Component != ""
+}
+
+func (f LogFilter) match(e LogEntry) bool {
+	return e.Level >= f.MinLevel && (f.Component == "" || strings.EqualFold(e.Component, f.Component))
+}
+
+// FilterLogLines keeps the lines of data whose entries match. Lines that are not entries
+// follow the entry before them; those before the first entry are dropped.
+func FilterLogLines(data string, f LogFilter) string {
+	if !f.Active() || data == "" {
+		return data
+	}
+	var b strings.Builder
+	keep := false
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		if e, ok := ParseLogLine(line); ok {
+			keep = f.match(e)
+		}
+		if keep {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
 }
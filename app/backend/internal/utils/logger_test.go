@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLoggerTextAndJSON(t *testing.T) {
+	defer ConfigureLogging("", "")
+	var buf bytes.Buffer
+	server := NewWriterLogger(&buf).With("server", 3)
+
+	server.Error("Failed to open players log")
+	server.Info("Player joined", "name", "Rook Two", "slot", 4)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], ": ERROR [server 3] Failed to open players log") {
+		t.Fatalf("error line = %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `: INFO [server 3] Player joined name="Rook Two" slot=4`) {
+		t.Fatalf("fields line = %q", lines[1])
+	}
+	e, ok := ParseLogLine(lines[1])
+	if !ok || e.Level != LevelInfo || e.Component != "server" || e.ServerID != 3 || !strings.HasPrefix(e.Message, "Player joined") {
+		t.Fatalf("parsed text entry = %+v %v", e, ok)
+	}
+
+	if err := ConfigureLogging("json", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	server.Info("dropped below the minimum level")
+	server.Warn("Save is slow", "seconds", 12)
+	var raw map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("json line %q: %v", buf.String(), err)
+	}
+	if raw["level"] != "warn" || raw["component"] != "server" || raw["server_id"] != float64(3) || raw["seconds"] != float64(12) {
+		t.Fatalf("json entry = %v", raw)
+	}
+	if e, ok := ParseLogLine(buf.String()); !ok || e.Level != LevelWarn || e.Message != "Save is slow" {
+		t.Fatalf("parsed json entry = %+v %v", e, ok)
+	}
+	if err := ConfigureLogging("xml", "info"); err == nil {
+		t.Fatal("unknown format accepted")
+	}
+}
+
+func TestLoggerWritePassesLinesThrough(t *testing.T) {
+	defer ConfigureLogging("", "")
+	if err := ConfigureLogging("json", "info"); err != nil {
+		t.Fatal(err)
+	}
+	var access, manager bytes.Buffer
+	NewWriterLogger(&access).With("http", 0).Warn("GET /api/x", "status", 404, "ip", "10.0.0.1")
+	if _, err := NewWriterLogger(&manager).With("manager", 0).Write(access.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if manager.String() != access.String() {
+		t.Fatalf("forwarded line = %q, want %q", manager.String(), access.String())
+	}
+}
+
+func TestFilterLogLines(t *testing.T) {
+	data := "" +
+		"tail of a cut-off line\n" +
+		"2026-03-01 08:00:00: Legacy line without level\n" +
+		"2026-03-01 08:00:01: ERROR [manager] Update failed\n" +
+		"  stack frame one\n" +
+		"2026-03-01 08:00:02: WARN [server 2] Retrying save\n" +
+		"2026-03-01 08:00:03: ERROR [server 2] Crash detected\n"
+
+	got := FilterLogLines(data, LogFilter{MinLevel: LevelError})
+	want := "2026-03-01 08:00:01: ERROR [manager] Update failed\n" +
+		"  stack frame one\n" +
+		"2026-03-01 08:00:03: ERROR [server 2] Crash detected\n"
+	if got != want {
+		t.Fatalf("level filter:\n%s\nwant:\n%s", got, want)
+	}
+	got = FilterLogLines(data, LogFilter{MinLevel: LevelDebug, Component: "server"})
+	if strings.Count(got, "\n") != 2 || strings.Contains(got, "manager") {
+		t.Fatalf("component filter = %q", got)
+	}
+	if FilterLogLines(data, LogFilter{}) != data {
+		t.Fatal("inactive filter changed data")
+	}
+}
@@ -0,0 +1,428 @@
+package utils
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationPolicy controls when a log file is rotated and how long its archives are kept.
+// Zero values disable the corresponding limit.
+type RotationPolicy struct {
+	// MaxSizeMB rotates the file once it grows past this size.
+	MaxSizeMB int `json:"max_size_mb"`
+	// MaxAgeDays rotates the file once its oldest content is this old.
+	MaxAgeDays int `json:"max_age_days"`
+	// MaxArchives keeps at most this many archives per log.
+	MaxArchives int `json:"max_archives"`
+	// RetentionDays deletes archives rotated longer ago than this.
+	RetentionDays int `json:"retention_days"`
+	// Compress gzips archives.
+	Compress bool `json:"compress"`
+}
+
+// Enabled reports whether the policy rotates at all.
+func (p RotationPolicy) Enabled() bool {
+	return p.MaxSizeMB > 0 || p.MaxAgeDays > 0
+}
+
+// Validate rejects negative limits.
+func (p RotationPolicy) Validate() error {
+	if p.MaxSizeMB < 0 || p.MaxAgeDays < 0 || p.MaxArchives < 0 || p.RetentionDays < 0 {
+		return errors.New("rotation limits must not be negative")
+	}
+	return nil
+}
+
+func (p RotationPolicy) due(size int64, started, now time.Time) bool {
+	if size <= 0 {
+		return false
+	}
+	if p.MaxSizeMB > 0 && size >= int64(p.MaxSizeMB)<<20 {
+		return true
+	}
+	return p.MaxAgeDays > 0 && !started.IsZero() && now.Sub(started) >= time.Duration(p.MaxAgeDays)*24*time.Hour
+}
+
+// LogArchive describes a rotated log file stored next to the active log.
+type LogArchive struct {
+	Name string `json:"name"`
+	// Source is the active log the archive was rotated from.
+	Source     string    `json:"source"`
+	Size       int64     `json:"size"`
+	Rotated    time.Time `json:"rotated"`
+	Compressed bool      `json:"compressed"`
+}
+
+// Archives are named <base>-<stamp>.log, plus .gz once compressed, so "Server1.log" rotates to
+// "Server1-20260102-150405.log.gz".
+const archiveStampLayout = "20060102-150405"
+
+var archiveNameRegex = regexp.MustCompile(`^(.+)-(\d{8}-\d{6})\.log(\.gz)?$`)
+
+// IsLogArchiveName reports whether name is a rotated log archive.
+func IsLogArchiveName(name string) bool {
+	return archiveNameRegex.MatchString(name)
+}
+
+// IsActiveLogName reports whether name is a live *.log file rather than an archive.
+func IsActiveLogName(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".log") && !IsLogArchiveName(name)
+}
+
+func parseArchiveName(name string) (LogArchive, bool) {
+	m := archiveNameRegex.FindStringSubmatch(name)
+	if m == nil {
+		return LogArchive{}, false
+	}
+	rotated, err := time.ParseInLocation(archiveStampLayout, m[2], time.Local)
+	if err != nil {
+		return LogArchive{}, false
+	}
+	return LogArchive{Name: name, Source: m[1] + ".log", Rotated: rotated, Compressed: m[3] != ""}, true
+}
+
+// ListLogArchives returns the archives in dir, newest first.
+func ListLogArchives(dir string) []LogArchive {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []LogArchive
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		a, ok := parseArchiveName(e.Name())
+		if !ok {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			a.Size = info.Size()
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Rotated.Equal(out[j].Rotated) {
+			return out[i].Rotated.After(out[j].Rotated)
+		}
+		return out[i].Name > out[j].Name
+	})
+	return out
+}
+
+func archivesOf(path string) []LogArchive {
+	source := filepath.Base(path)
+	var out []LogArchive
+	for _, a := range ListLogArchives(filepath.Dir(path)) {
+		if a.Source == source {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// RotateFileIfDue rotates path when the policy says it is too large or too old. It is meant for
+// files that no process holds open, such as a game output log before the server starts.
+func RotateFileIfDue(path string, p RotationPolicy, now time.Time) (string, error) {
+	if !p.Enabled() {
+		return "", nil
+	}
+	info, err := os.Stat(path)
+	if err != nil || !p.due(info.Size(), logStarted(path, info), now) {
+		return "", nil
+	}
+	archive, err := rotateRename(path, now)
+	if err != nil {
+		return "", err
+	}
+	return finishRotation(path, archive, p, now)
+}
+
+// rotateRename moves path aside to a new archive name and returns the archive path.
+func rotateRename(path string, now time.Time) (string, error) {
+	archive := archiveName(path, now)
+	return archive, os.Rename(path, archive)
+}
+
+// archiveName returns an unused archive path for path, stamped with now.
+func archiveName(path string, now time.Time) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	stamp := now
+	for {
+		archive := filepath.Join(filepath.Dir(path), base+"-"+stamp.Format(archiveStampLayout)+".log")
+		_, errPlain := os.Stat(archive)
+		_, errGz := os.Stat(archive + ".gz")
+		if errors.Is(errPlain, os.ErrNotExist) && errors.Is(errGz, os.ErrNotExist) {
+			return archive
+		}
+		stamp = stamp.Add(time.Second)
+	}
+}
+
+// finishRotation compresses the archive when configured and prunes old archives.
+func finishRotation(path, archive string, p RotationPolicy, now time.Time) (string, error) {
+	var err error
+	if p.Compress {
+		if err = gzipFile(archive); err == nil {
+			archive += ".gz"
+		}
+	}
+	if perr := PruneLogArchives(path, p, now); err == nil {
+		err = perr
+	}
+	return filepath.Base(archive), err
+}
+
+// PruneLogArchives deletes archives of path beyond MaxArchives or older than RetentionDays.
+func PruneLogArchives(path string, p RotationPolicy, now time.Time) error {
+	var errs []error
+	for i, a := range archivesOf(path) {
+		expired := p.RetentionDays > 0 && now.Sub(a.Rotated) > time.Duration(p.RetentionDays)*24*time.Hour
+		if (p.MaxArchives > 0 && i >= p.MaxArchives) || expired {
+			if err := os.Remove(filepath.Join(filepath.Dir(path), a.Name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OpenLogReader opens an active log or an archive, decompressing .gz archives.
+func OpenLogReader(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(strings.ToLower(path), ".gz") {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("open %s: %w", filepath.Base(path), err)
+	}
+	return gzipReadCloser{zr, f}, nil
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g gzipReadCloser) Close() error {
+	err := g.Reader.Close()
+	if cerr := g.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := path + ".gz.tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	zw.Name = filepath.Base(path)
+	_, err = io.Copy(zw, in)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compress %s: %w", filepath.Base(path), err)
+	}
+	in.Close()
+	return os.Remove(path)
+}
+
+// logStarted estimates when the current file began: the newest rotation of it, else the
+// timestamp on its first entry (text or JSON), else its modification time.
+func logStarted(path string, info os.FileInfo) time.Time {
+	if archives := archivesOf(path); len(archives) > 0 {
+		return archives[0].Rotated
+	}
+	if f, err := os.Open(path); err == nil {
+		line, _ := bufio.NewReader(io.LimitReader(f, 4096)).ReadString('\n')
+		f.Close()
+		if entry, ok := ParseLogLine(line); ok {
+			return entry.Time
+		}
+	}
+	if info != nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// RotatingFile is an append-only log file that rotates itself according to its policy.
+// Rotation renames the file and reopens it; compression and pruning run in the background.
+type RotatingFile struct {
+	path string
+
+	mu      sync.Mutex
+	f       *os.File
+	size    int64
+	started time.Time
+	policy  RotationPolicy
+	bg      sync.WaitGroup
+	// bgMu serializes compression and pruning across back-to-back rotations.
+	bgMu sync.Mutex
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// OpenRotatingFile opens path for appending, creating it when missing.
+func OpenRotatingFile(path string) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, now: time.Now}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	r.started = r.now()
+	if r.size > 0 {
+		r.started = logStarted(r.path, info)
+	}
+	return nil
+}
+
+// Path returns the active file path.
+func (r *RotatingFile) Path() string { return r.path }
+
+// File returns the current handle, for child processes that write output directly.
+func (r *RotatingFile) File() *os.File {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f
+}
+
+// SetPolicy replaces the rotation policy and rotates right away if the file is already due.
+func (r *RotatingFile) SetPolicy(p RotationPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = p
+	r.rotateIfDueLocked()
+}
+
+// Write appends b, rotating first when the file is due.
+func (r *RotatingFile) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotateIfDueLocked()
+	if r.f == nil {
+		return 0, os.ErrClosed
+	}
+	n, err := r.f.Write(b)
+	r.size += int64(n)
+	return n, err
+}
+
+// Sync commits the current file to stable storage.
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return os.ErrClosed
+	}
+	return r.f.Sync()
+}
+
+// Rotate archives the current file immediately.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotateLocked()
+}
+
+func (r *RotatingFile) rotateIfDueLocked() {
+	if r.f == nil || !r.policy.Enabled() || !r.policy.due(r.size, r.started, r.now()) {
+		return
+	}
+	// The size counter drifts when the file is truncated by hand; confirm before rotating.
+	if info, err := r.f.Stat(); err == nil {
+		r.size = info.Size()
+		if !r.policy.due(r.size, r.started, r.now()) {
+			return
+		}
+	}
+	if err := r.rotateLocked(); err != nil {
+		writeToDefaultLog(formatEntry(time.Now(), LevelError, "logrotate", 0, fmt.Sprintf("Log rotation failed for %s: %v", r.path, err), nil))
+	}
+}
+
+func (r *RotatingFile) rotateLocked() error {
+	if r.f == nil {
+		return os.ErrClosed
+	}
+	now := r.now()
+	// Windows cannot rename an open file, so close before moving it aside.
+	r.f.Close()
+	r.f = nil
+	archive, renameErr := rotateRename(r.path, now)
+	if err := r.open(); err != nil {
+		return err
+	}
+	r.started = now
+	if renameErr != nil {
+		return renameErr
+	}
+	policy := r.policy
+	r.bg.Add(1)
+	go func() {
+		defer r.bg.Done()
+		r.bgMu.Lock()
+		defer r.bgMu.Unlock()
+		if _, err := finishRotation(r.path, archive, policy, now); err != nil {
+			writeToDefaultLog(formatEntry(time.Now(), LevelError, "logrotate", 0, fmt.Sprintf("Log archive maintenance failed for %s: %v", r.path, err), nil))
+		}
+	}()
+	return nil
+}
+
+// Close waits for background compression and closes the file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	f := r.f
+	r.f = nil
+	r.mu.Unlock()
+	r.bg.Wait()
+	if f == nil {
+		return nil
+	}
+	return f.Close()
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSizeAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Server1.log")
+	r, err := OpenRotatingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	r.now = func() time.Time { return now }
+	r.SetPolicy(RotationPolicy{MaxSizeMB: 1, MaxArchives: 2, Compress: true})
+
+	chunk := []byte(strings.Repeat("x", 1<<20-1) + "\n")
+	for i := 0; i < 4; i++ {
+		if _, err := r.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Minute)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archives := ListLogArchives(dir)
+	if len(archives) != 2 {
+		t.Fatalf("archives = %+v, want 2 after pruning", archives)
+	}
+	newest := archives[0]
+	if newest.Source != "Server1.log" || !newest.Compressed || newest.Name != "Server1-20260301-120300.log.gz" {
+		t.Fatalf("newest archive = %+v", newest)
+	}
+	f, err := os.Open(filepath.Join(dir, newest.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, _ := io.Copy(io.Discard, zr)
+	if n != int64(len(chunk)) {
+		t.Fatalf("archive holds %d bytes, want %d", n, len(chunk))
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != int64(len(chunk)) {
+		t.Fatalf("active log should hold the last write: %v %v", info, err)
+	}
+	if IsActiveLogName(newest.Name) || !IsActiveLogName("Server1_output.log") {
+		t.Fatal("archive and active names confused")
+	}
+}
+
+func TestRotateFileIfDueByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Server1_output.log")
+	if err := os.WriteFile(path, []byte("2026-03-01 08:00:00: booting\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy := RotationPolicy{MaxAgeDays: 1, RetentionDays: 3}
+	now := time.Date(2026, 3, 1, 20, 0, 0, 0, time.Local)
+	if name, err := RotateFileIfDue(path, policy, now); err != nil || name != "" {
+		t.Fatalf("young log rotated: %q %v", name, err)
+	}
+	now = now.Add(24 * time.Hour)
+	name, err := RotateFileIfDue(path, policy, now)
+	if err != nil || name != "Server1_output-20260302-200000.log" {
+		t.Fatalf("rotate = %q %v", name, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("active log should be moved aside")
+	}
+
+	stale := filepath.Join(dir, "Server1_output-20260220-080000.log.gz")
+	if err := os.WriteFile(stale, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := PruneLogArchives(path, policy, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatal("archive past retention not pruned")
+	}
+}
@@ -62,6 +62,16 @@ func (p *Paths) LogsDir() string {
 	return filepath.Join(p.RootPath, "logs")
 }
 
+// ModsLibraryDir returns the shared mod library; each mod lives in a subdirectory named by its ID.
+func (p *Paths) ModsLibraryDir() string {
+	return filepath.Join(p.RootPath, "mods")
+}
+
+// HistoryDir returns the directory holding the telemetry history ring files.
+func (p *Paths) HistoryDir() string {
+	return filepath.Join(p.RootPath, "history")
+}
+
 // ConfigDir returns the application configuration directory.
 func (p *Paths) ConfigDir() string {
 	return filepath.Join(p.RootPath, "config")
@@ -72,6 +82,21 @@ func (p *Paths) UsersFile() string {
 	return filepath.Join(p.ConfigDir(), "users.json")
 }
 
+// RolesFile returns the path to the custom role definitions file.
+func (p *Paths) RolesFile() string {
+	return filepath.Join(p.ConfigDir(), "roles.json")
+}
+
+// PlayersFile returns the path to the cross-server player index.
+func (p *Paths) PlayersFile() string {
+	return filepath.Join(p.ConfigDir(), "players.json")
+}
+
+// BansFile returns the path to the ban record store.
+func (p *Paths) BansFile() string {
+	return filepath.Join(p.ConfigDir(), "bans.json")
+}
+
 // LogFile returns the main SDSM log file path.
 func (p *Paths) LogFile() string {
 	return filepath.Join(p.LogsDir(), "sdsm.log")
@@ -82,6 +107,11 @@ func (p *Paths) UpdateLogFile() string {
 	return filepath.Join(p.LogsDir(), "updates.log")
 }
 
+// AuditLogFile returns the path to the JSON-lines audit log of privileged actions.
+func (p *Paths) AuditLogFile() string {
+	return filepath.Join(p.LogsDir(), "audit.jsonl")
+}
+
 // CheckRoot verifies that core directories exist under the root path.
 func (p *Paths) CheckRoot() bool {
 	dirs := []string{p.RootPath, p.SteamDir(), p.ReleaseDir(), p.BetaDir(), p.BepInExDir(), p.SCONDir(), p.LaunchPadDir(), p.LogsDir()}
@@ -99,7 +129,7 @@ func (p *Paths) DeployRoot(logger *Logger) {
 	mkdirLog := func(path, label string) {
 		_ = os.MkdirAll(path, os.ModePerm)
 		if logger != nil {
-			logger.Write(fmt.Sprintf("Creating %s path: %s", label, path))
+			logger.Info(fmt.Sprintf("Creating %s path: %s", label, path))
 		}
 	}
 
@@ -112,6 +142,7 @@ func (p *Paths) DeployRoot(logger *Logger) {
 	mkdirLog(p.LaunchPadDir(), "launchpad")
 	mkdirLog(p.LogsDir(), "logs")
 	mkdirLog(p.ConfigDir(), "config")
+	mkdirLog(p.ModsLibraryDir(), "mods library")
 }
 
 // ServerName returns the canonical name for a server id.
@@ -134,6 +165,11 @@ func (p *Paths) ServerSavesDir(id int) string {
 	return filepath.Join(p.ServerDir(id), "saves")
 }
 
+// ServerBackupsDir returns the directory holding a server's world backup archives.
+func (p *Paths) ServerBackupsDir(id int) string {
+	return filepath.Join(p.ServerDir(id), "backups")
+}
+
 // ServerSettingsDir returns the settings directory for a server.
 func (p *Paths) ServerSettingsDir(id int) string {
 	return filepath.Join(p.ServerDir(id), "settings")
@@ -201,7 +237,7 @@ func (p *Paths) DeployServer(id int, logger *Logger) {
 	mkdirLog := func(path, label string) {
 		_ = os.MkdirAll(path, os.ModePerm)
 		if logger != nil {
-			logger.Write(fmt.Sprintf("Creating %s path: %s", label, path))
+			logger.Info(fmt.Sprintf("Creating %s path: %s", label, path))
 		}
 	}
 
@@ -217,13 +253,13 @@ func (p *Paths) DeployServer(id int, logger *Logger) {
 // DeleteServerDirectory removes the entire server directory tree.
 func (p *Paths) DeleteServerDirectory(id int, logger *Logger) error {
 	serverDir := p.ServerDir(id)
-	logger.Write(fmt.Sprintf("Deleting server directory: %s", serverDir))
+	logger.Info(fmt.Sprintf("Deleting server directory: %s", serverDir))
 	err := os.RemoveAll(serverDir)
 	if err != nil {
-		logger.Write(fmt.Sprintf("Error deleting server directory: %v", err))
+		logger.Error(fmt.Sprintf("Error deleting server directory: %v", err))
 		return err
 	}
-	logger.Write("Server directory successfully deleted")
+	logger.Info("Server directory successfully deleted")
 	return nil
 }
 
@@ -245,7 +281,7 @@ func RestartProcess(executable string, args []string) error {
 		commandLine := strings.Join(append([]string{executable}, args...), " ")
 		// Log restart execution to sdsm.log instead of stdout
 		logger := NewLogger("")
-		logger.Write("Executing command: " + commandLine)
+		logger.Info("Executing command: " + commandLine)
 		return cmd.Start()
 	}
 
@@ -129,7 +129,7 @@ func (s *Steam) GetVersions() ([]string, error) {
 		return nil, fmt.Errorf("version information not found")
 	}
 
-	s.Logger.Write(fmt.Sprintf("Release version: %s | Beta version: %s", releaseVersion, betaVersion))
+	s.Logger.Info(fmt.Sprintf("Release version: %s | Beta version: %s", releaseVersion, betaVersion))
 	return []string{releaseVersion, betaVersion}, nil
 }
 
@@ -151,7 +151,7 @@ func (s *Steam) UpdateSteamCMD() error {
 		return err
 	}
 
-	s.Logger.Write(fmt.Sprintf("Extracting %s to %s", steamcmdFile, s.Paths.SteamDir()))
+	s.Logger.Info(fmt.Sprintf("Extracting %s to %s", steamcmdFile, s.Paths.SteamDir()))
 	s.reportProgress("Extracting", 0, 0)
 
 	if runtime.GOOS == "windows" {
@@ -168,7 +168,7 @@ func (s *Steam) UpdateSteamCMD() error {
 
 	os.Remove(filePath)
 	s.reportProgress("Completed", 0, 0)
-	s.Logger.Write("SteamCMD deployed successfully")
+	s.Logger.Info("SteamCMD deployed successfully")
 	return nil
 }
 
@@ -194,7 +194,7 @@ func (s *Steam) UpdateGame(beta bool) error {
 	if strings.ContainsAny(cleanDir, "\n\r\x00") {
 		return fmt.Errorf("invalid characters in install dir path")
 	}
-	s.Logger.Write(fmt.Sprintf("Starting update for Steam ID: %s to %s", s.SteamID, dir))
+	s.Logger.Info(fmt.Sprintf("Starting update for Steam ID: %s to %s", s.SteamID, dir))
 	s.reportProgress("Preparing SteamCMD", 0, 0)
 
 	// Validate SteamID is numeric to satisfy command construction safety requirements
@@ -226,7 +226,7 @@ func (s *Steam) UpdateGame(beta bool) error {
 		return perr
 	}
 	// Log command with sanitized path (arguments already validated)
-	s.Logger.Write(fmt.Sprintf("Executing command: %s %s", execPath, strings.Join(steamCmd, " ")))
+	s.Logger.Debug(fmt.Sprintf("Executing command: %s %s", execPath, strings.Join(steamCmd, " ")))
 	cmd := exec.Command(execPath, steamCmd...)
 
 	// Stream output to update log if available, otherwise capture
@@ -248,16 +248,16 @@ func (s *Steam) UpdateGame(beta bool) error {
 	}
 	if err != nil {
 		s.reportProgress("SteamCMD failed", 0, 0)
-		s.Logger.Write(fmt.Sprintf("SteamCMD error: %v", err))
+		s.Logger.Error(fmt.Sprintf("SteamCMD error: %v", err))
 		if captureOutput {
-			s.Logger.Write(fmt.Sprintf("SteamCMD output: %s", string(output)))
+			s.Logger.Error(fmt.Sprintf("SteamCMD output: %s", string(output)))
 		}
 		return err
 	}
 	if captureOutput {
-		s.Logger.Write(fmt.Sprintf("SteamCMD output: %s", string(output)))
+		s.Logger.Debug(fmt.Sprintf("SteamCMD output: %s", string(output)))
 	}
-	s.Logger.Write("rocketstation_DedicatedServer updated successfully")
+	s.Logger.Info("rocketstation_DedicatedServer updated successfully")
 	s.reportProgress("Completed", 0, 0)
 	return nil
 }
@@ -278,12 +278,12 @@ func (s *Steam) UpdateBepInEx() error {
 	}
 
 	zipPath := filepath.Join(destDir, "BepInEx.zip")
-	s.Logger.Write(fmt.Sprintf("Downloading BepInEx %s (%s) from %s", version, archiveName, url))
+	s.Logger.Info(fmt.Sprintf("Downloading BepInEx %s (%s) from %s", version, archiveName, url))
 	if _, _, err := s.downloadFile(url, zipPath, "Downloading"); err != nil {
 		return err
 	}
 
-	s.Logger.Write(fmt.Sprintf("Extracting BepInEx to %s", destDir))
+	s.Logger.Info(fmt.Sprintf("Extracting BepInEx to %s", destDir))
 	s.reportProgress("Extracting", 0, 0)
 	if err := s.unzip(zipPath, destDir); err != nil {
 		s.reportProgress("Extraction failed", 0, 0)
@@ -297,14 +297,14 @@ func (s *Steam) UpdateBepInEx() error {
 		if recorded != "" {
 			versionFile := filepath.Join(destDir, bepInExVersionFile)
 			if writeErr := os.WriteFile(versionFile, []byte(recorded+"\n"), 0o644); writeErr != nil {
-				s.Logger.Write(fmt.Sprintf("Warning: unable to record BepInEx version: %v", writeErr))
+				s.Logger.Warn(fmt.Sprintf("Unable to record BepInEx version: %v", writeErr))
 			}
 		}
 	}
 	if version != "" {
-		s.Logger.Write(fmt.Sprintf("BepInEx %s deployed successfully", version))
+		s.Logger.Info(fmt.Sprintf("BepInEx %s deployed successfully", version))
 	} else {
-		s.Logger.Write("BepInEx deployed successfully")
+		s.Logger.Info("BepInEx deployed successfully")
 	}
 	return nil
 }
@@ -362,7 +362,7 @@ func (s *Steam) resolveBepInExDownload() (string, string, string, error) {
 // UpdateLaunchPad fetches and deploys Stationeers LaunchPad, flattening the root folder if needed.
 
 func (s *Steam) UpdateLaunchPad() error {
-	s.Logger.Write("Updating Stationeers LaunchPad")
+	s.Logger.Info("Updating Stationeers LaunchPad")
 	url, archiveName, releaseVersion, err := s.resolveLaunchPadDownload()
 	if err != nil {
 		return err
@@ -370,9 +370,9 @@ func (s *Steam) UpdateLaunchPad() error {
 
 	zipPath := filepath.Join(s.Paths.RootPath, archiveName)
 	if releaseVersion != "" {
-		s.Logger.Write(fmt.Sprintf("Downloading Stationeers LaunchPad %s from %s", releaseVersion, url))
+		s.Logger.Info(fmt.Sprintf("Downloading Stationeers LaunchPad %s from %s", releaseVersion, url))
 	} else {
-		s.Logger.Write(fmt.Sprintf("Downloading Stationeers LaunchPad from %s", url))
+		s.Logger.Info(fmt.Sprintf("Downloading Stationeers LaunchPad from %s", url))
 	}
 	if _, _, err := s.downloadFile(url, zipPath, "Downloading"); err != nil {
 		return err
@@ -393,7 +393,7 @@ func (s *Steam) UpdateLaunchPad() error {
 	os.Remove(zipPath)
 
 	if err := flattenSingleDirectory(s.Paths.LaunchPadDir()); err != nil {
-		s.Logger.Write(fmt.Sprintf("Warning: unable to flatten LaunchPad directory: %v", err))
+		s.Logger.Warn(fmt.Sprintf("Unable to flatten LaunchPad directory: %v", err))
 	}
 
 	if releaseVersion != "" {
@@ -401,16 +401,16 @@ func (s *Steam) UpdateLaunchPad() error {
 		clean := strings.TrimSpace(releaseVersion)
 		if clean != "" {
 			if werr := os.WriteFile(versionPath, []byte(clean+"\n"), 0o644); werr != nil {
-				s.Logger.Write(fmt.Sprintf("Warning: unable to record LaunchPad version: %v", werr))
+				s.Logger.Warn(fmt.Sprintf("Unable to record LaunchPad version: %v", werr))
 			}
 		}
 	}
 
 	s.reportProgress("Completed", 0, 0)
 	if releaseVersion != "" {
-		s.Logger.Write(fmt.Sprintf("Stationeers LaunchPad %s deployed successfully", releaseVersion))
+		s.Logger.Info(fmt.Sprintf("Stationeers LaunchPad %s deployed successfully", releaseVersion))
 	} else {
-		s.Logger.Write("Stationeers LaunchPad deployed successfully")
+		s.Logger.Info("Stationeers LaunchPad deployed successfully")
 	}
 	return nil
 }
@@ -425,13 +425,13 @@ func (s *Steam) resolveLaunchPadDownload() (string, string, string, error) {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		s.Logger.Write(fmt.Sprintf("Warning: unable to query LaunchPad release API: %v", err))
+		s.Logger.Warn(fmt.Sprintf("Unable to query LaunchPad release API: %v", err))
 		return launchPadFallback, "StationeersLaunchPad.zip", "", nil
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		s.Logger.Write(fmt.Sprintf("Warning: LaunchPad release API returned status %d", resp.StatusCode))
+		s.Logger.Warn(fmt.Sprintf("LaunchPad release API returned status %d", resp.StatusCode))
 		return launchPadFallback, "StationeersLaunchPad.zip", "", nil
 	}
 
@@ -445,7 +445,7 @@ func (s *Steam) resolveLaunchPadDownload() (string, string, string, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		s.Logger.Write(fmt.Sprintf("Warning: unable to parse LaunchPad release metadata: %v", err))
+		s.Logger.Warn(fmt.Sprintf("Unable to parse LaunchPad release metadata: %v", err))
 		return launchPadFallback, "StationeersLaunchPad.zip", "", nil
 	}
 
@@ -505,11 +505,11 @@ func (s *Steam) UpdateSCON() error {
 	tmpFile.Close()
 
 	if tag != "" {
-		s.Logger.Write(fmt.Sprintf("Downloading SCON %s (%s) from %s", tag, assetName, url))
+		s.Logger.Info(fmt.Sprintf("Downloading SCON %s (%s) from %s", tag, assetName, url))
 	} else if assetName != "" {
-		s.Logger.Write(fmt.Sprintf("Downloading SCON (%s) from %s", assetName, url))
+		s.Logger.Info(fmt.Sprintf("Downloading SCON (%s) from %s", assetName, url))
 	} else {
-		s.Logger.Write(fmt.Sprintf("Downloading SCON from %s", url))
+		s.Logger.Info(fmt.Sprintf("Downloading SCON from %s", url))
 	}
 	if _, _, err := s.downloadFile(url, tmpPath, "Downloading"); err != nil {
 		_ = os.Remove(tmpPath)
@@ -536,22 +536,22 @@ func (s *Steam) UpdateSCON() error {
 
 	// If the archive contains a single root folder, flatten it
 	if err := flattenSingleDirectory(sconDir); err != nil {
-		s.Logger.Write(fmt.Sprintf("Warning: unable to flatten SCON directory: %v", err))
+		s.Logger.Warn(fmt.Sprintf("Unable to flatten SCON directory: %v", err))
 	}
 
 	// Persist the deployed version tag if available so the Manager can report it
 	if tag != "" {
 		versionPath := filepath.Join(sconDir, sconVersionFile)
 		if werr := os.WriteFile(versionPath, []byte(strings.TrimSpace(tag)+"\n"), 0o644); werr != nil {
-			s.Logger.Write(fmt.Sprintf("Warning: unable to record SCON version: %v", werr))
+			s.Logger.Warn(fmt.Sprintf("Unable to record SCON version: %v", werr))
 		}
 	}
 
 	s.reportProgress("Completed", 0, 0)
 	if tag != "" {
-		s.Logger.Write(fmt.Sprintf("SCON %s deployed into bin/SCON successfully", tag))
+		s.Logger.Info(fmt.Sprintf("SCON %s deployed into bin/SCON successfully", tag))
 	} else {
-		s.Logger.Write("SCON deployed into bin/SCON successfully")
+		s.Logger.Info("SCON deployed into bin/SCON successfully")
 	}
 	return nil
 }
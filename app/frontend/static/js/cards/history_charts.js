@@ -0,0 +1,154 @@
+(function(window) {
+  if (!window.SDSM || !window.SDSM.cards) {
+    console.warn('SDSM cards subsystem missing; history chart module aborting.');
+    return;
+  }
+
+  const SVG_NS = 'http://www.w3.org/2000/svg';
+  const WIDTH = 300;
+  const HEIGHT = 80;
+  const POLL_INTERVAL = 60000;
+
+  const formatValue = (value, unit) => {
+    if (typeof value !== 'number' || !isFinite(value)) {
+      return '—';
+    }
+    if (unit === '%') {
+      return `${value.toFixed(1)}%`;
+    }
+    return Number.isInteger(value) ? String(value) : value.toFixed(1);
+  };
+
+  const buildPath = (points, key, max, from, span, step) => {
+    let d = '';
+    let pen = false;
+    let prevT = null;
+    const gap = step > 0 ? step * 2500 : Infinity;
+    points.forEach((point) => {
+      const t = new Date(point.t).getTime();
+      const x = ((t - from) / span) * WIDTH;
+      const v = Number(point[key]) || 0;
+      const y = HEIGHT - (max > 0 ? (v / max) * (HEIGHT - 4) : 0) - 2;
+      // Break the line where samples are missing (manager or server offline).
+      if (!pen || (prevT !== null && t - prevT > gap)) {
+        d += `M${x.toFixed(1)},${y.toFixed(1)}`;
+        pen = true;
+      } else {
+        d += `L${x.toFixed(1)},${y.toFixed(1)}`;
+      }
+      prevT = t;
+    });
+    return d;
+  };
+
+  const renderChart = (figure, points, from, to, step) => {
+    const svg = figure.querySelector('svg');
+    if (!svg) {
+      return;
+    }
+    while (svg.firstChild) {
+      svg.removeChild(svg.firstChild);
+    }
+    const key = figure.getAttribute('data-history-chart');
+    const peakKey = figure.getAttribute('data-history-peak');
+    const unit = figure.getAttribute('data-history-unit') || '';
+    const span = Math.max(to - from, 1);
+
+    let max = unit === '%' ? 100 : 1;
+    points.forEach((point) => {
+      max = Math.max(max, Number(point[key]) || 0, peakKey ? Number(point[peakKey]) || 0 : 0);
+    });
+
+    const series = peakKey ? [[peakKey, 'history-line history-line-peak'], [key, 'history-line']] : [[key, 'history-line']];
+    series.forEach(([field, className]) => {
+      const path = document.createElementNS(SVG_NS, 'path');
+      path.setAttribute('d', buildPath(points, field, max, from, span, step));
+      path.setAttribute('class', className);
+      path.setAttribute('vector-effect', 'non-scaling-stroke');
+      svg.appendChild(path);
+    });
+
+    const latest = figure.querySelector('[data-history-latest]');
+    if (latest) {
+      const last = points.length ? points[points.length - 1] : null;
+      let text = last ? formatValue(Number(last[key]), unit) : '';
+      if (last && peakKey) {
+        text += ` (peak ${formatValue(Number(last[peakKey]), unit)})`;
+      }
+      latest.textContent = text;
+    }
+  };
+
+  const module = {
+    mount(card) {
+      if (!(card instanceof Element)) {
+        return null;
+      }
+      const baseURL = card.getAttribute('data-history-url');
+      const select = card.querySelector('[data-history-range-select]');
+      const empty = card.querySelector('[data-history-empty]');
+      const figures = Array.from(card.querySelectorAll('[data-history-chart]'));
+      let range = card.getAttribute('data-history-range') || '24h';
+      let controller = null;
+      let timer = null;
+
+      if (select) {
+        select.value = range;
+      }
+
+      const load = () => {
+        if (!baseURL) {
+          return;
+        }
+        if (controller) {
+          controller.abort();
+        }
+        controller = new AbortController();
+        fetch(`${baseURL}?range=${encodeURIComponent(range)}`, { credentials: 'same-origin', signal: controller.signal })
+          .then((res) => (res.ok ? res.json() : Promise.reject(new Error(`HTTP ${res.status}`))))
+          .then((data) => {
+            const points = Array.isArray(data.points) ? data.points : [];
+            const from = new Date(data.from).getTime();
+            const to = new Date(data.to).getTime();
+            const step = Number(data.step_seconds) || 0;
+            if (empty) {
+              empty.hidden = points.length > 0;
+            }
+            figures.forEach((figure) => renderChart(figure, points, from, to, step));
+          })
+          .catch((err) => {
+            if (err && err.name === 'AbortError') {
+              return;
+            }
+            console.warn('History fetch failed', err);
+          });
+      };
+
+      const handleChange = () => {
+        range = select.value || '24h';
+        load();
+      };
+      if (select) {
+        select.addEventListener('change', handleChange);
+      }
+
+      load();
+      timer = window.setInterval(load, POLL_INTERVAL);
+
+      return () => {
+        if (select) {
+          select.removeEventListener('change', handleChange);
+        }
+        if (timer) {
+          window.clearInterval(timer);
+        }
+        if (controller) {
+          controller.abort();
+        }
+      };
+    }
+  };
+
+  window.SDSM.cards.define('dashboard-history', module);
+  window.SDSM.cards.define('server-status-history', module);
+})(window);
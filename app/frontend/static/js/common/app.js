@@ -1535,7 +1535,9 @@
         'dashboard-manager': [{ type: 'js', path: '/static/js/cards/dashboard_manager.js' }],
         'dashboard-users': [{ type: 'js', path: '/static/js/cards/dashboard_users.js' }],
         'dashboard-system-health': [{ type: 'js', path: '/static/js/cards/dashboard_system_health.js' }],
-        'dashboard-stats': [{ type: 'js', path: '/static/js/cards/dashboard_stats.js' }]
+        'dashboard-stats': [{ type: 'js', path: '/static/js/cards/dashboard_stats.js' }],
+        'dashboard-history': [{ type: 'js', path: '/static/js/cards/history_charts.js' }],
+        'server-status-history': [{ type: 'js', path: '/static/js/cards/history_charts.js' }]
       },
       loading: Object.create(null),
       styleRegistry: Object.create(null),
@@ -869,6 +869,10 @@ function initServerStatusDashboard() {
         }
         const entry = document.createElement('article');
         entry.className = 'chat-message';
+        if (message.flag) {
+            entry.classList.add('chat-message-flagged');
+            entry.title = `Moderation: ${message.flag}`;
+        }
         if (message.timestamp) {
             entry.dataset.timestamp = message.timestamp;
         }
@@ -906,6 +910,7 @@ function initServerStatusDashboard() {
             author: entry.author || entry.player || entry.name || entry.Author || entry.Player || 'Server',
             text: entry.text || entry.message || entry.Message || '',
             timestamp: entry.timestamp || entry.time || entry.datetime || entry.Datetime || entry.Timestamp || '',
+            flag: entry.flag || entry.Flag || '',
         };
     }
 
@@ -1447,6 +1452,25 @@ function initServerStatusDashboard() {
         nameCell.textContent = player.name || 'Unknown Player';
         row.appendChild(nameCell);
 
+        const reasonCell = document.createElement('td');
+        reasonCell.textContent = player.reason || '—';
+        if (player.scope || player.expiresAt) {
+            const meta = document.createElement('div');
+            meta.className = 'player-meta text-xs text-muted';
+            const parts = [];
+            if (player.scope && player.scope !== 'server') {
+                parts.push(`${player.scope} ban`);
+            }
+            const expires = player.expiresAt ? new Date(player.expiresAt) : null;
+            parts.push(expires && !isNaN(expires) ? `until ${expires.toLocaleString()}` : 'permanent');
+            if (player.author) {
+                parts.push(`by ${player.author}`);
+            }
+            meta.textContent = parts.join(' · ');
+            reasonCell.appendChild(meta);
+        }
+        row.appendChild(reasonCell);
+
         const actionsCell = document.createElement('td');
         actionsCell.className = 'player-actions text-right';
         const unbanBtn = document.createElement('button');
@@ -1545,6 +1569,10 @@ function initServerStatusDashboard() {
             return {
                 steamId: entry.SteamID || entry.steam_id || entry.guid || '',
                 name: entry.Name || entry.name || '',
+                reason: entry.Reason || entry.reason || '',
+                author: entry.Author || entry.author || '',
+                scope: entry.Scope || entry.scope || '',
+                expiresAt: entry.ExpiresAt || entry.expires_at || '',
             };
         }).filter(Boolean);
     }
@@ -1960,6 +1988,70 @@ function initServerStatusDashboard() {
         setActivePlayerSaveGroup(activePlayerSaveGroupKey);
     }
 
+    function formatBackupSize(bytes) {
+        const size = Number(bytes) || 0;
+        if (size >= 1024 * 1024) {
+            return `${(size / (1024 * 1024)).toFixed(1)} MB`;
+        }
+        return `${Math.max(1, Math.round(size / 1024))} KB`;
+    }
+
+    function renderBackups(backups) {
+        if (!savesList) {
+            return;
+        }
+        savesList.innerHTML = '';
+        if (!backups.length) {
+            if (savesEmpty) {
+                savesEmpty.classList.remove('hidden');
+            }
+            return;
+        }
+        const fragment = document.createDocumentFragment();
+        backups.forEach((backup) => {
+            const row = document.createElement('div');
+            row.className = 'save-row';
+            row.dataset.saveType = 'backup';
+
+            const typeCell = document.createElement('div');
+            typeCell.className = 'save-row-type';
+            typeCell.innerHTML = '<i data-feather="archive"></i>';
+            typeCell.title = 'World Backup';
+            row.appendChild(typeCell);
+
+            const labelCell = document.createElement('div');
+            labelCell.className = 'save-row-label';
+            labelCell.textContent = `${backup.reason || 'backup'} · ${formatBackupSize(backup.size)}`;
+            labelCell.title = backup.name;
+            row.appendChild(labelCell);
+
+            const dateCell = document.createElement('div');
+            dateCell.className = 'save-row-date';
+            dateCell.textContent = formatSaveDate(backup.created_at);
+            row.appendChild(dateCell);
+
+            const actionsCell = document.createElement('div');
+            actionsCell.className = 'save-actions';
+            const restoreBtn = document.createElement('button');
+            restoreBtn.className = 'btn btn-sm btn-secondary btn-restore-backup';
+            restoreBtn.dataset.backupName = backup.name;
+            restoreBtn.innerHTML = '<i data-feather="rotate-ccw"></i> Restore';
+            restoreBtn.title = 'Restore this backup';
+            const deleteBtn = document.createElement('button');
+            deleteBtn.className = 'btn btn-sm btn-danger btn-delete-backup';
+            deleteBtn.dataset.backupName = backup.name;
+            deleteBtn.innerHTML = '<i data-feather="trash-2"></i> Delete';
+            deleteBtn.title = 'Delete this backup';
+            actionsCell.appendChild(restoreBtn);
+            actionsCell.appendChild(deleteBtn);
+            row.appendChild(actionsCell);
+
+            fragment.appendChild(row);
+        });
+        savesList.appendChild(fragment);
+        refreshFeatherIcons();
+    }
+
     async function fetchSaves(filter = 'all') {
         if (!savesList) return;
         currentSavesFilter = filter;
@@ -1969,6 +2061,19 @@ function initServerStatusDashboard() {
             savesEmpty.classList.add('hidden');
         }
 
+        if (filter === 'backup') {
+            try {
+                const data = await serverRequest('/backups', { method: 'GET' });
+                renderBackups(Array.isArray(data?.items) ? data.items : []);
+            } catch (error) {
+                handleActionError('Fetch Backups', error);
+                if (savesList) {
+                    savesList.innerHTML = '';
+                }
+            }
+            return;
+        }
+
         if (filter === 'player') {
             try {
                 const data = await serverRequest(`/saves${buildQuery({ type: 'player' })}`, { method: 'GET' });
@@ -2007,6 +2112,34 @@ function initServerStatusDashboard() {
         }
     }
 
+    // promptBanDetails asks for an optional reason and duration; resolves null when cancelled.
+    async function promptBanDetails(steamId) {
+        if (!window.SDSM || !window.SDSM.modal) {
+            return {};
+        }
+        const reason = await window.SDSM.modal.prompt({
+            title: 'Ban Player',
+            label: `Reason for banning ${steamId} (optional)`,
+            defaultValue: '',
+            confirmText: 'Next',
+            validate: (value) => ((value || '').length > 500 ? 'Reason is too long.' : true)
+        });
+        if (reason === null || typeof reason === 'undefined') {
+            return null;
+        }
+        const duration = await window.SDSM.modal.prompt({
+            title: 'Ban Player',
+            label: 'Duration, e.g. 12h, 7d or 2w (blank for permanent)',
+            defaultValue: '',
+            confirmText: 'Ban',
+            validate: (value) => (!value || /^\s*\d+\s*(m|h|d|w)\s*$/i.test(value) ? true : 'Use a number followed by m, h, d or w.')
+        });
+        if (duration === null || typeof duration === 'undefined') {
+            return null;
+        }
+        return { reason: (reason || '').trim(), duration: (duration || '').trim().replace(/\s+/g, '') };
+    }
+
     function renameServerPrompt(currentName) {
         if (!window.SDSM || !window.SDSM.modal) {
             console.error('Modal script not loaded');
@@ -2261,7 +2394,14 @@ function initServerStatusDashboard() {
         if (banBtn) {
             const guid = resolveSteamIdFromElement(banBtn);
             if (guid) {
-                serverRequest('/ban', { method: 'POST', body: { steam_id: guid } }).catch(err => handleActionError('Ban', err));
+                promptBanDetails(guid)
+                    .then((details) => {
+                        if (details) {
+                            return serverRequest('/ban', { method: 'POST', body: { steam_id: guid, ...details } });
+                        }
+                        return null;
+                    })
+                    .catch(err => handleActionError('Ban', err));
             }
             return;
         }
@@ -2285,6 +2425,31 @@ function initServerStatusDashboard() {
             }
             return;
         }
+        const restoreBackupBtn = e.target.closest('.btn-restore-backup');
+        if (restoreBackupBtn) {
+            const name = restoreBackupBtn.dataset.backupName;
+            if (name && confirm(`Restore backup "${name}"? The server will stop, the current saves are archived, and the world is replaced.`)) {
+                serverRequest('/backups/restore', { method: 'POST', body: { name } })
+                    .catch(err => handleActionError('Restore Backup', err));
+            }
+            return;
+        }
+        const deleteBackupBtn = e.target.closest('.btn-delete-backup');
+        if (deleteBackupBtn) {
+            const name = deleteBackupBtn.dataset.backupName;
+            if (name && confirm(`Delete backup "${name}"? This cannot be undone.`)) {
+                serverRequest(`/backups${buildQuery({ name })}`, { method: 'DELETE' })
+                    .then(() => fetchSaves(currentSavesFilter))
+                    .catch(err => handleActionError('Delete Backup', err));
+            }
+            return;
+        }
+        if (e.target.closest('#btn-create-backup')) {
+            serverRequest('/backups', { method: 'POST' })
+                .then(() => fetchSaves(currentSavesFilter))
+                .catch(err => handleActionError('Create Backup', err));
+            return;
+        }
         const deleteSaveBtn = e.target.closest('.btn-delete-save');
         if (deleteSaveBtn) {
             const saveName = deleteSaveBtn.dataset.saveLabel || deleteSaveBtn.dataset.saveFilename;
@@ -13,6 +13,7 @@ require (
 	github.com/golang-jwt/jwt/v5 v5.3.0
 	github.com/gorilla/websocket v1.5.3
 	github.com/libp2p/go-nat v0.2.0
+	github.com/pkg/sftp v1.13.10
 	github.com/shirou/gopsutil/v4 v4.25.10
 	golang.org/x/crypto v0.43.0
 	golang.org/x/sys v0.37.0
@@ -21,6 +22,7 @@ require (
 
 require (
 	github.com/ebitengine/purego v0.9.0 // indirect
+	github.com/kr/fs v0.1.0 // indirect
 	github.com/lufia/plan9stats v0.0.0-20211012122336-39d0f177ccd0 // indirect
 	github.com/power-devops/perfstat v0.0.0-20240221224432-82ca36839d55 // indirect
 	github.com/tklauser/go-sysconf v0.3.15 // indirect